@@ -0,0 +1,62 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrContainerNotOwned is returned when terminating a container that was not created by
+// Testcontainers, see ContainerFromID, without the WithForce option.
+var ErrContainerNotOwned = errors.New("container not owned by testcontainers")
+
+// ContainerFromID returns a handle to an existing container, created outside of Testcontainers,
+// e.g. by a shell script of the CI, so that the DockerContainer API can be used against it.
+// The id can be the full or short ID of the container, or its name.
+//
+// The container is not owned by Testcontainers: it has no wait strategy, which can be run
+// passing it to WaitUntilReady, it's not removed by the garbage collector unless it has the
+// labels of the session, and Terminate returns an error wrapping [ErrContainerNotOwned] unless
+// the WithForce option is set, to not remove it by mistake.
+func ContainerFromID(ctx context.Context, id string) (*DockerContainer, error) {
+	provider, err := NewDockerProvider()
+	if err != nil {
+		return nil, fmt.Errorf("new docker provider: %w", err)
+	}
+
+	inspect, err := provider.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("inspect container %s: %w", id, err)
+	}
+	defer provider.Close()
+
+	ctr := &DockerContainer{
+		ID:             inspect.ID,
+		Image:          inspect.Config.Image,
+		provider:       provider,
+		logger:         provider.Logger,
+		lifecycleHooks: []ContainerLifecycleHooks{DefaultLoggingHook(provider.Logger)},
+		// the session of the labels, if the container was created by Testcontainers.
+		sessionID:  inspect.Config.Labels[core.LabelSessionID],
+		isRunning:  inspect.State.Running,
+		consumers:  []LogConsumer{},
+		adopted:    true,
+		moduleInfo: moduleInfoFromLabels(inspect.Config.Labels),
+	}
+
+	if inspect.HostConfig != nil {
+		ctr.hostPortBindingIP = portsBindingIP(inspect.HostConfig.PortBindings)
+	}
+
+	for port := range inspect.Config.ExposedPorts {
+		ctr.exposedPorts = append(ctr.exposedPorts, string(port))
+	}
+
+	if health := inspect.State.Health; health != nil {
+		ctr.healthStatus = health.Status
+	}
+
+	return ctr, nil
+}
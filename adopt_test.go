@@ -0,0 +1,96 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestContainerFromID(t *testing.T) {
+	ctx := context.Background()
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cli.Close()) })
+
+	rc, err := cli.ImagePull(ctx, nginxAlpineImage, image.PullOptions{})
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+
+	// the container is created with the raw client, as it would be by a shell script.
+	resp, err := cli.ContainerCreate(ctx, &container.Config{
+		Image:        nginxAlpineImage,
+		ExposedPorts: nat.PortSet{nginxDefaultPort: {}},
+	}, &container.HostConfig{
+		PortBindings: nat.PortMap{nginxDefaultPort: {{HostPort: ""}}},
+	}, nil, nil, "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			t.Error(err)
+		}
+	})
+	require.NoError(t, cli.ContainerStart(ctx, resp.ID, container.StartOptions{}))
+
+	// containerFromID {
+	ctr, err := ContainerFromID(ctx, resp.ID[:12])
+	require.NoError(t, err)
+
+	err = ctr.WaitUntilReady(ctx, wait.ForListeningPort(nginxDefaultPort))
+	require.NoError(t, err)
+	// }
+
+	require.Equal(t, resp.ID, ctr.GetContainerID())
+	require.Equal(t, nginxAlpineImage, ctr.Image)
+	require.Equal(t, []string{nginxDefaultPort}, ctr.exposedPorts)
+	require.Empty(t, ctr.SessionID())
+
+	state, err := ctr.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Running)
+
+	inspect, err := cli.ContainerInspect(ctx, resp.ID)
+	require.NoError(t, err)
+
+	port, err := ctr.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	require.Equal(t, inspect.NetworkSettings.Ports[nginxDefaultPort][0].HostPort, port.Port())
+
+	code, _, err := ctr.Exec(ctx, []string{"ls", "/usr/share/nginx/html/index.html"})
+	require.NoError(t, err)
+	require.Zero(t, code)
+
+	t.Run("no-wait-strategy", func(t *testing.T) {
+		require.ErrorIs(t, ctr.WaitUntilReady(ctx), ErrNoWaitStrategy)
+	})
+
+	t.Run("terminate", func(t *testing.T) {
+		err := ctr.Terminate(ctx)
+		require.ErrorIs(t, err, ErrContainerNotOwned)
+
+		// the container is kept
+		_, err = cli.ContainerInspect(ctx, resp.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, ctr.Terminate(ctx, WithForce(true)))
+
+		_, err = cli.ContainerInspect(ctx, resp.ID)
+		require.True(t, errdefs.IsNotFound(err), err)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		_, err := ContainerFromID(ctx, "not-found")
+		require.True(t, errdefs.IsNotFound(err), err)
+	})
+}
@@ -0,0 +1,393 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// defaultCompareDiffLimit is the default maximum size of the files whose unified diff
+// is included in the failure messages of AssertFileEqual and AssertDirEqual.
+const defaultCompareDiffLimit = 64 << 10 // 64 KiB
+
+// CompareOption is an option to compare the files of a container with the
+// expected files in the host, see AssertFileEqual and AssertDirEqual.
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	compareTimestamps    bool
+	ignoreModes          bool
+	normalizeLineEndings bool
+	diffLimit            int64
+}
+
+// CompareTimestamps also compares the modification times of the files, with a second precision.
+// They are not compared by default, as the files produced in the container are usually newer
+// than the golden files.
+func CompareTimestamps() CompareOption {
+	return func(o *compareOptions) {
+		o.compareTimestamps = true
+	}
+}
+
+// CompareIgnoringModes does not compare the permission bits of the files and directories.
+// Their types are still compared, e.g. a directory never equals a regular file.
+func CompareIgnoringModes() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreModes = true
+	}
+}
+
+// CompareNormalizingLineEndings converts the CRLF line endings to LF before comparing
+// the contents of the files, e.g. for golden files checked out on Windows.
+func CompareNormalizingLineEndings() CompareOption {
+	return func(o *compareOptions) {
+		o.normalizeLineEndings = true
+	}
+}
+
+// CompareWithDiffLimit sets the maximum size of the files whose unified diff is included
+// in the failure messages, 64 KiB by default. The contents of larger files are compared
+// by their hashes only. A zero limit disables the diffs.
+func CompareWithDiffLimit(size int64) CompareOption {
+	return func(o *compareOptions) {
+		o.diffLimit = size
+	}
+}
+
+// AssertFileEqual asserts that the file at containerPath in the container is identical to
+// the golden file at hostGoldenPath, reporting the differences, including a unified diff
+// of the contents of small files, to tb. By default the contents and the permission bits
+// are compared, which can be changed with the given options.
+// It returns true if the files are identical.
+func AssertFileEqual(ctx context.Context, tb testing.TB, ctr Container, containerPath string, hostGoldenPath string, opts ...CompareOption) bool {
+	tb.Helper()
+
+	return assertTreeEqual(ctx, tb, ctr, containerPath, hostGoldenPath, opts...)
+}
+
+// AssertDirEqual asserts that the directory at containerDir in the container is identical,
+// recursively, to the golden directory at hostGoldenDir, reporting the missing, unexpected and
+// differing files, including a unified diff of the contents of small files, to tb.
+// By default the contents and the permission bits are compared, which can be changed
+// with the given options. It returns true if the directories are identical.
+func AssertDirEqual(ctx context.Context, tb testing.TB, ctr Container, containerDir string, hostGoldenDir string, opts ...CompareOption) bool {
+	tb.Helper()
+
+	return assertTreeEqual(ctx, tb, ctr, containerDir, hostGoldenDir, opts...)
+}
+
+func assertTreeEqual(ctx context.Context, tb testing.TB, ctr Container, containerPath string, hostPath string, opts ...CompareOption) bool {
+	tb.Helper()
+
+	options := compareOptions{diffLimit: defaultCompareDiffLimit}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	expected, err := hostFileTree(hostPath, options)
+	if err != nil {
+		tb.Errorf("read %s from host: %s", hostPath, err)
+		return false
+	}
+
+	actual, err := containerFileTree(ctx, ctr, containerPath, options)
+	if err != nil {
+		tb.Errorf("read %s from container: %s", containerPath, err)
+		return false
+	}
+
+	diffs := compareFileTrees(expected, actual, options)
+	if len(diffs) == 0 {
+		return true
+	}
+
+	tb.Errorf("%s in the container differs from %s:\n%s", containerPath, hostPath, strings.Join(diffs, "\n"))
+	return false
+}
+
+// fileEntry describes a file or directory being compared.
+type fileEntry struct {
+	mode     fs.FileMode
+	modTime  time.Time
+	linkname string
+	hash     []byte
+	// content is nil if the file is larger than the diff limit.
+	content []byte
+}
+
+// newFileEntry creates the entry of a file, streaming its content, if any, to hash it.
+func newFileEntry(info fs.FileInfo, linkname string, r io.Reader, opts compareOptions) (*fileEntry, error) {
+	entry := &fileEntry{
+		mode:     info.Mode(),
+		modTime:  info.ModTime(),
+		linkname: linkname,
+	}
+
+	if !info.Mode().IsRegular() {
+		return entry, nil
+	}
+
+	h := sha256.New()
+	content := &cappedBuffer{limit: opts.diffLimit}
+
+	var w io.Writer = io.MultiWriter(h, content)
+	if opts.normalizeLineEndings {
+		w = &crlfWriter{w: w}
+	}
+
+	if _, err := io.Copy(w, r); err != nil {
+		return nil, err
+	}
+
+	if cw, ok := w.(*crlfWriter); ok {
+		if err := cw.Flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	entry.hash = h.Sum(nil)
+	if !content.overflow && opts.diffLimit > 0 {
+		entry.content = content.Bytes()
+	}
+
+	return entry, nil
+}
+
+// hostFileTree returns the entries of the file or directory at root, indexed by
+// their slash-separated path relative to root, root itself being ".".
+func hostFileTree(root string, opts compareOptions) (map[string]*fileEntry, error) {
+	tree := map[string]*fileEntry{}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var linkname string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if linkname, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+
+		var r io.Reader
+		if info.Mode().IsRegular() {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			r = f
+		}
+
+		entry, err := newFileEntry(info, linkname, r, opts)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+
+		tree[filepath.ToSlash(rel)] = entry
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// containerFileTree returns the entries of the file or directory at root in the container,
+// indexed by their slash-separated path relative to root, root itself being ".".
+// The archive returned by Docker is streamed, so the files are never fully loaded in memory.
+func containerFileTree(ctx context.Context, ctr Container, root string, opts compareOptions) (map[string]*fileEntry, error) {
+	dc, ok := ctr.(*DockerContainer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported container type %T", ctr)
+	}
+
+	r, stat, err := dc.provider.client.CopyFromContainer(ctx, dc.ID, root)
+	if err != nil {
+		return nil, fmt.Errorf("copy from container: %w", err)
+	}
+	defer dc.provider.Close()
+	defer r.Close()
+
+	tree := map[string]*fileEntry{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+
+		// the entries are prefixed by the base name of root.
+		name := strings.TrimSuffix(hdr.Name, "/")
+		rel := "."
+		if name != stat.Name {
+			rel = strings.TrimPrefix(name, stat.Name+"/")
+		}
+
+		entry, err := newFileEntry(hdr.FileInfo(), hdr.Linkname, tr, opts)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", hdr.Name, err)
+		}
+
+		tree[rel] = entry
+	}
+
+	return tree, nil
+}
+
+// compareFileTrees returns the differences between the expected and actual entries, sorted by path.
+func compareFileTrees(expected, actual map[string]*fileEntry, opts compareOptions) []string {
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	for name := range actual {
+		if _, ok := expected[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []string
+	for _, name := range names {
+		e, a := expected[name], actual[name]
+
+		switch {
+		case a == nil:
+			diffs = append(diffs, fmt.Sprintf("  %s: missing in the container", name))
+			continue
+		case e == nil:
+			diffs = append(diffs, fmt.Sprintf("  %s: unexpected in the container", name))
+			continue
+		case e.mode.Type() != a.mode.Type():
+			diffs = append(diffs, fmt.Sprintf("  %s: type differs: expected %s, got %s", name, e.mode.Type(), a.mode.Type()))
+			continue
+		}
+
+		if !opts.ignoreModes && e.mode.Perm() != a.mode.Perm() {
+			diffs = append(diffs, fmt.Sprintf("  %s: mode differs: expected %s, got %s", name, e.mode.Perm(), a.mode.Perm()))
+		}
+
+		if !e.mode.IsRegular() {
+			if e.linkname != a.linkname {
+				diffs = append(diffs, fmt.Sprintf("  %s: link differs: expected %s, got %s", name, e.linkname, a.linkname))
+			}
+			continue
+		}
+
+		// the archives only keep the timestamps with a second precision.
+		if opts.compareTimestamps && !e.modTime.Truncate(time.Second).Equal(a.modTime.Truncate(time.Second)) {
+			diffs = append(diffs, fmt.Sprintf("  %s: modification time differs: expected %s, got %s", name, e.modTime.Format(time.RFC3339), a.modTime.Format(time.RFC3339)))
+		}
+
+		if bytes.Equal(e.hash, a.hash) {
+			continue
+		}
+
+		if e.content == nil || a.content == nil {
+			diffs = append(diffs, fmt.Sprintf("  %s: content differs: expected sha256 %x, got %x", name, e.hash, a.hash))
+			continue
+		}
+
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(e.content)),
+			B:        difflib.SplitLines(string(a.content)),
+			FromFile: "expected",
+			ToFile:   "actual",
+			Context:  3,
+		})
+		diffs = append(diffs, fmt.Sprintf("  %s: content differs:\n%s", name, diff))
+	}
+
+	return diffs
+}
+
+// cappedBuffer is a buffer discarding its content once it exceeds its limit.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit    int64
+	overflow bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.overflow {
+		return len(p), nil
+	}
+
+	if int64(b.Len()+len(p)) > b.limit {
+		b.overflow = true
+		b.Reset()
+		return len(p), nil
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// crlfWriter converts the CRLF line endings written to it to LF.
+type crlfWriter struct {
+	w io.Writer
+	// cr is true if the last byte written was a carriage return, not written yet.
+	cr bool
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	out := make([]byte, 0, len(p)+1)
+	for _, b := range p {
+		if c.cr && b != '\n' {
+			out = append(out, '\r')
+		}
+
+		c.cr = b == '\r'
+		if !c.cr {
+			out = append(out, b)
+		}
+	}
+
+	if _, err := c.w.Write(out); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the trailing carriage return, if any.
+func (c *crlfWriter) Flush() error {
+	if !c.cr {
+		return nil
+	}
+
+	c.cr = false
+	_, err := c.w.Write([]byte{'\r'})
+	return err
+}
@@ -0,0 +1,196 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTB is a testing.TB recording the reported errors instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// writeGoldenFile writes a golden file with the given content and mode, creating its parent directories.
+func writeGoldenFile(t *testing.T, path string, content string, mode os.FileMode) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), mode))
+	// make the mode independent of the umask
+	require.NoError(t, os.Chmod(path, mode))
+}
+
+func TestAssertDirEqual(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/alpine",
+			Cmd: []string{"sh", "-c", strings.Join([]string{
+				"umask 022",
+				"mkdir -p /data/sub",
+				"printf 'hello\\nworld\\n' > /data/hello.txt",
+				"printf 'nested\\n' > /data/sub/nested.txt",
+				"sleep 60",
+			}, " && ")},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	golden := func(t *testing.T) string {
+		t.Helper()
+
+		dir := filepath.Join(t.TempDir(), "data")
+		writeGoldenFile(t, filepath.Join(dir, "hello.txt"), "hello\nworld\n", 0o644)
+		writeGoldenFile(t, filepath.Join(dir, "sub", "nested.txt"), "nested\n", 0o644)
+		require.NoError(t, os.Chmod(filepath.Join(dir, "sub"), 0o755))
+		require.NoError(t, os.Chmod(dir, 0o755))
+
+		return dir
+	}
+
+	// wait for the files to be written
+	require.Eventually(t, func() bool {
+		_, err := ctr.Run(ctx, []string{"test", "-f", "/data/sub/nested.txt"})
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	t.Run("equal", func(t *testing.T) {
+		// assertDirEqual {
+		AssertDirEqual(ctx, t, ctr, "/data", golden(t))
+		// }
+	})
+
+	t.Run("differing-content", func(t *testing.T) {
+		dir := golden(t)
+		writeGoldenFile(t, filepath.Join(dir, "hello.txt"), "hello\nWORLD\n", 0o644)
+
+		tb := &recordingTB{}
+		require.False(t, AssertDirEqual(ctx, tb, ctr, "/data", dir))
+		require.Len(t, tb.errors, 1)
+		require.Contains(t, tb.errors[0], "hello.txt: content differs:")
+		require.Contains(t, tb.errors[0], "--- expected\n+++ actual\n")
+		require.Contains(t, tb.errors[0], "-WORLD\n+world\n")
+	})
+
+	t.Run("missing-file", func(t *testing.T) {
+		dir := golden(t)
+		writeGoldenFile(t, filepath.Join(dir, "sub", "missing.txt"), "missing\n", 0o644)
+		require.NoError(t, os.Remove(filepath.Join(dir, "hello.txt")))
+
+		tb := &recordingTB{}
+		require.False(t, AssertDirEqual(ctx, tb, ctr, "/data", dir))
+		require.Len(t, tb.errors, 1)
+		require.Contains(t, tb.errors[0], "/data in the container differs from "+dir)
+		require.Contains(t, tb.errors[0], "hello.txt: unexpected in the container")
+		require.Contains(t, tb.errors[0], "sub/missing.txt: missing in the container")
+	})
+
+	t.Run("differing-mode", func(t *testing.T) {
+		dir := golden(t)
+		require.NoError(t, os.Chmod(filepath.Join(dir, "hello.txt"), 0o755))
+
+		tb := &recordingTB{}
+		require.False(t, AssertDirEqual(ctx, tb, ctr, "/data", dir))
+		require.Len(t, tb.errors, 1)
+		require.Contains(t, tb.errors[0], "hello.txt: mode differs: expected -rwxr-xr-x, got -rw-r--r--")
+
+		require.True(t, AssertDirEqual(ctx, t, ctr, "/data", dir, CompareIgnoringModes()))
+	})
+
+	t.Run("missing-container-dir", func(t *testing.T) {
+		tb := &recordingTB{}
+		require.False(t, AssertDirEqual(ctx, tb, ctr, "/missing", golden(t)))
+		require.Len(t, tb.errors, 1)
+		require.Contains(t, tb.errors[0], "read /missing from container")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir := golden(t)
+		require.True(t, AssertFileEqual(ctx, t, ctr, "/data/hello.txt", filepath.Join(dir, "hello.txt")))
+
+		// golden files checked out with CRLF line endings
+		writeGoldenFile(t, filepath.Join(dir, "hello.txt"), "hello\r\nworld\r\n", 0o644)
+
+		tb := &recordingTB{}
+		require.False(t, AssertFileEqual(ctx, tb, ctr, "/data/hello.txt", filepath.Join(dir, "hello.txt")))
+		require.True(t, AssertFileEqual(ctx, t, ctr, "/data/hello.txt", filepath.Join(dir, "hello.txt"), CompareNormalizingLineEndings()))
+	})
+}
+
+func TestCompareFileTrees_largeFiles(t *testing.T) {
+	opts := compareOptions{diffLimit: 16}
+
+	dir := t.TempDir()
+	writeGoldenFile(t, filepath.Join(dir, "expected", "large.bin"), strings.Repeat("a", 1024), 0o644)
+	writeGoldenFile(t, filepath.Join(dir, "actual", "large.bin"), strings.Repeat("a", 1023)+"b", 0o644)
+
+	expected, err := hostFileTree(filepath.Join(dir, "expected"), opts)
+	require.NoError(t, err)
+	require.Nil(t, expected["large.bin"].content)
+
+	actual, err := hostFileTree(filepath.Join(dir, "actual"), opts)
+	require.NoError(t, err)
+
+	diffs := compareFileTrees(expected, actual, compareOptions{})
+	require.Len(t, diffs, 1)
+	require.Contains(t, diffs[0], "large.bin: content differs: expected sha256 ")
+}
+
+func TestCompareFileTrees_timestamps(t *testing.T) {
+	dir := t.TempDir()
+	writeGoldenFile(t, filepath.Join(dir, "expected", "hello.txt"), "hello\n", 0o644)
+	writeGoldenFile(t, filepath.Join(dir, "actual", "hello.txt"), "hello\n", 0o644)
+
+	modTime := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "expected", "hello.txt"), modTime, modTime))
+
+	expected, err := hostFileTree(filepath.Join(dir, "expected"), compareOptions{})
+	require.NoError(t, err)
+
+	actual, err := hostFileTree(filepath.Join(dir, "actual"), compareOptions{})
+	require.NoError(t, err)
+
+	// the timestamps are ignored by default
+	require.Empty(t, compareFileTrees(expected, actual, compareOptions{}))
+
+	opts := compareOptions{}
+	CompareTimestamps()(&opts)
+
+	diffs := compareFileTrees(expected, actual, opts)
+	require.Len(t, diffs, 1)
+	require.Contains(t, diffs[0], "hello.txt: modification time differs: ")
+}
+
+func TestCRLFWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &crlfWriter{w: &buf}
+
+	// the line endings split across writes are converted too
+	for _, s := range []string{"a\r\nb\r", "\nc\rd\r"} {
+		n, err := w.Write([]byte(s))
+		require.NoError(t, err)
+		require.Equal(t, len(s), n)
+	}
+	require.NoError(t, w.Flush())
+
+	require.Equal(t, "a\nb\nc\rd\r", buf.String())
+}
@@ -0,0 +1,115 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// attachDrainTimeout is the time given to the attached output of a stopped container
+// to reach the end of its stream, before the stream is closed.
+const attachDrainTimeout = 5 * time.Second
+
+// outputAttachment is the stream of the output of the container process,
+// copied to the writers of the request by a goroutine.
+type outputAttachment struct {
+	resp types.HijackedResponse
+	done chan struct{}
+}
+
+// defaultAttachHook attaches the writers of the request to the output of the container process,
+// before the container starts, so that no output is missed, and detaches them once it's stopped.
+func defaultAttachHook(stdout io.Writer, stderr io.Writer) ContainerLifecycleHooks {
+	if stdout == nil && stderr == nil {
+		return ContainerLifecycleHooks{}
+	}
+
+	detach := func(_ context.Context, c Container) error {
+		c.(*DockerContainer).detachOutput()
+		return nil
+	}
+
+	return ContainerLifecycleHooks{
+		PreStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				return c.(*DockerContainer).attachOutput(ctx, stdout, stderr)
+			},
+		},
+		PostStops:      []ContainerHook{detach},
+		PostTerminates: []ContainerHook{detach},
+	}
+}
+
+// attachOutput attaches to the output of the container process, copying its stdout and stderr
+// to the given writers until the container stops. A nil writer discards the output of its stream.
+func (c *DockerContainer) attachOutput(ctx context.Context, stdout io.Writer, stderr io.Writer) error {
+	// the stream of a previous start ended when the container stopped.
+	c.detachOutput()
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect: %w", err)
+	}
+
+	resp, err := c.provider.client.ContainerAttach(context.WithoutCancel(ctx), c.ID, container.AttachOptions{
+		Stream: true,
+		Stdout: stdout != nil,
+		Stderr: stderr != nil,
+	})
+	if err != nil {
+		return fmt.Errorf("container attach: %w", err)
+	}
+
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
+	done := make(chan struct{})
+	go func(tty bool) {
+		defer close(done)
+
+		// the errors of the stream, closed on detach, are not reported.
+		if tty {
+			// the output of a TTY is a raw stream.
+			_, _ = io.Copy(stdout, resp.Reader)
+		} else {
+			_, _ = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		}
+	}(inspect.Config != nil && inspect.Config.Tty)
+
+	c.attachmentMtx.Lock()
+	defer c.attachmentMtx.Unlock()
+
+	c.attachment = &outputAttachment{resp: resp, done: done}
+
+	return nil
+}
+
+// detachOutput closes the stream of the output of the container process, if attached,
+// once the remaining output is copied to the writers. The concurrent calls wait for the
+// output to be copied by the first one.
+func (c *DockerContainer) detachOutput() {
+	c.attachmentMtx.Lock()
+	defer c.attachmentMtx.Unlock()
+
+	if c.attachment == nil {
+		return
+	}
+
+	select {
+	case <-c.attachment.done:
+	case <-time.After(attachDrainTimeout):
+	}
+
+	c.attachment.resp.Close()
+	<-c.attachment.done
+	c.attachment = nil
+}
@@ -0,0 +1,85 @@
+package testcontainers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestAttachedOutput(t *testing.T) {
+	ctx := context.Background()
+
+	// the buffers are read once the container is terminated, when the output is detached.
+	var stdout, stderr bytes.Buffer
+
+	req := GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine",
+			Cmd:        []string{"sh", "-c", "echo out; echo err >&2; echo ready; sleep 60"},
+			WaitingFor: wait.ForLog("ready"),
+		},
+		Started: true,
+	}
+	// attachedOutput {
+	err := WithAttachedOutput(&stdout, &stderr)(&req)
+	// }
+	require.NoError(t, err)
+
+	ctr, err := GenericContainer(ctx, req)
+	require.NoError(t, err)
+
+	// the output of the restarted container is attached again, without the previous output.
+	require.NoError(t, ctr.Stop(ctx, nil))
+	require.NoError(t, ctr.Start(ctx))
+	require.NoError(t, ctr.(*DockerContainer).WaitUntilReady(ctx, wait.ForLog("ready").WithOccurrence(2)))
+
+	require.NoError(t, ctr.Terminate(ctx))
+
+	require.Equal(t, 2, strings.Count(stdout.String(), "out\n"))
+	require.Equal(t, 2, strings.Count(stdout.String(), "ready\n"))
+	require.NotContains(t, stdout.String(), "err")
+	require.Equal(t, "err\nerr\n", stderr.String())
+}
+
+func TestDockerContainer_detachOutput(t *testing.T) {
+	// the daemon end of the stream is closed by the container stopping.
+	conn, daemonConn := net.Pipe()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	c := &DockerContainer{
+		attachment: &outputAttachment{
+			resp: types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)},
+			done: done,
+		},
+	}
+	require.NoError(t, daemonConn.Close())
+
+	// Stop and Terminate detach the output concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.detachOutput()
+		}()
+	}
+	wg.Wait()
+
+	require.Nil(t, c.attachment)
+}
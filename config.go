@@ -12,6 +12,9 @@ type TestcontainersConfig struct {
 	RyukDisabled   bool   `properties:"ryuk.disabled,default=false"`             // Deprecated: use Config.RyukDisabled instead
 	RyukPrivileged bool   `properties:"ryuk.container.privileged,default=false"` // Deprecated: use Config.RyukPrivileged instead
 	Config         config.Config
+
+	// ReaperObserver is notified of the lifecycle of the reapers, set with SetReaperObserver.
+	ReaperObserver ReaperObserver
 }
 
 // ReadConfig reads from testcontainers properties file, storing the result in a singleton instance
@@ -25,5 +28,6 @@ func ReadConfig() TestcontainersConfig {
 		RyukDisabled:   cfg.RyukDisabled,
 		RyukPrivileged: cfg.RyukPrivileged,
 		Config:         cfg,
+		ReaperObserver: reaperObserver,
 	}
 }
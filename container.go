@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,7 +34,7 @@ type DeprecatedContainer interface {
 	GetHostEndpoint(ctx context.Context, port string) (string, string, error)
 	GetIPAddress(ctx context.Context) (string, error)
 	LivenessCheckPorts(ctx context.Context) (nat.PortSet, error)
-	Terminate(ctx context.Context) error
+	Terminate(ctx context.Context, opts ...TerminateOption) error
 }
 
 // Container allows getting info about and controlling a single container instance
@@ -49,8 +51,19 @@ type Container interface {
 	Start(context.Context) error                                    // start the container
 	Stop(context.Context, *time.Duration) error                     // stop the container
 
+	// StopWithSignal stops the container sending the given signal, e.g. "SIGINT", instead of its stop signal.
+	// The container is killed with SIGKILL if it doesn't stop within the timeout.
+	StopWithSignal(ctx context.Context, signal string, timeout *time.Duration) error
+
+	Pause(context.Context) error                                    // freeze the processes of the container, see Unpause
+	Unpause(context.Context) error                                  // resume the processes of a paused container
+	Top(ctx context.Context, args ...string) (ProcessList, error)   // list the processes of the container
+	ProcessCount(ctx context.Context, matching string) (int, error) // count the processes whose command line contains the string
+
 	// Terminate stops and removes the container and its image if it was built and not flagged as kept.
-	Terminate(ctx context.Context) error
+	// By default the container is killed and removed along with its anonymous volumes,
+	// which can be changed with the given options.
+	Terminate(ctx context.Context, opts ...TerminateOption) error
 
 	Logs(context.Context) (io.ReadCloser, error)                    // Get logs of the container
 	FollowOutput(LogConsumer)                                       // Deprecated: it will be removed in the next major release
@@ -61,12 +74,25 @@ type Container interface {
 	Networks(context.Context) ([]string, error)                     // get container networks
 	NetworkAliases(context.Context) (map[string][]string, error)    // get container network aliases for a network
 	Exec(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (int, io.Reader, error)
-	ContainerIP(context.Context) (string, error)    // get container ip
-	ContainerIPs(context.Context) ([]string, error) // get all container IPs
+	ExecWithOptions(ctx context.Context, cmd []string, opts tcexec.ExecOptions) (int, io.Reader, error)
+	Run(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (ExecResult, error)
+
+	// EndpointWithHost gets scheme://host:port string for the given exposed port, with the given host
+	// instead of the resolved one, e.g. for a remote daemon whose host is not reachable as resolved.
+	EndpointWithHost(ctx context.Context, host string, port nat.Port, scheme string) (string, error)
+
+	MappedPorts(context.Context, ...nat.Port) (map[nat.Port]nat.Port, error)      // get externally mapped ports for container ports, with a single inspect
+	ContainerIP(context.Context) (string, error)                                  // get container ip
+	ContainerIPs(context.Context) (map[string]string, error)                      // get all container IPs, keyed by network name
+	ContainerIPByNetwork(ctx context.Context, networkName string) (string, error) // get container IP in the given network
+	Env(context.Context) (map[string]string, error)                               // get the env of the container, keyed by name
+	EnvValue(ctx context.Context, key string) (string, bool, error)               // get the value of an env var of the container
+	ModuleInfo() ModuleInfo                                                       // get the module which created the container
 	CopyToContainer(ctx context.Context, fileContent []byte, containerFilePath string, fileMode int64) error
 	CopyDirToContainer(ctx context.Context, hostDirPath string, containerParentPath string, fileMode int64) error
 	CopyFileToContainer(ctx context.Context, hostFilePath string, containerFilePath string, fileMode int64) error
 	CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error)
+	CopyDirFromContainer(ctx context.Context, containerPath string, hostDirPath string) ([]string, error)
 	GetLogProductionErrorChannel() <-chan error
 }
 
@@ -83,6 +109,20 @@ type ImageBuildInfo interface {
 	GetAuthConfigs() map[string]registry.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Return the auth configs to be able to pull from an authenticated docker registry
 }
 
+// ImageBuildSecrets is implemented by the ImageBuildInfo exposing secrets to the build, such as
+// ContainerRequest. It's checked with a type assertion, so that the existing implementations of
+// ImageBuildInfo don't need to implement it.
+type ImageBuildSecrets interface {
+	GetBuildSecrets() (map[string][]byte, error) // return the secrets exposed to the build, indexed by id
+}
+
+// BuildSecret is a secret exposed to the build of an image, read from a file on the host if File is set,
+// or from the given value otherwise, which may be empty. Only one of File and Value can be set.
+type BuildSecret struct {
+	File  string // the path to a file on the host, whose contents are used as the secret
+	Value string // the secret value itself, used if File is not set
+}
+
 // FromDockerfile represents the parameters needed to build an image from a Dockerfile
 // rather than using a pre-built one
 type FromDockerfile struct {
@@ -91,7 +131,7 @@ type FromDockerfile struct {
 	Dockerfile     string                         // the path from the context to the Dockerfile for the image, defaults to "Dockerfile"
 	Repo           string                         // the repo label for image, defaults to UUID
 	Tag            string                         // the tag label for image, defaults to UUID
-	BuildArgs      map[string]*string             // enable user to pass build args to docker daemon
+	BuildArgs      map[string]*string             // enable user to pass build args to docker daemon, a nil value inherits the host environment variable with the same name
 	PrintBuildLog  bool                           // enable user to print build log
 	AuthConfigs    map[string]registry.AuthConfig // Deprecated. Testcontainers will detect registry credentials automatically. Enable auth configs to be able to pull from an authenticated docker registry
 	// KeepImage describes whether DockerContainer.Terminate should not delete the
@@ -102,6 +142,13 @@ type FromDockerfile struct {
 	// advanced configurations while building the image. Please consider that the modifier
 	// is called after the default build options are set.
 	BuildOptionsModifier func(*types.ImageBuildOptions)
+	// Target is the name of the stage to build in a multi-stage Dockerfile.
+	// If empty, the final stage is built.
+	Target string
+	// Secrets are the secrets exposed to the RUN --mount=type=secret,id=<id> instructions
+	// of the Dockerfile, indexed by id, read either from a file on the host or from a value.
+	// Build secrets require BuildKit to be enabled in the Docker daemon.
+	Secrets map[string]BuildSecret
 }
 
 type ContainerFile struct {
@@ -128,11 +175,13 @@ func (c *ContainerFile) validate() error {
 type ContainerRequest struct {
 	FromDockerfile
 	HostAccessPorts         []int
+	HostGatewayAccessPorts  []int // Host ports reachable from the container at the hostname returned by HostAccessHostname, through the host gateway if supported by the daemon, or through the SSHD tunnel otherwise. Nil disables it
 	Image                   string
 	ImageSubstitutors       []ImageSubstitutor
 	Entrypoint              []string
 	Env                     map[string]string
 	ExposedPorts            []string // allow specifying protocol info
+	HostPortBindingIP       string   // Host IP the exposed ports are published on when their spec has no IP, e.g. "127.0.0.1", instead of the default of the daemon
 	Cmd                     []string
 	Labels                  map[string]string
 	Mounts                  ContainerMounts
@@ -156,15 +205,43 @@ type ContainerRequest struct {
 	AutoRemove              bool                                       // Deprecated: Use HostConfigModifier instead. If set to true, the container will be removed from the host when stopped
 	AlwaysPullImage         bool                                       // Always pull image
 	ImagePlatform           string                                     // ImagePlatform describes the platform which the image runs on.
+	PullRetryPolicy         *PullRetryPolicy                           // Retry policy of the image pull on transient errors, see PullRetryPolicy for the retries if nil
+	PullTimeout             time.Duration                              // Timeout of the image pull, including its retries, independent of the wait strategy. The pull inherits the context of the request if zero
+	PropagateProxy          bool                                       // Propagate the proxy settings of the host to the container environment and build args. It can be enabled for all the containers with the proxy.propagate property
 	Binds                   []string                                   // Deprecated: Use HostConfigModifier instead
 	ShmSize                 int64                                      // Amount of memory shared with the host (in bytes)
 	CapAdd                  []string                                   // Deprecated: Use HostConfigModifier instead. Add Linux capabilities
 	CapDrop                 []string                                   // Deprecated: Use HostConfigModifier instead. Drop Linux capabilities
-	ConfigModifier          func(*container.Config)                    // Modifier for the config before container creation
-	HostConfigModifier      func(*container.HostConfig)                // Modifier for the host config before container creation
-	EnpointSettingsModifier func(map[string]*network.EndpointSettings) // Modifier for the network settings before container creation
+	ConfigModifier          func(*container.Config)                    // Modifier for the config before container creation. The labels of Testcontainers are merged into the modified labels afterwards
+	HostConfigModifier      func(*container.HostConfig)                // Modifier for the host config before container creation, applied after HostConfigModifiers
+	HostConfigModifiers     []func(*container.HostConfig)              // Modifiers for the host config before container creation, applied in order, see AddHostConfigModifier. The slice fields set by a modifier are kept if a later one replaces them
+	HostConfigOverride      func(*container.HostConfig)                // Modifier for the host config applied after all the other modifiers, without append semantics, so that it can remove the entries of the slice fields
+	EnpointSettingsModifier func(map[string]*network.EndpointSettings) // Deprecated: Use EndpointSettingsModifier instead
 	LifecycleHooks          []ContainerLifecycleHooks                  // define hooks to be executed during container lifecycle
 	LogConsumerCfg          *LogConsumerConfig                         // define the configuration for the log producer and its log consumers to follow the logs
+	FailureLogLines         int                                        // Number of the last log lines attached to the error when the container fails to start or to become ready, 100 if zero. Negative values disable it
+	AttachStdout            io.Writer                                  // Writer receiving the stdout of the container process as it happens, attached before the container starts
+	AttachStderr            io.Writer                                  // Writer receiving the stderr of the container process as it happens, attached before the container starts
+	KeepOnFailure           bool                                       // Keep the container if it fails to start or to become ready, for post-mortem debugging. It's excluded from the reaper, and removed by CleanupOrphans. It can be enabled for all the containers with the keep.on.failure property
+	SessionID               string                                     // Session of the container, overriding the test session in its labels and in the reaper it registers with, e.g. for a session shared across CI jobs coordinated externally. The reaper of the session is created on demand
+	ResourceLimits          ResourceLimits                             // Limits of the CPU, memory and PIDs of the container, applied to the host config before HostConfigModifiers, so that they can be overridden
+
+	// EndpointSettingsModifier modifies the endpoint settings of the networks before container creation,
+	// keyed by network name, e.g. to set per-endpoint IPAM settings. It's applied after EnpointSettingsModifier.
+	EndpointSettingsModifier func(map[string]*network.EndpointSettings)
+
+	// PreCreateInspector observes the final configs right before the container is created, after all
+	// the modifiers and the hooks. It's a last-resort API, and the configs must not be modified.
+	PreCreateInspector func(config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig)
+
+	// PreCreateHostHooks run on the host, in order, after the customizers and the validation of the request,
+	// and before the image is pulled and the container is created, e.g. to generate certificates depending
+	// on values resolved late, such as the name of the container. They can modify the request, e.g. to
+	// append Files or Env, and the first error aborts the creation of the container.
+	PreCreateHostHooks []func(ctx context.Context, req *ContainerRequest) error
+
+	// envValidators validate the env vars required by the modules, see WithEnvValidators.
+	envValidators []envValidators
 }
 
 // containerOptions functional options for a container
@@ -193,6 +270,78 @@ func WithRegistryCredentials(registryCredentials string) ContainerOption {
 	}
 }
 
+// Clone returns a deep copy of the request, so that the maps and slices of the copy, including
+// the lifecycle hooks, the files, the mounts and the fields of FromDockerfile, can be modified
+// without affecting the original request, e.g. to tweak a base request per test case.
+// The functions, the wait strategy, the readers of the files, the build context archive
+// and the deprecated Resources field are shared with the original request.
+func (c ContainerRequest) Clone() ContainerRequest {
+	clone := c
+
+	clone.FromDockerfile.BuildArgs = maps.Clone(c.FromDockerfile.BuildArgs)
+	clone.FromDockerfile.AuthConfigs = maps.Clone(c.FromDockerfile.AuthConfigs)
+	clone.FromDockerfile.Secrets = maps.Clone(c.FromDockerfile.Secrets)
+
+	clone.HostAccessPorts = slices.Clone(c.HostAccessPorts)
+	clone.HostGatewayAccessPorts = slices.Clone(c.HostGatewayAccessPorts)
+	clone.ImageSubstitutors = slices.Clone(c.ImageSubstitutors)
+	clone.Entrypoint = slices.Clone(c.Entrypoint)
+	clone.Env = maps.Clone(c.Env)
+	clone.ExposedPorts = slices.Clone(c.ExposedPorts)
+	clone.Cmd = slices.Clone(c.Cmd)
+	clone.Labels = maps.Clone(c.Labels)
+	clone.Mounts = slices.Clone(c.Mounts)
+	clone.Tmpfs = maps.Clone(c.Tmpfs)
+	clone.ExtraHosts = slices.Clone(c.ExtraHosts)
+	clone.Networks = slices.Clone(c.Networks)
+	clone.Files = slices.Clone(c.Files)
+	clone.ReaperOptions = slices.Clone(c.ReaperOptions)
+	clone.Binds = slices.Clone(c.Binds)
+	clone.CapAdd = slices.Clone(c.CapAdd)
+	clone.CapDrop = slices.Clone(c.CapDrop)
+	clone.HostConfigModifiers = slices.Clone(c.HostConfigModifiers)
+	clone.PreCreateHostHooks = slices.Clone(c.PreCreateHostHooks)
+	clone.envValidators = slices.Clone(c.envValidators)
+
+	if c.NetworkAliases != nil {
+		clone.NetworkAliases = make(map[string][]string, len(c.NetworkAliases))
+		for nw, aliases := range c.NetworkAliases {
+			clone.NetworkAliases[nw] = slices.Clone(aliases)
+		}
+	}
+
+	if c.PullRetryPolicy != nil {
+		policy := *c.PullRetryPolicy
+		clone.PullRetryPolicy = &policy
+	}
+
+	if c.LifecycleHooks != nil {
+		clone.LifecycleHooks = make([]ContainerLifecycleHooks, len(c.LifecycleHooks))
+		for i, hooks := range c.LifecycleHooks {
+			clone.LifecycleHooks[i] = ContainerLifecycleHooks{
+				PreCreates:     slices.Clone(hooks.PreCreates),
+				PostCreates:    slices.Clone(hooks.PostCreates),
+				PreStarts:      slices.Clone(hooks.PreStarts),
+				PostStarts:     slices.Clone(hooks.PostStarts),
+				PostReadies:    slices.Clone(hooks.PostReadies),
+				PreStops:       slices.Clone(hooks.PreStops),
+				PostStops:      slices.Clone(hooks.PostStops),
+				PreTerminates:  slices.Clone(hooks.PreTerminates),
+				PostTerminates: slices.Clone(hooks.PostTerminates),
+			}
+		}
+	}
+
+	if c.LogConsumerCfg != nil {
+		clone.LogConsumerCfg = &LogConsumerConfig{
+			Opts:      slices.Clone(c.LogConsumerCfg.Opts),
+			Consumers: slices.Clone(c.LogConsumerCfg.Consumers),
+		}
+	}
+
+	return clone
+}
+
 // Validate ensures that the ContainerRequest does not have invalid parameters configured to it
 // ex. make sure you are not specifying both an image as well as a context
 func (c *ContainerRequest) Validate() error {
@@ -200,6 +349,9 @@ func (c *ContainerRequest) Validate() error {
 		c.validateContextAndImage,
 		c.validateContextOrImageIsSpecified,
 		c.validateMounts,
+		c.validateSessionID,
+		c.validateHostPortBindingIP,
+		c.validateEnv,
 	}
 
 	var err error
@@ -272,9 +424,60 @@ func parseDockerIgnore(targetDir string) (bool, []string, error) {
 	return exists, excluded, nil
 }
 
-// GetBuildArgs returns the env args to be used when creating from Dockerfile
+// GetBuildArgs returns the env args to be used when creating from Dockerfile.
+// The args with a nil value inherit the value of the host environment variable with the
+// same name, like docker build --build-arg FOO does, staying nil if the variable is not set.
+// The args with a non-nil value are used as-is, taking precedence over the host environment.
 func (c *ContainerRequest) GetBuildArgs() map[string]*string {
-	return c.FromDockerfile.BuildArgs
+	if c.FromDockerfile.BuildArgs == nil {
+		return nil
+	}
+
+	buildArgs := make(map[string]*string, len(c.FromDockerfile.BuildArgs))
+	for k, v := range c.FromDockerfile.BuildArgs {
+		if v == nil {
+			if value, ok := os.LookupEnv(k); ok {
+				v = &value
+			}
+		}
+
+		buildArgs[k] = v
+	}
+
+	return buildArgs
+}
+
+// GetBuildSecrets returns the secrets used to build the image from the Dockerfile, indexed by id,
+// with the contents of the files for the secrets read from a file.
+func (c *ContainerRequest) GetBuildSecrets() (map[string][]byte, error) {
+	if len(c.FromDockerfile.Secrets) == 0 {
+		return nil, nil
+	}
+
+	secrets := make(map[string][]byte, len(c.FromDockerfile.Secrets))
+	for id, secret := range c.FromDockerfile.Secrets {
+		if id == "" {
+			return nil, errors.New("build secret id must not be empty")
+		}
+
+		if secret.File != "" && secret.Value != "" {
+			return nil, fmt.Errorf("build secret %q: only one of File and Value can be set", id)
+		}
+
+		if secret.File == "" {
+			secrets[id] = []byte(secret.Value)
+			continue
+		}
+
+		content, err := os.ReadFile(secret.File)
+		if err != nil {
+			return nil, fmt.Errorf("read build secret %q: %w", id, err)
+		}
+
+		secrets[id] = content
+	}
+
+	return secrets, nil
 }
 
 // GetDockerfile returns the Dockerfile from the ContainerRequest, defaults to "Dockerfile"
@@ -412,6 +615,7 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 	buildOptions := types.ImageBuildOptions{
 		Remove:      true,
 		ForceRemove: true,
+		Target:      c.FromDockerfile.Target,
 	}
 
 	if c.FromDockerfile.BuildOptionsModifier != nil {
@@ -447,7 +651,7 @@ func (c *ContainerRequest) BuildOptions() (types.ImageBuildOptions, error) {
 		}
 
 		if modifiedTag != tag {
-			Logger.Printf("✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), tag, modifiedTag)
+			infof(Logger, "✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), tag, modifiedTag)
 			tag = modifiedTag
 		}
 	}
@@ -491,7 +695,7 @@ func (c *ContainerRequest) validateContextOrImageIsSpecified() error {
 }
 
 // validateMounts ensures that the mounts do not have duplicate targets.
-// It will check the Mounts and HostConfigModifier.Binds fields.
+// It will check the Mounts and the Binds set by the modifiers of the host config.
 func (c *ContainerRequest) validateMounts() error {
 	targets := make(map[string]bool, len(c.Mounts))
 
@@ -505,13 +709,13 @@ func (c *ContainerRequest) validateMounts() error {
 		}
 	}
 
-	if c.HostConfigModifier == nil {
+	if c.HostConfigModifier == nil && len(c.HostConfigModifiers) == 0 && c.HostConfigOverride == nil {
 		return nil
 	}
 
 	hostConfig := container.HostConfig{}
 
-	c.HostConfigModifier(&hostConfig)
+	c.applyHostConfigModifiers(&hostConfig)
 
 	if hostConfig.Binds != nil && len(hostConfig.Binds) > 0 {
 		for _, bind := range hostConfig.Binds {
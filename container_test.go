@@ -143,6 +143,124 @@ func Test_GetDockerfile(t *testing.T) {
 	}
 }
 
+func Test_GetBuildArgs(t *testing.T) {
+	t.Setenv("TC_INHERITED_ARG", "from host")
+	t.Setenv("TC_EXPLICIT_ARG", "from host")
+
+	explicit := "explicit"
+	req := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			BuildArgs: map[string]*string{
+				"TC_INHERITED_ARG": nil,
+				"TC_EXPLICIT_ARG":  &explicit,
+				"TC_UNSET_ARG":     nil,
+			},
+		},
+	}
+
+	buildArgs := req.GetBuildArgs()
+	require.Len(t, buildArgs, 3)
+	require.NotNil(t, buildArgs["TC_INHERITED_ARG"])
+	require.Equal(t, "from host", *buildArgs["TC_INHERITED_ARG"])
+	// explicit values take precedence over the host environment
+	require.NotNil(t, buildArgs["TC_EXPLICIT_ARG"])
+	require.Equal(t, "explicit", *buildArgs["TC_EXPLICIT_ARG"])
+	// args not set in the host environment stay nil
+	require.Contains(t, buildArgs, "TC_UNSET_ARG")
+	require.Nil(t, buildArgs["TC_UNSET_ARG"])
+
+	// the request is not modified
+	require.Nil(t, req.BuildArgs["TC_INHERITED_ARG"])
+
+	require.Nil(t, (&testcontainers.ContainerRequest{}).GetBuildArgs())
+}
+
+func TestContainerRequest_Clone(t *testing.T) {
+	buildArg := "value"
+	hook := func(_ context.Context, _ testcontainers.Container) error { return nil }
+
+	original := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			BuildArgs: map[string]*string{"ARG": &buildArg},
+			Secrets:   map[string]testcontainers.BuildSecret{"token": {Value: "value"}},
+		},
+		Image:          "docker.io/alpine",
+		Env:            map[string]string{"FOO": "bar"},
+		Cmd:            []string{"sleep", "10"},
+		ExposedPorts:   []string{"80/tcp"},
+		Labels:         map[string]string{"label": "value"},
+		Networks:       []string{"network"},
+		NetworkAliases: map[string][]string{"network": {"alias"}},
+		Files: []testcontainers.ContainerFile{
+			{HostFilePath: "file", ContainerFilePath: "/file"},
+		},
+		Mounts: testcontainers.Mounts(testcontainers.VolumeMount("volume", "/data")),
+		LifecycleHooks: []testcontainers.ContainerLifecycleHooks{
+			{PostStarts: []testcontainers.ContainerHook{hook}},
+		},
+		PullRetryPolicy: &testcontainers.PullRetryPolicy{MaxAttempts: 2},
+	}
+
+	// keep a second deep copy to compare with, as the functions cannot be compared
+	expected := original.Clone()
+
+	clone := original.Clone()
+	clone.FromDockerfile.BuildArgs["ARG"] = nil
+	clone.FromDockerfile.Secrets["other"] = testcontainers.BuildSecret{Value: "value"}
+	clone.Env["FOO"] = "changed"
+	clone.Env["NEW"] = "value"
+	clone.Cmd[1] = "20"
+	clone.ExposedPorts = append(clone.ExposedPorts[:0], "8080/tcp")
+	clone.Labels["label"] = "changed"
+	clone.Networks[0] = "other"
+	clone.NetworkAliases["network"][0] = "changed"
+	clone.Files[0].ContainerFilePath = "/changed"
+	clone.Mounts[0].Target = "/changed"
+	clone.LifecycleHooks[0].PostStarts = append(clone.LifecycleHooks[0].PostStarts, hook)
+	clone.LifecycleHooks[0].PreStops = []testcontainers.ContainerHook{hook}
+	clone.PullRetryPolicy.MaxAttempts = 10
+
+	require.Equal(t, expected.FromDockerfile.BuildArgs, original.FromDockerfile.BuildArgs)
+	require.Equal(t, expected.FromDockerfile.Secrets, original.FromDockerfile.Secrets)
+	require.Equal(t, expected.Env, original.Env)
+	require.Equal(t, expected.Cmd, original.Cmd)
+	require.Equal(t, expected.ExposedPorts, original.ExposedPorts)
+	require.Equal(t, expected.Labels, original.Labels)
+	require.Equal(t, expected.Networks, original.Networks)
+	require.Equal(t, expected.NetworkAliases, original.NetworkAliases)
+	require.Equal(t, expected.Files, original.Files)
+	require.Equal(t, expected.Mounts, original.Mounts)
+	require.Len(t, original.LifecycleHooks[0].PostStarts, 1)
+	require.Empty(t, original.LifecycleHooks[0].PreStops)
+	require.Equal(t, 2, original.PullRetryPolicy.MaxAttempts)
+}
+
+func TestGenericContainer_doesNotModifyRequest(t *testing.T) {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:  "docker.io/alpine",
+		Cmd:    []string{"sleep", "10"},
+		Labels: map[string]string{"label": "value"},
+		// the capacity allows appending without reallocating
+		Networks: make([]string, 0, 10),
+	}
+	expected := req.Clone()
+
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// the default labels are not added to the labels of the caller
+	require.Equal(t, expected.Labels, req.Labels)
+	// the default network is not appended to the backing array of the networks of the caller
+	require.Empty(t, req.Networks[:1][0])
+	require.Empty(t, req.LifecycleHooks)
+}
+
 func Test_BuildImageWithContexts(t *testing.T) {
 	type TestCase struct {
 		Name               string
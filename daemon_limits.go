@@ -0,0 +1,211 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/errdefs"
+)
+
+const (
+	// defaultDaemonMaxConcurrency is the default maximum number of expensive operations sent concurrently to the daemon.
+	defaultDaemonMaxConcurrency = 64
+	// defaultDaemonMaxRetries is the default maximum number of retries of the daemon operations failing with a transient error.
+	defaultDaemonMaxRetries = 3
+)
+
+// DaemonOperation is an expensive operation sent to the Docker daemon, whose concurrency
+// is limited, and which is retried when it fails with a transient error.
+type DaemonOperation string
+
+const (
+	DaemonOperationCreate DaemonOperation = "create"
+	DaemonOperationStart  DaemonOperation = "start"
+	DaemonOperationBuild  DaemonOperation = "build"
+	DaemonOperationPull   DaemonOperation = "pull"
+)
+
+// DaemonHooks are notified of the expensive operations sent to the Docker daemon, e.g. to monitor
+// the load of the parallel tests on the daemon. The hooks are called concurrently, so they must be
+// safe for concurrent use.
+type DaemonHooks struct {
+	// Queued is called when the operation waits for the end of another one, as the maximum number
+	// of operations sent concurrently to the daemon is reached.
+	Queued func(op DaemonOperation)
+	// Retried is called when the operation is retried after failing with a transient error,
+	// with the number of retries of the operation so far.
+	Retried func(op DaemonOperation, retries int, err error)
+}
+
+// daemonHooks are the hooks set with SetDaemonHooks.
+var daemonHooks DaemonHooks
+
+// SetDaemonHooks sets the hooks notified of the expensive operations sent to the Docker daemon.
+// It's not safe for concurrent use, so it must be called before creating any container, e.g. in TestMain.
+func SetDaemonHooks(h DaemonHooks) {
+	daemonHooks = h
+}
+
+func (h DaemonHooks) queued(op DaemonOperation) {
+	if h.Queued != nil {
+		h.Queued(op)
+	}
+}
+
+func (h DaemonHooks) retried(op DaemonOperation, retries int, err error) {
+	if h.Retried != nil {
+		h.Retried(op, retries, err)
+	}
+}
+
+var (
+	// daemonSlotsMtx guards daemonSlots.
+	daemonSlotsMtx sync.Mutex
+	// daemonSlots is the semaphore limiting the daemon operations, sized by the configured limit.
+	daemonSlots chan struct{}
+)
+
+// acquireDaemonSlot waits for a slot to send the operation to the daemon, notifying the hooks
+// if the limit is reached, until the context is done. The returned function releases the slot.
+func (p *DockerProvider) acquireDaemonSlot(ctx context.Context, op DaemonOperation) (func(), error) {
+	limit := p.config.DaemonMaxConcurrency
+	switch {
+	case limit < 0:
+		return func() {}, nil
+	case limit == 0:
+		limit = defaultDaemonMaxConcurrency
+	}
+
+	daemonSlotsMtx.Lock()
+	if cap(daemonSlots) != limit {
+		// the operations in progress release the slots of the previous semaphore.
+		daemonSlots = make(chan struct{}, limit)
+	}
+	slots := daemonSlots
+	daemonSlotsMtx.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+	default:
+		daemonHooks.queued(op)
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return func() { <-slots }, nil
+}
+
+// daemonBackOff returns the jittered exponential backoff of the retries of the daemon operations, bound to the context.
+func (p *DockerProvider) daemonBackOff(ctx context.Context) backoff.BackOff {
+	retries := p.config.DaemonMaxRetries
+	switch {
+	case retries < 0:
+		retries = 0
+	case retries == 0:
+		retries = defaultDaemonMaxRetries
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 200 * time.Millisecond
+	b.MaxInterval = 5 * time.Second
+	// the default randomization factor spreads the retries of the parallel tests.
+	b.MaxElapsedTime = 0
+
+	return backoff.WithContext(backoff.WithMaxRetries(b, uint64(retries)), ctx)
+}
+
+// daemonCall sends the operation to the daemon calling fn, within the concurrency limit of the
+// daemon operations, and retries it with a jittered exponential backoff while it fails with a
+// transient error, see isRetryableDaemonError. The slot of the operation is released while
+// waiting for the next retry.
+func (p *DockerProvider) daemonCall(ctx context.Context, op DaemonOperation, fn func() error) error {
+	var retries int
+
+	return backoff.RetryNotify(
+		func() error {
+			release, err := p.acquireDaemonSlot(ctx, op)
+			if err != nil {
+				return backoff.Permanent(err)
+			}
+			defer release()
+
+			if err := fn(); err != nil {
+				if !isRetryableDaemonError(op, err) {
+					return backoff.Permanent(err)
+				}
+				return err
+			}
+
+			return nil
+		},
+		p.daemonBackOff(ctx),
+		func(err error, duration time.Duration) {
+			retries++
+			warnf(p.Logger, "Failed to %s: %s, will retry in %s", op, err, duration)
+			daemonHooks.retried(op, retries, err)
+		},
+	)
+}
+
+// daemonTransientErrorRegex matches the messages of the transient failures of the daemon
+// which are not reported with a dedicated error type, such as the rate limits.
+var daemonTransientErrorRegex = regexp.MustCompile(`(?i)toomanyrequests|too many requests|connection reset|unexpected EOF|broken pipe`)
+
+// daemonRateLimitErrorRegex matches the messages of the rate limits of the daemon.
+var daemonRateLimitErrorRegex = regexp.MustCompile(`(?i)toomanyrequests|too many requests`)
+
+// isRetryableDaemonError returns true if the daemon operation failed with an error worth retrying.
+// The container creations are not idempotent: a creation failing once the request reached the daemon,
+// e.g. with a closed connection or a 5xx response, may have created the container anyway, so that
+// they are only retried if the request was rejected before being processed, i.e. on a refused
+// connection or a rate limit. The container starts are not retried on an internal server error either,
+// as it's mostly reported for the failures which happen again, such as a port already allocated or
+// an error of the OCI runtime, but only on the other transient errors, e.g. an unavailable daemon.
+func isRetryableDaemonError(op DaemonOperation, err error) bool {
+	switch op {
+	case DaemonOperationCreate:
+		return isRetryableCreateError(err)
+	case DaemonOperationStart:
+		return !errdefs.IsSystem(err) && isTransientDaemonError(err)
+	default:
+		return isTransientDaemonError(err)
+	}
+}
+
+// isRetryableCreateError returns true if the container creation was rejected by the daemon
+// before being processed, i.e. on a refused connection or a rate limit.
+func isRetryableCreateError(err error) bool {
+	switch {
+	case err == nil, errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return true
+	default:
+		return daemonRateLimitErrorRegex.MatchString(err.Error())
+	}
+}
+
+// isTransientDaemonError returns true if the daemon operation failed with an error worth retrying:
+// a 5xx response, a rate limit, or a connection closed by the daemon under load.
+func isTransientDaemonError(err error) bool {
+	switch {
+	case err == nil, errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET):
+		return true
+	case errdefs.IsSystem(err), errdefs.IsUnavailable(err):
+		return true
+	default:
+		return daemonTransientErrorRegex.MatchString(err.Error())
+	}
+}
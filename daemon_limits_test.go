@@ -0,0 +1,216 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestIsTransientDaemonError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "eof", err: fmt.Errorf("read response: %w", io.EOF), expected: true},
+		{name: "unexpected-eof", err: io.ErrUnexpectedEOF, expected: true},
+		{name: "connection-reset", err: syscall.ECONNRESET, expected: true},
+		{name: "internal-server-error", err: errdefs.System(errors.New("daemon busy")), expected: true},
+		{name: "service-unavailable", err: errdefs.Unavailable(errors.New("daemon unavailable")), expected: true},
+		{name: "too-many-requests", err: errdefs.InvalidParameter(errors.New("toomanyrequests: slow down")), expected: true},
+		{name: "not-found", err: errdefs.NotFound(errors.New("no such image")), expected: false},
+		{name: "conflict", err: errdefs.Conflict(errors.New("name already in use")), expected: false},
+		{name: "context-canceled", err: context.Canceled, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isTransientDaemonError(tt.err))
+		})
+	}
+}
+
+func TestIsRetryableDaemonError(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       DaemonOperation
+		err      error
+		expected bool
+	}{
+		{name: "start-eof", op: DaemonOperationStart, err: io.EOF, expected: true},
+		{name: "start-internal-server-error", op: DaemonOperationStart, err: errdefs.System(errors.New("daemon busy")), expected: false},
+		{name: "start-port-allocated", op: DaemonOperationStart, err: errdefs.System(errors.New("Bind for 0.0.0.0:8080 failed: port is already allocated")), expected: false},
+		{name: "start-oci-runtime-error", op: DaemonOperationStart, err: errdefs.System(errors.New("OCI runtime create failed: exec: \"foo\": executable file not found")), expected: false},
+		{name: "start-service-unavailable", op: DaemonOperationStart, err: errdefs.Unavailable(errors.New("daemon unavailable")), expected: true},
+		{name: "build-internal-server-error", op: DaemonOperationBuild, err: errdefs.System(errors.New("daemon busy")), expected: true},
+		{name: "create-eof", op: DaemonOperationCreate, err: io.EOF, expected: false},
+		{name: "create-connection-reset", op: DaemonOperationCreate, err: syscall.ECONNRESET, expected: false},
+		{name: "create-internal-server-error", op: DaemonOperationCreate, err: errdefs.System(errors.New("daemon busy")), expected: false},
+		{name: "create-connection-refused", op: DaemonOperationCreate, err: fmt.Errorf("dial unix: %w", syscall.ECONNREFUSED), expected: true},
+		{name: "create-too-many-requests", op: DaemonOperationCreate, err: errors.New("toomanyrequests: slow down"), expected: true},
+		{name: "create-context-canceled", op: DaemonOperationCreate, err: context.Canceled, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isRetryableDaemonError(tt.op, tt.err))
+		})
+	}
+}
+
+func TestDockerProvider_daemonCall(t *testing.T) {
+	var retried []int
+	SetDaemonHooks(DaemonHooks{
+		Retried: func(op DaemonOperation, retries int, err error) {
+			require.Equal(t, DaemonOperationStart, op)
+			retried = append(retried, retries)
+		},
+	})
+	t.Cleanup(func() { SetDaemonHooks(DaemonHooks{}) })
+
+	p, err := NewDockerProvider()
+	require.NoError(t, err)
+
+	t.Run("transient-errors", func(t *testing.T) {
+		retried = nil
+
+		var calls int
+		err := p.daemonCall(context.Background(), DaemonOperationStart, func() error {
+			calls++
+			if calls < 3 {
+				return io.EOF
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+		require.Equal(t, []int{1, 2}, retried)
+	})
+
+	t.Run("too-many-retries", func(t *testing.T) {
+		retried = nil
+		p.config.DaemonMaxRetries = 1
+		t.Cleanup(func() { p.config.DaemonMaxRetries = 0 })
+
+		err := p.daemonCall(context.Background(), DaemonOperationStart, func() error {
+			return errdefs.Unavailable(errors.New("daemon busy"))
+		})
+		require.ErrorContains(t, err, "daemon busy")
+		require.Equal(t, []int{1}, retried)
+	})
+
+	t.Run("permanent-error", func(t *testing.T) {
+		retried = nil
+
+		var calls int
+		err := p.daemonCall(context.Background(), DaemonOperationStart, func() error {
+			calls++
+			return errdefs.NotFound(errors.New("no such image"))
+		})
+		require.True(t, errdefs.IsNotFound(err), err)
+		require.Equal(t, 1, calls)
+		require.Empty(t, retried)
+	})
+}
+
+func TestDockerProvider_acquireDaemonSlot(t *testing.T) {
+	var queued atomic.Int32
+	SetDaemonHooks(DaemonHooks{
+		Queued: func(DaemonOperation) { queued.Add(1) },
+	})
+	t.Cleanup(func() { SetDaemonHooks(DaemonHooks{}) })
+
+	p := &DockerProvider{}
+	p.config.DaemonMaxConcurrency = 1
+
+	release, err := p.acquireDaemonSlot(context.Background(), DaemonOperationStart)
+	require.NoError(t, err)
+	require.Zero(t, queued.Load())
+
+	t.Run("context-done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := p.acquireDaemonSlot(ctx, DaemonOperationStart)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, int32(1), queued.Load())
+	})
+
+	t.Run("released", func(t *testing.T) {
+		time.AfterFunc(100*time.Millisecond, release)
+
+		release, err := p.acquireDaemonSlot(context.Background(), DaemonOperationStart)
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		p := &DockerProvider{}
+		p.config.DaemonMaxConcurrency = -1
+
+		for i := 0; i < 10; i++ {
+			_, err := p.acquireDaemonSlot(context.Background(), DaemonOperationStart)
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestDaemonConcurrencyLimit(t *testing.T) {
+	t.Setenv("TESTCONTAINERS_DAEMON_MAX_CONCURRENCY", "2")
+	config.Reset() // reset the config using the internal method to avoid the sync.Once
+	t.Cleanup(config.Reset)
+
+	var queued, retried atomic.Int32
+	SetDaemonHooks(DaemonHooks{
+		Queued: func(DaemonOperation) { queued.Add(1) },
+		Retried: func(DaemonOperation, int, error) {
+			retried.Add(1)
+		},
+	})
+	t.Cleanup(func() { SetDaemonHooks(DaemonHooks{}) })
+
+	ctx := context.Background()
+
+	const containers = 50
+
+	var wg sync.WaitGroup
+	errs := make(chan error, containers)
+	for i := 0; i < containers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctr, err := GenericContainer(ctx, GenericContainerRequest{
+				ProviderType: providerType,
+				ContainerRequest: ContainerRequest{
+					Image: "docker.io/busybox",
+					Cmd:   []string{"sleep", "10"},
+				},
+				Started: true,
+			})
+			terminateContainerOnEnd(t, ctx, ctr)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Positive(t, queued.Load())
+	t.Logf("queued operations: %d, retried operations: %d", queued.Load(), retried.Load())
+}
@@ -0,0 +1,107 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// DescriptorVersion is the version of the JSON descriptor of a container, see ContainerDescriptor.
+// It's increased on incompatible changes of the descriptor.
+const DescriptorVersion = 1
+
+var (
+	// ErrStaleDescriptor is returned when attaching to a descriptor whose container doesn't exist anymore.
+	ErrStaleDescriptor = errors.New("stale container descriptor")
+
+	// ErrUnsupportedDescriptor is returned when attaching to a descriptor of another version than DescriptorVersion.
+	ErrUnsupportedDescriptor = errors.New("unsupported container descriptor")
+)
+
+// ContainerDescriptor describes a running container, so that it can be passed across a process boundary,
+// e.g. from a Go helper starting the containers to a test orchestrator written in another language.
+// It's serialized to a stable JSON document, versioned with DescriptorVersion.
+type ContainerDescriptor struct {
+	Version   int               `json:"version"`   // version of the descriptor, see DescriptorVersion
+	ID        string            `json:"id"`        // ID of the container
+	Image     string            `json:"image"`     // image of the container
+	SessionID string            `json:"sessionId"` // session ID of the container, empty if not created by Testcontainers
+	Host      string            `json:"host"`      // host where the ports of the container are exposed
+	Ports     map[string]string `json:"ports"`     // host ports of the container, keyed by container port, e.g. "80/tcp"
+	Networks  map[string]string `json:"networks"`  // IP addresses of the container, keyed by network name
+}
+
+// Descriptor returns the descriptor of the running container, which can be serialized with json.Marshal,
+// and attached to in another process with AttachToDescriptor.
+func (c *DockerContainer) Descriptor(ctx context.Context) (*ContainerDescriptor, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("host: %w", err)
+	}
+
+	d := &ContainerDescriptor{
+		Version:   DescriptorVersion,
+		ID:        c.ID,
+		Image:     c.Image,
+		SessionID: c.sessionID,
+		Host:      host,
+		Ports:     make(map[string]string),
+		Networks:  make(map[string]string),
+	}
+
+	for port, bindings := range inspect.NetworkSettings.Ports {
+		if len(bindings) > 0 {
+			d.Ports[string(port)] = bindings[0].HostPort
+		}
+	}
+
+	for name, settings := range inspect.NetworkSettings.Networks {
+		d.Networks[name] = settings.IPAddress
+	}
+
+	return d, nil
+}
+
+// AttachToDescriptor returns a handle to the container of the given JSON descriptor, produced by the
+// Descriptor method of a container, usually in another process. The container is re-inspected, so the
+// handle is usable as the one returned by ContainerFromID: it's not owned by the current process, and
+// Terminate returns an error wrapping ErrContainerNotOwned unless the WithForce option is set.
+// An error wrapping ErrStaleDescriptor is returned if the container doesn't exist anymore, and one
+// wrapping ErrUnsupportedDescriptor if the descriptor is not of the DescriptorVersion version.
+func AttachToDescriptor(ctx context.Context, data []byte) (*DockerContainer, error) {
+	var d ContainerDescriptor
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("unmarshal descriptor: %w", err)
+	}
+
+	if d.Version != DescriptorVersion {
+		return nil, fmt.Errorf("%w: version %d, expected %d", ErrUnsupportedDescriptor, d.Version, DescriptorVersion)
+	}
+
+	if d.ID == "" {
+		return nil, fmt.Errorf("%w: missing container ID", ErrUnsupportedDescriptor)
+	}
+
+	ctr, err := ContainerFromID(ctx, d.ID)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %w", ErrStaleDescriptor, err)
+		}
+		return nil, err
+	}
+
+	// the session of the descriptor, even if the container has no session label, e.g. when kept on failure.
+	if ctr.sessionID == "" {
+		ctr.sessionID = d.SessionID
+	}
+
+	return ctr, nil
+}
@@ -0,0 +1,98 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestAttachToDescriptor(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// containerDescriptor {
+	descriptor, err := ctr.(*DockerContainer).Descriptor(ctx)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(descriptor)
+	require.NoError(t, err)
+	// }
+
+	port, err := ctr.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	require.Equal(t, DescriptorVersion, descriptor.Version)
+	require.Equal(t, ctr.GetContainerID(), descriptor.ID)
+	require.Equal(t, ctr.SessionID(), descriptor.SessionID)
+	require.Equal(t, port.Port(), descriptor.Ports[nginxDefaultPort])
+	require.NotEmpty(t, descriptor.Networks)
+
+	t.Run("subprocess", func(t *testing.T) {
+		// force verbosity in subprocesses, so that the output is printed
+		cmd := exec.Command(os.Args[0], "-test.run=TestHelperDescriptorAttacherProcess", "-test.v=true")
+		cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1", "TESTCONTAINERS_HELPER_DESCRIPTOR="+string(data))
+
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	})
+
+	t.Run("unsupported-version", func(t *testing.T) {
+		_, err := AttachToDescriptor(ctx, []byte(`{"version": 0, "id": "`+descriptor.ID+`"}`))
+		require.ErrorIs(t, err, ErrUnsupportedDescriptor)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		require.NoError(t, ctr.Terminate(ctx))
+
+		_, err := AttachToDescriptor(ctx, data)
+		require.ErrorIs(t, err, ErrStaleDescriptor)
+	})
+}
+
+// TestHelperDescriptorAttacherProcess is a helper function
+// to attach to a container descriptor in a subprocess. It's not a real test.
+func TestHelperDescriptorAttacherProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		t.Skip("Skipping helper test function. It's not a real test")
+	}
+
+	ctx := context.Background()
+
+	data := []byte(os.Getenv("TESTCONTAINERS_HELPER_DESCRIPTOR"))
+
+	var descriptor ContainerDescriptor
+	require.NoError(t, json.Unmarshal(data, &descriptor))
+
+	// attachToDescriptor {
+	ctr, err := AttachToDescriptor(ctx, data)
+	// }
+	require.NoError(t, err)
+	require.Equal(t, descriptor.ID, ctr.GetContainerID())
+	require.Equal(t, descriptor.SessionID, ctr.SessionID())
+
+	port, err := ctr.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	require.Equal(t, descriptor.Ports[nginxDefaultPort], port.Port())
+
+	require.NoError(t, ctr.WaitUntilReady(ctx, wait.ForListeningPort(nginxDefaultPort)))
+
+	// the container is not owned by the helper process.
+	require.ErrorIs(t, ctr.Terminate(ctx), ErrContainerNotOwned)
+}
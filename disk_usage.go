@@ -0,0 +1,179 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+)
+
+// diskUsageCacheTTL is how long the disk usage of the daemon is cached, as computing it is expensive.
+const diskUsageCacheTTL = 10 * time.Second
+
+// ErrLowDiskSpace is returned when the free disk space of the Docker daemon is below the configured minimum,
+// see DaemonDiskUsage.
+var ErrLowDiskSpace = errors.New("low disk space on the Docker daemon")
+
+// DiskUsage is the disk space used by the Docker daemon, in bytes, see DaemonDiskUsage.
+type DiskUsage struct {
+	Images     int64 // size of the image layers, counting the layers shared by several images once
+	Containers int64 // size of the writable layers of the containers
+	Volumes    int64 // size of the local volumes
+	BuildCache int64 // size of the build cache
+
+	// Free is the free disk space of the data root of the daemon, or -1 if it's unknown,
+	// e.g. for a remote daemon, or a daemon running in a VM, such as Docker Desktop.
+	Free int64
+}
+
+// Total returns the disk space used by the images, the containers, the volumes and the build cache.
+func (u DiskUsage) Total() int64 {
+	return u.Images + u.Containers + u.Volumes + u.BuildCache
+}
+
+// String returns a human-readable summary of the disk usage,
+// e.g. "images: 1.2GB, containers: 3MB, volumes: 0B, build cache: 0B, free: 2GB".
+func (u DiskUsage) String() string {
+	free := "unknown"
+	if u.Free >= 0 {
+		free = units.HumanSize(float64(u.Free))
+	}
+
+	return fmt.Sprintf("images: %s, containers: %s, volumes: %s, build cache: %s, free: %s",
+		units.HumanSize(float64(u.Images)),
+		units.HumanSize(float64(u.Containers)),
+		units.HumanSize(float64(u.Volumes)),
+		units.HumanSize(float64(u.BuildCache)),
+		free,
+	)
+}
+
+var (
+	// diskUsageMtx guards diskUsageCache and diskUsageCachedAt.
+	diskUsageMtx sync.Mutex
+	// diskUsageCache is the disk usage of the daemon computed last, at diskUsageCachedAt.
+	diskUsageCache    DiskUsage
+	diskUsageCachedAt time.Time
+)
+
+// DaemonDiskUsage returns the disk space used by the Docker daemon, from its /system/df endpoint, and its
+// free disk space when it can be known. The result is cached for a few seconds, as computing it is expensive.
+func DaemonDiskUsage(ctx context.Context) (DiskUsage, error) {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+	defer cli.Close()
+
+	return daemonDiskUsage(ctx, cli)
+}
+
+// daemonDiskUsage returns the disk usage of the daemon of the client, from the cache if it's recent enough.
+func daemonDiskUsage(ctx context.Context, cli client.APIClient) (DiskUsage, error) {
+	diskUsageMtx.Lock()
+	defer diskUsageMtx.Unlock()
+
+	if !diskUsageCachedAt.IsZero() && time.Since(diskUsageCachedAt) < diskUsageCacheTTL {
+		return diskUsageCache, nil
+	}
+
+	df, err := cli.DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return DiskUsage{}, fmt.Errorf("disk usage: %w", err)
+	}
+
+	usage := diskUsageFromDF(df)
+	usage.Free = daemonFreeDiskSpace(ctx, cli)
+
+	diskUsageCache = usage
+	diskUsageCachedAt = time.Now()
+
+	return usage, nil
+}
+
+// diskUsageFromDF sums up the disk usage reported by the /system/df endpoint of the daemon.
+// The free disk space is unknown.
+func diskUsageFromDF(df types.DiskUsage) DiskUsage {
+	usage := DiskUsage{
+		Images: df.LayersSize,
+		Free:   -1,
+	}
+
+	for _, c := range df.Containers {
+		if c != nil {
+			usage.Containers += c.SizeRw
+		}
+	}
+
+	for _, v := range df.Volumes {
+		// the size is -1 if it can't be computed, e.g. for the volumes of another driver.
+		if v != nil && v.UsageData != nil && v.UsageData.Size > 0 {
+			usage.Volumes += v.UsageData.Size
+		}
+	}
+
+	for _, bc := range df.BuildCache {
+		if bc != nil {
+			usage.BuildCache += bc.Size
+		}
+	}
+
+	return usage
+}
+
+// daemonFreeDiskSpace returns the free disk space of the data root of the daemon, or -1 if it's unknown.
+// It's only known when the daemon runs on the same host as the tests, as the data root is then
+// a local directory.
+func daemonFreeDiskSpace(ctx context.Context, cli client.APIClient) int64 {
+	if !strings.HasPrefix(cli.DaemonHost(), "unix://") {
+		return -1
+	}
+
+	info, err := cli.Info(ctx)
+	if err != nil || info.DockerRootDir == "" || info.OperatingSystem == "Docker Desktop" {
+		// the data root of Docker Desktop is in its VM.
+		return -1
+	}
+
+	return freeDiskSpace(info.DockerRootDir)
+}
+
+// checkDiskSpace returns an ErrLowDiskSpace error, with a summary of the disk usage, if the free disk space
+// of the daemon is known, and less than the given minimum.
+func (p *DockerProvider) checkDiskSpace(ctx context.Context, minFree int64) error {
+	usage, err := daemonDiskUsage(ctx, p.client)
+	if err != nil {
+		// the guard doesn't prevent the creation of the container if the disk usage is unavailable.
+		warnf(p.Logger, "Failed to get the disk usage of the Docker daemon: %s", err)
+		return nil
+	}
+
+	if usage.Free >= 0 && usage.Free < minFree {
+		return fmt.Errorf("%w: less than %s free (%s)", ErrLowDiskSpace, units.HumanSize(float64(minFree)), usage)
+	}
+
+	return nil
+}
+
+// SkipIfLowDiskSpace is a utility function capable of skipping tests if the free disk space of the Docker
+// daemon is less than the given number of bytes, instead of letting them fail with misleading image pull
+// errors. Tests are not skipped if the free disk space can't be known, e.g. with Docker Desktop.
+func SkipIfLowDiskSpace(tb testing.TB, minFreeBytes int64) {
+	tb.Helper()
+
+	usage, err := DaemonDiskUsage(context.Background())
+	if err != nil {
+		tb.Fatalf("failed to get the disk usage of the Docker daemon: %s", err)
+	}
+
+	if usage.Free >= 0 && usage.Free < minFreeBytes {
+		tb.Skipf("Skipping test as the Docker daemon has less than %s free: %s", units.HumanSize(float64(minFreeBytes)), usage)
+	}
+}
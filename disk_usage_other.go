@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package testcontainers
+
+// freeDiskSpace returns -1, as the free disk space is not known on this platform.
+func freeDiskSpace(_ string) int64 {
+	return -1
+}
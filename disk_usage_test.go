@@ -0,0 +1,97 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestDiskUsageFromDF(t *testing.T) {
+	// recorded response of the /system/df endpoint of the daemon.
+	raw, err := os.ReadFile(filepath.Join("testdata", "system_df.json"))
+	require.NoError(t, err)
+
+	var df types.DiskUsage
+	require.NoError(t, json.Unmarshal(raw, &df))
+
+	usage := diskUsageFromDF(df)
+	require.Equal(t, DiskUsage{
+		Images:     1092588,
+		Containers: 1024 + 2048,
+		// the size of the volume of another driver is unknown.
+		Volumes:    10920104,
+		BuildCache: 51,
+		Free:       -1,
+	}, usage)
+	require.Equal(t, int64(1092588+3072+10920104+51), usage.Total())
+	require.Equal(t, "images: 1.093MB, containers: 3.072kB, volumes: 10.92MB, build cache: 51B, free: unknown", usage.String())
+
+	usage.Free = 2_000_000_000
+	require.Contains(t, usage.String(), "free: 2GB")
+}
+
+func TestDaemonDiskUsage(t *testing.T) {
+	ctx := context.Background()
+
+	usage, err := DaemonDiskUsage(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, usage.Total(), int64(0))
+
+	// the disk usage is cached.
+	cached, err := DaemonDiskUsage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, usage, cached)
+}
+
+func TestSkipIfLowDiskSpace(t *testing.T) {
+	usage, err := DaemonDiskUsage(context.Background())
+	require.NoError(t, err)
+
+	var skipped bool
+	t.Run("absurd-threshold", func(t *testing.T) {
+		defer func() {
+			skipped = t.Skipped()
+		}()
+
+		// skipIfLowDiskSpace {
+		SkipIfLowDiskSpace(t, math.MaxInt64)
+		// }
+	})
+
+	// the test is only skipped if the free disk space is known.
+	require.Equal(t, usage.Free >= 0, skipped, usage)
+}
+
+func TestDaemonMinFreeDisk(t *testing.T) {
+	usage, err := DaemonDiskUsage(context.Background())
+	require.NoError(t, err)
+	if usage.Free < 0 {
+		t.Skip("the free disk space of the Docker daemon is unknown")
+	}
+
+	t.Setenv("TESTCONTAINERS_DAEMON_MIN_FREE_DISK", "9223372036854775807")
+	config.Reset() // reset the config using the internal method to avoid the sync.Once
+	t.Cleanup(config.Reset)
+
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/busybox",
+			Cmd:   []string{"sleep", "10"},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.ErrorIs(t, err, ErrLowDiskSpace)
+	require.ErrorContains(t, err, "free:")
+}
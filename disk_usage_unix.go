@@ -0,0 +1,17 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package testcontainers
+
+import "syscall"
+
+// freeDiskSpace returns the disk space available to unprivileged users on the file system
+// of the given directory, or -1 if it can't be known.
+func freeDiskSpace(dir string) int64 {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return -1
+	}
+
+	return int64(stat.Bavail) * int64(stat.Bsize)
+}
@@ -16,6 +16,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -52,6 +54,10 @@ const (
 	logStoppedForOutOfSyncMessage = "Stopping log consumer: Headers out of sync"
 )
 
+// ErrNoWaitStrategy is returned when re-running the readiness check of a container
+// that was created without a wait strategy.
+var ErrNoWaitStrategy = errors.New("no wait strategy")
+
 var createContainerFailDueToNameConflictRegex = regexp.MustCompile("Conflict. The container name .* is already in use by container .*")
 
 // DockerContainer represents a container started using Docker
@@ -85,9 +91,64 @@ type DockerContainer struct {
 
 	logProductionTimeout *time.Duration
 	logger               Logging
-	lifecycleHooks       []ContainerLifecycleHooks
+
+	// lifecycleHooks are the hooks of the container, the ones of the request combined with the default
+	// ones first, then the ones added with AddLifecycleHooks, guarded by hooksMtx.
+	hooksMtx       sync.Mutex
+	lifecycleHooks []ContainerLifecycleHooks
 
 	healthStatus string // container health status, will default to healthStatusNone if no healthcheck is present
+
+	// failureLogLines is the number of log lines attached to the startup errors, see StartupError.
+	failureLogLines int
+
+	// secrets are the sensitive values of the request, redacted in the errors and logs of the container.
+	secrets []string
+
+	// overridesCommand is set if the request overrides the Cmd or the Entrypoint of the image,
+	// enabling the detection of the entrypoint conflicts, see checkEarlyExit.
+	overridesCommand bool
+
+	// attachment is the stream of the output of the container process, see AttachStdout, guarded by
+	// attachmentMtx, as it's detached by both Stop and Terminate, which can be called concurrently.
+	attachmentMtx sync.Mutex
+	attachment    *outputAttachment
+
+	// adopted is set for the containers created outside of Testcontainers, see ContainerFromID.
+	adopted bool
+
+	// keepOnFailure is set for the containers kept if they fail to start or to become ready, see
+	// ContainerRequest.KeepOnFailure, and kept is set once such a container failed, so that Terminate
+	// doesn't remove it.
+	keepOnFailure bool
+	kept          bool
+
+	// hostPortBindingIP is the specific host IP all the ports are published on, if any, see portsBindingIP.
+	// It's computed from the port bindings when the container is created, reused or adopted.
+	hostPortBindingIP string
+
+	// hostAccessHostname is the hostname to reach the host from the container, see WithHostGatewayAccess.
+	hostAccessHostname string
+
+	// moduleInfo is the module which created the container, see WithModuleInfo.
+	moduleInfo ModuleInfo
+
+	// helpers are the containers supporting the container, such as the SSHD container exposing the
+	// host ports, terminated after the PreTerminates hooks of the container, before removing it.
+	helpers []Container
+
+	// terminateMtx guards terminated, set once the container is removed, so that Terminate is idempotent.
+	terminateMtx sync.Mutex
+	terminated   bool
+
+	// snapshots are the IDs of the images committed by Snapshot, by name.
+	snapshots map[string]string
+
+	// shellMtx guards the shell detected in the container, see detectShell.
+	shellMtx      sync.Mutex
+	shell         string
+	shellErr      error
+	shellDetected bool
 }
 
 // SetLogger sets the logger for the container
@@ -134,34 +195,73 @@ func (c *DockerContainer) Endpoint(ctx context.Context, proto string) (string, e
 
 // PortEndpoint gets proto://host:port string for the given exposed port
 // Will returns just host:port if proto is ""
+//
+// The host is bracketed if it's an IPv6 address, e.g. http://[::1]:8080.
 func (c *DockerContainer) PortEndpoint(ctx context.Context, port nat.Port, proto string) (string, error) {
-	host, err := c.Host(ctx)
+	host, err := c.provider.DaemonHost(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	outerPort, err := c.MappedPort(ctx, port)
+	if err != nil {
+		return "", err
+	}
+
+	inspect, err := c.Inspect(ctx)
 	if err != nil {
 		return "", err
 	}
 
+	// the port is reached on the IP it's published on, if it's a specific one.
+	if inspect.NetworkSettings != nil {
+		host = hostForBindingIP(host, portBindingIP(inspect.NetworkSettings.Ports, outerPort))
+	}
+
+	return formatEndpoint(proto, host, outerPort), nil
+}
+
+// EndpointWithHost gets scheme://host:port string for the given exposed port, as PortEndpoint does,
+// but with the given host instead of the resolved one, e.g. the externally reachable host of a remote
+// daemon accessed through an SSH tunnel, or "host.testcontainers.internal".
+// The resolved host is used if the given one is empty.
+func (c *DockerContainer) EndpointWithHost(ctx context.Context, host string, port nat.Port, scheme string) (string, error) {
+	if host == "" {
+		return c.PortEndpoint(ctx, port, scheme)
+	}
+
 	outerPort, err := c.MappedPort(ctx, port)
 	if err != nil {
 		return "", err
 	}
 
-	protoFull := ""
-	if proto != "" {
-		protoFull = fmt.Sprintf("%s://", proto)
+	return formatEndpoint(scheme, host, outerPort), nil
+}
+
+// formatEndpoint returns the scheme://host:port string, or host:port if the scheme is empty,
+// bracketing the host if it's an IPv6 address.
+func formatEndpoint(scheme string, host string, port nat.Port) string {
+	prefix := ""
+	if scheme != "" {
+		prefix = fmt.Sprintf("%s://", scheme)
 	}
 
-	return fmt.Sprintf("%s%s:%s", protoFull, host, outerPort.Port()), nil
+	return prefix + net.JoinHostPort(host, port.Port())
 }
 
 // Host gets host (ip or name) of the docker daemon where the container port is exposed
 // Warning: this is based on your Docker host setting. Will fail if using an SSH tunnel
 // You can use the "TESTCONTAINERS_HOST_OVERRIDE" env variable to set this yourself
+//
+// If all the ports of the container are published on a specific host IP, e.g. with
+// ContainerRequest.HostPortBindingIP, that IP is returned instead, see hostForBindingIP.
 func (c *DockerContainer) Host(ctx context.Context) (string, error) {
 	host, err := c.provider.DaemonHost(ctx)
 	if err != nil {
 		return "", err
 	}
-	return host, nil
+
+	return hostForBindingIP(host, c.hostPortBindingIP), nil
 }
 
 // Inspect gets the raw container info
@@ -176,30 +276,87 @@ func (c *DockerContainer) Inspect(ctx context.Context) (*types.ContainerJSON, er
 
 // MappedPort gets externally mapped port for a container port
 func (c *DockerContainer) MappedPort(ctx context.Context, port nat.Port) (nat.Port, error) {
-	inspect, err := c.Inspect(ctx)
+	ports, err := c.MappedPorts(ctx, port)
 	if err != nil {
 		return "", err
 	}
-	if inspect.ContainerJSONBase.HostConfig.NetworkMode == "host" {
-		return port, nil
-	}
 
-	ports := inspect.NetworkSettings.Ports
+	return ports[port], nil
+}
 
-	for k, p := range ports {
-		if k.Port() != port.Port() {
+// MappedPorts gets the externally mapped ports of the given container ports, keyed by container port,
+// with a single inspect of the container, e.g. to build the endpoints of a container exposing several
+// ports. An error is returned if any of the ports is not mapped.
+func (c *DockerContainer) MappedPorts(ctx context.Context, ports ...nat.Port) (map[nat.Port]nat.Port, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := make(map[nat.Port]nat.Port, len(ports))
+	for _, port := range ports {
+		if inspect.ContainerJSONBase.HostConfig.NetworkMode == "host" {
+			mapped[port] = port
 			continue
 		}
-		if port.Proto() != "" && k.Proto() != port.Proto() {
-			continue
+
+		p, err := mappedPort(inspect.NetworkSettings.Ports, port)
+		if err != nil {
+			return nil, err
 		}
-		if len(p) == 0 {
+		mapped[port] = p
+	}
+
+	return mapped, nil
+}
+
+// mappedPort returns the host port bound to the given container port, using its protocol.
+// If the port has no protocol, e.g. "53", the TCP binding is preferred, falling back to the binding
+// of the only other protocol exposed for that port number. An error is returned if there are
+// bindings for multiple non-TCP protocols, as it's not possible to choose one of them.
+func mappedPort(ports nat.PortMap, port nat.Port) (nat.Port, error) {
+	hostPort := func(k nat.Port) (nat.Port, bool) {
+		bindings := ports[k]
+		if len(bindings) == 0 {
+			return "", false
+		}
+
+		p, err := nat.NewPort(k.Proto(), bindings[0].HostPort)
+		return p, err == nil
+	}
+
+	if strings.Contains(string(port), "/") {
+		if p, ok := hostPort(nat.Port(port.Port() + "/" + port.Proto())); ok {
+			return p, nil
+		}
+
+		return "", fmt.Errorf("port not found: %s", port)
+	}
+
+	if p, ok := hostPort(nat.Port(port.Port() + "/tcp")); ok {
+		return p, nil
+	}
+
+	var candidates []nat.Port
+	for k := range ports {
+		if k.Port() != port.Port() {
 			continue
 		}
-		return nat.NewPort(k.Proto(), p[0].HostPort)
+
+		if _, ok := hostPort(k); ok {
+			candidates = append(candidates, k)
+		}
 	}
 
-	return "", errors.New("port not found")
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("port not found: %s", port)
+	case 1:
+		p, _ := hostPort(candidates[0])
+		return p, nil
+	default:
+		return "", fmt.Errorf("port %s is exposed with multiple protocols %v, please specify one of them", port, candidates)
+	}
 }
 
 // Deprecated: use c.Inspect(ctx).NetworkSettings.Ports instead.
@@ -219,13 +376,25 @@ func (c *DockerContainer) SessionID() string {
 
 // Start will start an already created container
 func (c *DockerContainer) Start(ctx context.Context) error {
+	if err := c.start(ctx); err != nil {
+		return c.keepFailed(err)
+	}
+
+	return nil
+}
+
+// start runs the container and its lifecycle hooks, waiting for it to be ready.
+func (c *DockerContainer) start(ctx context.Context) error {
 	err := c.startingHook(ctx)
 	if err != nil {
 		return fmt.Errorf("starting hook: %w", err)
 	}
 
-	if err := c.provider.client.ContainerStart(ctx, c.ID, container.StartOptions{}); err != nil {
-		return fmt.Errorf("container start: %w", err)
+	err = c.provider.daemonCall(ctx, DaemonOperationStart, func() error {
+		return c.provider.client.ContainerStart(ctx, c.ID, container.StartOptions{})
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrContainerStart, err)
 	}
 	defer c.provider.Close()
 
@@ -244,6 +413,60 @@ func (c *DockerContainer) Start(ctx context.Context) error {
 	return nil
 }
 
+// WaitUntilReady re-runs the wait strategy of the container request against the current state
+// of the container, e.g. to check that the container is ready again after restarting the process
+// inside it. The deadline of the context is honored, in addition to the timeouts of the strategy.
+//
+// If strategies are given, they are run in order instead of the wait strategy of the request,
+// e.g. for a container adopted with ContainerFromID, which has no wait strategy.
+// It returns ErrNoWaitStrategy if there is no strategy to run.
+func (c *DockerContainer) WaitUntilReady(ctx context.Context, strategies ...wait.Strategy) error {
+	if err := c.waitUntilReady(ctx, strategies...); err != nil {
+		return c.keepFailed(err)
+	}
+
+	return nil
+}
+
+// waitUntilReady runs the given strategies, or the wait strategy of the request, in order.
+func (c *DockerContainer) waitUntilReady(ctx context.Context, strategies ...wait.Strategy) error {
+	if len(strategies) == 0 {
+		if c.WaitingFor == nil {
+			return ErrNoWaitStrategy
+		}
+
+		strategies = []wait.Strategy{c.WaitingFor}
+	}
+
+	for _, strategy := range strategies {
+		infof(c.logger,
+			"⏳ Waiting for container id %s image: %s. Waiting for: %s",
+			c.ID[:12], c.Image, redactValues(fmt.Sprintf("%+v", strategy), c.secrets),
+		)
+		limit := waitLimit(ctx, strategy)
+		if err := c.waitStrategy(ctx, strategy); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("%w (%s): %w", ErrWaitStrategyTimeout, limit, err)
+			}
+
+			return fmt.Errorf("wait until ready: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// waitLimit describes what limits the wait of the strategy run with the given context, i.e. the deadline
+// of the context if it ends before the startup timeout of the strategy, see wait.EffectiveDeadline.
+func waitLimit(ctx context.Context, strategy wait.Strategy) string {
+	deadline, ok := ctx.Deadline()
+	if effective, _ := wait.EffectiveDeadline(ctx, strategy); ok && !deadline.After(effective) {
+		return "context deadline"
+	}
+
+	return "startup timeout"
+}
+
 // Stop stops the container.
 //
 // In case the container fails to stop gracefully within a time frame specified
@@ -259,12 +482,31 @@ func (c *DockerContainer) Start(ctx context.Context) error {
 //
 // If the container is already stopped, the method is a no-op.
 func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) error {
-	err := c.stoppingHook(ctx)
+	return c.stop(ctx, container.StopOptions{}, timeout)
+}
+
+// StopWithSignal stops the container as Stop, sending the given signal instead of the
+// stop signal of the container, e.g. "SIGINT" for a process which only shuts down cleanly
+// on it. The container is killed with SIGKILL if it doesn't stop within the timeout.
+//
+// The signal is a Linux signal name, with or without the SIG prefix, e.g. "SIGINT" or
+// "INT", or a signal number, e.g. "2". An error wrapping ErrUnknownSignal is returned for
+// any other value, before calling the hooks.
+func (c *DockerContainer) StopWithSignal(ctx context.Context, signal string, timeout *time.Duration) error {
+	signal, err := parseSignal(signal)
 	if err != nil {
 		return err
 	}
 
-	var options container.StopOptions
+	return c.stop(ctx, container.StopOptions{Signal: signal}, timeout)
+}
+
+// stop stops the container with the given options, see Stop.
+func (c *DockerContainer) stop(ctx context.Context, options container.StopOptions, timeout *time.Duration) error {
+	err := c.stoppingHook(ctx)
+	if err != nil {
+		return err
+	}
 
 	if timeout != nil {
 		timeoutSeconds := int(timeout.Seconds())
@@ -286,8 +528,54 @@ func (c *DockerContainer) Stop(ctx context.Context, timeout *time.Duration) erro
 	return nil
 }
 
+// Pause freezes all the processes of the container, with the cgroups freezer, so that
+// connections to it hang instead of being refused as for a stopped container, e.g. to test
+// the timeouts and retries of a client against a stalled dependency. The container is still
+// running, and State reports it as paused until Unpause is called.
+func (c *DockerContainer) Pause(ctx context.Context) error {
+	defer c.provider.Close()
+
+	return c.provider.client.ContainerPause(ctx, c.ID)
+}
+
+// Unpause resumes all the processes of a container paused with Pause.
+func (c *DockerContainer) Unpause(ctx context.Context) error {
+	defer c.provider.Close()
+
+	return c.provider.client.ContainerUnpause(ctx, c.ID)
+}
+
 // Terminate is used to kill the container. It is usually triggered by as defer function.
-func (c *DockerContainer) Terminate(ctx context.Context) error {
+// By default the container is killed and removed along with its anonymous volumes, which can be
+// changed with the WithStopTimeout, WithRemoveVolumes and WithForce options.
+//
+// All hooks are called in the following order:
+//   - [ContainerLifecycleHooks.PreTerminates]
+//   - [ContainerLifecycleHooks.PreStops] and [ContainerLifecycleHooks.PostStops], if the container is stopped gracefully
+//   - [ContainerLifecycleHooks.PostTerminates]
+func (c *DockerContainer) Terminate(ctx context.Context, opts ...TerminateOption) error {
+	var options terminateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if c.adopted && options.force == nil {
+		return fmt.Errorf("%w: %s, terminate it with WithForce", ErrContainerNotOwned, c.ID)
+	}
+
+	if c.kept && options.force == nil {
+		infof(c.logger, "🩺 Container %s kept for debugging, not terminated", c.ID)
+		return nil
+	}
+
+	// the concurrent calls wait for the end of the termination in progress.
+	c.terminateMtx.Lock()
+	defer c.terminateMtx.Unlock()
+
+	if c.terminated {
+		return nil
+	}
+
 	select {
 	// close reaper if it was created
 	case c.terminationSignal <- true:
@@ -296,15 +584,41 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 
 	defer c.provider.client.Close()
 
-	errs := []error{
-		c.terminatingHook(ctx),
-		c.provider.client.ContainerRemove(ctx, c.GetContainerID(), container.RemoveOptions{
-			RemoveVolumes: true,
-			Force:         true,
-		}),
-		c.terminatedHook(ctx),
+	errs := []error{c.terminatingHook(ctx)}
+
+	// the helpers are terminated once the PreTerminates hooks are executed, so that the hooks can still use them.
+	for _, helper := range c.helpers {
+		errs = append(errs, helper.Terminate(ctx))
 	}
 
+	if options.stopTimeout != nil || options.noForce() {
+		errs = append(errs, c.Stop(ctx, options.stopTimeout))
+	}
+
+	var volumes []string
+	if options.removeVolumes != nil && *options.removeVolumes {
+		var err error
+		volumes, err = c.sessionVolumes(ctx)
+		errs = append(errs, err)
+	}
+
+	err := c.provider.client.ContainerRemove(ctx, c.GetContainerID(), container.RemoveOptions{
+		RemoveVolumes: options.removeVolumes == nil || *options.removeVolumes,
+		Force:         !options.noForce(),
+	})
+	if err == nil || errdefs.IsNotFound(err) {
+		// the container was removed, maybe by the reaper, so it's not removed again.
+		c.terminated = true
+		containerLeaks.untrack(c.ID)
+	}
+	errs = append(errs, err)
+
+	for _, volume := range volumes {
+		errs = append(errs, c.provider.client.VolumeRemove(ctx, volume, false))
+	}
+
+	errs = append(errs, c.terminatedHook(ctx))
+
 	if c.imageWasBuilt && !c.keepBuiltImage {
 		_, err := c.provider.client.ImageRemove(ctx, c.Image, image.RemoveOptions{
 			Force:         true,
@@ -319,6 +633,33 @@ func (c *DockerContainer) Terminate(ctx context.Context) error {
 	return errors.Join(errs...)
 }
 
+// AddLifecycleHooks registers lifecycle hooks on a container already created, e.g. a PreTerminates
+// hook flushing a buffer before the container is terminated, as the hooks of the request.
+//
+// The added hooks run after all the hooks of the same kind registered before, including the ones of
+// the request and the default ones, in the order they're added. The PreCreates and PostCreates hooks
+// are ignored, as the container is already created, and the PreStarts, PostStarts and PostReadies
+// hooks run on the next start of the container.
+func (c *DockerContainer) AddLifecycleHooks(hooks ...ContainerLifecycleHooks) {
+	c.hooksMtx.Lock()
+	defer c.hooksMtx.Unlock()
+
+	c.lifecycleHooks = append(c.lifecycleHooks, hooks...)
+}
+
+// hooks returns a snapshot of the lifecycle hooks of the container, see AddLifecycleHooks.
+func (c *DockerContainer) hooks() []ContainerLifecycleHooks {
+	c.hooksMtx.Lock()
+	defer c.hooksMtx.Unlock()
+
+	return slices.Clone(c.lifecycleHooks)
+}
+
+// addHelper adds a container supporting the container, terminated along with it, see helpers.
+func (c *DockerContainer) addHelper(helper Container) {
+	c.helpers = append(c.helpers, helper)
+}
+
 // update container raw info
 func (c *DockerContainer) inspectRawContainer(ctx context.Context) (*types.ContainerJSON, error) {
 	defer c.provider.Close()
@@ -414,6 +755,24 @@ func (c *DockerContainer) State(ctx context.Context) (*types.ContainerState, err
 	return inspect.State, nil
 }
 
+// WaitExit blocks until the container is not running, returning its exit code. It uses the wait
+// endpoint of the daemon, so the state of the container is not polled.
+func (c *DockerContainer) WaitExit(ctx context.Context) (int, error) {
+	defer c.provider.Close()
+
+	statusCh, errCh := c.provider.client.ContainerWait(ctx, c.ID, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		return 0, fmt.Errorf("container wait: %w", err)
+	case status := <-statusCh:
+		if status.Error != nil {
+			return 0, fmt.Errorf("container wait: %s", status.Error.Message)
+		}
+
+		return int(status.StatusCode), nil
+	}
+}
+
 // Networks gets the names of the networks the container is attached to.
 func (c *DockerContainer) Networks(ctx context.Context) ([]string, error) {
 	inspect, err := c.Inspect(ctx)
@@ -453,23 +812,97 @@ func (c *DockerContainer) ContainerIP(ctx context.Context) (string, error) {
 	return ip, nil
 }
 
-// ContainerIPs gets the IP addresses of all the networks within the container.
-func (c *DockerContainer) ContainerIPs(ctx context.Context) ([]string, error) {
-	ips := make([]string, 0)
-
+// ContainerIPs gets the IP addresses of the container, keyed by the name of the network they belong to.
+func (c *DockerContainer) ContainerIPs(ctx context.Context) (map[string]string, error) {
 	inspect, err := c.Inspect(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	networks := inspect.NetworkSettings.Networks
-	for _, nw := range networks {
-		ips = append(ips, nw.IPAddress)
+	ips := make(map[string]string, len(inspect.NetworkSettings.Networks))
+	for name, nw := range inspect.NetworkSettings.Networks {
+		ips[name] = nw.IPAddress
 	}
 
 	return ips, nil
 }
 
+// NetworkNotFoundError is returned by [DockerContainer.ContainerIPByNetwork]
+// when the container is not attached to the requested network.
+type NetworkNotFoundError struct {
+	// Network is the name of the requested network.
+	Network string
+	// Networks are the names of the networks the container is attached to.
+	Networks []string
+}
+
+// Error returns the requested network along with the networks of the container.
+func (e *NetworkNotFoundError) Error() string {
+	return fmt.Sprintf("container not attached to network %q, attached to %q", e.Network, e.Networks)
+}
+
+// ContainerIPByNetwork gets the IP address of the container in the given network.
+// It returns a [*NetworkNotFoundError] if the container is not attached to the network,
+// so that callers can inspect it using [errors.As].
+func (c *DockerContainer) ContainerIPByNetwork(ctx context.Context, networkName string) (string, error) {
+	ips, err := c.ContainerIPs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ip, ok := ips[networkName]
+	if !ok {
+		networks := make([]string, 0, len(ips))
+		for name := range ips {
+			networks = append(networks, name)
+		}
+		sort.Strings(networks)
+
+		return "", &NetworkNotFoundError{Network: networkName, Networks: networks}
+	}
+
+	return ip, nil
+}
+
+// Env gets the env of the container, keyed by name, as reported by the daemon:
+// the env of the request along with the env of the image.
+func (c *DockerContainer) Env(ctx context.Context) (map[string]string, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if inspect.Config == nil {
+		return map[string]string{}, nil
+	}
+
+	return parseEnv(inspect.Config.Env), nil
+}
+
+// EnvValue gets the value of the env var of the container with the given name,
+// and whether it's set, which tells an unset var from a var set to an empty value.
+func (c *DockerContainer) EnvValue(ctx context.Context, key string) (string, bool, error) {
+	env, err := c.Env(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	value, ok := env[key]
+	return value, ok, nil
+}
+
+// parseEnv parses the KEY=value pairs of an env, splitting them on the first '=',
+// so that the values may contain '='. A pair without '=' is a var set to an empty value.
+func parseEnv(pairs []string) map[string]string {
+	env := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, _ := strings.Cut(pair, "=")
+		env[key] = value
+	}
+
+	return env
+}
+
 // NetworkAliases gets the aliases of the container for the networks it is attached to.
 func (c *DockerContainer) NetworkAliases(ctx context.Context) (map[string][]string, error) {
 	inspect, err := c.Inspect(ctx)
@@ -542,6 +975,14 @@ func (c *DockerContainer) Exec(ctx context.Context, cmd []string, options ...tce
 	return exitCode, processOptions.Reader, nil
 }
 
+// ExecWithOptions executes a command in the current container, using the user, working directory,
+// environment and privileges defined in the given [tcexec.ExecOptions].
+// It returns the same values as [DockerContainer.Exec], which is the convenience wrapper
+// running the command with the defaults of the container.
+func (c *DockerContainer) ExecWithOptions(ctx context.Context, cmd []string, opts tcexec.ExecOptions) (int, io.Reader, error) {
+	return c.Exec(ctx, cmd, opts.ProcessOptions()...)
+}
+
 type FileFromContainer struct {
 	underlying *io.ReadCloser
 	tarreader  *tar.Reader
@@ -555,6 +996,15 @@ func (fc *FileFromContainer) Close() error {
 	return (*fc.underlying).Close()
 }
 
+// StatPath returns the stat of the file or directory at the given path in the container, read through
+// the archive endpoint of the Docker API, so that no command is needed in the image. The error
+// satisfies errdefs.IsNotFound if the path doesn't exist.
+func (c *DockerContainer) StatPath(ctx context.Context, path string) (container.PathStat, error) {
+	defer c.provider.Close()
+
+	return c.provider.client.ContainerStatPath(ctx, c.ID, path)
+}
+
 func (c *DockerContainer) CopyFileFromContainer(ctx context.Context, filePath string) (io.ReadCloser, error) {
 	r, _, err := c.provider.client.CopyFromContainer(ctx, c.ID, filePath)
 	if err != nil {
@@ -579,6 +1029,31 @@ func (c *DockerContainer) CopyFileFromContainer(ctx context.Context, filePath st
 	return ret, nil
 }
 
+// CopyDirFromContainer copies the contents of a directory in the container to a directory in the host,
+// which is created if it does not exist. The archive returned by Docker is streamed to disk, and any entry
+// that would be extracted outside the host directory is rejected with ErrPathTraversal.
+// It returns the paths of the extracted files in the host.
+func (c *DockerContainer) CopyDirFromContainer(ctx context.Context, containerPath string, hostDirPath string) ([]string, error) {
+	r, stat, err := c.provider.client.CopyFromContainer(ctx, c.ID, containerPath)
+	if err != nil {
+		return nil, fmt.Errorf("copy from container: %w", err)
+	}
+	defer c.provider.Close()
+	defer r.Close()
+
+	if !stat.Mode.IsDir() {
+		// it's not a dir: let the consumer to handle an error
+		return nil, fmt.Errorf("path %s is not a directory", containerPath)
+	}
+
+	files, err := untarDir(r, hostDirPath)
+	if err != nil {
+		return files, fmt.Errorf("extract %s: %w", containerPath, err)
+	}
+
+	return files, nil
+}
+
 // CopyDirToContainer copies the contents of a directory to a parent path in the container. This parent path must exist in the container first
 // as we cannot create it
 func (c *DockerContainer) CopyDirToContainer(ctx context.Context, hostDirPath string, containerParentPath string, fileMode int64) error {
@@ -842,6 +1317,7 @@ type DockerNetwork struct {
 	ID                string // Network ID from Docker
 	Driver            string
 	Name              string
+	Labels            map[string]string // Labels of the network, including the Testcontainers for Go labels
 	provider          *DockerProvider
 	terminationSignal chan bool
 }
@@ -863,6 +1339,89 @@ func (n *DockerNetwork) SetTerminationSignal(signal chan bool) {
 	n.terminationSignal = signal
 }
 
+// ErrNetworkNotAttachable is returned when connecting a container to a network, or disconnecting it,
+// while the network mode of the container doesn't allow it, e.g. the host network mode.
+var ErrNetworkNotAttachable = errors.New("network not attachable")
+
+// Connect connects the running container with the given ID to the network, with the given aliases,
+// e.g. to reconnect a container disconnected with Disconnect, simulating the end of a network partition.
+// It returns an error wrapping ErrNetworkNotAttachable if the network mode of the container,
+// such as host, none, or the network of another container, doesn't allow it.
+func (n *DockerNetwork) Connect(ctx context.Context, containerID string, aliases ...string) error {
+	cli, err := n.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	if err := checkNetworkAttachable(ctx, cli, containerID); err != nil {
+		return err
+	}
+
+	err = cli.NetworkConnect(ctx, n.ID, containerID, &network.EndpointSettings{Aliases: aliases})
+	if err != nil {
+		return fmt.Errorf("network connect %s: %w", n.Name, err)
+	}
+
+	return nil
+}
+
+// Disconnect disconnects the running container with the given ID from the network,
+// e.g. to simulate a network partition. It returns an error wrapping ErrNetworkNotAttachable
+// if the network mode of the container, such as host, doesn't allow it.
+func (n *DockerNetwork) Disconnect(ctx context.Context, containerID string) error {
+	cli, err := n.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	if err := checkNetworkAttachable(ctx, cli, containerID); err != nil {
+		return err
+	}
+
+	if err := cli.NetworkDisconnect(ctx, n.ID, containerID, false); err != nil {
+		return fmt.Errorf("network disconnect %s: %w", n.Name, err)
+	}
+
+	return nil
+}
+
+// client returns the client of the provider of the network, or a new client
+// for the networks not created by a provider, e.g. the networks of a compose stack.
+func (n *DockerNetwork) client(ctx context.Context) (client.APIClient, error) {
+	if n.provider != nil {
+		return n.provider.client, nil
+	}
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("new docker client: %w", err)
+	}
+
+	return cli, nil
+}
+
+// checkNetworkAttachable returns an error wrapping ErrNetworkNotAttachable if the network mode
+// of the container doesn't allow connecting it to user-defined networks.
+func checkNetworkAttachable(ctx context.Context, cli client.APIClient, containerID string) error {
+	inspect, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("inspect container: %w", err)
+	}
+
+	if inspect.HostConfig == nil {
+		return nil
+	}
+
+	mode := inspect.HostConfig.NetworkMode
+	if mode.IsHost() || mode.IsNone() || mode.IsContainer() {
+		return fmt.Errorf("%w: container %s uses the %s network mode", ErrNetworkNotAttachable, containerID, mode)
+	}
+
+	return nil
+}
+
 // DockerProvider implements the ContainerProvider interface
 type DockerProvider struct {
 	*DockerProviderOptions
@@ -895,7 +1454,26 @@ var _ ContainerProvider = (*DockerProvider)(nil)
 
 // BuildImage will build and image from context and Dockerfile, then return the tag
 func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (string, error) {
-	var buildOptions types.ImageBuildOptions
+	var secrets map[string][]byte
+	if bs, ok := img.(ImageBuildSecrets); ok {
+		var err error
+		secrets, err = bs.GetBuildSecrets()
+		if err != nil {
+			return "", fmt.Errorf("build secrets: %w", err)
+		}
+	}
+
+	if len(secrets) > 0 {
+		if err := p.checkBuildKit(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	var (
+		buildOptions types.ImageBuildOptions
+		release      func()
+		retries      int
+	)
 	resp, err := backoff.RetryNotifyWithData(
 		func() (types.ImageBuildResponse, error) {
 			var err error
@@ -905,8 +1483,33 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 			}
 			defer tryClose(buildOptions.Context) // release resources in any case
 
+			releaseSlot, err := p.acquireDaemonSlot(ctx, DaemonOperationBuild)
+			if err != nil {
+				return types.ImageBuildResponse{}, backoff.Permanent(fmt.Errorf("build image: %w", err))
+			}
+
+			if len(secrets) > 0 {
+				// The secrets are served to the daemon through a BuildKit session, which must be
+				// alive for the whole build, and is started again if the build is retried.
+				s, err := p.startBuildSession(ctx, secrets)
+				if err != nil {
+					releaseSlot()
+					return types.ImageBuildResponse{}, fmt.Errorf("build session: %w", err)
+				}
+
+				buildOptions.Version = types.BuilderBuildKit
+				buildOptions.SessionID = s.ID()
+
+				slot := releaseSlot
+				releaseSlot = func() {
+					s.Close()
+					slot()
+				}
+			}
+
 			resp, err := p.client.ImageBuild(ctx, buildOptions.Context, buildOptions)
 			if err != nil {
+				releaseSlot()
 				if isPermanentClientError(err) {
 					return types.ImageBuildResponse{}, backoff.Permanent(fmt.Errorf("build image: %w", err))
 				}
@@ -914,16 +1517,22 @@ func (p *DockerProvider) BuildImage(ctx context.Context, img ImageBuildInfo) (st
 			}
 			defer p.Close()
 
+			// the build runs while its output is streamed, so the slot is held until it's read.
+			release = releaseSlot
+
 			return resp, nil
 		},
 		backoff.WithContext(backoff.NewExponentialBackOff(), ctx),
 		func(err error, duration time.Duration) {
-			p.Logger.Printf("Failed to build image: %s, will retry", err)
+			retries++
+			warnf(p.Logger, "Failed to build image: %s, will retry", err)
+			daemonHooks.retried(DaemonOperationBuild, retries, err)
 		},
 	)
 	if err != nil {
 		return "", err // Error is already wrapped.
 	}
+	defer release()
 	defer resp.Body.Close()
 
 	output := io.Discard
@@ -950,6 +1559,10 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 	// defer the close of the Docker client connection the soonest
 	defer p.Close()
 
+	// work on a copy of the request, as its maps and slices are modified below,
+	// so that the request of the caller is never modified.
+	req = req.Clone()
+
 	// Make sure that bridge network exists
 	// In case it is disabled we will create reaper_default network
 	if p.DefaultNetwork == "" {
@@ -978,20 +1591,42 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 
 	imageName := req.Image
 
-	env := []string{}
-	for envKey, envVar := range req.Env {
-		env = append(env, envKey+"="+envVar)
+	// the reaper does not need to start a reaper for itself
+	isReaperContainer := strings.HasSuffix(imageName, config.ReaperDefaultImage)
+
+	if !isReaperContainer && p.config.DaemonMinFreeDisk > 0 {
+		if err := p.checkDiskSpace(ctx, p.config.DaemonMinFreeDisk); err != nil {
+			return nil, err
+		}
+	}
+
+	if !isReaperContainer && (req.PropagateProxy || p.config.ProxyPropagate) {
+		if err := p.propagateProxy(ctx, &req); err != nil {
+			return nil, err
+		}
 	}
 
 	if req.Labels == nil {
 		req.Labels = make(map[string]string)
 	}
 
+	// the session ID is validated before creating the reaper of the session.
+	if err := req.validateSessionID(); err != nil {
+		return nil, err
+	}
+	sessionID := req.sessionID()
+
+	if !isReaperContainer {
+		// add the labels that the reaper will use to terminate the container to the request.
+		// User-defined labels must not collide with them, as the reaper relies on their values.
+		if err := core.AddDefaultLabels(req.Labels, sessionID); err != nil {
+			return nil, fmt.Errorf("container labels: %w", err)
+		}
+	}
+
 	var termSignal chan bool
-	// the reaper does not need to start a reaper for itself
-	isReaperContainer := strings.HasSuffix(imageName, config.ReaperDefaultImage)
 	if !p.config.RyukDisabled && !isReaperContainer {
-		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), core.SessionID(), p)
+		r, err := p.sessionReaper(ctx, sessionID)
 		if err != nil {
 			return nil, fmt.Errorf("%w: creating reaper failed", err)
 		}
@@ -1012,6 +1647,14 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
+	if err := p.validateNetworkAliases(ctx, req, sessionID); err != nil {
+		return nil, err
+	}
+
+	if err := req.preCreateHostHooks(ctx); err != nil {
+		return nil, err
+	}
+
 	// always append the hub substitutor after the user-defined ones
 	req.ImageSubstitutors = append(req.ImageSubstitutors, newPrependHubRegistry(p.config.HubImageNamePrefix))
 
@@ -1030,7 +1673,7 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			}
 
 			if modifiedTag != imageName {
-				p.Logger.Printf("✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), imageName, modifiedTag)
+				infof(p.Logger, "✍🏼 Replacing image with %s. From: %s to %s\n", is.Description(), imageName, modifiedTag)
 				imageName = modifiedTag
 			}
 		}
@@ -1065,17 +1708,15 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 			pullOpt := image.PullOptions{
 				Platform: req.ImagePlatform, // may be empty
 			}
-			if err := p.attemptToPullImage(ctx, imageName, pullOpt); err != nil {
-				return nil, err
+			if err := p.pullImageWithTimeout(ctx, imageName, pullOpt, req.PullRetryPolicy, req.PullTimeout); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrImagePull, err)
 			}
 		}
 	}
 
-	if !isReaperContainer {
-		// add the labels that the reaper will use to terminate the container to the request
-		for k, v := range core.DefaultLabels(core.SessionID()) {
-			req.Labels[k] = v
-		}
+	env := []string{}
+	for envKey, envVar := range req.Env {
+		env = append(env, envKey+"="+envVar)
 	}
 
 	dockerInput := &container.Config{
@@ -1103,9 +1744,25 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		defaultPreCreateHook(p, dockerInput, hostConfig, networkingConfig),
 		defaultCopyFileToContainerHook(req.Files),
 		defaultLogConsumersHook(req.LogConsumerCfg),
+		defaultAttachHook(req.AttachStdout, req.AttachStderr),
 		defaultReadinessHook(),
 	}
 
+	// archive the logs of the container, grouped by the test creating it
+	if archive := currentLogArchive(p.config); archive != nil && !isReaperContainer {
+		defaultHooks = append(defaultHooks, archive.hooks())
+	}
+
+	// the host is reached through the host gateway if the daemon supports it,
+	// or through the SSHD tunnel otherwise.
+	var hostAccessHostname string
+	if req.HostGatewayAccessPorts != nil {
+		hostAccessHostname, err = p.hostGatewayAccess(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("host gateway access: %w", err)
+		}
+	}
+
 	// in the case the container needs to access a local port
 	// we need to forward the local port to the container
 	if len(req.HostAccessPorts) > 0 {
@@ -1127,9 +1784,41 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
-	resp, err := p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
+	if err := req.validateWaitPorts(hostConfig.NetworkMode); err != nil {
+		if !p.config.WaitPortCheckLenient {
+			return nil, err
+		}
+		warnf(p.Logger, "%v", err)
+	}
+
+	// the labels mandated by Testcontainers are merged last, after the modifiers,
+	// so that they are never lost if a ConfigModifier replaces the labels map.
+	if !isReaperContainer {
+		if dockerInput.Labels == nil {
+			dockerInput.Labels = make(map[string]string)
+		}
+
+		if err := core.AddDefaultLabels(dockerInput.Labels, sessionID); err != nil {
+			return nil, fmt.Errorf("container labels: %w", err)
+		}
+
+		if p.keepOnFailure(req) {
+			keepOnFailureLabels(dockerInput.Labels)
+		}
+	}
+
+	if req.PreCreateInspector != nil {
+		req.PreCreateInspector(*dockerInput, *hostConfig, *networkingConfig)
+	}
+
+	var resp container.CreateResponse
+	err = p.daemonCall(ctx, DaemonOperationCreate, func() error {
+		var err error
+		resp, err = p.client.ContainerCreate(ctx, dockerInput, hostConfig, networkingConfig, platform, req.Name)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("container create: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrContainerCreate, err)
 	}
 
 	// #248: If there is more than one network specified in the request attach newly created container to them one by one
@@ -1151,17 +1840,24 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 	}
 
 	c := &DockerContainer{
-		ID:                resp.ID,
-		WaitingFor:        req.WaitingFor,
-		Image:             imageName,
-		imageWasBuilt:     req.ShouldBuildImage(),
-		keepBuiltImage:    req.ShouldKeepBuiltImage(),
-		sessionID:         core.SessionID(),
-		exposedPorts:      req.ExposedPorts,
-		provider:          p,
-		terminationSignal: termSignal,
-		logger:            p.Logger,
-		lifecycleHooks:    req.LifecycleHooks,
+		ID:                 resp.ID,
+		WaitingFor:         req.WaitingFor,
+		Image:              imageName,
+		imageWasBuilt:      req.ShouldBuildImage(),
+		keepBuiltImage:     req.ShouldKeepBuiltImage(),
+		sessionID:          sessionID,
+		exposedPorts:       req.ExposedPorts,
+		provider:           p,
+		terminationSignal:  termSignal,
+		logger:             p.Logger,
+		lifecycleHooks:     req.LifecycleHooks,
+		failureLogLines:    req.FailureLogLines,
+		secrets:            req.sensitiveValues(),
+		overridesCommand:   len(req.Cmd) > 0 || len(req.Entrypoint) > 0,
+		keepOnFailure:      !isReaperContainer && p.keepOnFailure(req),
+		hostAccessHostname: hostAccessHostname,
+		hostPortBindingIP:  portsBindingIP(hostConfig.PortBindings),
+		moduleInfo:         moduleInfoFromLabels(req.Labels),
 	}
 
 	err = c.createdHook(ctx)
@@ -1169,6 +1865,10 @@ func (p *DockerProvider) CreateContainer(ctx context.Context, req ContainerReque
 		return nil, err
 	}
 
+	if !isReaperContainer {
+		containerLeaks.track(c.ID, imageName, callerTestName())
+	}
+
 	// Disable cleanup on success
 	termSignal = nil
 
@@ -1182,7 +1882,8 @@ func (p *DockerProvider) findContainerByName(ctx context.Context, name string) (
 
 	// Note that, 'name' filter will use regex to find the containers
 	filter := filters.NewArgs(filters.Arg("name", fmt.Sprintf("^%s$", name)))
-	containers, err := p.client.ContainerList(ctx, container.ListOptions{Filters: filter})
+	// the stopped containers are listed too, as they hold the name.
+	containers, err := p.client.ContainerList(ctx, container.ListOptions{All: true, Filters: filter})
 	if err != nil {
 		return nil, err
 	}
@@ -1194,6 +1895,10 @@ func (p *DockerProvider) findContainerByName(ctx context.Context, name string) (
 	return nil, nil
 }
 
+// waitContainerCreation waits for the container with the given name, created by another process,
+// to be running. A created container is started, e.g. if the process creating it crashed before
+// starting it, and a paused container is unpaused. It fails right away if the container is
+// restarting, stopped or dead, as it won't be running anytime soon.
 func (p *DockerProvider) waitContainerCreation(ctx context.Context, name string) (*types.Container, error) {
 	return backoff.RetryNotifyWithData(
 		func() (*types.Container, error) {
@@ -1208,26 +1913,79 @@ func (p *DockerProvider) waitContainerCreation(ctx context.Context, name string)
 			if c == nil {
 				return nil, errdefs.NotFound(fmt.Errorf("container %s not found", name))
 			}
-			return c, nil
+
+			switch c.State {
+			case "running":
+				return c, nil
+			case "created":
+				// starting a container being started by another process is a no-op.
+				infof(p.Logger, "♻️ Starting created container %s with name %s", c.ID[:12], name)
+				if err := p.client.ContainerStart(ctx, c.ID, container.StartOptions{}); err != nil {
+					return nil, fmt.Errorf("start container %s: %w", c.ID, err)
+				}
+				c.State = "running"
+				return c, nil
+			case "paused":
+				infof(p.Logger, "♻️ Unpausing container %s with name %s", c.ID[:12], name)
+				if err := p.client.ContainerUnpause(ctx, c.ID); err != nil && !errdefs.IsConflict(err) {
+					return nil, fmt.Errorf("unpause container %s: %w", c.ID, err)
+				}
+				c.State = "running"
+				return c, nil
+			case "restarting", "exited", "dead":
+				return nil, backoff.Permanent(fmt.Errorf("container %s with name %s can't be reused: %s", c.ID, name, c.Status))
+			default:
+				// the container is being removed.
+				return nil, errdefs.NotFound(fmt.Errorf("container %s %s", name, c.State))
+			}
 		},
 		backoff.WithContext(backoff.NewExponentialBackOff(), ctx),
 		func(err error, duration time.Duration) {
 			if errdefs.IsNotFound(err) {
 				return
 			}
-			p.Logger.Printf("Waiting for container. Got an error: %v; Retrying in %d seconds", err, duration/time.Second)
+			warnf(p.Logger, "Waiting for container. Got an error: %v; Retrying in %d seconds", err, duration/time.Second)
 		},
 	)
 }
 
 func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req ContainerRequest) (Container, error) {
+	// the request of the caller is never modified, see CreateContainer.
+	req = req.Clone()
+
+	if err := req.validateSessionID(); err != nil {
+		return nil, err
+	}
+
+	if err := req.validateEnv(); err != nil {
+		return nil, err
+	}
+
 	c, err := p.findContainerByName(ctx, req.Name)
 	if err != nil {
 		return nil, err
 	}
+	if c != nil && (c.State == "exited" || c.State == "dead") {
+		// a leftover of a previous run, which can't be reused, so it's recreated.
+		infof(p.Logger, "♻️ Recreating stopped container %s with name %s", c.ID[:12], req.Name)
+		err := p.client.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			return nil, fmt.Errorf("remove stopped container %s: %w", c.ID, err)
+		}
+		c = nil
+	}
+	if c != nil && c.State != "running" {
+		// the container is being created and started by another process, or was left paused or not started.
+		c, err = p.waitContainerCreation(ctx, req.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if c == nil {
 		createdContainer, err := p.CreateContainer(ctx, req)
 		if err == nil {
+			// the reused containers are long-lived, so they are not leaks.
+			containerLeaks.untrack(createdContainer.GetContainerID())
 			return createdContainer, nil
 		}
 		if !createContainerFailDueToNameConflictRegex.MatchString(err.Error()) {
@@ -1239,11 +1997,11 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		}
 	}
 
-	sessionID := core.SessionID()
+	sessionID := req.sessionID()
 
 	var termSignal chan bool
 	if !p.config.RyukDisabled {
-		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), sessionID, p)
+		r, err := p.sessionReaper(ctx, sessionID)
 		if err != nil {
 			return nil, fmt.Errorf("reaper: %w", err)
 		}
@@ -1270,6 +2028,10 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 		terminationSignal: termSignal,
 		logger:            p.Logger,
 		lifecycleHooks:    []ContainerLifecycleHooks{combineContainerHooks(defaultHooks, req.LifecycleHooks)},
+		failureLogLines:   req.FailureLogLines,
+		secrets:           req.sensitiveValues(),
+		hostPortBindingIP: publishedPortsBindingIP(c.Ports),
+		moduleInfo:        moduleInfoFromLabels(c.Labels),
 	}
 
 	err = dc.startedHook(ctx)
@@ -1287,49 +2049,79 @@ func (p *DockerProvider) ReuseOrCreateContainer(ctx context.Context, req Contain
 	return dc, nil
 }
 
+// pullImageWithTimeout pulls the image, including its retries, within the given timeout, if positive,
+// returning an error wrapping ErrPullTimeout if it expires. The parent context is inherited otherwise.
+func (p *DockerProvider) pullImageWithTimeout(ctx context.Context, tag string, pullOpt image.PullOptions, policy *PullRetryPolicy, timeout time.Duration) error {
+	if timeout <= 0 {
+		return p.attemptToPullImage(ctx, tag, pullOpt, policy)
+	}
+
+	pullCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := p.attemptToPullImage(pullCtx, tag, pullOpt, policy)
+	if err != nil && ctx.Err() == nil && errors.Is(pullCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %s after %s: %w", ErrPullTimeout, tag, timeout, err)
+	}
+
+	return err
+}
+
 // attemptToPullImage tries to pull the image while respecting the ctx cancellations.
-// Besides, if the image cannot be pulled due to ErrorNotFound then no need to retry but terminate immediately.
-func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt image.PullOptions) error {
+// The failed pulls are retried according to the given policy, see PullRetryPolicy for the retries
+// of a nil policy.
+func (p *DockerProvider) attemptToPullImage(ctx context.Context, tag string, pullOpt image.PullOptions, policy *PullRetryPolicy) error {
 	registry, imageAuth, err := DockerImageAuth(ctx, tag)
 	if err != nil {
-		p.Logger.Printf("Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is: %s", registry, tag, err)
+		warnf(p.Logger, "Failed to get image auth for %s. Setting empty credentials for the image: %s. Error is: %s", registry, tag, err)
 	} else {
 		// see https://github.com/docker/docs/blob/e8e1204f914767128814dca0ea008644709c117f/engine/api/sdk/examples.md?plain=1#L649-L657
 		encodedJSON, err := json.Marshal(imageAuth)
 		if err != nil {
-			p.Logger.Printf("Failed to marshal image auth. Setting empty credentials for the image: %s. Error is: %s", tag, err)
+			warnf(p.Logger, "Failed to marshal image auth. Setting empty credentials for the image: %s. Error is: %s", tag, err)
 		} else {
 			pullOpt.RegistryAuth = base64.URLEncoding.EncodeToString(encodedJSON)
 		}
 	}
 
-	var pull io.ReadCloser
-	err = backoff.RetryNotify(
+	var retries int
+	return backoff.RetryNotify(
 		func() error {
-			pull, err = p.client.ImagePull(ctx, tag, pullOpt)
+			release, err := p.acquireDaemonSlot(ctx, DaemonOperationPull)
 			if err != nil {
-				if isPermanentClientError(err) {
+				return backoff.Permanent(err)
+			}
+			defer release()
+
+			pull, err := p.client.ImagePull(ctx, tag, pullOpt)
+			if err != nil {
+				if !policy.retryable(err) {
 					return backoff.Permanent(err)
 				}
 				return err
 			}
 			defer p.Close()
+			defer pull.Close()
+
+			// download of docker image finishes at EOF of the pull request,
+			// the errors happening during the download being reported in the stream.
+			// They are only retried with a policy, which classifies them.
+			if err := jsonmessage.DisplayJSONMessagesStream(pull, io.Discard, 0, false, nil); err != nil {
+				if policy == nil || !policy.retryable(err) {
+					return backoff.Permanent(err)
+				}
+				return err
+			}
 
 			return nil
 		},
-		backoff.WithContext(backoff.NewExponentialBackOff(), ctx),
+		policy.backOff(ctx),
 		func(err error, duration time.Duration) {
-			p.Logger.Printf("Failed to pull image: %s, will retry", err)
+			retries++
+			warnf(p.Logger, "Failed to pull image: %s, will retry in %s", err, duration)
+			daemonHooks.retried(DaemonOperationPull, retries, err)
 		},
 	)
-	if err != nil {
-		return err
-	}
-	defer pull.Close()
-
-	// download of docker image finishes at EOF of the pull request
-	_, err = io.ReadAll(pull)
-	return err
 }
 
 // Health measure the healthiness of the provider. Right now we leverage the
@@ -1365,6 +2157,7 @@ func (p *DockerProvider) Config() TestcontainersConfig {
 		RyukDisabled:   p.config.RyukDisabled,
 		RyukPrivileged: p.config.RyukPrivileged,
 		Config:         p.config,
+		ReaperObserver: reaperObserver,
 	}
 }
 
@@ -1387,35 +2180,52 @@ func daemonHost(ctx context.Context, p *DockerProvider) (string, error) {
 	}
 
 	// infer from Docker host
-	daemonURL, err := url.Parse(p.client.DaemonHost())
+	host, remote, err := remoteDaemonHost(p.client.DaemonHost())
 	if err != nil {
 		return "", err
 	}
 	defer p.Close()
 
-	switch daemonURL.Scheme {
-	case "http", "https", "tcp":
-		p.hostCache = daemonURL.Hostname()
-	case "unix", "npipe":
-		if core.InAContainer() {
-			ip, err := p.GetGatewayIP(ctx)
+	switch {
+	case remote:
+		p.hostCache = host
+	case core.InAContainer():
+		ip, err := p.GetGatewayIP(ctx)
+		if err != nil {
+			ip, err = core.DefaultGatewayIP()
 			if err != nil {
-				ip, err = core.DefaultGatewayIP()
-				if err != nil {
-					ip = "localhost"
-				}
+				ip = "localhost"
 			}
-			p.hostCache = ip
-		} else {
-			p.hostCache = "localhost"
 		}
+		p.hostCache = ip
 	default:
-		return "", errors.New("could not determine host through env or docker host")
+		p.hostCache = "localhost"
 	}
 
 	return p.hostCache, nil
 }
 
+// remoteDaemonHost returns the host of a Docker daemon reached through the network, using the
+// tcp, http or https schemas, e.g. "remote.example.com" for "tcp://remote.example.com:2376".
+// It returns false if the daemon is reached through a local unix socket or named pipe,
+// including the rootless Docker and Podman sockets, and an error for any other schema,
+// including ssh, as the Docker client is not configured to connect through ssh.
+func remoteDaemonHost(daemonHost string) (string, bool, error) {
+	daemonURL, err := url.Parse(daemonHost)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch daemonURL.Scheme {
+	case "http", "https", "tcp":
+		return daemonURL.Hostname(), true, nil
+	case "unix", "npipe":
+		return "", false, nil
+	default:
+		return "", false, errors.New("could not determine host through env or docker host")
+	}
+}
+
 // Deprecated: use network.New instead
 // CreateNetwork returns the object representing a new network identified by its name
 func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest) (Network, error) {
@@ -1447,6 +2257,12 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 
 	sessionID := core.SessionID()
 
+	// add the labels that the reaper will use to terminate the network to the request.
+	// User-defined labels must not collide with them, as the reaper relies on their values.
+	if err := core.AddDefaultLabels(req.Labels, sessionID); err != nil {
+		return nil, fmt.Errorf("network labels: %w", err)
+	}
+
 	var termSignal chan bool
 	if !p.config.RyukDisabled {
 		r, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, p.host), sessionID, p)
@@ -1459,11 +2275,6 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		}
 	}
 
-	// add the labels that the reaper will use to terminate the network to the request
-	for k, v := range core.DefaultLabels(sessionID) {
-		req.Labels[k] = v
-	}
-
 	// Cleanup on error, otherwise set termSignal to nil before successful return.
 	defer func() {
 		if termSignal != nil {
@@ -1480,6 +2291,7 @@ func (p *DockerProvider) CreateNetwork(ctx context.Context, req NetworkRequest)
 		ID:                response.ID,
 		Driver:            req.Driver,
 		Name:              req.Name,
+		Labels:            req.Labels,
 		terminationSignal: termSignal,
 		provider:          p,
 	}
@@ -1655,7 +2467,7 @@ func (p *DockerProvider) SaveImages(ctx context.Context, output string, images .
 
 // PullImage pulls image from registry
 func (p *DockerProvider) PullImage(ctx context.Context, img string) error {
-	return p.attemptToPullImage(ctx, img, image.PullOptions{})
+	return p.attemptToPullImage(ctx, img, image.PullOptions{}, nil)
 }
 
 var permanentClientErrors = []func(error) bool{
@@ -0,0 +1,70 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/testcontainers/testcontainers-go/internal/buildkit"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrBuildKitNotAvailable is returned when building an image requires BuildKit,
+// e.g. because build secrets were requested, but it is disabled or not supported by the Docker daemon.
+var ErrBuildKitNotAvailable = errors.New("buildkit not available")
+
+// buildKitEnabled returns true if the images can be built with BuildKit,
+// following the same rules as the Docker CLI: the DOCKER_BUILDKIT environment
+// variable takes precedence, then the builder advertised by the daemon is used,
+// defaulting to BuildKit for non-Windows daemons.
+func (p *DockerProvider) buildKitEnabled(ctx context.Context) (bool, error) {
+	if v := os.Getenv("DOCKER_BUILDKIT"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("parse DOCKER_BUILDKIT: %w", err)
+		}
+
+		return enabled, nil
+	}
+
+	ping, err := p.client.Ping(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ping: %w", err)
+	}
+
+	if ping.BuilderVersion == types.BuilderBuildKit {
+		return true, nil
+	}
+
+	return ping.OSType != "windows", nil
+}
+
+// checkBuildKit returns an error wrapping ErrBuildKitNotAvailable if BuildKit is disabled or
+// not supported by the Docker daemon, as required by the build secrets.
+func (p *DockerProvider) checkBuildKit(ctx context.Context) error {
+	enabled, err := p.buildKitEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("buildkit enabled: %w", err)
+	}
+
+	if !enabled {
+		return fmt.Errorf("%w: build secrets require BuildKit, which is disabled or not supported by the Docker daemon", ErrBuildKitNotAvailable)
+	}
+
+	return nil
+}
+
+// startBuildSession starts a BuildKit session exposing the given secrets to a build,
+// so that they can be consumed by the RUN --mount=type=secret instructions of the Dockerfile.
+// A session is started for each attempt of the build, and the caller must close it once the
+// build is done.
+func (p *DockerProvider) startBuildSession(ctx context.Context, secrets map[string][]byte) (*buildkit.Session, error) {
+	return buildkit.Start(ctx, core.SessionID(), secrets, func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+		return p.client.DialHijack(ctx, "/session", proto, meta)
+	})
+}
@@ -73,7 +73,7 @@ func (c *DockerClient) Info(ctx context.Context) (system.Info, error) {
 		}
 	}
 
-	Logger.Printf(infoMessage, packagePath,
+	infof(Logger, infoMessage, packagePath,
 		dockerInfo.ServerVersion,
 		c.Client.ClientVersion(),
 		dockerInfo.OperatingSystem, dockerInfo.MemTotal/1024/1024,
@@ -227,3 +227,39 @@ func TestCopyDirectoryToRunningContainerAsDir(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, container.Terminate(ctx))
 }
+
+func TestCopyDirFromContainer(t *testing.T) {
+	ctx, cnl := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cnl()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "docker.io/bash",
+			Cmd:        []string{"bash", "-c", "mkdir -p /reports/nested && echo ok > /reports/summary.txt && echo done > /reports/nested/data.txt && echo ready && sleep 30"},
+			WaitingFor: wait.ForLog("ready"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	})
+
+	hostDir := filepath.Join(t.TempDir(), "reports")
+
+	// copyDirFromContainer {
+	files, err := container.CopyDirFromContainer(ctx, "/reports", hostDir)
+	// }
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		filepath.Join(hostDir, "summary.txt"),
+		filepath.Join(hostDir, "nested", "data.txt"),
+	}, files)
+
+	content, err := os.ReadFile(filepath.Join(hostDir, "nested", "data.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "done\n", string(content))
+
+	_, err = container.CopyDirFromContainer(ctx, "/reports/summary.txt", hostDir)
+	require.Error(t, err)
+}
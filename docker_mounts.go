@@ -115,7 +115,7 @@ func mapToDockerMounts(containerMounts ContainerMounts) []mount.Mount {
 		case TmpfsMounter:
 			containerMount.TmpfsOptions = typedMounter.GetTmpfsOptions()
 		case BindMounter:
-			Logger.Printf("Mount type %s is not supported by Testcontainers for Go", m.Source.Type())
+			warnf(Logger, "Mount type %s is not supported by Testcontainers for Go", m.Source.Type())
 		default:
 			// The provided source type has no custom options
 		}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -22,9 +23,12 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -778,6 +782,46 @@ func Test_BuildContainerFromDockerfileWithBuildArgs(t *testing.T) {
 	require.Equal(t, ba, string(body))
 }
 
+func Test_BuildContainerFromDockerfileWithInheritedBuildArgs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("FOO", "inherited value")
+
+	// fromDockerfileWithInheritedBuildArgs {
+	req := ContainerRequest{
+		FromDockerfile: FromDockerfile{
+			Context:    filepath.Join(".", "testdata"),
+			Dockerfile: "args.Dockerfile",
+			BuildArgs: map[string]*string{
+				"FOO": nil, // inherits the value of the FOO environment variable
+			},
+		},
+		ExposedPorts: []string{"8080/tcp"},
+		WaitingFor:   wait.ForLog("ready"),
+	}
+	// }
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, c)
+	require.NoError(t, err)
+
+	ep, err := c.Endpoint(ctx, "http")
+	require.NoError(t, err)
+
+	resp, err := http.Get(ep + "/env")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.Equal(t, "inherited value", string(body))
+}
+
 func Test_BuildContainerFromDockerfileWithBuildLog(t *testing.T) {
 	rescueStdout := os.Stderr
 	r, w, _ := os.Pipe()
@@ -1079,6 +1123,144 @@ func ExampleContainer_Stop() {
 	// Container has been stopped
 }
 
+func TestRemoteDaemonHost(t *testing.T) {
+	tests := []struct {
+		name       string
+		daemonHost string
+		host       string
+		remote     bool
+		wantErr    bool
+	}{
+		{name: "tcp", daemonHost: "tcp://192.168.1.10:2375", host: "192.168.1.10", remote: true},
+		{name: "https", daemonHost: "https://docker.example.com:2376", host: "docker.example.com", remote: true},
+		{name: "ssh", daemonHost: "ssh://user@remote.example.com:22", wantErr: true},
+		{name: "default-socket", daemonHost: "unix:///var/run/docker.sock"},
+		{name: "rootless-docker-socket", daemonHost: "unix:///run/user/1000/docker.sock"},
+		{name: "rootless-podman-socket", daemonHost: "unix:///run/user/1000/podman/podman.sock"},
+		{name: "podman-machine-socket", daemonHost: "unix:///Users/me/.local/share/containers/podman/machine/podman.sock"},
+		{name: "npipe", daemonHost: "npipe:////./pipe/docker_engine"},
+		{name: "unknown-schema", daemonHost: "fd://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, remote, err := remoteDaemonHost(tt.daemonHost)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.host, host)
+			require.Equal(t, tt.remote, remote)
+		})
+	}
+}
+
+func TestMappedPortProtocols(t *testing.T) {
+	ports := nat.PortMap{
+		"53/tcp":  {{HostIP: "0.0.0.0", HostPort: "32000"}},
+		"53/udp":  {{HostIP: "0.0.0.0", HostPort: "32001"}},
+		"69/udp":  {{HostIP: "0.0.0.0", HostPort: "32002"}},
+		"80/udp":  {{HostIP: "0.0.0.0", HostPort: "32003"}},
+		"80/sctp": {{HostIP: "0.0.0.0", HostPort: "32004"}},
+		"90/tcp":  {},
+	}
+
+	tests := []struct {
+		port     nat.Port
+		expected nat.Port
+		err      string
+	}{
+		{port: "53/tcp", expected: "32000/tcp"},
+		{port: "53/udp", expected: "32001/udp"},
+		{port: "53", expected: "32000/tcp"},
+		{port: "69", expected: "32002/udp"},
+		{port: "69/tcp", err: "port not found: 69/tcp"},
+		{port: "80", err: "port 80 is exposed with multiple protocols"},
+		{port: "90", err: "port not found: 90"},
+		{port: "100/udp", err: "port not found: 100/udp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.port), func(t *testing.T) {
+			p, err := mappedPort(ports, tt.port)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestContainerMappedPortTCPAndUDP(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp", "80/udp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, nginxC)
+	require.NoError(t, err)
+
+	inspect, err := nginxC.Inspect(ctx)
+	require.NoError(t, err)
+
+	tcpPort, err := nginxC.MappedPort(ctx, "80/tcp")
+	require.NoError(t, err)
+	require.Equal(t, "tcp", tcpPort.Proto())
+	require.Equal(t, inspect.NetworkSettings.Ports["80/tcp"][0].HostPort, tcpPort.Port())
+
+	udpPort, err := nginxC.MappedPort(ctx, "80/udp")
+	require.NoError(t, err)
+	require.Equal(t, "udp", udpPort.Proto())
+	require.Equal(t, inspect.NetworkSettings.Ports["80/udp"][0].HostPort, udpPort.Port())
+
+	// a bare port prefers TCP
+	port, err := nginxC.MappedPort(ctx, "80")
+	require.NoError(t, err)
+	require.Equal(t, tcpPort, port)
+}
+
+func TestContainerMappedPorts(t *testing.T) {
+	ctx := context.Background()
+
+	nginxC, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp", "80/udp", "8080/tcp"},
+			WaitingFor:   wait.ForListeningPort("80/tcp"),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, nginxC)
+	require.NoError(t, err)
+
+	// mappedPorts {
+	ports, err := nginxC.MappedPorts(ctx, "80/tcp", "80/udp", "8080")
+	// }
+	require.NoError(t, err)
+	require.Len(t, ports, 3)
+
+	for _, port := range []nat.Port{"80/tcp", "80/udp", "8080"} {
+		p, err := nginxC.MappedPort(ctx, port)
+		require.NoError(t, err)
+		require.Equal(t, p, ports[port], port)
+	}
+
+	_, err = nginxC.MappedPorts(ctx, "80/tcp", "9090/tcp")
+	require.ErrorContains(t, err, "port not found: 9090/tcp")
+}
+
 func ExampleContainer_MappedPort() {
 	ctx := context.Background()
 	req := ContainerRequest{
@@ -1202,6 +1384,130 @@ func TestContainerWithTmpFs(t *testing.T) {
 	}
 }
 
+func TestContainerWithTmpFs_restart(t *testing.T) {
+	ctx := context.Background()
+
+	// withTmpfs {
+	req := GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/busybox",
+			Cmd:   []string{"sleep", "60"},
+		},
+		Started: true,
+	}
+	require.NoError(t, WithTmpfs("/data", "rw,size=16m")(&req))
+	// }
+
+	ctr, err := GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	inspect, err := ctr.Inspect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"/data": "rw,size=16m"}, inspect.HostConfig.Tmpfs)
+
+	c, _, err := ctr.Exec(ctx, []string{"touch", "/data/test.file"})
+	require.NoError(t, err)
+	require.Zero(t, c)
+
+	c, _, err = ctr.Exec(ctx, []string{"ls", "/data/test.file"})
+	require.NoError(t, err)
+	require.Zero(t, c)
+
+	// the content of the tmpfs is lost when the container stops.
+	require.NoError(t, ctr.Stop(ctx, nil))
+	require.NoError(t, ctr.Start(ctx))
+
+	c, _, err = ctr.Exec(ctx, []string{"ls", "/data/test.file"})
+	require.NoError(t, err)
+	require.Equal(t, 1, c, "the file should not have survived the restart")
+}
+
+func TestContainerExecWithOptions(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/busybox",
+			Cmd:   []string{"sleep", "10"},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// exec_with_options_example {
+	c, reader, err := ctr.ExecWithOptions(ctx, []string{"sh", "-c", "id -u; pwd; echo $GREETING"}, tcexec.ExecOptions{
+		User:       "nobody",
+		WorkingDir: "/tmp",
+		Env:        []string{"GREETING=hello"},
+	})
+	// }
+	require.NoError(t, err)
+	require.Zero(t, c)
+
+	var stdout, stderr bytes.Buffer
+	_, err = stdcopy.StdCopy(&stdout, &stderr, reader)
+	require.NoError(t, err)
+	require.Empty(t, stderr.String())
+	require.Equal(t, "65534\n/tmp\nhello\n", stdout.String())
+}
+
+func TestDockerContainer_WaitUntilReady(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("restarted-process", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:        "docker.io/busybox",
+				ExposedPorts: []string{"8080/tcp"},
+				// the server is restarted by the shell when killed
+				Cmd: []string{"sh", "-c", "echo ok > /tmp/index.html; while true; do httpd -f -p 8080 -h /tmp; sleep 2; done"},
+				WaitingFor: wait.ForAll(
+					wait.ForListeningPort("8080/tcp"),
+					wait.ForHTTP("/").WithPort("8080/tcp"),
+				),
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		_, err = ctr.Run(ctx, []string{"pkill", "httpd"})
+		require.NoError(t, err)
+
+		dc := ctr.(*DockerContainer)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		require.NoError(t, dc.WaitUntilReady(waitCtx))
+
+		// the caller deadline is honored
+		expiredCtx, cancel := context.WithTimeout(ctx, time.Nanosecond)
+		defer cancel()
+		<-expiredCtx.Done()
+		require.Error(t, dc.WaitUntilReady(expiredCtx))
+	})
+
+	t.Run("no-wait-strategy", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/busybox",
+				Cmd:   []string{"sleep", "10"},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, ctr.(*DockerContainer).WaitUntilReady(ctx), ErrNoWaitStrategy)
+	})
+}
+
 func TestContainerNonExistentImage(t *testing.T) {
 	t.Run("if the image not found don't propagate the error", func(t *testing.T) {
 		_, err := GenericContainer(context.Background(), GenericContainerRequest{
@@ -2082,7 +2388,7 @@ func (f *errMockCli) ImageBuild(_ context.Context, _ io.Reader, _ types.ImageBui
 
 func (f *errMockCli) ContainerList(_ context.Context, _ container.ListOptions) ([]types.Container, error) {
 	f.containerListCount++
-	return []types.Container{{}}, f.err
+	return []types.Container{{State: "running"}}, f.err
 }
 
 func (f *errMockCli) ImagePull(_ context.Context, _ string, _ image.PullOptions) (io.ReadCloser, error) {
@@ -2220,6 +2526,74 @@ func TestDockerProvider_waitContainerCreation_retries(t *testing.T) {
 	}
 }
 
+// stateMockCli is a mock implementation of client.APIClient, listing a container in the given state,
+// which is running once it's started or unpaused.
+type stateMockCli struct {
+	client.APIClient
+
+	state        string
+	startCount   int
+	unpauseCount int
+}
+
+func (s *stateMockCli) ContainerList(_ context.Context, _ container.ListOptions) ([]types.Container, error) {
+	return []types.Container{{ID: "0123456789abcdef", State: s.state, Status: s.state}}, nil
+}
+
+func (s *stateMockCli) ContainerStart(_ context.Context, _ string, _ container.StartOptions) error {
+	s.startCount++
+	s.state = "running"
+	return nil
+}
+
+func (s *stateMockCli) ContainerUnpause(_ context.Context, _ string) error {
+	s.unpauseCount++
+	s.state = "running"
+	return nil
+}
+
+func (s *stateMockCli) Close() error {
+	return nil
+}
+
+func TestDockerProvider_waitContainerCreation_states(t *testing.T) {
+	tests := []struct {
+		state        string
+		expectedErr  string
+		startCount   int
+		unpauseCount int
+	}{
+		{state: "running"},
+		{state: "created", startCount: 1},
+		{state: "paused", unpauseCount: 1},
+		{state: "restarting", expectedErr: "can't be reused: restarting"},
+		{state: "exited", expectedErr: "can't be reused: exited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			p, err := NewDockerProvider()
+			require.NoError(t, err)
+			m := &stateMockCli{state: tt.state}
+			p.client = m
+
+			// the states are handled right away, without waiting for a retry.
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			c, err := p.waitContainerCreation(ctx, "someName")
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "running", c.State)
+			}
+			require.Equal(t, tt.startCount, m.startCount)
+			require.Equal(t, tt.unpauseCount, m.unpauseCount)
+		})
+	}
+}
+
 func TestDockerProvider_attemptToPullImage_retries(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -2261,6 +2635,11 @@ func TestDockerProvider_attemptToPullImage_retries(t *testing.T) {
 			errReturned: errors.New("whoops"),
 			shouldRetry: true,
 		},
+		{
+			name:        "no retry on daemon error",
+			errReturned: errdefs.System(errors.New("received unexpected HTTP status: 503 Service Unavailable")),
+			shouldRetry: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2273,7 +2652,7 @@ func TestDockerProvider_attemptToPullImage_retries(t *testing.T) {
 			// give a chance to retry
 			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 			defer cancel()
-			_ = p.attemptToPullImage(ctx, "someTag", image.PullOptions{})
+			_ = p.attemptToPullImage(ctx, "someTag", image.PullOptions{}, nil)
 
 			assert.Positive(t, m.imagePullCount)
 			assert.Equal(t, tt.shouldRetry, m.imagePullCount > 1)
@@ -2281,6 +2660,169 @@ func TestDockerProvider_attemptToPullImage_retries(t *testing.T) {
 	}
 }
 
+// flakyPullCli is a mock implementation of client.APIClient, whose image pulls fail
+// with the given errors, one per attempt, before succeeding.
+type flakyPullCli struct {
+	client.APIClient
+
+	// failures are either errors returned by ImagePull, or messages reported in the pull stream.
+	failures  []any
+	pullCount int
+}
+
+func (f *flakyPullCli) ImagePull(_ context.Context, _ string, _ image.PullOptions) (io.ReadCloser, error) {
+	f.pullCount++
+	if f.pullCount > len(f.failures) {
+		return io.NopCloser(strings.NewReader(`{"status":"Status: Downloaded newer image"}`)), nil
+	}
+
+	switch failure := f.failures[f.pullCount-1].(type) {
+	case error:
+		return nil, failure
+	default:
+		return io.NopCloser(strings.NewReader(fmt.Sprintf(`{"errorDetail":{"message":%q},"error":%q}`, failure, failure))), nil
+	}
+}
+
+func (f *flakyPullCli) Close() error {
+	return nil
+}
+
+func TestDockerProvider_attemptToPullImage_retryPolicy(t *testing.T) {
+	policy := PullRetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    50 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name          string
+		failures      []any
+		expectedPulls int
+		expectedErr   string
+	}{
+		{
+			name: "succeeds on the third attempt",
+			failures: []any{
+				errdefs.System(errors.New("received unexpected HTTP status: 503 Service Unavailable")),
+				"toomanyrequests: You have reached your pull rate limit",
+			},
+			expectedPulls: 3,
+		},
+		{
+			name: "bounded attempts",
+			failures: []any{
+				errdefs.System(errors.New("received unexpected HTTP status: 502 Bad Gateway")),
+				errdefs.System(errors.New("received unexpected HTTP status: 502 Bad Gateway")),
+				errdefs.System(errors.New("received unexpected HTTP status: 502 Bad Gateway")),
+			},
+			expectedPulls: 3,
+			expectedErr:   "502 Bad Gateway",
+		},
+		{
+			name: "no retry on wrong credentials",
+			failures: []any{
+				errdefs.System(errors.New("Head \"https://localhost:5000/v2/redis/manifests/latest\": unauthorized: incorrect username or password")),
+			},
+			expectedPulls: 1,
+			expectedErr:   "incorrect username or password",
+		},
+		{
+			name:          "no retry on denied access in the stream",
+			failures:      []any{"pull access denied for redis, repository does not exist or may require 'docker login'"},
+			expectedPulls: 1,
+			expectedErr:   "pull access denied",
+		},
+		{
+			name:          "network error in the stream",
+			failures:      []any{"read tcp 127.0.0.1:5000: connection reset by peer"},
+			expectedPulls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewDockerProvider()
+			require.NoError(t, err)
+			m := &flakyPullCli{failures: tt.failures}
+			p.client = m
+
+			err = p.attemptToPullImage(context.Background(), "someTag", image.PullOptions{}, &policy)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedPulls, m.pullCount)
+		})
+	}
+}
+
+// slowPullCli is a mock implementation of client.APIClient, acting as a slow registry proxy:
+// its image pulls report a progress message at every interval, never completing before the
+// context of the pull is done.
+type slowPullCli struct {
+	client.APIClient
+
+	interval time.Duration
+}
+
+func (s *slowPullCli) ImagePull(ctx context.Context, _ string, _ image.PullOptions) (io.ReadCloser, error) {
+	r, w := io.Pipe()
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				w.CloseWithError(ctx.Err())
+				return
+			case <-ticker.C:
+				if _, err := io.WriteString(w, `{"status":"Downloading","progressDetail":{"current":1,"total":1000000}}`+"\n"); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return r, nil
+}
+
+func (s *slowPullCli) Close() error {
+	return nil
+}
+
+func TestDockerProvider_pullImageWithTimeout(t *testing.T) {
+	p, err := NewDockerProvider()
+	require.NoError(t, err)
+	p.client = &slowPullCli{interval: 10 * time.Millisecond}
+
+	t.Run("timeout", func(t *testing.T) {
+		err := p.pullImageWithTimeout(context.Background(), "someTag", image.PullOptions{}, nil, 200*time.Millisecond)
+		require.ErrorIs(t, err, ErrPullTimeout)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "image pull timed out: someTag after 200ms")
+	})
+
+	t.Run("parent-context-expires-first", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := p.pullImageWithTimeout(ctx, "someTag", image.PullOptions{}, nil, time.Minute)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NotErrorIs(t, err, ErrPullTimeout)
+	})
+
+	t.Run("no-timeout", func(t *testing.T) {
+		p, err := NewDockerProvider()
+		require.NoError(t, err)
+		p.client = &flakyPullCli{}
+
+		require.NoError(t, p.pullImageWithTimeout(context.Background(), "someTag", image.PullOptions{}, nil, 0))
+	})
+}
+
 func TestCustomPrefixTrailingSlashIsProperlyRemovedIfPresent(t *testing.T) {
 	hubPrefixWithTrailingSlash := "public.ecr.aws/"
 	dockerImage := "amazonlinux/amazonlinux:2023"
@@ -2307,3 +2849,232 @@ func TestCustomPrefixTrailingSlashIsProperlyRemovedIfPresent(t *testing.T) {
 	dockerContainer := c.(*DockerContainer)
 	assert.Equal(t, fmt.Sprintf("%s%s", hubPrefixWithTrailingSlash, dockerImage), dockerContainer.Image)
 }
+
+func TestParseEnv(t *testing.T) {
+	env := parseEnv([]string{
+		"PATH=/usr/local/bin:/usr/bin",
+		"EMPTY=",
+		"NO_EQUALS",
+		"DSN=user=admin password=a=b",
+		"MULTILINE=first\nsecond",
+	})
+
+	require.Equal(t, map[string]string{
+		"PATH":      "/usr/local/bin:/usr/bin",
+		"EMPTY":     "",
+		"NO_EQUALS": "",
+		"DSN":       "user=admin password=a=b",
+		"MULTILINE": "first\nsecond",
+	}, env)
+
+	require.Empty(t, parseEnv(nil))
+}
+
+func TestDockerContainer_Env(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			Env: map[string]string{
+				"EMPTY": "",
+				"DSN":   "user=admin password=a=b",
+			},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	env, err := ctr.Env(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "user=admin password=a=b", env["DSN"])
+	// the env of the image is included.
+	require.Contains(t, env, "NGINX_VERSION")
+
+	value, ok, err := ctr.EnvValue(ctx, "EMPTY")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Empty(t, value)
+
+	_, ok, err = ctr.EnvValue(ctx, "MISSING")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestDockerContainer_PauseUnpause(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	endpoint, err := ctr.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	client := http.Client{Timeout: time.Second}
+
+	// pauseContainer {
+	err = ctr.Pause(ctx)
+	// }
+	require.NoError(t, err)
+
+	state, err := ctr.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Paused)
+	require.Equal(t, "paused", state.Status)
+	require.True(t, ctr.IsRunning())
+
+	// the connection hangs instead of being refused.
+	_, err = client.Get(endpoint)
+	require.Error(t, err)
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	require.True(t, netErr.Timeout(), err)
+
+	require.NoError(t, ctr.Unpause(ctx))
+
+	state, err = ctr.State(ctx)
+	require.NoError(t, err)
+	require.False(t, state.Paused)
+	require.Equal(t, "running", state.Status)
+
+	resp, err := client.Get(endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestDockerContainer_PortEndpoint_ipv6(t *testing.T) {
+	t.Setenv("TESTCONTAINERS_HOST_OVERRIDE", "::1")
+
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	mapped, err := ctr.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	endpoint, err := ctr.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+	require.Equal(t, "http://[::1]:"+mapped.Port(), endpoint)
+
+	// without a scheme, the endpoint is just the host and the port.
+	endpoint, err = ctr.PortEndpoint(ctx, nginxDefaultPort, "")
+	require.NoError(t, err)
+	require.Equal(t, "[::1]:"+mapped.Port(), endpoint)
+}
+
+func TestDockerContainer_EndpointWithHost(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	mapped, err := ctr.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	// endpointWithHost {
+	endpoint, err := ctr.EndpointWithHost(ctx, "docker.example.com", nginxDefaultPort, "http")
+	// }
+	require.NoError(t, err)
+	require.Equal(t, "http://docker.example.com:"+mapped.Port(), endpoint)
+
+	endpoint, err = ctr.EndpointWithHost(ctx, "fd00::1", nginxDefaultPort, "")
+	require.NoError(t, err)
+	require.Equal(t, "[fd00::1]:"+mapped.Port(), endpoint)
+
+	// the resolved host is used without a host.
+	endpoint, err = ctr.EndpointWithHost(ctx, "", nginxDefaultPort, "http")
+	require.NoError(t, err)
+
+	expected, err := ctr.PortEndpoint(ctx, nginxDefaultPort, "http")
+	require.NoError(t, err)
+	require.Equal(t, expected, endpoint)
+}
+
+func TestDockerContainer_waitForExit(t *testing.T) {
+	ctx := context.Background()
+
+	run := func(t *testing.T, script string) (Container, error) {
+		t.Helper()
+
+		// waitForExit {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:      "docker.io/alpine",
+				Cmd:        []string{"sh", "-c", script},
+				WaitingFor: wait.ForExit().WithExitCode(0).WithExitTimeout(30 * time.Second),
+			},
+			Started: true,
+		})
+		// }
+		terminateContainerOnEnd(t, ctx, ctr)
+
+		return ctr, err
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctr, err := run(t, "sleep 1; echo migrated")
+		require.NoError(t, err)
+
+		state, err := ctr.State(ctx)
+		require.NoError(t, err)
+		require.False(t, state.Running)
+		require.Equal(t, 0, state.ExitCode)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		_, err := run(t, "echo 'migration 42 failed' >&2; exit 3")
+		require.ErrorContains(t, err, "container exited with code 3, expected 0")
+
+		// the error includes the last log lines of the container.
+		var startupErr *StartupError
+		require.ErrorAs(t, err, &startupErr)
+		require.Contains(t, startupErr.Logs, "migration 42 failed")
+	})
+
+	t.Run("wait-exit", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/alpine",
+				Cmd:   []string{"sh", "-c", "sleep 1; exit 5"},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		exitCode, err := ctr.(*DockerContainer).WaitExit(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 5, exitCode)
+	})
+}
@@ -0,0 +1,24 @@
+package testcontainers
+
+import "errors"
+
+// The errors returned by GenericContainer and the Run functions of the modules wrap one of these
+// errors, depending on the step of the container startup that failed, so that the callers can
+// tell them apart with [errors.Is], e.g. to retry the image pulls failing on a flaky network.
+var (
+	// ErrImagePull is returned when the image of the container cannot be pulled.
+	ErrImagePull = errors.New("image pull")
+
+	// ErrContainerCreate is returned when the daemon fails to create the container.
+	ErrContainerCreate = errors.New("container create")
+
+	// ErrContainerStart is returned when the daemon fails to start the container,
+	// e.g. because a host port requested for the container is already allocated.
+	ErrContainerStart = errors.New("container start")
+
+	// ErrWaitStrategyTimeout is returned when the container doesn't become ready before the
+	// deadline of its wait strategy. Unless the FailureLogLines of the request is negative,
+	// the error is wrapped in a [StartupError], carrying the container ID and the last log
+	// lines of the container.
+	ErrWaitStrategyTimeout = errors.New("wait strategy timeout")
+)
@@ -0,0 +1,78 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartupErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("image-pull", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:           "docker.io/busybox:nonexistent-version",
+				PullRetryPolicy: &PullRetryPolicy{MaxAttempts: 1},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.ErrorIs(t, err, ErrImagePull)
+		require.NotErrorIs(t, err, ErrContainerCreate)
+	})
+
+	t.Run("container-create", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/busybox",
+				HostConfigModifier: func(hc *container.HostConfig) {
+					// below the minimum memory limit of the daemon.
+					hc.Memory = 1024
+				},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.ErrorIs(t, err, ErrContainerCreate)
+		require.NotErrorIs(t, err, ErrImagePull)
+	})
+
+	t.Run("container-start", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{nginxDefaultPort},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		port, err := ctr.MappedPort(ctx, nginxDefaultPort)
+		require.NoError(t, err)
+
+		// the host port of the first container is requested for the second one.
+		conflicting, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{nginxDefaultPort},
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.PortBindings = nat.PortMap{
+						nginxDefaultPort: {{HostIP: "0.0.0.0", HostPort: port.Port()}},
+					}
+				},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, conflicting)
+		require.ErrorIs(t, err, ErrContainerStart)
+	})
+}
@@ -0,0 +1,72 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/pkg/stdcopy"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// ExecResult represents the result of a command executed in a container.
+type ExecResult struct {
+	// ExitCode is the exit code of the command.
+	ExitCode int
+	// Stdout is the standard output of the command.
+	Stdout []byte
+	// Stderr is the standard error of the command.
+	Stderr []byte
+}
+
+// Combined returns the standard output of the command followed by its standard error.
+func (r ExecResult) Combined() string {
+	return string(r.Stdout) + string(r.Stderr)
+}
+
+// ExecError is returned by [DockerContainer.Run] when the command exits with a non-zero exit code.
+type ExecError struct {
+	// Cmd is the command executed in the container.
+	Cmd []string
+	// Result is the result of the command, including its output.
+	Result ExecResult
+}
+
+// Error returns the combined output of the command, or its exit code when the command produced no output.
+func (e *ExecError) Error() string {
+	if output := e.Result.Combined(); output != "" {
+		return output
+	}
+
+	return fmt.Sprintf("%s: exit code %d", strings.Join(e.Cmd, " "), e.Result.ExitCode)
+}
+
+// Run executes a command in the current container, like [DockerContainer.Exec], but reading its whole output.
+// It returns the [ExecResult] of the command, with its exit code and its standard output and error,
+// and an [*ExecError] when the exit code is not zero, so that callers can inspect it using [errors.As].
+// The [tcexec.Multiplexed] option must not be used, as the output is demultiplexed by this method.
+func (c *DockerContainer) Run(ctx context.Context, cmd []string, options ...tcexec.ProcessOption) (ExecResult, error) {
+	code, reader, err := c.Exec(ctx, cmd, options...)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, reader); err != nil {
+		return ExecResult{}, fmt.Errorf("read exec output: %w", err)
+	}
+
+	result := ExecResult{
+		ExitCode: code,
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+	}
+
+	if code != 0 {
+		return result, &ExecError{Cmd: cmd, Result: result}
+	}
+
+	return result, nil
+}
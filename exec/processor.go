@@ -87,3 +87,48 @@ func Multiplexed() ProcessOption {
 		opts.Reader = io.MultiReader(&outBuff, &errBuff)
 	})
 }
+
+// WithPrivileged returns a [ProcessOption] that runs the command with extended privileges.
+func WithPrivileged(privileged bool) ProcessOption {
+	return ProcessOptionFunc(func(opts *ProcessOptions) {
+		opts.ExecConfig.Privileged = privileged
+	})
+}
+
+// ExecOptions defines the Docker's exec configuration used to run a command in a container.
+// The zero value runs the command with the defaults of the container: its user, its working
+// directory and its environment, without extended privileges.
+type ExecOptions struct {
+	// User is the user, and optionally the group, that runs the command, e.g. "1001" or "ldap:ldap".
+	User string
+	// WorkingDir is the working directory in which the command is run.
+	WorkingDir string
+	// Env is the list of environment variables, in the KEY=VALUE form, set only for the command.
+	Env []string
+	// Privileged runs the command with extended privileges.
+	Privileged bool
+}
+
+// ProcessOptions converts the exec options into the equivalent slice of [ProcessOption].
+// Empty fields are skipped, so the defaults of the container apply.
+func (o ExecOptions) ProcessOptions() []ProcessOption {
+	var opts []ProcessOption
+
+	if o.User != "" {
+		opts = append(opts, WithUser(o.User))
+	}
+
+	if o.WorkingDir != "" {
+		opts = append(opts, WithWorkingDir(o.WorkingDir))
+	}
+
+	if len(o.Env) > 0 {
+		opts = append(opts, WithEnv(o.Env))
+	}
+
+	if o.Privileged {
+		opts = append(opts, WithPrivileged(true))
+	}
+
+	return opts
+}
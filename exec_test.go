@@ -0,0 +1,68 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecError(t *testing.T) {
+	t.Run("with-output", func(t *testing.T) {
+		err := &ExecError{
+			Cmd: []string{"ls", "/missing"},
+			Result: ExecResult{
+				ExitCode: 1,
+				Stdout:   []byte("out\n"),
+				Stderr:   []byte("ls: /missing: No such file or directory\n"),
+			},
+		}
+
+		require.EqualError(t, err, "out\nls: /missing: No such file or directory\n")
+	})
+
+	t.Run("without-output", func(t *testing.T) {
+		err := &ExecError{
+			Cmd:    []string{"false"},
+			Result: ExecResult{ExitCode: 1},
+		}
+
+		require.EqualError(t, err, "false: exit code 1")
+	})
+}
+
+func TestContainerRun(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/busybox",
+			Cmd:   []string{"sleep", "10"},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		result, err := ctr.Run(ctx, []string{"sh", "-c", "echo out; echo err >&2"})
+		require.NoError(t, err)
+		require.Zero(t, result.ExitCode)
+		require.Equal(t, "out\n", string(result.Stdout))
+		require.Equal(t, "err\n", string(result.Stderr))
+		require.Equal(t, "out\nerr\n", result.Combined())
+	})
+
+	t.Run("non-zero-exit-code", func(t *testing.T) {
+		result, err := ctr.Run(ctx, []string{"sh", "-c", "echo failed >&2; exit 3"})
+		require.Error(t, err)
+
+		var execErr *ExecError
+		require.True(t, errors.As(err, &execErr))
+		require.Equal(t, 3, execErr.Result.ExitCode)
+		require.Equal(t, result, execErr.Result)
+		require.EqualError(t, err, "failed\n")
+	})
+}
@@ -4,13 +4,19 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
+// ErrPathTraversal is returned when an entry of a tar archive would be extracted
+// outside the destination directory.
+var ErrPathTraversal = errors.New("path traversal")
+
 func isDir(path string) (bool, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -41,7 +47,7 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 
 	buffer := &bytes.Buffer{}
 
-	Logger.Printf(">> creating TAR file from directory: %s\n", src)
+	debugf(Logger, ">> creating TAR file from directory: %s\n", src)
 
 	// tar > gzip > buffer
 	zr := gzip.NewWriter(buffer)
@@ -59,7 +65,7 @@ func tarDir(src string, fileMode int64) (*bytes.Buffer, error) {
 
 		// if a symlink, skip file
 		if fi.Mode().Type() == os.ModeSymlink {
-			Logger.Printf(">> skipping symlink: %s\n", file)
+			debugf(Logger, ">> skipping symlink: %s\n", file)
 			return nil
 		}
 
@@ -139,3 +145,85 @@ func tarFile(basePath string, fileContent func(tw io.Writer) error, fileContentS
 
 	return buffer, nil
 }
+
+// untarDir extracts the uncompressed tar stream of a directory, as returned by the Docker copy API,
+// into the dst directory, which is created if it does not exist. The first element of each entry
+// name, which is the base name of the copied directory, is removed, so the contents of the directory
+// are extracted directly into dst. Each file is streamed to disk, so the archive is never fully
+// buffered in memory. Entries escaping dst are rejected with ErrPathTraversal, while links are skipped.
+// It returns the host paths of the extracted files.
+func untarDir(r io.Reader, dst string) ([]string, error) {
+	// always extract to an absolute path
+	abs, err := filepath.Abs(dst)
+	if err != nil {
+		return nil, fmt.Errorf("error getting absolute path: %w", err)
+	}
+	dst = abs
+
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating directory: %w", err)
+	}
+
+	var files []string
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return files, nil
+			}
+
+			return files, fmt.Errorf("error reading tar archive: %w", err)
+		}
+
+		cleanName := path.Clean(header.Name)
+		if path.IsAbs(cleanName) || cleanName == ".." || strings.HasPrefix(cleanName, "../") {
+			return files, fmt.Errorf("%w: %s", ErrPathTraversal, header.Name)
+		}
+
+		// remove the base directory added by Docker, skipping the entry for the directory itself
+		_, name, found := strings.Cut(cleanName, "/")
+		if !found {
+			continue
+		}
+
+		target := filepath.Join(dst, filepath.FromSlash(name))
+		if !strings.HasPrefix(target, dst+string(os.PathSeparator)) {
+			return files, fmt.Errorf("%w: %s", ErrPathTraversal, header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return files, fmt.Errorf("error creating directory: %w", err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return files, fmt.Errorf("error creating directory: %w", err)
+			}
+
+			if err := extractFile(tr, target, header.FileInfo().Mode().Perm()); err != nil {
+				return files, err
+			}
+
+			files = append(files, target)
+		default:
+			debugf(Logger, ">> skipping unsupported tar entry: %s\n", header.Name)
+		}
+	}
+}
+
+// extractFile writes the content of the reader to the target file, using the given permissions
+func extractFile(r io.Reader, target string, perm os.FileMode) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("error opening file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("error extracting file: %w", err)
+	}
+
+	return f.Close()
+}
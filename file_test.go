@@ -139,6 +139,65 @@ func Test_TarFile(t *testing.T) {
 	assert.Equal(t, b, untarBytes)
 }
 
+func Test_UntarDir(t *testing.T) {
+	tarEntries := func(t *testing.T, entries map[string]string) io.Reader {
+		t.Helper()
+
+		buff := &bytes.Buffer{}
+		tw := tar.NewWriter(buff)
+		for name, content := range entries {
+			hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+			if content == "" {
+				hdr.Typeflag = tar.TypeDir
+				hdr.Mode = 0o755
+			}
+			require.NoError(t, tw.WriteHeader(hdr))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+
+		return buff
+	}
+
+	t.Run("success", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "reports")
+
+		files, err := untarDir(tarEntries(t, map[string]string{
+			"reports/":                 "",
+			"reports/summary.txt":      "summary",
+			"reports/nested/":          "",
+			"reports/nested/data.json": "{}",
+		}), dst)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			filepath.Join(dst, "summary.txt"),
+			filepath.Join(dst, "nested", "data.json"),
+		}, files)
+
+		content, err := os.ReadFile(filepath.Join(dst, "nested", "data.json"))
+		require.NoError(t, err)
+		require.Equal(t, "{}", string(content))
+	})
+
+	t.Run("path-traversal", func(t *testing.T) {
+		names := []string{
+			"../evil.txt",
+			"reports/../../evil.txt",
+			"/etc/evil.txt",
+		}
+
+		for _, name := range names {
+			dst := filepath.Join(t.TempDir(), "reports")
+
+			files, err := untarDir(tarEntries(t, map[string]string{name: "evil"}), dst)
+			require.ErrorIs(t, err, ErrPathTraversal, name)
+			require.Empty(t, files)
+			require.NoFileExists(t, filepath.Join(filepath.Dir(dst), "evil.txt"))
+		}
+	})
+}
+
 // untar takes a destination path and a reader; a tar reader loops over the tarfile
 // creating the file structure at 'dst' along the way, and writing any files
 func untar(dst string, r io.Reader) error {
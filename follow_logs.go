@@ -0,0 +1,162 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// errStopFollowingLogs is returned by the line writers to stop reading the log stream
+// once the callback of FollowLogs returns true.
+var errStopFollowingLogs = errors.New("stop following logs")
+
+// FollowLogs streams the standard output and error of the container, from its start, calling fn
+// for each line, without the trailing newline, until fn returns true or the context is done.
+// For containers without a TTY, the stdout and stderr streams are demultiplexed, each line being
+// read from a single stream, as the lines of both streams may be interleaved.
+//
+// It returns nil if fn returned true, the context error if the context is done, and [io.EOF]
+// if the log stream ended, e.g. because the container exited, before fn returned true.
+// Any other error of the log stream is returned too.
+func (c *DockerContainer) FollowLogs(ctx context.Context, fn func(line string) bool) error {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rc, err := c.provider.client.ContainerLogs(ctx, c.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return fmt.Errorf("container logs: %w", err)
+	}
+	defer c.provider.Close()
+	defer rc.Close()
+
+	stdout := &lineWriter{fn: fn}
+	stderr := &lineWriter{fn: fn}
+
+	if inspect.Config.Tty {
+		// the output of a TTY is a raw stream.
+		_, err = io.Copy(stdout, rc)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, rc)
+	}
+
+	switch {
+	case errors.Is(err, errStopFollowingLogs):
+		return nil
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		return fmt.Errorf("read logs: %w", err)
+	}
+
+	// the last lines may not end with a newline.
+	if stdout.flush() || stderr.flush() {
+		return nil
+	}
+
+	return io.EOF
+}
+
+// StreamLogs gets the logs of the selected output streams of the container, so that the logs of
+// a single stream can be read, e.g. only stderr when stdout is too chatty. For containers with a TTY,
+// the output is only written to stdout. Both streams are read, merged, if both are selected, as with Logs.
+func (c *DockerContainer) StreamLogs(ctx context.Context, stdout bool, stderr bool) (io.ReadCloser, error) {
+	if stdout && stderr {
+		return c.Logs(ctx)
+	}
+
+	return c.demuxLogs(ctx, container.LogsOptions{
+		ShowStdout: stdout,
+		ShowStderr: stderr,
+	})
+}
+
+// LogsSince gets the logs of the selected output streams of the container, written since the given time,
+// e.g. to skip the output of the previous starts of a restarted container. The timestamps of the lines
+// are set by the daemon when it reads them. Both streams are merged if both are selected.
+func (c *DockerContainer) LogsSince(ctx context.Context, since time.Time, stdout bool, stderr bool) (io.ReadCloser, error) {
+	return c.demuxLogs(ctx, container.LogsOptions{
+		ShowStdout: stdout,
+		ShowStderr: stderr,
+		Since:      since.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// demuxLogs gets the logs of the container with the given options, demultiplexing the streams
+// of containers without a TTY.
+func (c *DockerContainer) demuxLogs(ctx context.Context, options container.LogsOptions) (io.ReadCloser, error) {
+	inspect, err := c.Inspect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+
+	rc, err := c.provider.client.ContainerLogs(ctx, c.ID, options)
+	if err != nil {
+		return nil, fmt.Errorf("container logs: %w", err)
+	}
+	defer c.provider.Close()
+
+	if inspect.Config.Tty {
+		// the output of a TTY is a raw stream.
+		return rc, nil
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer rc.Close()
+
+		// only the selected streams are sent by the daemon.
+		_, err := stdcopy.StdCopy(pw, pw, rc)
+		_ = pw.CloseWithError(err)
+	}()
+
+	return pr, nil
+}
+
+// lineWriter calls fn for each line written to it, until fn returns true.
+type lineWriter struct {
+	fn  func(line string) bool
+	buf bytes.Buffer
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+
+	for {
+		idx := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if idx < 0 {
+			return len(p), nil
+		}
+
+		line := w.buf.Next(idx + 1)[:idx]
+		if w.fn(string(bytes.TrimSuffix(line, []byte("\r")))) {
+			return len(p), errStopFollowingLogs
+		}
+	}
+}
+
+// flush calls fn for the incomplete line, if any, returning its result.
+func (w *lineWriter) flush() bool {
+	if w.buf.Len() == 0 {
+		return false
+	}
+
+	line := w.buf.String()
+	w.buf.Reset()
+
+	return w.fn(line)
+}
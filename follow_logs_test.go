@@ -0,0 +1,206 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDockerContainer_FollowLogs(t *testing.T) {
+	ctx := context.Background()
+
+	run := func(t *testing.T, script string, tty bool) *DockerContainer {
+		t.Helper()
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/alpine",
+				Cmd:   []string{"sh", "-c", script},
+				ConfigModifier: func(cfg *container.Config) {
+					cfg.Tty = tty
+				},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		return ctr.(*DockerContainer)
+	}
+
+	t.Run("stdout-and-stderr", func(t *testing.T) {
+		ctr := run(t, "echo one; echo two >&2; echo three; sleep 60", false)
+
+		// followLogs {
+		var lines []string
+		err := ctr.FollowLogs(ctx, func(line string) bool {
+			lines = append(lines, line)
+			return len(lines) == 3
+		})
+		// }
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"one", "two", "three"}, lines)
+	})
+
+	t.Run("tty", func(t *testing.T) {
+		ctr := run(t, "echo one; echo two; sleep 60", true)
+
+		var lines []string
+		err := ctr.FollowLogs(ctx, func(line string) bool {
+			lines = append(lines, line)
+			return line == "two"
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"one", "two"}, lines)
+	})
+
+	t.Run("container-exited", func(t *testing.T) {
+		ctr := run(t, "echo one; printf two", false)
+
+		var lines []string
+		err := ctr.FollowLogs(ctx, func(line string) bool {
+			lines = append(lines, line)
+			return false
+		})
+		require.ErrorIs(t, err, io.EOF)
+		require.Equal(t, []string{"one", "two"}, lines)
+	})
+
+	t.Run("context-done", func(t *testing.T) {
+		ctr := run(t, "echo one; sleep 60", false)
+
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		err := ctr.FollowLogs(ctx, func(string) bool {
+			return false
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestDockerContainer_StreamLogs(t *testing.T) {
+	ctx := context.Background()
+
+	// waitForLogStream {
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/alpine",
+			Cmd: []string{"sh", "-c", "for i in 1 2 3; do echo consumer group $i rebalanced >&2; sleep 1; done; " +
+				"echo consumer group 4 rebalanced; sleep 60"},
+			WaitingFor: wait.ForLogRegex("consumer group .* rebalanced").
+				WithOccurrence(3).
+				WithStream(wait.LogStreamStderr),
+		},
+		Started: true,
+	})
+	// }
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	dc := ctr.(*DockerContainer)
+
+	read := func(t *testing.T, stdout bool, stderr bool) string {
+		t.Helper()
+
+		// the stdout line is written once the stderr lines are.
+		require.Eventually(t, func() bool {
+			rc, err := dc.StreamLogs(ctx, true, false)
+			require.NoError(t, err)
+			defer rc.Close()
+
+			bs, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			return len(bs) > 0
+		}, 10*time.Second, 100*time.Millisecond)
+
+		rc, err := dc.StreamLogs(ctx, stdout, stderr)
+		require.NoError(t, err)
+		defer rc.Close()
+
+		bs, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		return string(bs)
+	}
+
+	require.Equal(t, "consumer group 4 rebalanced\n", read(t, true, false))
+	require.Equal(t, "consumer group 1 rebalanced\nconsumer group 2 rebalanced\nconsumer group 3 rebalanced\n", read(t, false, true))
+	require.Len(t, strings.Split(strings.TrimSpace(read(t, true, true)), "\n"), 4)
+}
+
+func TestDockerContainer_LogsSince(t *testing.T) {
+	ctx := context.Background()
+
+	// each start prints the number of starts, once the service is ready.
+	var starts []string
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/alpine",
+			Cmd: []string{"sh", "-c", "n=$(($(cat /tmp/starts 2>/dev/null || echo 0) + 1)); echo $n > /tmp/starts; " +
+				"sleep 1; echo ready $n; sleep 60"},
+			// restartAwareLog {
+			WaitingFor: wait.ForLogRegex(`ready (\d+)`).
+				WithSubmatchCallback(func(matches [][]string) error {
+					for _, m := range matches {
+						starts = append(starts, m[1])
+					}
+					return nil
+				}),
+			// }
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, starts)
+
+	stopTimeout := time.Second
+	require.NoError(t, ctr.Stop(ctx, &stopTimeout))
+	require.NoError(t, ctr.Start(ctx))
+
+	// the log of the first start is not matched once restarted.
+	require.Equal(t, []string{"1", "2"}, starts)
+
+	inspect, err := ctr.Inspect(ctx)
+	require.NoError(t, err)
+	startedAt, err := time.Parse(time.RFC3339Nano, inspect.State.StartedAt)
+	require.NoError(t, err)
+
+	rc, err := ctr.(*DockerContainer).LogsSince(ctx, startedAt, true, true)
+	require.NoError(t, err)
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.Equal(t, "ready 2\n", string(bs))
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{fn: func(line string) bool {
+		lines = append(lines, line)
+		return line == "stop"
+	}}
+
+	// the lines split across writes are reassembled
+	for _, s := range []string{"a\r\nb", "c\n", "\nd"} {
+		n, err := w.Write([]byte(s))
+		require.NoError(t, err)
+		require.Equal(t, len(s), n)
+	}
+	require.Equal(t, []string{"a", "bc", ""}, lines)
+
+	_, err := w.Write([]byte("\nstop\nignored\n"))
+	require.ErrorIs(t, err, errStopFollowingLogs)
+	require.Equal(t, []string{"a", "bc", "", "d", "stop"}, lines)
+}
@@ -2,9 +2,11 @@ package testcontainers
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"log"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -239,3 +241,175 @@ func TestBuildImageFromDockerfile_TargetDoesNotExist(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestBuildImageFromDockerfile_Secrets(t *testing.T) {
+	ctx := context.Background()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-from-file"), 0o600))
+
+	t.Run("secrets", func(t *testing.T) {
+		// buildWithSecrets {
+		c, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				FromDockerfile: FromDockerfile{
+					Context:    "testdata",
+					Dockerfile: "secrets.Dockerfile",
+					Secrets: map[string]BuildSecret{
+						"token":    {File: tokenFile},         // read from a file on the host
+						"password": {Value: "password-value"}, // the secret value itself
+					},
+				},
+			},
+			Started: true,
+		})
+		// }
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+
+		r, err := c.Logs(ctx)
+		require.NoError(t, err)
+
+		logs, err := io.ReadAll(r)
+		require.NoError(t, err)
+		// the image only stores the hashes of the secrets.
+		require.Contains(t, string(logs), fmt.Sprintf("%x", sha256.Sum256([]byte("token-from-file"))))
+		require.Contains(t, string(logs), fmt.Sprintf("%x", sha256.Sum256([]byte("password-value"))))
+	})
+
+	t.Run("missing-secret", func(t *testing.T) {
+		_, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				FromDockerfile: FromDockerfile{
+					Context:    "testdata",
+					Dockerfile: "secrets.Dockerfile",
+					Secrets: map[string]BuildSecret{
+						"token": {File: tokenFile},
+					},
+				},
+			},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("buildkit-disabled", func(t *testing.T) {
+		t.Setenv("DOCKER_BUILDKIT", "0")
+
+		_, err := GenericContainer(ctx, GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				FromDockerfile: FromDockerfile{
+					Context:    "testdata",
+					Dockerfile: "secrets.Dockerfile",
+					Secrets: map[string]BuildSecret{
+						"token":    {File: tokenFile},
+						"password": {Value: "password-value"},
+					},
+				},
+			},
+		})
+		require.ErrorIs(t, err, ErrBuildKitNotAvailable)
+	})
+}
+
+func TestContainerRequest_GetBuildSecrets(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-from-file"), 0o600))
+
+	t.Run("no-secrets", func(t *testing.T) {
+		req := ContainerRequest{}
+
+		secrets, err := req.GetBuildSecrets()
+		require.NoError(t, err)
+		require.Nil(t, secrets)
+	})
+
+	t.Run("files-and-values", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Secrets: map[string]BuildSecret{
+					"token":    {File: tokenFile},
+					"password": {Value: "password-value"},
+					// a value that is a path is not read
+					"path":  {Value: tokenFile},
+					"empty": {},
+				},
+			},
+		}
+
+		secrets, err := req.GetBuildSecrets()
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{
+			"token":    []byte("token-from-file"),
+			"password": []byte("password-value"),
+			"path":     []byte(tokenFile),
+			"empty":    []byte(""),
+		}, secrets)
+	})
+
+	t.Run("empty-id", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Secrets: map[string]BuildSecret{"": {Value: "value"}},
+			},
+		}
+
+		_, err := req.GetBuildSecrets()
+		require.EqualError(t, err, "build secret id must not be empty")
+	})
+
+	t.Run("file-and-value", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Secrets: map[string]BuildSecret{"token": {File: tokenFile, Value: "value"}},
+			},
+		}
+
+		_, err := req.GetBuildSecrets()
+		require.EqualError(t, err, `build secret "token": only one of File and Value can be set`)
+	})
+
+	t.Run("missing-file", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Secrets: map[string]BuildSecret{"token": {File: filepath.Join(t.TempDir(), "missing")}},
+			},
+		}
+
+		_, err := req.GetBuildSecrets()
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestBuildImageFromDockerfile_TargetStage(t *testing.T) {
+	ctx := context.Background()
+
+	for target, expected := range map[string]string{
+		"first": "first stage",
+		// the final stage is built when no target is set
+		"": "second stage",
+	} {
+		t.Run("target="+target, func(t *testing.T) {
+			// buildFromDockerfileWithTarget {
+			c, err := GenericContainer(ctx, GenericContainerRequest{
+				ContainerRequest: ContainerRequest{
+					FromDockerfile: FromDockerfile{
+						Context:    "testdata",
+						Dockerfile: "stages.Dockerfile",
+						Target:     target,
+					},
+				},
+				Started: true,
+			})
+			// }
+			terminateContainerOnEnd(t, ctx, c)
+			require.NoError(t, err)
+
+			r, err := c.Logs(ctx)
+			require.NoError(t, err)
+
+			logs, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, expected, strings.TrimSpace(string(logs)))
+		})
+	}
+}
@@ -6,12 +6,17 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/docker/docker/client"
+
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
 
 var (
 	reuseContainerMx  sync.Mutex
 	ErrReuseEmptyName = errors.New("with reuse option a container name mustn't be empty")
+	// ErrReservedLabel is returned when a container request defines a label that is reserved
+	// by Testcontainers with a value different from the one set by the library.
+	ErrReservedLabel = core.ErrReservedLabel
 )
 
 // GenericContainerRequest represents parameters to a generic container
@@ -21,6 +26,11 @@ type GenericContainerRequest struct {
 	ProviderType     ProviderType // which provider to use, Docker if empty
 	Logger           Logging      // provide a container specific Logging - use default global logger if empty
 	Reuse            bool         // reuse an existing container if it exists or create a new one. a container name mustn't be empty
+
+	// DockerClient is the Docker client used to create the container and to interact with it, instead of
+	// the one resolved from the environment and the properties file, e.g. to talk to a remote daemon or to
+	// a fake one. It's only used by the Docker providers.
+	DockerClient client.APIClient
 }
 
 // Deprecated: will be removed in the future.
@@ -51,11 +61,20 @@ func GenericContainer(ctx context.Context, req GenericContainerRequest) (Contain
 		return nil, ErrReuseEmptyName
 	}
 
+	// the env is validated before the provider, and so the session reaper, are created.
+	if err := req.validateEnv(); err != nil {
+		return nil, err
+	}
+
 	logging := req.Logger
 	if logging == nil {
 		logging = Logger
 	}
-	provider, err := req.ProviderType.GetProvider(WithLogger(logging))
+	providerOpts := []GenericProviderOption{WithLogger(logging)}
+	if req.DockerClient != nil {
+		providerOpts = append(providerOpts, WithDockerClient(req.DockerClient))
+	}
+	provider, err := req.ProviderType.GetProvider(providerOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("get provider: %w", err)
 	}
@@ -96,3 +115,10 @@ type GenericProvider interface {
 func GenericLabels() map[string]string {
 	return core.DefaultLabels(core.SessionID())
 }
+
+// ReservedLabels returns the label keys that Testcontainers sets on the resources it creates.
+// Defining any of them in a container request with a different value than the one set by the
+// library results in an error wrapping ErrReservedLabel.
+func ReservedLabels() []string {
+	return core.ReservedLabels()
+}
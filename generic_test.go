@@ -3,6 +3,8 @@ package testcontainers
 import (
 	"context"
 	"errors"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"strings"
@@ -12,8 +14,11 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
 	"github.com/stretchr/testify/require"
 
+	"github.com/testcontainers/testcontainers-go/internal/core"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -106,6 +111,60 @@ func TestGenericReusableContainer(t *testing.T) {
 	}
 }
 
+func TestGenericReusableContainer_stopped(t *testing.T) {
+	ctx := context.Background()
+
+	name := reusableContainerName + "_stopped_" + time.Now().Format("20060102150405")
+
+	req := GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	}
+
+	// withReuseByName {
+	require.NoError(t, WithReuseByName(name)(&req))
+	// }
+
+	n1, err := GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, n1)
+	require.NoError(t, err)
+
+	t.Run("running", func(t *testing.T) {
+		n2, err := GenericContainer(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, n1.GetContainerID(), n2.GetContainerID())
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		// a leftover of a previous run.
+		require.NoError(t, n1.Stop(ctx, nil))
+
+		n2, err := GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, n2)
+		require.NoError(t, err)
+		require.NotEqual(t, n1.GetContainerID(), n2.GetContainerID())
+		require.True(t, n2.IsRunning())
+
+		// the stopped container was removed.
+		_, err = n1.Inspect(ctx)
+		require.True(t, errdefs.IsNotFound(err), err)
+
+		// the recreated container belongs to the current session, so it's reaped with it.
+		inspect, err := n2.Inspect(ctx)
+		require.NoError(t, err)
+		require.Equal(t, core.SessionID(), inspect.Config.Labels[core.LabelSessionID])
+	})
+
+	t.Run("empty-name", func(t *testing.T) {
+		require.ErrorIs(t, WithReuseByName("")(&GenericContainerRequest{}), ErrReuseEmptyName)
+	})
+}
+
 func TestGenericContainerShouldReturnRefOnError(t *testing.T) {
 	// In this test, we are going to cancel the context to exit the `wait.Strategy`.
 	// We want to make sure that the GenericContainer call will still return a reference to the
@@ -126,6 +185,22 @@ func TestGenericContainerShouldReturnRefOnError(t *testing.T) {
 	terminateContainerOnEnd(t, context.Background(), c)
 }
 
+func TestGenericContainerReservedLabels(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			Labels: map[string]string{
+				core.LabelSessionID: "my-session",
+			},
+		},
+	})
+	require.ErrorIs(t, err, ErrReservedLabel)
+	require.Nil(t, c)
+}
+
 func TestGenericReusableContainerInSubprocess(t *testing.T) {
 	wg := sync.WaitGroup{}
 	wg.Add(10)
@@ -197,3 +272,66 @@ func TestHelperContainerStarterProcess(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, nginxC.IsRunning())
 }
+
+// fakeDaemon is a RoundTripper recording the requests sent to the Docker API, and failing them all.
+type fakeDaemon struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (d *fakeDaemon) RoundTrip(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	d.paths = append(d.paths, req.URL.Path)
+	d.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"message":"fake daemon"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestGenericContainer_DockerClient(t *testing.T) {
+	daemon := &fakeDaemon{}
+
+	// dockerClient {
+	cli, err := client.NewClientWithOpts(
+		client.WithHost("tcp://fake-daemon:2375"),
+		client.WithVersion("1.44"),
+		client.WithHTTPClient(&http.Client{Transport: daemon}),
+	)
+	require.NoError(t, err)
+
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/nginx:alpine",
+		},
+		DockerClient: cli,
+		Started:      true,
+	}
+	// }
+
+	ctr, err := GenericContainer(context.Background(), req)
+	require.ErrorContains(t, err, "fake daemon")
+	require.Nil(t, ctr)
+
+	daemon.mu.Lock()
+	defer daemon.mu.Unlock()
+	require.NotEmpty(t, daemon.paths, "the create path should have called the fake daemon")
+	require.Equal(t, "/v1.44/networks", daemon.paths[0])
+}
+
+func TestWithDockerClient(t *testing.T) {
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://fake-daemon:2375"))
+	require.NoError(t, err)
+
+	req := GenericContainerRequest{}
+	require.NoError(t, WithDockerClient(cli).Customize(&req))
+	require.Same(t, cli, req.DockerClient)
+
+	provider, err := NewDockerProvider(WithDockerClient(cli))
+	require.NoError(t, err)
+	require.Same(t, cli, provider.Client())
+	require.Equal(t, "tcp://fake-daemon:2375", provider.host)
+}
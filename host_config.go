@@ -0,0 +1,138 @@
+package testcontainers
+
+import (
+	"slices"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// AddHostConfigModifier appends a modifier to the chain of modifiers of the host config, see
+// HostConfigModifiers, so that it's applied along with the modifiers set by the modules, the
+// options and Testcontainers itself, e.g. for the host access ports, instead of replacing them.
+func (c *ContainerRequest) AddHostConfigModifier(modifier func(hostConfig *container.HostConfig)) {
+	c.HostConfigModifiers = append(c.HostConfigModifiers, modifier)
+}
+
+// composeHostConfigModifiers returns a modifier running the given modifiers in order, skipping the nil ones.
+func composeHostConfigModifiers(modifiers ...func(hostConfig *container.HostConfig)) func(hostConfig *container.HostConfig) {
+	modifiers = slices.DeleteFunc(modifiers, func(m func(*container.HostConfig)) bool { return m == nil })
+	if len(modifiers) == 0 {
+		return nil
+	}
+
+	return func(hostConfig *container.HostConfig) {
+		for _, modifier := range modifiers {
+			modifier(hostConfig)
+		}
+	}
+}
+
+// hostConfigModifiers returns the modifiers of the host config, in the order they are applied:
+// the default modifier setting the deprecated fields, unless HostConfigModifier is set, the
+// modifier setting the ResourceLimits, then the chain of HostConfigModifiers, and HostConfigModifier last.
+func (c *ContainerRequest) hostConfigModifiers() []func(*container.HostConfig) {
+	modifiers := make([]func(*container.HostConfig), 0, len(c.HostConfigModifiers)+1)
+
+	if c.HostConfigModifier == nil {
+		modifiers = append(modifiers, defaultHostConfigModifier(*c))
+	}
+
+	if c.ResourceLimits != (ResourceLimits{}) {
+		modifiers = append(modifiers, c.ResourceLimits.hostConfigModifier())
+	}
+
+	for _, modifier := range c.HostConfigModifiers {
+		if modifier != nil {
+			modifiers = append(modifiers, modifier)
+		}
+	}
+
+	if c.HostConfigModifier != nil {
+		modifiers = append(modifiers, c.HostConfigModifier)
+	}
+
+	return modifiers
+}
+
+// applyHostConfigModifiers applies the modifiers of the host config in order, with append
+// semantics for the slice fields: the entries set by a modifier are kept even if a later
+// modifier replaces the slice, and the ulimits are merged by name, the last one winning.
+// The HostConfigOverride is applied last as is, so that it can remove entries.
+func (c *ContainerRequest) applyHostConfigModifiers(hostConfig *container.HostConfig) {
+	for _, modifier := range c.hostConfigModifiers() {
+		var before [][]string
+		for _, field := range hostConfigSlices(hostConfig) {
+			before = append(before, slices.Clone(*field))
+		}
+		ulimits := slices.Clone(hostConfig.Ulimits)
+
+		modifier(hostConfig)
+
+		for i, field := range hostConfigSlices(hostConfig) {
+			*field = appendMissing(before[i], *field)
+		}
+		hostConfig.Ulimits = mergeUlimits(ulimits, hostConfig.Ulimits)
+	}
+
+	if c.HostConfigOverride != nil {
+		c.HostConfigOverride(hostConfig)
+	}
+}
+
+// hostConfigSlices returns pointers to the string slice fields of the host config.
+func hostConfigSlices(hostConfig *container.HostConfig) []*[]string {
+	return []*[]string{
+		&hostConfig.Binds,
+		(*[]string)(&hostConfig.CapAdd),
+		(*[]string)(&hostConfig.CapDrop),
+		&hostConfig.DNS,
+		&hostConfig.DNSOptions,
+		&hostConfig.DNSSearch,
+		&hostConfig.ExtraHosts,
+		&hostConfig.GroupAdd,
+		&hostConfig.Links,
+		&hostConfig.SecurityOpt,
+		&hostConfig.VolumesFrom,
+	}
+}
+
+// appendMissing returns the values of a slice modified by a modifier, preceded by the values
+// it had before which are missing, if the modifier replaced the slice instead of appending to it.
+func appendMissing(before []string, after []string) []string {
+	if len(after) >= len(before) && slices.Equal(before, after[:len(before)]) {
+		return after
+	}
+
+	merged := slices.Clone(before)
+	for _, v := range after {
+		if !slices.Contains(merged, v) {
+			merged = append(merged, v)
+		}
+	}
+
+	return merged
+}
+
+// mergeUlimits returns the ulimits set before a modifier, updated with the ones set by
+// the modifier: an ulimit with the same name as a previous one replaces it.
+func mergeUlimits(before []*container.Ulimit, after []*container.Ulimit) []*container.Ulimit {
+	merged := slices.Clone(before)
+
+	for _, ulimit := range after {
+		if ulimit == nil {
+			continue
+		}
+
+		i := slices.IndexFunc(merged, func(u *container.Ulimit) bool {
+			return u != nil && u.Name == ulimit.Name
+		})
+		if i < 0 {
+			merged = append(merged, ulimit)
+			continue
+		}
+
+		merged[i] = ulimit
+	}
+
+	return merged
+}
@@ -0,0 +1,122 @@
+package testcontainers
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRequest_applyHostConfigModifiers(t *testing.T) {
+	t.Run("order", func(t *testing.T) {
+		var calls []string
+
+		req := ContainerRequest{
+			HostConfigModifier: func(hc *container.HostConfig) {
+				calls = append(calls, "field")
+				hc.NetworkMode = "field"
+			},
+		}
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			calls = append(calls, "first")
+			hc.NetworkMode = "first"
+		})
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			calls = append(calls, "second")
+		})
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		require.Equal(t, []string{"first", "second", "field"}, calls)
+		require.Equal(t, container.NetworkMode("field"), hc.NetworkMode)
+	})
+
+	t.Run("deprecated-fields", func(t *testing.T) {
+		req := ContainerRequest{
+			ExtraHosts: []string{"deprecated:10.0.0.1"},
+			CapAdd:     []string{"NET_ADMIN"},
+		}
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			hc.ExtraHosts = append(hc.ExtraHosts, "chain:10.0.0.2")
+		})
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		// the deprecated fields are applied first, as HostConfigModifier is not set.
+		require.Equal(t, []string{"deprecated:10.0.0.1", "chain:10.0.0.2"}, hc.ExtraHosts)
+		require.Equal(t, []string{"NET_ADMIN"}, []string(hc.CapAdd))
+	})
+
+	t.Run("append-semantics", func(t *testing.T) {
+		req := ContainerRequest{
+			// replaces the slices, as most modifiers do.
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.ExtraHosts = []string{"user:10.0.0.3"}
+				hc.Binds = []string{"/tmp:/data"}
+				hc.Ulimits = []*container.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}}
+			},
+		}
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			hc.ExtraHosts = []string{"module:10.0.0.1"}
+			hc.Ulimits = []*container.Ulimit{
+				{Name: "memlock", Soft: -1, Hard: -1},
+				{Name: "nofile", Soft: 65536, Hard: 65536},
+			}
+		})
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			hc.ExtraHosts = append(hc.ExtraHosts, "internal:10.0.0.2", "module:10.0.0.1")
+		})
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		require.Equal(t, []string{"module:10.0.0.1", "internal:10.0.0.2", "module:10.0.0.1", "user:10.0.0.3"}, hc.ExtraHosts)
+		require.Equal(t, []string{"/tmp:/data"}, hc.Binds)
+		// the ulimits are merged by name, the last modifier winning.
+		require.Equal(t, []*container.Ulimit{
+			{Name: "memlock", Soft: -1, Hard: -1},
+			{Name: "nofile", Soft: 1024, Hard: 1024},
+		}, hc.Ulimits)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		req := ContainerRequest{
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.CapAdd = []string{"SYS_ADMIN"}
+			},
+			HostConfigOverride: func(hc *container.HostConfig) {
+				hc.CapAdd = slices.DeleteFunc(hc.CapAdd, func(c string) bool { return c == "IPC_LOCK" })
+				hc.ExtraHosts = nil
+			},
+		}
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			hc.CapAdd = append(hc.CapAdd, "IPC_LOCK")
+			hc.ExtraHosts = append(hc.ExtraHosts, "module:10.0.0.1")
+		})
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		// the override is applied last, as is.
+		require.Equal(t, []string{"SYS_ADMIN"}, []string(hc.CapAdd))
+		require.Empty(t, hc.ExtraHosts)
+	})
+
+	t.Run("validate-mounts", func(t *testing.T) {
+		req := ContainerRequest{
+			Image: nginxAlpineImage,
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.Binds = []string{"/tmp/b:/data"}
+			},
+		}
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			hc.Binds = []string{"/tmp/a:/data"}
+		})
+
+		// the binds of all the modifiers are validated.
+		require.ErrorIs(t, req.Validate(), ErrDuplicateMountTarget)
+	})
+}
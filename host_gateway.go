@@ -0,0 +1,100 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/versions"
+)
+
+const (
+	// HostGateway is the hostname used to reach the host from the container,
+	// resolved by the daemon to the gateway of the host, see WithHostGatewayAccess.
+	HostGateway string = "host.docker.internal"
+
+	// minHostGatewayAPIVersion is the first API version of the Docker Engine resolving
+	// the host-gateway special value of the extra hosts, released with Docker 20.10.
+	minHostGatewayAPIVersion = "1.41"
+)
+
+// forceHostGatewayFallback forces the containers requesting the host gateway access to reach
+// the host through the SSHD tunnel, as if the daemon didn't support the host gateway.
+var forceHostGatewayFallback = false
+
+// WithHostGatewayAccess allows the container to reach the host at the hostname returned by
+// the HostAccessHostname method of the container: HostGateway if the daemon resolves it to
+// the gateway of the host, or HostInternal otherwise, in which case the given host ports are
+// exposed to the container through the SSHD tunnel, as with WithHostPortAccess.
+// The host servers must listen on all the interfaces to be reachable through the gateway.
+func WithHostGatewayAccess(ports ...int) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if req.HostGatewayAccessPorts == nil {
+			req.HostGatewayAccessPorts = []int{}
+		}
+
+		req.HostGatewayAccessPorts = append(req.HostGatewayAccessPorts, ports...)
+		return nil
+	}
+}
+
+// supportsHostGateway reports whether the daemon resolves the host-gateway special value of the
+// extra hosts, which is the case for the Docker Engine since the 20.10 release, and not for Podman.
+func (p *DockerProvider) supportsHostGateway(ctx context.Context) (bool, error) {
+	if forceHostGatewayFallback {
+		return false, nil
+	}
+
+	v, err := p.client.ServerVersion(ctx)
+	if err != nil {
+		return false, fmt.Errorf("server version: %w", err)
+	}
+
+	for _, c := range v.Components {
+		if strings.Contains(strings.ToLower(c.Name), "podman") {
+			return false, nil
+		}
+	}
+
+	return versions.GreaterThanOrEqualTo(v.APIVersion, minHostGatewayAPIVersion), nil
+}
+
+// hostGatewayAccess prepares the request to reach the host, returning the hostname of the host in the
+// container: HostGateway, added as an extra host resolved to the gateway of the host, if the daemon
+// supports it, or HostInternal otherwise, adding the ports of the request to the ports exposed
+// through the SSHD tunnel.
+func (p *DockerProvider) hostGatewayAccess(ctx context.Context, req *ContainerRequest) (string, error) {
+	supported, err := p.supportsHostGateway(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !supported {
+		if len(req.HostGatewayAccessPorts) == 0 {
+			return "", fmt.Errorf("the daemon doesn't support the host gateway, and no host port to expose through the SSHD tunnel")
+		}
+
+		for _, port := range req.HostGatewayAccessPorts {
+			if !slices.Contains(req.HostAccessPorts, port) {
+				req.HostAccessPorts = append(req.HostAccessPorts, port)
+			}
+		}
+
+		return HostInternal, nil
+	}
+
+	req.AddHostConfigModifier(func(hostConfig *container.HostConfig) {
+		hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, HostGateway+":host-gateway")
+	})
+
+	return HostGateway, nil
+}
+
+// HostAccessHostname returns the hostname to reach the host from the container, requested with
+// WithHostGatewayAccess: HostGateway if the daemon supports it, or HostInternal otherwise.
+// It's empty if the host access was not requested with WithHostGatewayAccess.
+func (c *DockerContainer) HostAccessHostname() string {
+	return c.hostAccessHostname
+}
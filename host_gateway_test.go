@@ -0,0 +1,104 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+func TestWithHostGatewayAccess(t *testing.T) {
+	// the server listens on all the interfaces, to be reachable through the host gateway.
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "Hello, host!")
+	}))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		name     string
+		fallback bool
+		expected string
+	}{
+		{name: "host-gateway", expected: HostGateway},
+		{name: "sshd-fallback", fallback: true, expected: HostInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			forceHostGatewayFallback = tt.fallback
+			t.Cleanup(func() { forceHostGatewayFallback = false })
+
+			if !tt.fallback {
+				p, err := NewDockerProvider()
+				require.NoError(t, err)
+				defer p.Close()
+
+				supported, err := p.supportsHostGateway(ctx)
+				require.NoError(t, err)
+				if !supported {
+					t.Skip("the daemon doesn't support the host gateway")
+				}
+			}
+
+			req := GenericContainerRequest{
+				ProviderType: providerType,
+				ContainerRequest: ContainerRequest{
+					Image: "docker.io/alpine:3.17",
+					Cmd:   []string{"top"},
+				},
+				Started: true,
+			}
+			require.NoError(t, WithHostGatewayAccess(port)(&req))
+
+			ctr, err := GenericContainer(ctx, req)
+			terminateContainerOnEnd(t, ctx, ctr)
+			require.NoError(t, err)
+
+			// hostAccessHostname {
+			hostname := ctr.(*DockerContainer).HostAccessHostname()
+			code, reader, err := ctr.Exec(ctx,
+				[]string{"wget", "-q", "-O", "-", fmt.Sprintf("http://%s:%d", hostname, port)},
+				tcexec.Multiplexed(),
+			)
+			// }
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, hostname)
+
+			bs, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Zero(t, code, string(bs))
+			require.Equal(t, "Hello, host!", string(bs))
+		})
+	}
+
+	t.Run("fallback-without-ports", func(t *testing.T) {
+		forceHostGatewayFallback = true
+		t.Cleanup(func() { forceHostGatewayFallback = false })
+
+		ctr, err := GenericContainer(context.Background(), GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:                  "docker.io/alpine:3.17",
+				HostGatewayAccessPorts: []int{},
+			},
+		})
+		require.ErrorContains(t, err, "no host port to expose")
+		require.Nil(t, ctr)
+	})
+}
@@ -0,0 +1,158 @@
+package buildkit
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// message is a message of the session services, encoded in the protobuf wire format.
+type message interface {
+	marshal() []byte
+	unmarshal(b []byte) error
+}
+
+// codec encodes the messages of the session services. It's named after the protobuf codec,
+// as the daemon sends and expects the application/grpc+proto content type.
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(message)
+	if !ok {
+		return nil, fmt.Errorf("marshal: unexpected message type %T", v)
+	}
+
+	return m.marshal(), nil
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(message)
+	if !ok {
+		return fmt.Errorf("unmarshal: unexpected message type %T", v)
+	}
+
+	return m.unmarshal(data)
+}
+
+func (codec) Name() string {
+	return "proto"
+}
+
+// healthServing is the SERVING status of the health check response.
+const healthServing = 1
+
+// getSecretRequest is the moby.buildkit.secrets.v1.GetSecretRequest message. Its annotations are ignored.
+type getSecretRequest struct {
+	id string // field 1
+}
+
+func (r *getSecretRequest) marshal() []byte {
+	var b []byte
+	if r.id != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, r.id)
+	}
+
+	return b
+}
+
+func (r *getSecretRequest) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.BytesType {
+			return 0, nil
+		}
+
+		id, n := protowire.ConsumeString(b)
+		r.id = id
+		return n, protowire.ParseError(n)
+	})
+}
+
+// getSecretResponse is the moby.buildkit.secrets.v1.GetSecretResponse message.
+type getSecretResponse struct {
+	data []byte // field 1
+}
+
+func (r *getSecretResponse) marshal() []byte {
+	var b []byte
+	if len(r.data) > 0 {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.data)
+	}
+
+	return b
+}
+
+func (r *getSecretResponse) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.BytesType {
+			return 0, nil
+		}
+
+		data, n := protowire.ConsumeBytes(b)
+		r.data = append([]byte(nil), data...)
+		return n, protowire.ParseError(n)
+	})
+}
+
+// healthCheckRequest is the grpc.health.v1.HealthCheckRequest message. Its service is ignored.
+type healthCheckRequest struct{}
+
+func (*healthCheckRequest) marshal() []byte {
+	return nil
+}
+
+func (*healthCheckRequest) unmarshal(b []byte) error {
+	return consumeFields(b, func(protowire.Number, protowire.Type, []byte) (int, error) {
+		return 0, nil
+	})
+}
+
+// healthCheckResponse is the grpc.health.v1.HealthCheckResponse message.
+type healthCheckResponse struct {
+	status uint64 // field 1
+}
+
+func (r *healthCheckResponse) marshal() []byte {
+	b := protowire.AppendTag(nil, 1, protowire.VarintType)
+	return protowire.AppendVarint(b, r.status)
+}
+
+func (r *healthCheckResponse) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != 1 || typ != protowire.VarintType {
+			return 0, nil
+		}
+
+		v, n := protowire.ConsumeVarint(b)
+		r.status = v
+		return n, protowire.ParseError(n)
+	})
+}
+
+// consumeFields parses the fields of a message, calling field with the number and type of each field,
+// and the bytes following its tag. It returns the length of the value it consumed, or zero to skip it.
+func consumeFields(b []byte, field func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		n, err := field(num, typ, b)
+		if err != nil {
+			return err
+		}
+
+		if n == 0 {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+		}
+		b = b[n:]
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package buildkit implements the client side of the BuildKit sessions of the Docker daemon, which
+// expose the build secrets to the RUN --mount=type=secret instructions of a Dockerfile. Only the
+// secrets and health services are served, so that the BuildKit module is not needed.
+package buildkit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// the headers of the request opening a session, see github.com/moby/buildkit/session.
+const (
+	headerSessionID        = "X-Docker-Expose-Session-Uuid"
+	headerSessionName      = "X-Docker-Expose-Session-Name"
+	headerSessionSharedKey = "X-Docker-Expose-Session-Sharedkey"
+	headerSessionMethod    = "X-Docker-Expose-Session-Grpc-Method"
+)
+
+// Dialer opens the connection of a session to the Docker daemon, e.g. with the DialHijack method of
+// the Docker client and the /session endpoint. The proto is the protocol the connection is upgraded to,
+// and meta the headers of the request.
+type Dialer func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error)
+
+// Session is a BuildKit session serving the secrets of a build. The ID of the session is passed
+// to the build, in the SessionID field of the build options, while the session is running.
+type Session struct {
+	id     string
+	server *grpc.Server
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Start opens a session serving the given secrets, indexed by id, with the dialer, and serves it
+// in the background until it's closed or the context is done. The caller must close the session
+// once the build is done.
+func Start(ctx context.Context, name string, secrets map[string][]byte, dial Dialer) (*Session, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("session id: %w", err)
+	}
+
+	server := grpc.NewServer(grpc.ForceServerCodec(codec{}))
+	server.RegisterService(&secretsServiceDesc, secretStore(secrets))
+	server.RegisterService(&healthServiceDesc, struct{}{})
+
+	meta := map[string][]string{
+		headerSessionID:        {id},
+		headerSessionName:      {name},
+		headerSessionSharedKey: {""},
+	}
+	for service, info := range server.GetServiceInfo() {
+		for _, method := range info.Methods {
+			meta[headerSessionMethod] = append(meta[headerSessionMethod], "/"+service+"/"+method.Name)
+		}
+	}
+
+	conn, err := dial(ctx, "h2c", meta)
+	if err != nil {
+		return nil, fmt.Errorf("dial session: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Session{id: id, server: server, cancel: cancel, done: make(chan struct{})}
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	go func() {
+		defer close(s.done)
+		// the daemon is the gRPC client of the session, over HTTP/2 without TLS.
+		(&http2.Server{}).ServeConn(conn, &http2.ServeConnOpts{Context: ctx, Handler: server})
+	}()
+
+	return s, nil
+}
+
+// ID returns the ID of the session.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Close stops serving the session, closing its connection.
+func (s *Session) Close() error {
+	s.server.Stop()
+	s.cancel()
+	<-s.done
+
+	return nil
+}
+
+// newID returns a random session ID.
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// secretStore serves the secrets, indexed by id.
+type secretStore map[string][]byte
+
+// secretsServiceDesc describes the moby.buildkit.secrets.v1.Secrets service, whose only
+// method returns the secret with the requested id.
+var secretsServiceDesc = grpc.ServiceDesc{
+	ServiceName: "moby.buildkit.secrets.v1.Secrets",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSecret",
+			Handler: func(srv any, _ context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := &getSecretRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+
+				data, ok := srv.(secretStore)[req.id]
+				if !ok {
+					// the daemon reports the missing secrets from the NotFound code.
+					return nil, status.Errorf(codes.NotFound, "secret %s not found", req.id)
+				}
+
+				return &getSecretResponse{data: data}, nil
+			},
+		},
+	},
+	Metadata: "github.com/moby/buildkit/session/secrets/secrets.proto",
+}
+
+// healthServiceDesc describes the grpc.health.v1.Health service, checked by the daemon
+// while the session is running, which always reports the session as serving.
+var healthServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc.health.v1.Health",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Check",
+			Handler: func(_ any, _ context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				if err := dec(&healthCheckRequest{}); err != nil {
+					return nil, err
+				}
+
+				return &healthCheckResponse{status: healthServing}, nil
+			},
+		},
+	},
+	Metadata: "grpc/health/v1/health.proto",
+}
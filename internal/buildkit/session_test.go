@@ -0,0 +1,88 @@
+package buildkit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestSession(t *testing.T) {
+	ctx := context.Background()
+
+	// the daemon side of the session connection.
+	daemonConn, sessionConn := net.Pipe()
+
+	var meta map[string][]string
+	s, err := Start(ctx, "test-session", map[string][]byte{
+		"token": []byte("token-value"),
+		"empty": {},
+	}, func(_ context.Context, proto string, m map[string][]string) (net.Conn, error) {
+		require.Equal(t, "h2c", proto)
+		meta = m
+		return sessionConn, nil
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+
+	require.Equal(t, []string{s.ID()}, meta[headerSessionID])
+	require.Equal(t, []string{"test-session"}, meta[headerSessionName])
+	require.ElementsMatch(t, []string{
+		"/moby.buildkit.secrets.v1.Secrets/GetSecret",
+		"/grpc.health.v1.Health/Check",
+	}, meta[headerSessionMethod])
+
+	cc, err := grpc.NewClient("passthrough:///session",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return daemonConn, nil
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec{})),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, cc.Close())
+	})
+
+	t.Run("health", func(t *testing.T) {
+		resp := &healthCheckResponse{}
+		require.NoError(t, cc.Invoke(ctx, "/grpc.health.v1.Health/Check", &healthCheckRequest{}, resp))
+		require.Equal(t, uint64(healthServing), resp.status)
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		resp := &getSecretResponse{}
+		require.NoError(t, cc.Invoke(ctx, "/moby.buildkit.secrets.v1.Secrets/GetSecret", &getSecretRequest{id: "token"}, resp))
+		require.Equal(t, []byte("token-value"), resp.data)
+	})
+
+	t.Run("empty-secret", func(t *testing.T) {
+		resp := &getSecretResponse{}
+		require.NoError(t, cc.Invoke(ctx, "/moby.buildkit.secrets.v1.Secrets/GetSecret", &getSecretRequest{id: "empty"}, resp))
+		require.Empty(t, resp.data)
+	})
+
+	t.Run("missing-secret", func(t *testing.T) {
+		err := cc.Invoke(ctx, "/moby.buildkit.secrets.v1.Secrets/GetSecret", &getSecretRequest{id: "missing"}, &getSecretResponse{})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGetSecretRequest_unmarshal(t *testing.T) {
+	// the annotations of the request, in field 2, are skipped.
+	b := (&getSecretRequest{id: "token"}).marshal()
+	b = append(b, 0x12, 0x04, 0x0a, 0x00, 0x12, 0x00)
+
+	req := &getSecretRequest{}
+	require.NoError(t, req.unmarshal(b))
+	require.Equal(t, "token", req.id)
+
+	require.Error(t, req.unmarshal([]byte{0x0a, 0x05, 't'}))
+}
@@ -85,6 +85,84 @@ type Config struct {
 	//
 	// Environment variable: TESTCONTAINERS_DOCKER_SOCKET_OVERRIDE
 	TestcontainersHost string `properties:"tc.host,default="`
+
+	// ProxyPropagate is a flag to enable or disable the propagation of the proxy settings
+	// to the containers and to the images built from a Dockerfile, for all the container requests.
+	//
+	// Environment variable: TESTCONTAINERS_PROXY_PROPAGATE
+	ProxyPropagate bool `properties:"proxy.propagate,default=false"`
+
+	// HTTPProxy is the proxy used for HTTP requests when propagating the proxy settings.
+	// It overrides the value of the HTTP_PROXY environment variable.
+	HTTPProxy string `properties:"proxy.http,default="`
+
+	// HTTPSProxy is the proxy used for HTTPS requests when propagating the proxy settings.
+	// It overrides the value of the HTTPS_PROXY environment variable.
+	HTTPSProxy string `properties:"proxy.https,default="`
+
+	// NoProxy is the comma-separated list of hosts excluded from the proxy when propagating the proxy settings.
+	// It overrides the value of the NO_PROXY environment variable.
+	NoProxy string `properties:"proxy.no,default="`
+
+	// LogArchiveDir is the directory where the logs of all the containers are archived,
+	// in a file per container, grouped by the test that created the container.
+	// The log archive is disabled if empty.
+	//
+	// Environment variable: TESTCONTAINERS_LOG_ARCHIVE_DIR
+	LogArchiveDir string `properties:"log.archive.dir,default="`
+
+	// PruneStale is a flag to enable or disable the removal, once per test session, of the
+	// resources left behind by previous test sessions that crashed before their reaper cleaned up.
+	//
+	// Environment variable: TESTCONTAINERS_PRUNE_STALE
+	PruneStale bool `properties:"prune.stale,default=false"`
+
+	// PruneStaleOlderThan is the minimum age of the stale resources removed when PruneStale is enabled.
+	//
+	// Environment variable: TESTCONTAINERS_PRUNE_STALE_OLDER_THAN
+	PruneStaleOlderThan time.Duration `properties:"prune.stale.older.than,default=1h"`
+
+	// DaemonMaxConcurrency is the maximum number of expensive operations sent concurrently to the
+	// Docker daemon by the test process: creating and starting containers, building and pulling images.
+	// Zero means the default limit, which is high enough to not affect normal runs, and a negative
+	// value disables the limit.
+	//
+	// Environment variable: TESTCONTAINERS_DAEMON_MAX_CONCURRENCY
+	DaemonMaxConcurrency int `properties:"daemon.max.concurrency,default=0"`
+
+	// DaemonMaxRetries is the maximum number of retries of the expensive operations sent to the
+	// Docker daemon that fail with a transient error, such as a 5xx response or a connection reset.
+	// Zero means the default number of retries, and a negative value disables the retries.
+	//
+	// Environment variable: TESTCONTAINERS_DAEMON_MAX_RETRIES
+	DaemonMaxRetries int `properties:"daemon.max.retries,default=0"`
+
+	// DaemonMinFreeDisk is the minimum free disk space of the Docker daemon, in bytes, below which the
+	// containers are not created, failing fast with a summary of the disk usage instead of misleading
+	// image pull errors. Zero disables the check, which only applies if the free disk space is known.
+	//
+	// Environment variable: TESTCONTAINERS_DAEMON_MIN_FREE_DISK
+	DaemonMinFreeDisk int64 `properties:"daemon.min.free.disk,default=0"`
+
+	// KeepOnFailure is a flag to keep the containers failing to start or to become ready, for all the
+	// container requests, so that they can be inspected after the test. The kept containers are not
+	// removed by the Garbage Collector, but by the testcontainers.CleanupOrphans function.
+	//
+	// Environment variable: TESTCONTAINERS_KEEP_ON_FAILURE
+	KeepOnFailure bool `properties:"keep.on.failure,default=false"`
+
+	// WaitPortCheckLenient is a flag to only warn, instead of failing the creation of the container,
+	// when the ports of the wait strategy of a container request are not in its exposed ports.
+	//
+	// Environment variable: TESTCONTAINERS_WAIT_PORT_CHECK_LENIENT
+	WaitPortCheckLenient bool `properties:"wait.port.check.lenient,default=false"`
+
+	// ParallelStaggerInterval is the mean delay between the creations of the containers started
+	// in parallel, jittered to avoid thrashing the Docker daemon and its disk when many containers
+	// are created at once. It's used unless set in the ParallelContainersOptions. Zero disables it.
+	//
+	// Environment variable: TESTCONTAINERS_PARALLEL_STAGGER_INTERVAL
+	ParallelStaggerInterval time.Duration `properties:"parallel.stagger.interval,default=0"`
 }
 
 // }
@@ -141,6 +219,56 @@ func read() Config {
 			config.RyukConnectionTimeout = timeout
 		}
 
+		proxyPropagateEnv := os.Getenv("TESTCONTAINERS_PROXY_PROPAGATE")
+		if parseBool(proxyPropagateEnv) {
+			config.ProxyPropagate = proxyPropagateEnv == "true"
+		}
+
+		logArchiveDir := os.Getenv("TESTCONTAINERS_LOG_ARCHIVE_DIR")
+		if logArchiveDir != "" {
+			config.LogArchiveDir = logArchiveDir
+		}
+
+		pruneStaleEnv := os.Getenv("TESTCONTAINERS_PRUNE_STALE")
+		if parseBool(pruneStaleEnv) {
+			config.PruneStale = pruneStaleEnv == "true"
+		}
+
+		pruneStaleOlderThanEnv := os.Getenv("TESTCONTAINERS_PRUNE_STALE_OLDER_THAN")
+		if olderThan, err := time.ParseDuration(pruneStaleOlderThanEnv); err == nil {
+			config.PruneStaleOlderThan = olderThan
+		}
+
+		daemonMaxConcurrencyEnv := os.Getenv("TESTCONTAINERS_DAEMON_MAX_CONCURRENCY")
+		if limit, err := strconv.Atoi(daemonMaxConcurrencyEnv); err == nil {
+			config.DaemonMaxConcurrency = limit
+		}
+
+		daemonMaxRetriesEnv := os.Getenv("TESTCONTAINERS_DAEMON_MAX_RETRIES")
+		if retries, err := strconv.Atoi(daemonMaxRetriesEnv); err == nil {
+			config.DaemonMaxRetries = retries
+		}
+
+		daemonMinFreeDiskEnv := os.Getenv("TESTCONTAINERS_DAEMON_MIN_FREE_DISK")
+		if minFree, err := strconv.ParseInt(daemonMinFreeDiskEnv, 10, 64); err == nil {
+			config.DaemonMinFreeDisk = minFree
+		}
+
+		keepOnFailureEnv := os.Getenv("TESTCONTAINERS_KEEP_ON_FAILURE")
+		if parseBool(keepOnFailureEnv) {
+			config.KeepOnFailure = keepOnFailureEnv == "true"
+		}
+
+		waitPortCheckLenientEnv := os.Getenv("TESTCONTAINERS_WAIT_PORT_CHECK_LENIENT")
+		if parseBool(waitPortCheckLenientEnv) {
+			config.WaitPortCheckLenient = waitPortCheckLenientEnv == "true"
+		}
+
+		parallelStaggerIntervalEnv := os.Getenv("TESTCONTAINERS_PARALLEL_STAGGER_INTERVAL")
+		if interval, err := time.ParseDuration(parallelStaggerIntervalEnv); err == nil {
+			config.ParallelStaggerInterval = interval
+		}
+
 		return config
 	}
 
@@ -25,6 +25,16 @@ func resetTestEnv(t *testing.T) {
 	t.Setenv("TESTCONTAINERS_RYUK_VERBOSE", "")
 	t.Setenv("TESTCONTAINERS_RYUK_RECONNECTION_TIMEOUT", "")
 	t.Setenv("TESTCONTAINERS_RYUK_CONNECTION_TIMEOUT", "")
+	t.Setenv("TESTCONTAINERS_PROXY_PROPAGATE", "")
+	t.Setenv("TESTCONTAINERS_LOG_ARCHIVE_DIR", "")
+	t.Setenv("TESTCONTAINERS_PRUNE_STALE", "")
+	t.Setenv("TESTCONTAINERS_PRUNE_STALE_OLDER_THAN", "")
+	t.Setenv("TESTCONTAINERS_DAEMON_MAX_CONCURRENCY", "")
+	t.Setenv("TESTCONTAINERS_DAEMON_MAX_RETRIES", "")
+	t.Setenv("TESTCONTAINERS_DAEMON_MIN_FREE_DISK", "")
+	t.Setenv("TESTCONTAINERS_KEEP_ON_FAILURE", "")
+	t.Setenv("TESTCONTAINERS_WAIT_PORT_CHECK_LENIENT", "")
+	t.Setenv("TESTCONTAINERS_PARALLEL_STAGGER_INTERVAL", "")
 }
 
 func TestReadConfig(t *testing.T) {
@@ -144,9 +154,11 @@ func TestReadTCConfig(t *testing.T) {
 	t.Run("HOME contains TC properties file", func(t *testing.T) {
 		defaultRyukConnectionTimeout := 60 * time.Second
 		defaultRyukReconnectionTimeout := 10 * time.Second
+		defaultPruneStaleOlderThan := time.Hour
 		defaultConfig := Config{
 			RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 			RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+			PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 		}
 
 		tests := []struct {
@@ -163,6 +175,7 @@ func TestReadTCConfig(t *testing.T) {
 					Host:                    tcpDockerHost33293,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -175,6 +188,7 @@ func TestReadTCConfig(t *testing.T) {
 					Host:                    tcpDockerHost4711,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -190,6 +204,7 @@ func TestReadTCConfig(t *testing.T) {
 					TLSVerify:               1,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -199,6 +214,7 @@ func TestReadTCConfig(t *testing.T) {
 				Config{
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -211,6 +227,7 @@ func TestReadTCConfig(t *testing.T) {
 					Host:                    tcpDockerHost1234,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -221,6 +238,7 @@ func TestReadTCConfig(t *testing.T) {
 					Host:                    tcpDockerHost33293,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -241,6 +259,7 @@ func TestReadTCConfig(t *testing.T) {
 					CertPath:                "/tmp/certs",
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -251,6 +270,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukDisabled:            true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -261,6 +281,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukPrivileged:          true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -271,6 +292,7 @@ func TestReadTCConfig(t *testing.T) {
 				Config{
 					RyukReconnectionTimeout: 13 * time.Second,
 					RyukConnectionTimeout:   12 * time.Second,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -283,6 +305,7 @@ func TestReadTCConfig(t *testing.T) {
 				Config{
 					RyukReconnectionTimeout: 13 * time.Second,
 					RyukConnectionTimeout:   12 * time.Second,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -296,6 +319,7 @@ func TestReadTCConfig(t *testing.T) {
 				Config{
 					RyukReconnectionTimeout: 13 * time.Second,
 					RyukConnectionTimeout:   12 * time.Second,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -306,6 +330,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukVerbose:             true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -318,6 +343,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukDisabled:            true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -330,6 +356,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukPrivileged:          true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -342,6 +369,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukDisabled:            true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -354,6 +382,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukDisabled:            true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -382,6 +411,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukVerbose:             true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -394,6 +424,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukVerbose:             true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -422,6 +453,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukPrivileged:          true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -434,6 +466,7 @@ func TestReadTCConfig(t *testing.T) {
 					RyukPrivileged:          true,
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -489,6 +522,7 @@ func TestReadTCConfig(t *testing.T) {
 					HubImageNamePrefix:      defaultHubPrefix + "/props/",
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -501,6 +535,7 @@ func TestReadTCConfig(t *testing.T) {
 					HubImageNamePrefix:      defaultHubPrefix + "/env/",
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 			{
@@ -513,6 +548,134 @@ func TestReadTCConfig(t *testing.T) {
 					HubImageNamePrefix:      defaultHubPrefix + "/env/",
 					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
 					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+				},
+			},
+			{
+				"With proxy settings in the properties",
+				`proxy.propagate=true
+	proxy.http=http://proxy.example.com:3128
+	proxy.https=http://proxy.example.com:3129
+	proxy.no=localhost,.example.com
+	`,
+				map[string]string{},
+				Config{
+					ProxyPropagate:          true,
+					HTTPProxy:               "http://proxy.example.com:3128",
+					HTTPSProxy:              "http://proxy.example.com:3129",
+					NoProxy:                 "localhost,.example.com",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+				},
+			},
+			{
+				"With log archive dir in the properties, overridden by the env",
+				`log.archive.dir=/tmp/props-logs`,
+				map[string]string{
+					"TESTCONTAINERS_LOG_ARCHIVE_DIR": "/tmp/env-logs",
+				},
+				Config{
+					LogArchiveDir:           "/tmp/env-logs",
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+				},
+			},
+			{
+				"With stale resources pruning in the properties, overridden by the env",
+				`prune.stale=true
+	prune.stale.older.than=30m
+	`,
+				map[string]string{
+					"TESTCONTAINERS_PRUNE_STALE_OLDER_THAN": "2h",
+				},
+				Config{
+					PruneStale:              true,
+					PruneStaleOlderThan:     2 * time.Hour,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With the daemon limits in the properties and the env. Env var wins",
+				`daemon.max.concurrency=4
+	daemon.max.retries=2
+	`,
+				map[string]string{
+					"TESTCONTAINERS_DAEMON_MAX_CONCURRENCY": "8",
+				},
+				Config{
+					DaemonMaxConcurrency:    8,
+					DaemonMaxRetries:        2,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With the minimum free disk space in the properties, overridden by the env",
+				`daemon.min.free.disk=1073741824`,
+				map[string]string{
+					"TESTCONTAINERS_DAEMON_MIN_FREE_DISK": "2147483648",
+				},
+				Config{
+					DaemonMinFreeDisk:       2147483648,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+				},
+			},
+			{
+				"With keep on failure disabled in the properties, but enabled in the env",
+				`keep.on.failure=false`,
+				map[string]string{
+					"TESTCONTAINERS_KEEP_ON_FAILURE": "true",
+				},
+				Config{
+					KeepOnFailure:           true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+				},
+			},
+			{
+				"With wait port check lenient disabled in the properties, but enabled in the env",
+				`wait.port.check.lenient=false`,
+				map[string]string{
+					"TESTCONTAINERS_WAIT_PORT_CHECK_LENIENT": "true",
+				},
+				Config{
+					WaitPortCheckLenient:    true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+				},
+			},
+			{
+				"With parallel stagger interval set in the properties, but overridden in the env",
+				`parallel.stagger.interval=100ms`,
+				map[string]string{
+					"TESTCONTAINERS_PARALLEL_STAGGER_INTERVAL": "250ms",
+				},
+				Config{
+					ParallelStaggerInterval: 250 * time.Millisecond,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
+				},
+			},
+			{
+				"With proxy propagation disabled in the properties, but enabled in the env",
+				`proxy.propagate=false`,
+				map[string]string{
+					"TESTCONTAINERS_PROXY_PROPAGATE": "true",
+				},
+				Config{
+					ProxyPropagate:          true,
+					RyukConnectionTimeout:   defaultRyukConnectionTimeout,
+					RyukReconnectionTimeout: defaultRyukReconnectionTimeout,
+					PruneStaleOlderThan:     defaultPruneStaleOlderThan,
 				},
 			},
 		}
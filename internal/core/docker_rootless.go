@@ -17,6 +17,9 @@ var (
 	ErrRootlessDockerNotFoundRunDir         = errors.New("checked path: /run/user/${uid}/docker.sock")
 	ErrRootlessDockerNotFoundXDGRuntimeDir  = errors.New("checked path: $XDG_RUNTIME_DIR")
 	ErrRootlessDockerNotSupportedWindows    = errors.New("rootless Docker is not supported on Windows")
+	ErrRootlessPodmanNotFoundMachineDir     = errors.New("checked path: ~/.local/share/containers/podman/machine/podman.sock")
+	ErrRootlessPodmanNotFoundRunDir         = errors.New("checked path: /run/user/${uid}/podman/podman.sock")
+	ErrRootlessPodmanNotFoundXDGRuntimeDir  = errors.New("checked path: $XDG_RUNTIME_DIR/podman/podman.sock")
 	ErrXDGRuntimeDirNotSet                  = errors.New("XDG_RUNTIME_DIR is not set")
 )
 
@@ -36,7 +39,10 @@ func IsWindows() bool {
 //  2. ~/.docker/run/docker.sock file.
 //  3. ~/.docker/desktop/docker.sock file.
 //  4. /run/user/${uid}/docker.sock file.
-//  5. Else, return ErrRootlessDockerNotFound, wrapping secific errors for each of the above paths.
+//  5. ${XDG_RUNTIME_DIR}/podman/podman.sock file, created by Podman's podman.socket user service.
+//  6. /run/user/${uid}/podman/podman.sock file, created by Podman's podman.socket user service.
+//  7. ~/.local/share/containers/podman/machine/podman.sock file, created by a Podman machine.
+//  8. Else, return ErrRootlessDockerNotFound, wrapping secific errors for each of the above paths.
 //
 // It should include the Docker socket schema (unix://) in the returned path.
 func rootlessDockerSocketPath(_ context.Context) (string, error) {
@@ -51,6 +57,9 @@ func rootlessDockerSocketPath(_ context.Context) (string, error) {
 		rootlessSocketPathFromHomeRunDir,
 		rootlessSocketPathFromHomeDesktopDir,
 		rootlessSocketPathFromRunDir,
+		rootlessPodmanSocketPathFromEnv,
+		rootlessPodmanSocketPathFromRunDir,
+		rootlessPodmanSocketPathFromMachineDir,
 	}
 
 	outerErr := ErrRootlessDockerNotFound
@@ -144,3 +153,44 @@ func rootlessSocketPathFromRunDir() (string, error) {
 	}
 	return "", ErrRootlessDockerNotFoundRunDir
 }
+
+// rootlessPodmanSocketPathFromEnv returns the path to the rootless Podman socket from the XDG_RUNTIME_DIR environment variable,
+// which is the location used by the podman.socket user service.
+func rootlessPodmanSocketPathFromEnv() (string, error) {
+	xdgRuntimeDir, exists := os.LookupEnv("XDG_RUNTIME_DIR")
+	if exists {
+		f := filepath.Join(xdgRuntimeDir, "podman", "podman.sock")
+		if fileExists(f) {
+			return f, nil
+		}
+
+		return "", ErrRootlessPodmanNotFoundXDGRuntimeDir
+	}
+
+	return "", ErrXDGRuntimeDirNotSet
+}
+
+// rootlessPodmanSocketPathFromRunDir returns the path to the rootless Podman socket from the /run/user/<uid>/podman/podman.sock file.
+func rootlessPodmanSocketPathFromRunDir() (string, error) {
+	uid := os.Getuid()
+	f := filepath.Join(baseRunDir, "user", fmt.Sprintf("%d", uid), "podman", "podman.sock")
+	if fileExists(f) {
+		return f, nil
+	}
+	return "", ErrRootlessPodmanNotFoundRunDir
+}
+
+// rootlessPodmanSocketPathFromMachineDir returns the path to the Podman machine socket from the
+// ~/.local/share/containers/podman/machine/podman.sock file.
+func rootlessPodmanSocketPathFromMachineDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	f := filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock")
+	if fileExists(f) {
+		return f, nil
+	}
+	return "", ErrRootlessPodmanNotFoundMachineDir
+}
@@ -186,9 +186,76 @@ func TestRootlessDockerSocketPath(t *testing.T) {
 		require.ErrorContains(t, err, ErrRootlessDockerNotFoundHomeRunDir.Error())
 		require.ErrorContains(t, err, ErrRootlessDockerNotFoundHomeDesktopDir.Error())
 		require.ErrorContains(t, err, ErrRootlessDockerNotFoundRunDir.Error())
+		require.ErrorContains(t, err, ErrRootlessPodmanNotFoundXDGRuntimeDir.Error())
+		require.ErrorContains(t, err, ErrRootlessPodmanNotFoundRunDir.Error())
+		require.ErrorContains(t, err, ErrRootlessPodmanNotFoundMachineDir.Error())
 	})
 }
 
+func TestRootlessPodmanSocketPath(t *testing.T) {
+	if IsWindows() {
+		t.Skip("Podman rootless sockets are not supported on Windows")
+	}
+
+	tests := []struct {
+		name string
+		// socketDir returns the directory where the podman.sock file is created,
+		// given the temporary XDG_RUNTIME_DIR, home and base run directories.
+		socketDir func(xdgRuntimeDir, homeDir, runDir string) string
+	}{
+		{
+			name: "XDG_RUNTIME_DIR: ${XDG_RUNTIME_DIR}/podman/podman.sock",
+			socketDir: func(xdgRuntimeDir, _, _ string) string {
+				return filepath.Join(xdgRuntimeDir, "podman")
+			},
+		},
+		{
+			name: "Run dir: /run/user/${uid}/podman/podman.sock",
+			socketDir: func(_, _, runDir string) string {
+				return filepath.Join(runDir, "user", fmt.Sprintf("%d", os.Getuid()), "podman")
+			},
+		},
+		{
+			name: "Machine dir: ~/.local/share/containers/podman/machine/podman.sock",
+			socketDir: func(_, homeDir, _ string) string {
+				return filepath.Join(homeDir, ".local", "share", "containers", "podman", "machine")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				baseRunDir = originalBaseRunDir
+			})
+
+			tmpDir := t.TempDir()
+
+			xdgRuntimeDir := filepath.Join(tmpDir, "xdg-runtime-dir")
+			require.NoError(t, createTmpDir(xdgRuntimeDir))
+			t.Setenv("XDG_RUNTIME_DIR", xdgRuntimeDir)
+
+			homeDir := filepath.Join(tmpDir, "home")
+			require.NoError(t, createTmpDir(homeDir))
+			t.Setenv("HOME", homeDir)
+
+			runDir := filepath.Join(tmpDir, "run")
+			require.NoError(t, createTmpDir(runDir))
+			baseRunDir = runDir
+
+			socketDir := tt.socketDir(xdgRuntimeDir, homeDir, runDir)
+			require.NoError(t, createTmpDir(socketDir))
+			f, err := os.Create(filepath.Join(socketDir, "podman.sock"))
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			socketPath, err := rootlessDockerSocketPath(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, DockerSocketSchema+filepath.Join(socketDir, "podman.sock"), socketPath)
+		})
+	}
+}
+
 func setupRootlessNotFound(t *testing.T) {
 	t.Cleanup(func() {
 		baseRunDir = originalBaseRunDir
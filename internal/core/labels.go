@@ -1,18 +1,34 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/testcontainers/testcontainers-go/internal"
 )
 
 const (
 	LabelBase      = "org.testcontainers"
 	LabelLang      = LabelBase + ".lang"
+	LabelModule    = LabelBase + ".module"
 	LabelReaper    = LabelBase + ".reaper"
 	LabelRyuk      = LabelBase + ".ryuk"
 	LabelSessionID = LabelBase + ".sessionId"
 	LabelVersion   = LabelBase + ".version"
+
+	// LabelKeptSessionID replaces the session label of the containers kept on failure,
+	// so that the reaper, which removes the resources by session label, never removes them.
+	LabelKeptSessionID = LabelBase + ".keptSessionId"
+
+	// LabelModuleVersion is the version of the module which created a container, identified by LabelModule.
+	LabelModuleVersion = LabelModule + ".version"
 )
 
+// ErrReservedLabel is returned when a user-defined label collides with one of the labels
+// that Testcontainers uses to identify and clean up the resources it creates.
+var ErrReservedLabel = errors.New("reserved label")
+
 func DefaultLabels(sessionID string) map[string]string {
 	return map[string]string{
 		LabelBase:      "true",
@@ -21,3 +37,49 @@ func DefaultLabels(sessionID string) map[string]string {
 		LabelVersion:   internal.Version,
 	}
 }
+
+// ReservedLabels returns the label keys that Testcontainers sets on the resources it creates,
+// sorted alphabetically.
+func ReservedLabels() []string {
+	return []string{
+		LabelBase,
+		LabelKeptSessionID,
+		LabelLang,
+		LabelReaper,
+		LabelRyuk,
+		LabelSessionID,
+		LabelVersion,
+	}
+}
+
+// AddDefaultLabels adds the default labels for the given session to the labels map.
+// A reserved label already present in the map is only accepted if it holds the very same
+// value as the default one, otherwise an error wrapping ErrReservedLabel is returned and
+// the map is left untouched, so that user-defined labels are never silently overwritten.
+func AddDefaultLabels(labels map[string]string, sessionID string) error {
+	defaults := DefaultLabels(sessionID)
+
+	var collisions []string
+	for _, key := range ReservedLabels() {
+		value, ok := labels[key]
+		if !ok {
+			continue
+		}
+
+		if defaultValue, isDefault := defaults[key]; isDefault && defaultValue == value {
+			continue
+		}
+
+		collisions = append(collisions, key)
+	}
+
+	if len(collisions) > 0 {
+		return fmt.Errorf("%w: %s", ErrReservedLabel, strings.Join(collisions, ", "))
+	}
+
+	for k, v := range defaults {
+		labels[k] = v
+	}
+
+	return nil
+}
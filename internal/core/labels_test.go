@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal"
+)
+
+func TestAddDefaultLabels(t *testing.T) {
+	const sessionID = "test-session"
+
+	t.Run("custom-labels", func(t *testing.T) {
+		labels := map[string]string{
+			"com.example.team":        "platform",
+			LabelBase + ".tooling.id": "custom",
+		}
+
+		err := AddDefaultLabels(labels, sessionID)
+		require.NoError(t, err)
+		require.Equal(t, "platform", labels["com.example.team"])
+		require.Equal(t, "custom", labels[LabelBase+".tooling.id"])
+		require.Equal(t, sessionID, labels[LabelSessionID])
+		require.Equal(t, "go", labels[LabelLang])
+		require.Equal(t, internal.Version, labels[LabelVersion])
+	})
+
+	t.Run("default-labels-with-same-value", func(t *testing.T) {
+		labels := DefaultLabels(sessionID)
+
+		err := AddDefaultLabels(labels, sessionID)
+		require.NoError(t, err)
+		require.Equal(t, DefaultLabels(sessionID), labels)
+	})
+
+	t.Run("colliding-labels", func(t *testing.T) {
+		labels := map[string]string{
+			LabelSessionID: "my-session",
+			LabelReaper:    "true",
+		}
+
+		err := AddDefaultLabels(labels, sessionID)
+		require.ErrorIs(t, err, ErrReservedLabel)
+		require.EqualError(t, err, "reserved label: "+LabelReaper+", "+LabelSessionID)
+
+		// the labels must not be modified
+		require.Equal(t, map[string]string{
+			LabelSessionID: "my-session",
+			LabelReaper:    "true",
+		}, labels)
+	})
+}
+
+func TestReservedLabels(t *testing.T) {
+	reserved := ReservedLabels()
+
+	require.IsIncreasing(t, reserved)
+	for k := range DefaultLabels("test-session") {
+		require.Contains(t, reserved, k)
+	}
+}
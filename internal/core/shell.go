@@ -0,0 +1,32 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoShell is returned when a container does not include any shell, such as distroless images.
+var ErrNoShell = errors.New("no shell found in the container")
+
+// shells are the shells looked up in the container, in order of preference.
+var shells = []string{"/bin/sh", "/bin/bash", "/bin/ash"}
+
+// DetectShell returns the path of the first shell available in a container, running a no-op
+// command with each shell through exec, which returns the exit code of the command.
+// It returns ErrNoShell if none is available.
+func DetectShell(ctx context.Context, exec func(ctx context.Context, cmd []string) (int, error)) (string, error) {
+	for _, shell := range shells {
+		exitCode, err := exec(ctx, []string{shell, "-c", "exit 0"})
+		if err != nil {
+			return "", fmt.Errorf("exec %s: %w", shell, err)
+		}
+
+		if exitCode == 0 {
+			return shell, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: checked %s", ErrNoShell, strings.Join(shells, ", "))
+}
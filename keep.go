@@ -0,0 +1,79 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// keepOnFailure reports whether the container of the request is kept if it fails to start or to become ready,
+// as requested by the request or, for all the requests, by the configuration.
+func (p *DockerProvider) keepOnFailure(req ContainerRequest) bool {
+	return req.KeepOnFailure || p.config.KeepOnFailure
+}
+
+// keepOnFailureLabels replaces the session label of a container kept on failure with the kept session label,
+// so that the reaper never removes the container. The labels of a container can't be changed once it's
+// created, so the container is excluded from the reaper even if it starts successfully.
+func keepOnFailureLabels(labels map[string]string) {
+	labels[core.LabelKeptSessionID] = labels[core.LabelSessionID]
+	delete(labels, core.LabelSessionID)
+}
+
+// keepFailed marks the container requesting to be kept on failure as kept for debugging after failing
+// to start or to become ready, so that it's left running and not removed by Terminate, and returns
+// the error along with the ID of the container and a hint to remove it. The error is returned as is
+// for the other containers, or if the container is already kept, e.g. when its wait strategy failed
+// while starting it.
+func (c *DockerContainer) keepFailed(err error) error {
+	if !c.keepOnFailure || c.kept {
+		return err
+	}
+
+	c.kept = true
+	// the container is kept on purpose, so it's not a leak.
+	containerLeaks.untrack(c.ID)
+
+	return fmt.Errorf("%w: container %s kept for debugging, e.g. with `docker logs %s`, remove it with testcontainers.CleanupOrphans(ctx, %q)",
+		err, c.ID, c.ID, c.sessionID)
+}
+
+// CleanupOrphans removes the containers of the given session which were kept after failing to start
+// or to become ready, see ContainerRequest.KeepOnFailure, along with their anonymous volumes.
+// The kept containers of all the sessions are removed if the session ID is empty.
+func CleanupOrphans(ctx context.Context, sessionID string) error {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("new docker client: %w", err)
+	}
+	defer cli.Close()
+
+	label := core.LabelKeptSessionID
+	if sessionID != "" {
+		label += "=" + sessionID
+	}
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", label)),
+	})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	var errs []error
+	for _, c := range containers {
+		err := cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("remove container %s: %w", c.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
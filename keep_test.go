@@ -0,0 +1,130 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestKeepOnFailureLabels(t *testing.T) {
+	labels := core.DefaultLabels("test-session")
+	keepOnFailureLabels(labels)
+
+	require.NotContains(t, labels, core.LabelSessionID)
+	require.Equal(t, "test-session", labels[core.LabelKeptSessionID])
+	require.Equal(t, "true", labels[core.LabelBase])
+}
+
+func TestDockerContainer_keepFailed(t *testing.T) {
+	errStart := errors.New("start failed")
+
+	t.Run("kept", func(t *testing.T) {
+		c := &DockerContainer{ID: "abcdef", sessionID: "test-session", keepOnFailure: true}
+
+		err := c.keepFailed(errStart)
+		require.ErrorIs(t, err, errStart)
+		require.ErrorContains(t, err, "container abcdef kept for debugging")
+		require.ErrorContains(t, err, `testcontainers.CleanupOrphans(ctx, "test-session")`)
+		require.True(t, c.kept)
+
+		// the failure of the wait strategy while starting the container is not reported twice.
+		require.Equal(t, err, c.keepFailed(err))
+	})
+
+	t.Run("not-kept", func(t *testing.T) {
+		c := &DockerContainer{ID: "abcdef", sessionID: "test-session"}
+
+		require.Equal(t, errStart, c.keepFailed(errStart))
+		require.False(t, c.kept)
+	})
+}
+
+func TestKeepOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	// keepOnFailure {
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:         nginxAlpineImage,
+			WaitingFor:    wait.ForLog("never logged").WithStartupTimeout(3 * time.Second),
+			KeepOnFailure: true,
+		},
+		Started: true,
+	})
+	// }
+	require.ErrorIs(t, err, ErrWaitStrategyTimeout)
+	require.NotNil(t, ctr)
+
+	dc := ctr.(*DockerContainer)
+	require.ErrorContains(t, err, "container "+dc.ID+" kept for debugging")
+
+	// the container is not removed by Terminate.
+	require.NoError(t, ctr.Terminate(ctx))
+
+	inspect, err := dc.Inspect(ctx)
+	require.NoError(t, err)
+	require.True(t, inspect.State.Running)
+	require.NotContains(t, inspect.Config.Labels, core.LabelSessionID)
+	require.Equal(t, core.SessionID(), inspect.Config.Labels[core.LabelKeptSessionID])
+
+	require.NoError(t, CleanupOrphans(ctx, core.SessionID()))
+
+	_, err = dc.Inspect(ctx)
+	require.True(t, errdefs.IsNotFound(err), err)
+}
+
+func TestKeepOnFailure_waitUntilReady(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:         nginxAlpineImage,
+			KeepOnFailure: true,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	// the container is kept if its wait strategy fails once it started.
+	dc := ctr.(*DockerContainer)
+	err = dc.WaitUntilReady(ctx, wait.ForLog("never logged").WithStartupTimeout(time.Second))
+	require.ErrorIs(t, err, ErrWaitStrategyTimeout)
+	require.ErrorContains(t, err, "container "+dc.ID+" kept for debugging")
+
+	require.NoError(t, ctr.Terminate(ctx))
+
+	inspect, err := dc.Inspect(ctx)
+	require.NoError(t, err)
+	require.True(t, inspect.State.Running)
+
+	require.NoError(t, CleanupOrphans(ctx, core.SessionID()))
+}
+
+func TestKeepOnFailure_started(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:         nginxAlpineImage,
+			KeepOnFailure: true,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	// the container started, so Terminate removes it.
+	require.NoError(t, ctr.Terminate(ctx))
+
+	_, err = ctr.Inspect(ctx)
+	require.True(t, errdefs.IsNotFound(err), err)
+}
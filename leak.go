@@ -0,0 +1,162 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// trackedContainer is a container created by the current process, which is expected to be
+// terminated before the process ends, see StrictLeakCheck.
+type trackedContainer struct {
+	seq   int    // creation order of the container
+	image string // image of the container
+	test  string // name of the test function creating the container, empty if not created by a test
+}
+
+// leakTracker records the containers created by the current process which are not terminated yet.
+type leakTracker struct {
+	mtx        sync.Mutex
+	seq        int
+	containers map[string]trackedContainer // keyed by container ID
+}
+
+// containerLeaks tracks the containers of the current process, for StrictLeakCheck.
+var containerLeaks = &leakTracker{containers: make(map[string]trackedContainer)}
+
+// track records a container created by the given test.
+func (t *leakTracker) track(id string, image string, test string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.seq++
+	t.containers[id] = trackedContainer{seq: t.seq, image: image, test: test}
+}
+
+// untrack forgets a container, e.g. because it has been terminated, or because it's long-lived.
+func (t *leakTracker) untrack(id string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	delete(t.containers, id)
+}
+
+// replace tracks a container with the ID of the container replacing it, see DockerContainer.Restore.
+func (t *leakTracker) replace(oldID string, newID string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if tc, ok := t.containers[oldID]; ok {
+		delete(t.containers, oldID)
+		t.containers[newID] = tc
+	}
+}
+
+// leakedContainer is a running container which has not been terminated by the process creating it.
+type leakedContainer struct {
+	trackedContainer
+	id   string
+	name string
+}
+
+// leaked returns the tracked containers which are still running, in creation order.
+func (t *leakTracker) leaked(ctx context.Context) ([]leakedContainer, error) {
+	t.mtx.Lock()
+	args := filters.NewArgs(filters.Arg("status", "running"))
+	for id := range t.containers {
+		args.Add("id", id)
+	}
+	tracked := len(t.containers)
+	t.mtx.Unlock()
+
+	if tracked == 0 {
+		return nil, nil
+	}
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	resp, err := cli.ContainerList(ctx, container.ListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("container list: %w", err)
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	var leaks []leakedContainer
+	for _, c := range resp {
+		tc, ok := t.containers[c.ID]
+		if !ok {
+			// terminated in the meantime.
+			continue
+		}
+
+		var name string
+		if len(c.Names) > 0 {
+			name = c.Names[0]
+		}
+
+		leaks = append(leaks, leakedContainer{trackedContainer: tc, id: c.ID, name: name})
+	}
+
+	sort.Slice(leaks, func(i, j int) bool {
+		return leaks[i].seq < leaks[j].seq
+	})
+
+	return leaks, nil
+}
+
+// StrictLeakCheck runs the tests, as m.Run, and then fails the test binary if any container it
+// created is still running, because it was not terminated by the test creating it. The leaked
+// containers are printed to stderr, along with their image and the test creating them, and the
+// returned exit code is changed to 1 if the tests passed. It's meant to be used in TestMain,
+// to fail the CI instead of silently relying on Ryuk to remove the leaked containers:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(testcontainers.StrictLeakCheck(m))
+//	}
+//
+// The leaked containers are not terminated, so that they are still removed by Ryuk once the process
+// ends. The reused containers, see ContainerRequest.Reuse, and the containers kept on failure, see
+// ContainerRequest.KeepOnFailure, are long-lived, so they are never reported.
+func StrictLeakCheck(m *testing.M) int {
+	return checkLeaks(context.Background(), m.Run(), os.Stderr)
+}
+
+// checkLeaks reports the leaked containers to w, returning the exit code of the tests,
+// changed to 1 if they passed but containers were leaked, or they can't be listed.
+func checkLeaks(ctx context.Context, code int, w io.Writer) int {
+	leaks, err := containerLeaks.leaked(ctx)
+	switch {
+	case err != nil:
+		fmt.Fprintf(w, "🚨 Leaked containers can't be checked: %v\n", err)
+	case len(leaks) == 0:
+		return code
+	default:
+		fmt.Fprintf(w, "🚨 %d containers leaked, still running at the end of the tests:\n", len(leaks))
+		for _, l := range leaks {
+			test := l.test
+			if test == "" {
+				test = "outside of a test"
+			}
+			fmt.Fprintf(w, "\t%s %s (%s) created by %s\n", l.id[:12], l.name, l.image, test)
+		}
+	}
+
+	if code == 0 {
+		return 1
+	}
+
+	return code
+}
@@ -0,0 +1,110 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictLeakCheck(t *testing.T) {
+	ctx := context.Background()
+
+	// the containers of the subprocesses are labeled, so that the leaked ones are removed
+	// even if Ryuk is disabled.
+	leakID := uuid.NewString()
+	t.Cleanup(func() {
+		cli, err := NewDockerClientWithOpts(ctx)
+		require.NoError(t, err)
+		defer cli.Close()
+
+		resp, err := cli.ContainerList(ctx, container.ListOptions{
+			All:     true,
+			Filters: filters.NewArgs(filters.Arg("label", "testcontainers.leak.id="+leakID)),
+		})
+		require.NoError(t, err)
+
+		for _, c := range resp {
+			require.NoError(t, cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true}))
+		}
+	})
+
+	run := func(t *testing.T, leak bool) (int, string) {
+		t.Helper()
+
+		// force verbosity in subprocesses, so that the output is printed
+		cmd := exec.Command(os.Args[0], "-test.run=TestHelperLeakingProcess", "-test.v=true")
+		cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1", "TESTCONTAINERS_HELPER_LEAK_ID="+leakID)
+		if leak {
+			cmd.Env = append(cmd.Env, "TESTCONTAINERS_HELPER_LEAK=true")
+		}
+
+		output, err := cmd.CombinedOutput()
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), string(output)
+		}
+		require.NoError(t, err, string(output))
+
+		return 0, string(output)
+	}
+
+	t.Run("leak", func(t *testing.T) {
+		code, output := run(t, true)
+		require.Equal(t, 1, code, output)
+		require.Contains(t, output, "1 containers leaked")
+		require.Contains(t, output, "("+nginxAlpineImage+") created by TestHelperLeakingProcess")
+	})
+
+	t.Run("no-leak", func(t *testing.T) {
+		code, output := run(t, false)
+		require.Equal(t, 0, code, output)
+		require.NotContains(t, output, "leaked")
+	})
+}
+
+// TestHelperLeakingProcess is a helper function to check the leaked containers
+// in a subprocess, as StrictLeakCheck does after running the tests. It's not a real test.
+func TestHelperLeakingProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		t.Skip("Skipping helper test function. It's not a real test")
+	}
+
+	ctx := context.Background()
+
+	labels := map[string]string{"testcontainers.leak.id": os.Getenv("TESTCONTAINERS_HELPER_LEAK_ID")}
+
+	// terminated by the test, so it's never reported.
+	terminated, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:  nginxAlpineImage,
+			Labels: labels,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, terminated.Terminate(ctx))
+
+	if os.Getenv("TESTCONTAINERS_HELPER_LEAK") == "true" {
+		// not terminated, as if the test forgot to.
+		_, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:  nginxAlpineImage,
+				Labels: labels,
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+	}
+
+	os.Exit(checkLeaks(ctx, 0, os.Stdout))
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 // ContainerRequestHook is a hook that will be called before a container is created.
@@ -59,55 +61,55 @@ var DefaultLoggingHook = func(logger Logging) ContainerLifecycleHooks {
 	return ContainerLifecycleHooks{
 		PreCreates: []ContainerRequestHook{
 			func(ctx context.Context, req ContainerRequest) error {
-				logger.Printf("🐳 Creating container for image %s", req.Image)
+				infof(logger, "🐳 Creating container for image %s", req.Image)
 				return nil
 			},
 		},
 		PostCreates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container created: %s", shortContainerID(c))
+				infof(logger, "✅ Container created: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PreStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Starting container: %s", shortContainerID(c))
+				infof(logger, "🐳 Starting container: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostStarts: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container started: %s", shortContainerID(c))
+				infof(logger, "✅ Container started: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostReadies: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🔔 Container is ready: %s", shortContainerID(c))
+				infof(logger, "🔔 Container is ready: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PreStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Stopping container: %s", shortContainerID(c))
+				infof(logger, "🐳 Stopping container: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostStops: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("✅ Container stopped: %s", shortContainerID(c))
+				infof(logger, "✅ Container stopped: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PreTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🐳 Terminating container: %s", shortContainerID(c))
+				infof(logger, "🐳 Terminating container: %s", shortContainerID(c))
 				return nil
 			},
 		},
 		PostTerminates: []ContainerHook{
 			func(ctx context.Context, c Container) error {
-				logger.Printf("🚫 Container terminated: %s", shortContainerID(c))
+				infof(logger, "🚫 Container terminated: %s", shortContainerID(c))
 				return nil
 			},
 		},
@@ -225,6 +227,209 @@ func checkPortsMapped(exposedAndMappedPorts nat.PortMap, exposedPorts []string)
 	return nil
 }
 
+const (
+	// earlyExitGracePeriod is the time window, after the container is started, in which exiting
+	// is considered a symptom of a conflict between the image entrypoint and the request overrides.
+	earlyExitGracePeriod = 2 * time.Second
+
+	// earlyExitLogLines is the number of container log lines included in an early exit error.
+	earlyExitLogLines = 10
+
+	// earlyExitPollInterval is the interval at which the state of the container is checked while
+	// its wait strategy runs, to detect an early exit without waiting for the strategy to time out.
+	earlyExitPollInterval = 250 * time.Millisecond
+
+	// earlyExitRecheckTimeout is the timeout of the last run of the wait strategy once the container
+	// exited, so that a strategy satisfied by the output of a one-shot container still succeeds.
+	earlyExitRecheckTimeout = time.Second
+)
+
+// earlyExitError is returned when a container exits right after being started, before the wait
+// strategy completes, with an exit code usually caused by the image entrypoint conflicting with the
+// Cmd or Entrypoint overrides in the container request: 0 when the entrypoint runs the command and
+// returns, and 127 when the command is not found.
+type earlyExitError struct {
+	err             error
+	exitCode        int
+	uptime          time.Duration
+	imageEntrypoint []string
+	imageCmd        []string
+	entrypoint      []string
+	cmd             []string
+	logs            []string
+	// secrets are the sensitive values of the request, redacted in the error message.
+	secrets []string
+}
+
+// Error returns the wait strategy error enriched with the entrypoint and command of both
+// the image and the container, the last log lines, and a hint about how to fix the request.
+func (e *earlyExitError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "container exited with code %d %s after starting, before the wait strategy completed: %v\n", e.exitCode, e.uptime.Round(time.Millisecond), e.err)
+	fmt.Fprintf(&sb, "  image entrypoint: %q, image cmd: %q\n", e.imageEntrypoint, e.imageCmd)
+	fmt.Fprintf(&sb, "  effective entrypoint: %q, effective cmd: %q\n", e.entrypoint, e.cmd)
+
+	if len(e.logs) > 0 {
+		sb.WriteString("  last log lines:\n")
+		for _, line := range e.logs {
+			fmt.Fprintf(&sb, "    %s\n", line)
+		}
+	}
+
+	sb.WriteString("Cmd replaces the image cmd and is passed as arguments to the image entrypoint, while Entrypoint replaces the image entrypoint and clears the image cmd. ")
+	sb.WriteString("Please consider using WithCmdArgs or WithEntrypointArgs to append arguments, or WithEntrypoint to run the command without the image entrypoint.")
+
+	return redactValues(sb.String(), e.secrets)
+}
+
+// Unwrap returns the wait strategy error.
+func (e *earlyExitError) Unwrap() error {
+	return e.err
+}
+
+// lastLines returns the last n non-empty lines of the given text.
+func lastLines(text string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return lines
+}
+
+// checkEarlyExit checks if the container exited right after being started with an exit code
+// revealing an entrypoint conflict, returning an earlyExitError wrapping the wait strategy error
+// if so. Otherwise, or if the container state cannot be checked, the original error is returned.
+func (c *DockerContainer) checkEarlyExit(ctx context.Context, waitErr error) error {
+	if ctx.Err() != nil {
+		// Context has timed out so need a new context to inspect the container.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+	}
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil || inspect.State == nil || inspect.Config == nil {
+		return waitErr
+	}
+
+	state := inspect.State
+	if state.Running || state.Status != "exited" || (state.ExitCode != 0 && state.ExitCode != 127) {
+		return waitErr
+	}
+
+	startedAt, err := time.Parse(time.RFC3339Nano, state.StartedAt)
+	if err != nil {
+		return waitErr
+	}
+
+	finishedAt, err := time.Parse(time.RFC3339Nano, state.FinishedAt)
+	if err != nil {
+		return waitErr
+	}
+
+	uptime := finishedAt.Sub(startedAt)
+	if uptime > earlyExitGracePeriod {
+		return waitErr
+	}
+
+	exitErr := &earlyExitError{
+		err:        waitErr,
+		exitCode:   state.ExitCode,
+		uptime:     uptime,
+		entrypoint: inspect.Config.Entrypoint,
+		cmd:        inspect.Config.Cmd,
+		secrets:    c.secrets,
+	}
+
+	img, _, err := c.provider.client.ImageInspectWithRaw(ctx, inspect.Image)
+	if err == nil && img.Config != nil {
+		exitErr.imageEntrypoint = img.Config.Entrypoint
+		exitErr.imageCmd = img.Config.Cmd
+	}
+
+	if reader, err := c.Logs(ctx); err == nil {
+		if b, err := io.ReadAll(reader); err == nil {
+			exitErr.logs = lastLines(string(b), earlyExitLogLines)
+		}
+	}
+
+	return exitErr
+}
+
+// waitStrategy runs the wait strategy against the container. If the request overrides the Cmd or
+// the Entrypoint of the image, the state of the container is checked meanwhile, so that a container
+// exiting right after being started, see checkEarlyExit, fails fast with an earlyExitError instead of
+// waiting for the strategy to time out, unless the strategy is satisfied once the container exited.
+// The state is not checked for the strategies expecting the container to exit, see wait.WaitsForExit.
+func (c *DockerContainer) waitStrategy(ctx context.Context, strategy wait.Strategy) error {
+	if !c.overridesCommand {
+		return strategy.WaitUntilReady(ctx, c)
+	}
+
+	waitCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	if !wait.WaitsForExit(strategy) {
+		go c.watchEarlyExit(waitCtx, cancel)
+	}
+
+	err := strategy.WaitUntilReady(waitCtx, c)
+	if err == nil {
+		return nil
+	}
+
+	var exitErr *earlyExitError
+	if errors.As(context.Cause(waitCtx), &exitErr) {
+		// the strategy is run a last time, e.g. to find a log line written right before the exit.
+		recheckCtx, recheckCancel := context.WithTimeout(ctx, earlyExitRecheckTimeout)
+		defer recheckCancel()
+
+		if strategy.WaitUntilReady(recheckCtx, c) == nil {
+			return nil
+		}
+
+		return exitErr
+	}
+
+	return c.checkEarlyExit(ctx, err)
+}
+
+// watchEarlyExit polls the state of the container until the context is done, cancelling it with
+// an earlyExitError if the container exits right after being started.
+func (c *DockerContainer) watchEarlyExit(ctx context.Context, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(earlyExitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		state, err := c.State(ctx)
+		if err != nil || state.Status != "exited" {
+			continue
+		}
+
+		// the container exited: the exit is left to the strategy if it's not an early exit.
+		var exitErr *earlyExitError
+		if errors.As(c.checkEarlyExit(ctx, fmt.Errorf("container exited with code %d", state.ExitCode)), &exitErr) {
+			cancel(exitErr)
+		}
+
+		return
+	}
+}
+
 // defaultReadinessHook is a hook that will wait for the container to be ready
 var defaultReadinessHook = func() ContainerLifecycleHooks {
 	return ContainerLifecycleHooks{
@@ -253,7 +458,7 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 					},
 					b,
 					func(err error, duration time.Duration) {
-						dockerContainer.logger.Printf("All requested ports were not exposed: %v", err)
+						warnf(dockerContainer.logger, "All requested ports were not exposed: %v", err)
 					},
 				)
 				if err != nil {
@@ -268,12 +473,8 @@ var defaultReadinessHook = func() ContainerLifecycleHooks {
 
 				// if a Wait Strategy has been specified, wait before returning
 				if dockerContainer.WaitingFor != nil {
-					dockerContainer.logger.Printf(
-						"⏳ Waiting for container id %s image: %s. Waiting for: %+v",
-						dockerContainer.ID[:12], dockerContainer.Image, dockerContainer.WaitingFor,
-					)
-					if err := dockerContainer.WaitingFor.WaitUntilReady(ctx, c); err != nil {
-						return fmt.Errorf("wait until ready: %w", err)
+					if err := dockerContainer.WaitUntilReady(ctx); err != nil {
+						return err
 					}
 				}
 
@@ -295,6 +496,23 @@ func (req ContainerRequest) creatingHook(ctx context.Context) error {
 	return errors.Join(errs...)
 }
 
+// preCreateHostHooks runs the PreCreateHostHooks of the request in order, stopping at the first error,
+// annotated with the index of the hook. The request is validated again if there are hooks, as they
+// can modify it.
+func (req *ContainerRequest) preCreateHostHooks(ctx context.Context) error {
+	if len(req.PreCreateHostHooks) == 0 {
+		return nil
+	}
+
+	for i, hook := range req.PreCreateHostHooks {
+		if err := hook(ctx, req); err != nil {
+			return fmt.Errorf("pre-create host hook #%d: %w", i, err)
+		}
+	}
+
+	return req.Validate()
+}
+
 // createdHook is a hook that will be called after a container is created.
 func (c *DockerContainer) createdHook(ctx context.Context) error {
 	return c.applyLifecycleHooks(ctx, false, func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook {
@@ -328,17 +546,17 @@ func (c *DockerContainer) readiedHook(ctx context.Context) error {
 func (c *DockerContainer) printLogs(ctx context.Context, cause error) {
 	reader, err := c.Logs(ctx)
 	if err != nil {
-		c.logger.Printf("failed accessing container logs: %v\n", err)
+		errorf(c.logger, "failed accessing container logs: %v\n", err)
 		return
 	}
 
 	b, err := io.ReadAll(reader)
 	if err != nil {
-		c.logger.Printf("failed reading container logs: %v\n", err)
+		errorf(c.logger, "failed reading container logs: %v\n", err)
 		return
 	}
 
-	c.logger.Printf("container logs (%s):\n%s", cause, b)
+	errorf(c.logger, "container logs (%s):\n%s", redactValues(cause.Error(), c.secrets), redactValues(string(b), c.secrets))
 }
 
 // stoppingHook is a hook that will be called before a container is stopped.
@@ -371,8 +589,9 @@ func (c *DockerContainer) terminatedHook(ctx context.Context) error {
 
 // applyLifecycleHooks applies all lifecycle hooks reporting the container logs on error if logError is true.
 func (c *DockerContainer) applyLifecycleHooks(ctx context.Context, logError bool, hooks func(lifecycleHooks ContainerLifecycleHooks) []ContainerHook) error {
-	errs := make([]error, len(c.lifecycleHooks))
-	for i, lifecycleHooks := range c.lifecycleHooks {
+	containerHooks := c.hooks()
+	errs := make([]error, len(containerHooks))
+	for i, lifecycleHooks := range containerHooks {
 		errs[i] = containerHookFn(ctx, hooks(lifecycleHooks))(c)
 	}
 
@@ -384,8 +603,10 @@ func (c *DockerContainer) applyLifecycleHooks(ctx context.Context, logError bool
 				ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 				defer cancel()
 				c.printLogs(ctx, err)
+				err = c.withStartupLogs(ctx, err)
 			default:
 				c.printLogs(ctx, err)
+				err = c.withStartupLogs(ctx, err)
 			}
 		}
 
@@ -493,15 +714,16 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 		req.ConfigModifier(dockerInput)
 	}
 
-	if req.HostConfigModifier == nil {
-		req.HostConfigModifier = defaultHostConfigModifier(req)
-	}
-	req.HostConfigModifier(hostConfig)
+	req.applyHostConfigModifiers(hostConfig)
 
 	if req.EnpointSettingsModifier != nil {
 		req.EnpointSettingsModifier(endpointSettings)
 	}
 
+	if req.EndpointSettingsModifier != nil {
+		req.EndpointSettingsModifier(endpointSettings)
+	}
+
 	networkingConfig.EndpointsConfig = endpointSettings
 
 	exposedPorts := req.ExposedPorts
@@ -521,6 +743,8 @@ func (p *DockerProvider) preCreateContainerHook(ctx context.Context, req Contain
 		return err
 	}
 
+	applyHostPortBindingIP(exposedPortMap, req.HostPortBindingIP)
+
 	dockerInput.ExposedPorts = exposedPortSet
 
 	// only exposing those ports automatically if the container request exposes zero ports and the container does not run in a container network
@@ -599,14 +823,16 @@ func mergePortBindings(configPortMap, exposedPortMap nat.PortMap, exposedPorts [
 		exposedPortMap = make(map[nat.Port][]nat.PortBinding)
 	}
 
-	mappedPorts := make(map[string]struct{}, len(exposedPorts))
+	// exposed ports are indexed by port and protocol, so that a binding for a port
+	// is not merged when the port is exposed with a different protocol.
+	mappedPorts := make(map[nat.Port]struct{}, len(exposedPorts))
 	for _, p := range exposedPorts {
-		p = strings.Split(p, "/")[0]
-		mappedPorts[p] = struct{}{}
+		proto, port := nat.SplitProtoPort(p)
+		mappedPorts[nat.Port(port+"/"+proto)] = struct{}{}
 	}
 
 	for k, v := range configPortMap {
-		if _, ok := mappedPorts[k.Port()]; ok {
+		if _, ok := mappedPorts[nat.Port(k.Port()+"/"+k.Proto())]; ok {
 			exposedPortMap[k] = v
 		}
 	}
@@ -3,7 +3,18 @@ package testcontainers
 import (
 	"bufio"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -13,9 +24,11 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/go-connections/nat"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/testcontainers/testcontainers-go/internal/core"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -446,6 +459,22 @@ func TestMergePortBindings(t *testing.T) {
 				"90/tcp": {{HostIP: "", HostPort: ""}},
 			},
 		},
+		{
+			name: "merge config for the exposed protocol only",
+			arg: arg{
+				configPortMap: map[nat.Port][]nat.PortBinding{
+					"53/tcp": {{HostIP: "1", HostPort: "2"}},
+					"53/udp": {{HostIP: "1", HostPort: "3"}},
+				},
+				parsedPortMap: map[nat.Port][]nat.PortBinding{
+					"53/udp": {{HostIP: "", HostPort: ""}},
+				},
+				exposedPorts: []string{"53/udp"},
+			},
+			expected: map[nat.Port][]nat.PortBinding{
+				"53/udp": {{HostIP: "1", HostPort: "3"}},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -944,6 +973,74 @@ func TestPrintContainerLogsOnError(t *testing.T) {
 	}
 }
 
+func TestEarlyExitError(t *testing.T) {
+	waitErr := errors.New("container exited with code 127")
+
+	t.Run("with-logs", func(t *testing.T) {
+		err := &earlyExitError{
+			err:             waitErr,
+			exitCode:        127,
+			uptime:          150 * time.Millisecond,
+			imageEntrypoint: []string{"/docker-entrypoint.sh"},
+			imageCmd:        []string{"nginx", "-g", "daemon off;"},
+			entrypoint:      []string{"/docker-entrypoint.sh"},
+			cmd:             []string{"exit 0"},
+			logs:            []string{"/docker-entrypoint.sh: exec: line 47: exit 0: not found"},
+		}
+
+		require.ErrorIs(t, err, waitErr)
+
+		msg := err.Error()
+		require.Contains(t, msg, "container exited with code 127 150ms after starting, before the wait strategy completed: container exited with code 127")
+		require.Contains(t, msg, `image entrypoint: ["/docker-entrypoint.sh"], image cmd: ["nginx" "-g" "daemon off;"]`)
+		require.Contains(t, msg, `effective entrypoint: ["/docker-entrypoint.sh"], effective cmd: ["exit 0"]`)
+		require.Contains(t, msg, "last log lines:\n    /docker-entrypoint.sh: exec: line 47: exit 0: not found\n")
+		require.Contains(t, msg, "WithCmdArgs or WithEntrypointArgs")
+	})
+
+	t.Run("without-logs", func(t *testing.T) {
+		err := &earlyExitError{
+			err:      waitErr,
+			exitCode: 0,
+			uptime:   time.Second,
+		}
+
+		require.NotContains(t, err.Error(), "last log lines")
+	})
+}
+
+func TestLastLines(t *testing.T) {
+	require.Empty(t, lastLines("", 10))
+	require.Equal(t, []string{"a", "b"}, lastLines("a\r\n\nb\n", 10))
+	require.Equal(t, []string{"c", "d"}, lastLines("a\nb\nc\nd\n", 2))
+}
+
+func TestEarlyExitWithConflictingCmd(t *testing.T) {
+	ctx := context.Background()
+
+	// the nginx entrypoint executes the command, which does not exist in the image.
+	// The wait strategy has the default startup timeout, and fails as soon as the container exits.
+	start := time.Now()
+	container, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      nginxAlpineImage,
+			Cmd:        []string{"exit 0"},
+			WaitingFor: wait.ForLog("ready"),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, container)
+	require.Less(t, time.Since(start), 30*time.Second)
+
+	var exitErr *earlyExitError
+	require.ErrorAs(t, err, &exitErr)
+	require.Equal(t, 127, exitErr.exitCode)
+	require.Equal(t, []string{"/docker-entrypoint.sh"}, exitErr.imageEntrypoint)
+	require.Equal(t, []string{"exit 0"}, exitErr.cmd)
+	require.Contains(t, err.Error(), "WithEntrypoint")
+}
+
 func lifecycleHooksIsHonouredFn(t *testing.T, ctx context.Context, prints []string) {
 	require.Len(t, prints, 24)
 
@@ -983,3 +1080,299 @@ func lifecycleHooksIsHonouredFn(t *testing.T, ctx context.Context, prints []stri
 	assert.True(t, strings.HasPrefix(prints[22], "post-terminate hook 1: "))
 	assert.True(t, strings.HasPrefix(prints[23], "post-terminate hook 2: "))
 }
+
+func TestConfigModifier_notClobberedByLibraryLabels(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("user-values-survive", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:  nginxAlpineImage,
+				Labels: map[string]string{"request.label": "from-request"},
+				ConfigModifier: func(cfg *container.Config) {
+					// replacing the labels map must not drop the session labels
+					cfg.Labels = map[string]string{"modifier.label": "from-modifier"}
+					cfg.StopSignal = "SIGQUIT"
+				},
+			},
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, "SIGQUIT", inspect.Config.StopSignal)
+		require.Equal(t, "from-modifier", inspect.Config.Labels["modifier.label"])
+		require.NotContains(t, inspect.Config.Labels, "request.label")
+		for k, v := range core.DefaultLabels(core.SessionID()) {
+			require.Equal(t, v, inspect.Config.Labels[k], k)
+		}
+	})
+
+	t.Run("reserved-label", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: nginxAlpineImage,
+				ConfigModifier: func(cfg *container.Config) {
+					cfg.Labels[core.LabelSessionID] = "another-session"
+				},
+			},
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.ErrorIs(t, err, ErrReservedLabel)
+	})
+}
+
+func TestEndpointSettingsModifier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("after-deprecated-modifier", func(t *testing.T) {
+		var calls []string
+		req := ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+			EnpointSettingsModifier: func(settings map[string]*network.EndpointSettings) {
+				calls = append(calls, "deprecated")
+				settings["a"] = &network.EndpointSettings{Aliases: []string{"deprecated"}}
+			},
+			EndpointSettingsModifier: func(settings map[string]*network.EndpointSettings) {
+				calls = append(calls, "new")
+				settings["a"].Aliases = append(settings["a"].Aliases, "new")
+			},
+		}
+
+		networkingConfig := &network.NetworkingConfig{}
+		err := (&DockerProvider{}).preCreateContainerHook(ctx, req, &container.Config{}, &container.HostConfig{}, networkingConfig)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"deprecated", "new"}, calls)
+		require.Equal(t, []string{"deprecated", "new"}, networkingConfig.EndpointsConfig["a"].Aliases)
+	})
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	networkName := "tc-endpoint-" + uuid.NewString()
+	nw, err := provider.CreateNetwork(ctx, NetworkRequest{
+		Name:   networkName,
+		Labels: GenericLabels(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	var inspected network.NetworkingConfig
+
+	// endpointSettingsModifier {
+	req := ContainerRequest{
+		Image:    nginxAlpineImage,
+		Networks: []string{networkName},
+		EndpointSettingsModifier: func(settings map[string]*network.EndpointSettings) {
+			settings[networkName].IPAMConfig = &network.EndpointIPAMConfig{
+				LinkLocalIPs: []string{"169.254.10.10"},
+			}
+		},
+		PreCreateInspector: func(_ container.Config, _ container.HostConfig, networkingConfig network.NetworkingConfig) {
+			inspected = networkingConfig
+		},
+	}
+	// }
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType:     providerType,
+		ContainerRequest: req,
+		Started:          true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// the inspector observes the settings set by the modifier.
+	require.Contains(t, inspected.EndpointsConfig, networkName)
+	require.NotNil(t, inspected.EndpointsConfig[networkName].IPAMConfig)
+	require.Equal(t, []string{"169.254.10.10"}, inspected.EndpointsConfig[networkName].IPAMConfig.LinkLocalIPs)
+
+	inspect, err := ctr.Inspect(ctx)
+	require.NoError(t, err)
+
+	endpoint, ok := inspect.NetworkSettings.Networks[networkName]
+	require.True(t, ok)
+	require.NotNil(t, endpoint.IPAMConfig)
+	require.Equal(t, []string{"169.254.10.10"}, endpoint.IPAMConfig.LinkLocalIPs)
+}
+
+func TestPreCreateHostHooks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("certificate-for-the-container-name", func(t *testing.T) {
+		certPath := filepath.Join(t.TempDir(), "tls.pem")
+
+		// preCreateHostHooks {
+		req := ContainerRequest{
+			Image: nginxAlpineImage,
+			Name:  "tc-hooks-" + uuid.NewString(),
+			Env:   map[string]string{},
+			PreCreateHostHooks: []func(ctx context.Context, req *ContainerRequest) error{
+				func(_ context.Context, req *ContainerRequest) error {
+					// the certificate is valid for the name of the container, resolved by now.
+					if err := writeSelfSignedCert(certPath, req.Name); err != nil {
+						return err
+					}
+
+					req.Files = append(req.Files, ContainerFile{
+						HostFilePath:      certPath,
+						ContainerFilePath: "/certs/tls.pem",
+						FileMode:          0o644,
+					})
+					return nil
+				},
+				func(_ context.Context, req *ContainerRequest) error {
+					req.Env["TLS_CERT"] = "/certs/tls.pem"
+					return nil
+				},
+			},
+		}
+		// }
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType:     providerType,
+			ContainerRequest: req,
+			Started:          true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		rc, err := ctr.CopyFileFromContainer(ctx, "/certs/tls.pem")
+		require.NoError(t, err)
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+
+		block, _ := pem.Decode(data)
+		require.NotNil(t, block)
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		require.Equal(t, []string{req.Name}, cert.DNSNames)
+
+		value, ok, err := ctr.EnvValue(ctx, "TLS_CERT")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "/certs/tls.pem", value)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errHook := errors.New("certificate not generated")
+
+		var called bool
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: nginxAlpineImage,
+				PreCreateHostHooks: []func(ctx context.Context, req *ContainerRequest) error{
+					func(context.Context, *ContainerRequest) error {
+						return nil
+					},
+					func(context.Context, *ContainerRequest) error {
+						return errHook
+					},
+					func(context.Context, *ContainerRequest) error {
+						called = true
+						return nil
+					},
+				},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.ErrorIs(t, err, errHook)
+		require.ErrorContains(t, err, "pre-create host hook #1")
+		require.Nil(t, ctr)
+		require.False(t, called)
+	})
+}
+
+func TestDockerContainer_AddLifecycleHooks(t *testing.T) {
+	ctx := context.Background()
+
+	var calls []string
+	record := func(name string) []ContainerHook {
+		return []ContainerHook{
+			func(context.Context, Container) error {
+				calls = append(calls, name)
+				return nil
+			},
+		}
+	}
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+			LifecycleHooks: []ContainerLifecycleHooks{
+				{
+					PreStops:      record("request-pre-stop"),
+					PreTerminates: record("request-pre-terminate"),
+				},
+			},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// addLifecycleHooks {
+	dc := ctr.(*DockerContainer)
+	dc.AddLifecycleHooks(ContainerLifecycleHooks{
+		PreTerminates: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				// flush the buffers of the process before the container is terminated.
+				_, _, err := c.Exec(ctx, []string{"sync"})
+				return err
+			},
+		},
+	})
+	// }
+	dc.AddLifecycleHooks(
+		ContainerLifecycleHooks{PreStops: record("added-pre-stop-1"), PreTerminates: record("added-pre-terminate-1")},
+		ContainerLifecycleHooks{PreStops: record("added-pre-stop-2"), PreTerminates: record("added-pre-terminate-2")},
+	)
+
+	// the container is stopped gracefully, so that the PreStops hooks are called too.
+	require.NoError(t, dc.Terminate(ctx, WithStopTimeout(10*time.Second)))
+	require.Equal(t, []string{
+		"request-pre-terminate",
+		"added-pre-terminate-1",
+		"added-pre-terminate-2",
+		"request-pre-stop",
+		"added-pre-stop-1",
+		"added-pre-stop-2",
+	}, calls)
+}
+
+// writeSelfSignedCert writes a PEM encoded self-signed certificate for the given DNS name to the path.
+func writeSelfSignedCert(path string, dnsName string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+}
@@ -0,0 +1,495 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// logArchiveStopTimeout is the time to wait for a follower to read the remaining logs
+// of a terminated container, before stopping it.
+const logArchiveStopTimeout = 5 * time.Second
+
+var (
+	logArchiveMx sync.Mutex
+	// testLogArchives are the log archives enabled with WithLogArchive, in the order they were enabled.
+	testLogArchives []*logArchive
+	// outlivingLogArchives are the log archives of the ended tests, still following the containers outliving them.
+	outlivingLogArchives []*logArchive
+	// configLogArchive is the log archive enabled with the log.archive.dir property, created on demand.
+	configLogArchive *logArchive
+)
+
+// WithLogArchive enables the log archive for the given test: the stdout and stderr of every container
+// created by the test while it's enabled are written, demultiplexed and timestamped, to the
+// <dir>/<test>/<container-name>.log file, where <test> is the name of the test, i.e. tb.Name().
+// If the file already exists, a numeric suffix is added to the container name.
+// The containers created by a goroutine of the test, e.g. with ParallelContainers, are archived
+// with the log archive enabled last.
+//
+// The file of a container is closed when the container is terminated. The log archive is disabled
+// once the test and its cleanup functions ended, see testing.TB.Cleanup. The containers outliving
+// the test are still archived, their file being moved to the <dir>/<session-id>/<test>-<container-name>.log
+// file when it's closed, once they are terminated or with CloseLogArchive.
+//
+// The log archive can also be enabled for the whole process with the TESTCONTAINERS_LOG_ARCHIVE_DIR
+// environment variable, or the log.archive.dir property.
+func WithLogArchive(tb testing.TB, dir string) {
+	tb.Helper()
+
+	archive := newLogArchive(dir)
+	archive.testName = tb.Name()
+
+	logArchiveMx.Lock()
+	testLogArchives = append(testLogArchives, archive)
+	logArchiveMx.Unlock()
+
+	tb.Cleanup(func() {
+		logArchiveMx.Lock()
+		testLogArchives = slices.DeleteFunc(testLogArchives, func(a *logArchive) bool { return a == archive })
+		logArchiveMx.Unlock()
+
+		if archive.end() {
+			logArchiveMx.Lock()
+			outlivingLogArchives = append(outlivingLogArchives, archive)
+			logArchiveMx.Unlock()
+		}
+	})
+}
+
+// currentLogArchive returns the enabled log archive, or nil if it's disabled: the one enabled by the test
+// creating the container, otherwise the one enabled last, or the one of the configuration.
+func currentLogArchive(cfg config.Config) *logArchive {
+	logArchiveMx.Lock()
+	defer logArchiveMx.Unlock()
+
+	if len(testLogArchives) > 0 {
+		test := callerTestName()
+		for i := len(testLogArchives) - 1; i >= 0; i-- {
+			if name, _, _ := strings.Cut(testLogArchives[i].testName, "/"); name == test {
+				return testLogArchives[i]
+			}
+		}
+
+		return testLogArchives[len(testLogArchives)-1]
+	}
+
+	if cfg.LogArchiveDir == "" {
+		return nil
+	}
+
+	if configLogArchive == nil || configLogArchive.dir != cfg.LogArchiveDir {
+		if configLogArchive != nil {
+			// the archive of the previous directory is closed without delaying the creation of the container.
+			previous := configLogArchive
+			go func() {
+				if err := previous.close(); err != nil {
+					warnf(Logger, "close log archive %s: %s", previous.dir, err)
+				}
+			}()
+		}
+		configLogArchive = newLogArchive(cfg.LogArchiveDir)
+	}
+
+	return configLogArchive
+}
+
+// CloseLogArchive closes the log archive enabled with the TESTCONTAINERS_LOG_ARCHIVE_DIR environment variable,
+// or the log.archive.dir property, and the log archives of the ended tests, see WithLogArchive, stopping the
+// followers of the containers that are still running and closing their files. Call it when the process exits,
+// e.g. at the end of TestMain. Otherwise, the file of a container is closed when the container is terminated
+// or removed. The log archive of the configuration is enabled again for the containers created afterwards.
+func CloseLogArchive() error {
+	logArchiveMx.Lock()
+	archives := outlivingLogArchives
+	outlivingLogArchives = nil
+	if configLogArchive != nil {
+		archives = append(archives, configLogArchive)
+		configLogArchive = nil
+	}
+	logArchiveMx.Unlock()
+
+	var errs []error
+	for _, archive := range archives {
+		errs = append(errs, archive.close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// logArchive writes the logs of the containers to files, grouped by test.
+type logArchive struct {
+	dir string
+	// testName is the name of the test enabling the archive with WithLogArchive, empty for the archive
+	// of the configuration, whose containers are grouped by the test function creating them.
+	testName string
+
+	mtx       sync.Mutex
+	followers map[string]*logArchiveFollower // indexed by container ID
+	// ended is set once the test enabling the archive ended, see WithLogArchive.
+	ended bool
+	wg    sync.WaitGroup
+}
+
+func newLogArchive(dir string) *logArchive {
+	return &logArchive{
+		dir:       dir,
+		followers: map[string]*logArchiveFollower{},
+	}
+}
+
+// logArchiveFollower follows the logs of a container, writing them to its file.
+type logArchiveFollower struct {
+	file   *os.File
+	cancel context.CancelFunc
+	done   chan struct{}
+	// since is the timestamp following the one of the last log line read, used to resume following
+	// when the container is restarted.
+	since string
+
+	// testName is the name of the test creating the container, and outlived is set once the test
+	// enabling the archive ended before the container, see WithLogArchive.
+	testName string
+	outlived atomic.Bool
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// hooks returns the lifecycle hooks to archive the logs of a container. It must be called by the goroutine
+// creating the container, to group the containers of the archive of the configuration by test function.
+func (a *logArchive) hooks() ContainerLifecycleHooks {
+	testName := a.testName
+	if testName == "" {
+		testName = callerTestName()
+	}
+
+	return ContainerLifecycleHooks{
+		PostStarts: []ContainerHook{
+			func(ctx context.Context, c Container) error {
+				return a.follow(ctx, c.(*DockerContainer), testName)
+			},
+		},
+		PostStops: []ContainerHook{
+			func(_ context.Context, c Container) error {
+				// the logs stream ends when the container stops.
+				return a.stop(c.GetContainerID(), false)
+			},
+		},
+		PostTerminates: []ContainerHook{
+			func(_ context.Context, c Container) error {
+				return a.stop(c.GetContainerID(), true)
+			},
+		},
+	}
+}
+
+// follow starts following the logs of the container, creating its file if needed.
+func (a *logArchive) follow(ctx context.Context, c *DockerContainer, testName string) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	f, ok := a.followers[c.ID]
+	if ok && f.done != nil {
+		// already following the logs of the container.
+		return nil
+	}
+
+	if !ok {
+		inspect, err := c.Inspect(ctx)
+		if err != nil {
+			return fmt.Errorf("inspect container: %w", err)
+		}
+
+		dir := testName
+		if dir == "" {
+			dir = core.SessionID()
+		}
+
+		file, err := createLogArchiveFile(filepath.Join(a.dir, dir), strings.TrimPrefix(inspect.Name, "/"))
+		if err != nil {
+			return fmt.Errorf("create log archive file: %w", err)
+		}
+
+		f = &logArchiveFollower{file: file, testName: testName}
+		f.outlived.Store(a.ended)
+		a.followers[c.ID] = f
+	}
+
+	followCtx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+	f.done = make(chan struct{})
+
+	a.wg.Add(1)
+	go func(f *logArchiveFollower, done chan struct{}, since string) {
+		defer func() {
+			close(done)
+			a.wg.Done()
+		}()
+
+		rc, err := c.provider.client.ContainerLogs(followCtx, c.ID, container.LogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Follow:     true,
+			Timestamps: true,
+			Since:      since,
+		})
+		if err != nil {
+			warnf(c.logger, "archive logs of container %s: %s", c.ID, err)
+			return
+		}
+		defer rc.Close()
+
+		// both streams are written to the same file, keeping the order in which they were produced.
+		w := &logArchiveWriter{w: f.file, lineStart: true}
+		_, err = stdcopy.StdCopy(w, w, rc)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			warnf(c.logger, "archive logs of container %s: %s", c.ID, err)
+		}
+
+		if !w.last.IsZero() {
+			// the since option is inclusive, so the last line read is skipped.
+			a.mtx.Lock()
+			f.since = w.last.Add(time.Nanosecond).Format(time.RFC3339Nano)
+			a.mtx.Unlock()
+		}
+
+		if err == nil {
+			// the logs stream ended on its own: the file is closed if the container was removed,
+			// e.g. without being terminated, otherwise it's kept in case the container is restarted.
+			if _, err := c.provider.client.ContainerInspect(context.Background(), c.ID); errdefs.IsNotFound(err) {
+				a.mtx.Lock()
+				if a.followers[c.ID] == f {
+					delete(a.followers, c.ID)
+				}
+				a.mtx.Unlock()
+
+				if err := f.close(a.dir); err != nil {
+					warnf(c.logger, "archive logs of container %s: %s", c.ID, err)
+				}
+			}
+		}
+	}(f, f.done, f.since)
+
+	return nil
+}
+
+// logArchiveWriter writes the timestamped log lines to the file of a container,
+// recording the timestamp of the last line.
+type logArchiveWriter struct {
+	w         io.Writer
+	lineStart bool
+	last      time.Time
+}
+
+func (w *logArchiveWriter) Write(p []byte) (int, error) {
+	for rest := p; len(rest) > 0; {
+		line := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, rest = rest[:i+1], rest[i+1:]
+		} else {
+			rest = nil
+		}
+
+		// each line starts with its timestamp, e.g. 2024-01-02T15:04:05.999999999Z first
+		if w.lineStart {
+			if timestamp, _, ok := bytes.Cut(line, []byte(" ")); ok {
+				if t, err := time.Parse(time.RFC3339Nano, string(timestamp)); err == nil {
+					w.last = t
+				}
+			}
+		}
+		w.lineStart = line[len(line)-1] == '\n'
+	}
+
+	return w.w.Write(p)
+}
+
+// stop waits for the follower of the container to read its remaining logs, stopping it
+// after a timeout. If remove is true, the file of the container is closed.
+func (a *logArchive) stop(containerID string, remove bool) error {
+	a.mtx.Lock()
+	f, ok := a.followers[containerID]
+	if !ok {
+		a.mtx.Unlock()
+		return nil
+	}
+	if remove {
+		delete(a.followers, containerID)
+	}
+	done, cancel := f.done, f.cancel
+	a.mtx.Unlock()
+
+	if done != nil {
+		select {
+		case <-done:
+		case <-time.After(logArchiveStopTimeout):
+		}
+		cancel()
+		<-done
+
+		a.mtx.Lock()
+		if f.done == done {
+			f.done = nil
+		}
+		a.mtx.Unlock()
+	}
+
+	if !remove {
+		return nil
+	}
+
+	return f.close(a.dir)
+}
+
+// end marks the followers of the containers still running once the test enabling the archive ended
+// as outliving it, see WithLogArchive. It returns true if there are such followers.
+func (a *logArchive) end() bool {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.ended = true
+	for _, f := range a.followers {
+		f.outlived.Store(true)
+	}
+
+	return len(a.followers) > 0
+}
+
+// close stops all the followers, closing their files.
+func (a *logArchive) close() error {
+	a.mtx.Lock()
+	followers := a.followers
+	a.followers = map[string]*logArchiveFollower{}
+	a.mtx.Unlock()
+
+	var errs []error
+	for _, f := range followers {
+		a.mtx.Lock()
+		done, cancel := f.done, f.cancel
+		a.mtx.Unlock()
+
+		if done != nil {
+			cancel()
+			<-done
+		}
+		errs = append(errs, f.close(a.dir))
+	}
+
+	a.wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// close closes the file of the follower, once. If the container outlived the test enabling the archive,
+// the file is moved to the session directory of the archive in the given directory, prefixed with the name
+// of the test.
+func (f *logArchiveFollower) close(dir string) error {
+	f.closeOnce.Do(func() {
+		if f.closeErr = f.file.Close(); f.closeErr != nil {
+			return
+		}
+
+		if !f.outlived.Load() {
+			return
+		}
+
+		// the names of the subtests are flattened, e.g. TestName-subtest.
+		name := strings.ReplaceAll(f.testName, "/", "-") + "-" + strings.TrimSuffix(filepath.Base(f.file.Name()), ".log")
+		dst, err := createLogArchiveFile(filepath.Join(dir, core.SessionID()), name)
+		if err != nil {
+			f.closeErr = fmt.Errorf("move log archive file: %w", err)
+			return
+		}
+		if err := dst.Close(); err != nil {
+			f.closeErr = fmt.Errorf("move log archive file: %w", err)
+			return
+		}
+
+		// the empty file created for the name is replaced.
+		if err := os.Rename(f.file.Name(), dst.Name()); err != nil {
+			f.closeErr = fmt.Errorf("move log archive file: %w", err)
+		}
+	})
+
+	return f.closeErr
+}
+
+// createLogArchiveFile creates the log file for the container in the given directory,
+// adding a numeric suffix to its name if a file with the same name already exists.
+func createLogArchiveFile(dir string, name string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	for i := 0; ; i++ {
+		fileName := name + ".log"
+		if i > 0 {
+			fileName = fmt.Sprintf("%s-%d.log", name, i)
+		}
+
+		f, err := os.OpenFile(filepath.Join(dir, fileName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+
+		return f, err
+	}
+}
+
+// callerTestName returns the name of the top-level test function in the call stack
+// of the current goroutine, or an empty string if it's not called from a test.
+func callerTestName() string {
+	pcs := make([]uintptr, 128)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		// e.g. github.com/org/repo/pkg_test.TestName.func1
+		name := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+		if parts := strings.Split(name, "."); len(parts) > 1 && isTestFunc(parts[1]) {
+			return parts[1]
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isTestFunc returns true if the name is the name of a test function, following the rules of go test.
+func isTestFunc(name string) bool {
+	if !strings.HasPrefix(name, "Test") || name == "TestMain" {
+		return false
+	}
+
+	if len(name) == len("Test") {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(name[len("Test"):])
+	return !unicode.IsLower(r)
+}
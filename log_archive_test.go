@@ -0,0 +1,255 @@
+package testcontainers
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestCallerTestName(t *testing.T) {
+	require.Equal(t, "TestCallerTestName", callerTestName())
+
+	t.Run("subtest", func(t *testing.T) {
+		require.Equal(t, "TestCallerTestName", callerTestName())
+	})
+
+	t.Run("goroutine", func(t *testing.T) {
+		// the goroutine doesn't run a closure of the test, which would be named after it
+		name := make(chan string)
+		go sendCallerTestName(name)
+		require.Empty(t, <-name)
+	})
+}
+
+func sendCallerTestName(name chan<- string) {
+	name <- callerTestName()
+}
+
+func TestWithLogArchive(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Config{}
+
+	var archive *logArchive
+	t.Run("enabled", func(t *testing.T) {
+		WithLogArchive(t, dir)
+
+		archive = currentLogArchive(cfg)
+		require.NotNil(t, archive)
+		require.Equal(t, "TestWithLogArchive/enabled", archive.testName)
+
+		// the archive of the test creating the container is preferred to the one enabled last.
+		t.Run("nested", func(t *testing.T) {
+			WithLogArchive(t, filepath.Join(dir, "nested"))
+			require.Equal(t, "TestWithLogArchive/enabled/nested", currentLogArchive(cfg).testName)
+
+			other := newLogArchive(dir)
+			other.testName = "TestOther"
+			logArchiveMx.Lock()
+			testLogArchives = append(testLogArchives, other)
+			logArchiveMx.Unlock()
+			t.Cleanup(func() {
+				logArchiveMx.Lock()
+				testLogArchives = slices.DeleteFunc(testLogArchives, func(a *logArchive) bool { return a == other })
+				logArchiveMx.Unlock()
+			})
+
+			require.Equal(t, "TestWithLogArchive/enabled/nested", currentLogArchive(cfg).testName)
+		})
+
+		require.Equal(t, archive, currentLogArchive(cfg))
+
+		// a container still running once the test ended.
+		file, err := createLogArchiveFile(filepath.Join(dir, t.Name()), "outliving")
+		require.NoError(t, err)
+		archive.followers["outliving"] = &logArchiveFollower{file: file, testName: t.Name()}
+	})
+
+	// the archive is disabled once the test ended, and closed with CloseLogArchive.
+	require.Nil(t, currentLogArchive(cfg))
+	require.True(t, archive.followers["outliving"].outlived.Load())
+
+	logArchiveMx.Lock()
+	require.Contains(t, outlivingLogArchives, archive)
+	logArchiveMx.Unlock()
+
+	require.NoError(t, CloseLogArchive())
+	require.Zero(t, archive.len())
+	require.FileExists(t, filepath.Join(dir, core.SessionID(), "TestWithLogArchive-enabled-outliving.log"))
+}
+
+func TestLogArchiveWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &logArchiveWriter{w: &buf, lineStart: true}
+
+	_, err := w.Write([]byte("2024-01-02T15:04:05.123456789Z first\n2024-01-02T15:04:05.223456789Z sec"))
+	require.NoError(t, err)
+	// the timestamp of a partial line is recorded, and the rest of the line is not parsed as a timestamp
+	_, err = w.Write([]byte("ond\n"))
+	require.NoError(t, err)
+
+	require.Equal(t, "2024-01-02T15:04:05.123456789Z first\n2024-01-02T15:04:05.223456789Z second\n", buf.String())
+	require.Equal(t, time.Date(2024, 1, 2, 15, 4, 5, 223456789, time.UTC), w.last)
+}
+
+func TestLogArchiveFollowerClose(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("running-test", func(t *testing.T) {
+		file, err := createLogArchiveFile(filepath.Join(dir, "TestLogArchiveFollowerClose"), "running")
+		require.NoError(t, err)
+
+		f := &logArchiveFollower{file: file, testName: "TestLogArchiveFollowerClose"}
+		require.NoError(t, f.close(dir))
+		require.NoError(t, f.close(dir))
+
+		require.FileExists(t, filepath.Join(dir, "TestLogArchiveFollowerClose", "running.log"))
+	})
+
+	t.Run("outliving-test", func(t *testing.T) {
+		file, err := createLogArchiveFile(filepath.Join(dir, "TestEnded", "subtest"), "outliving")
+		require.NoError(t, err)
+		_, err = file.WriteString("2024-01-02T15:04:05.123456789Z first\n")
+		require.NoError(t, err)
+
+		// the container outlived its test, so its file is moved to the session directory
+		f := &logArchiveFollower{file: file, testName: "TestEnded/subtest"}
+		f.outlived.Store(true)
+		require.NoError(t, f.close(dir))
+
+		require.NoFileExists(t, filepath.Join(dir, "TestEnded", "subtest", "outliving.log"))
+		content, err := os.ReadFile(filepath.Join(dir, core.SessionID(), "TestEnded-subtest-outliving.log"))
+		require.NoError(t, err)
+		require.Equal(t, "2024-01-02T15:04:05.123456789Z first\n", string(content))
+	})
+}
+
+func TestIsTestFunc(t *testing.T) {
+	require.True(t, isTestFunc("Test"))
+	require.True(t, isTestFunc("TestLogArchive"))
+	require.True(t, isTestFunc("Test_logArchive"))
+	require.False(t, isTestFunc("TestMain"))
+	require.False(t, isTestFunc("Testify"))
+	require.False(t, isTestFunc("ExampleTest"))
+}
+
+func TestCreateLogArchiveFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "TestCreateLogArchiveFile")
+
+	for _, expected := range []string{"nginx.log", "nginx-1.log", "nginx-2.log"} {
+		f, err := createLogArchiveFile(dir, "nginx")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, expected), f.Name())
+		require.NoError(t, f.Close())
+	}
+}
+
+// len returns the number of containers whose logs are being archived.
+func (a *logArchive) len() int {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	return len(a.followers)
+}
+
+func TestLogArchive(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+
+	WithLogArchive(t, dir)
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/busybox",
+			Cmd:        []string{"sh", "-c", "echo first; echo second >&2; echo third; echo ready; sleep 30"},
+			WaitingFor: wait.ForLog("ready"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	inspect, err := ctr.Inspect(ctx)
+	require.NoError(t, err)
+
+	archive := currentLogArchive(ReadConfig().Config)
+	require.Equal(t, 1, archive.len())
+
+	require.NoError(t, ctr.Terminate(ctx))
+
+	// the follower is stopped and its file closed on terminate
+	require.Zero(t, archive.len())
+
+	logFile := filepath.Join(dir, "TestLogArchive", strings.TrimPrefix(inspect.Name, "/")+".log")
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		// each line is prefixed with the timestamp
+		timestamp, text, ok := strings.Cut(line, " ")
+		require.True(t, ok)
+		_, err := time.Parse(time.RFC3339Nano, timestamp)
+		require.NoError(t, err)
+
+		lines = append(lines, text)
+	}
+
+	require.Equal(t, []string{"first", "second", "third", "ready"}, lines)
+}
+
+func TestLogArchiveOutlivingTest(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+
+	var ctr Container
+	var name string
+	t.Run("subtest", func(t *testing.T) {
+		WithLogArchive(t, dir)
+
+		// containers created by a goroutine of the test are archived too.
+		ctrs := make(chan Container)
+		errs := make(chan error)
+		go func() {
+			ctr, err := GenericContainer(ctx, GenericContainerRequest{
+				ProviderType: providerType,
+				ContainerRequest: ContainerRequest{
+					Image:      "docker.io/busybox",
+					Cmd:        []string{"sh", "-c", "echo ready; sleep 30"},
+					WaitingFor: wait.ForLog("ready"),
+				},
+				Started: true,
+			})
+			ctrs <- ctr
+			errs <- err
+		}()
+
+		ctr = <-ctrs
+		require.NoError(t, <-errs)
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+		name = strings.TrimPrefix(inspect.Name, "/")
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+
+	// the container outlived the subtest, so its file is moved to the session directory once closed.
+	require.FileExists(t, filepath.Join(dir, "TestLogArchiveOutlivingTest", "subtest", name+".log"))
+	require.NoError(t, CloseLogArchive())
+
+	content, err := os.ReadFile(filepath.Join(dir, core.SessionID(), "TestLogArchiveOutlivingTest-subtest-"+name+".log"))
+	require.NoError(t, err)
+	require.Contains(t, string(content), "ready")
+}
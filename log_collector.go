@@ -0,0 +1,220 @@
+package testcontainers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	// logCollectorImage is the image of the log collector, receiving the syslog records over TCP with socat.
+	logCollectorImage = "docker.io/alpine/socat:1.8.0.0"
+
+	logCollectorPort    = "514/tcp"
+	logCollectorRecords = "/tmp/records.log"
+)
+
+// LogRecord is a syslog record received by a LogCollector, sent by the syslog log driver of Docker
+// in the RFC 5424 format.
+type LogRecord struct {
+	Facility  int       // the facility of the record, daemon (3) by default
+	Severity  int       // the severity of the record: info (6) for stdout, err (3) for stderr
+	Timestamp time.Time // the time the line was logged by the container
+	Hostname  string    // the hostname of the Docker daemon
+	AppName   string    // the tag of the log driver, the short ID of the container by default
+	ProcID    string    // the PID of the Docker daemon
+	MsgID     string    // the tag of the log driver too
+	Message   string    // the line logged by the container
+
+	// Raw is the record as received, e.g. if it's not in the RFC 5424 format.
+	Raw string
+}
+
+// LogCollector is a container receiving the logs of other containers through the syslog log driver
+// of Docker, so that a log pipeline can be tested end to end. The logs of a container are forwarded
+// to it with WithLogForwarding, and read back with Received.
+type LogCollector struct {
+	*DockerContainer
+}
+
+// RunLogCollector starts a log collector container, listening for syslog records over TCP and storing
+// them in a file, read by Received. The customizers are applied to the request of the container, e.g.
+// to attach it to a network.
+func RunLogCollector(ctx context.Context, opts ...ContainerCustomizer) (*LogCollector, error) {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image:        logCollectorImage,
+			ExposedPorts: []string{logCollectorPort},
+			Cmd: []string{
+				"-u",
+				"TCP-LISTEN:514,fork,reuseaddr",
+				"OPEN:" + logCollectorRecords + ",creat,append",
+			},
+			WaitingFor: wait.ForListeningPort(logCollectorPort),
+		},
+		Started: true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.Customize(&req); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := GenericContainer(ctx, req)
+	var collector *LogCollector
+	if dc, ok := c.(*DockerContainer); ok && dc != nil {
+		collector = &LogCollector{DockerContainer: dc}
+	}
+	if err != nil {
+		// return the container and the error to the caller to handle it
+		return collector, fmt.Errorf("run log collector: %w", err)
+	}
+
+	return collector, nil
+}
+
+// DaemonAddress returns the syslog address of the collector, as reachable by the Docker daemon, which
+// connects to the collector on behalf of the containers forwarding their logs. Unlike the mapped port,
+// which is reachable by the tests, it's the IP of the collector in the networks managed by the daemon,
+// e.g. "tcp://172.17.0.2:514", which also holds if the daemon runs in a VM or on a remote host.
+func (c *LogCollector) DaemonAddress(ctx context.Context) (string, error) {
+	ip, err := c.ContainerIP(ctx)
+	if err != nil {
+		return "", fmt.Errorf("container ip: %w", err)
+	}
+
+	if ip == "" {
+		// attached to several networks: any of them is reachable by the daemon.
+		ips, err := c.ContainerIPs(ctx)
+		if err != nil {
+			return "", fmt.Errorf("container ips: %w", err)
+		}
+
+		networks := make([]string, 0, len(ips))
+		for network, networkIP := range ips {
+			if networkIP != "" {
+				networks = append(networks, network)
+			}
+		}
+		if len(networks) == 0 {
+			return "", errors.New("log collector has no IP address")
+		}
+
+		sort.Strings(networks)
+		ip = ips[networks[0]]
+	}
+
+	return "tcp://" + net.JoinHostPort(ip, nat.Port(logCollectorPort).Port()), nil
+}
+
+// Received returns the records received by the collector so far, in the order they were received.
+func (c *LogCollector) Received(ctx context.Context) ([]LogRecord, error) {
+	r, err := c.CopyFileFromContainer(ctx, logCollectorRecords)
+	if err != nil {
+		// the file is created on the first connection.
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("copy records: %w", err)
+	}
+	defer r.Close()
+
+	return readLogRecords(r)
+}
+
+// WithLogForwarding forwards the logs of the container to the collector, with the syslog log driver
+// of Docker. The logs remain readable with Logs only if the dual logging cache of the daemon is enabled.
+func WithLogForwarding(collector *LogCollector) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		address, err := collector.DaemonAddress(context.Background())
+		if err != nil {
+			return fmt.Errorf("log collector address: %w", err)
+		}
+
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			hc.LogConfig = container.LogConfig{
+				Type: "syslog",
+				Config: map[string]string{
+					"syslog-address": address,
+					"syslog-format":  "rfc5424micro",
+				},
+			}
+		})
+
+		return nil
+	}
+}
+
+// readLogRecords reads the records, one per line.
+func readLogRecords(r io.Reader) ([]LogRecord, error) {
+	var records []LogRecord
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		records = append(records, parseLogRecord(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read records: %w", err)
+	}
+
+	return records, nil
+}
+
+// parseLogRecord parses an RFC 5424 record, e.g. "<30>1 2024-06-01T10:00:00.000000Z host 0123456789ab 42 0123456789ab - hello",
+// without structured data, as sent by Docker. The records in another format only have their Raw and Message fields set.
+func parseLogRecord(line string) LogRecord {
+	record := LogRecord{Raw: line, Message: line}
+
+	fields := strings.SplitN(line, " ", 8)
+	if len(fields) < 7 || !strings.HasPrefix(fields[0], "<") {
+		return record
+	}
+
+	pri, version, ok := strings.Cut(strings.TrimPrefix(fields[0], "<"), ">")
+	if !ok || version != "1" {
+		return record
+	}
+	priority, err := strconv.Atoi(pri)
+	if err != nil {
+		return record
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, fields[1])
+	if err != nil {
+		return record
+	}
+
+	record.Facility = priority / 8
+	record.Severity = priority % 8
+	record.Timestamp = timestamp
+	record.Hostname = fields[2]
+	record.AppName = fields[3]
+	record.ProcID = fields[4]
+	record.MsgID = fields[5]
+	record.Message = ""
+	// fields[6] is the structured data, "-" for Docker.
+	if len(fields) == 8 {
+		record.Message = fields[7]
+	}
+
+	return record
+}
@@ -0,0 +1,100 @@
+package testcontainers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogRecord(t *testing.T) {
+	t.Run("rfc5424", func(t *testing.T) {
+		line := "<30>1 2024-06-01T10:00:00.123456Z docker-host 0123456789ab 42 0123456789ab - hello world"
+		record := parseLogRecord(line)
+
+		require.Equal(t, 3, record.Facility)
+		require.Equal(t, 6, record.Severity)
+		require.Equal(t, time.Date(2024, 6, 1, 10, 0, 0, 123456000, time.UTC), record.Timestamp)
+		require.Equal(t, "docker-host", record.Hostname)
+		require.Equal(t, "0123456789ab", record.AppName)
+		require.Equal(t, "42", record.ProcID)
+		require.Equal(t, "0123456789ab", record.MsgID)
+		require.Equal(t, "hello world", record.Message)
+		require.Equal(t, line, record.Raw)
+	})
+
+	t.Run("empty-message", func(t *testing.T) {
+		record := parseLogRecord("<27>1 2024-06-01T10:00:00Z docker-host 0123456789ab 42 0123456789ab -")
+		require.Equal(t, 3, record.Severity)
+		require.Empty(t, record.Message)
+	})
+
+	t.Run("other-format", func(t *testing.T) {
+		record := parseLogRecord("<30>Jun  1 10:00:00 0123456789ab[42]: hello")
+		require.Zero(t, record.Facility)
+		require.True(t, record.Timestamp.IsZero())
+		require.Equal(t, "<30>Jun  1 10:00:00 0123456789ab[42]: hello", record.Message)
+	})
+}
+
+func TestReadLogRecords(t *testing.T) {
+	records, err := readLogRecords(strings.NewReader(
+		"<30>1 2024-06-01T10:00:00Z h a 1 a - first\n\n<27>1 2024-06-01T10:00:01Z h a 1 a - second\n",
+	))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "first", records[0].Message)
+	require.Equal(t, "second", records[1].Message)
+	require.Equal(t, 3, records[1].Severity)
+}
+
+func TestLogCollector(t *testing.T) {
+	ctx := context.Background()
+
+	// logCollector {
+	collector, err := RunLogCollector(ctx)
+	// }
+	if collector != nil {
+		terminateContainerOnEnd(t, ctx, collector)
+	}
+	require.NoError(t, err)
+
+	records, err := collector.Received(ctx)
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	// logForwarding {
+	req := GenericContainerRequest{
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/alpine",
+			Cmd:   []string{"sh", "-c", "echo first; echo second >&2; sleep 60"},
+		},
+		Started: true,
+	}
+	require.NoError(t, WithLogForwarding(collector)(&req))
+	// }
+
+	ctr, err := GenericContainer(ctx, req)
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	var messages []string
+	require.Eventually(t, func() bool {
+		records, err := collector.Received(ctx)
+		require.NoError(t, err)
+
+		messages = messages[:0]
+		for _, r := range records {
+			messages = append(messages, r.Message)
+
+			// the records are tagged with the short ID of the container by default.
+			require.Equal(t, ctr.GetContainerID()[:12], r.AppName)
+		}
+
+		return len(messages) == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.ElementsMatch(t, []string{"first", "second"}, messages)
+}
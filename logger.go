@@ -2,6 +2,7 @@ package testcontainers
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -10,8 +11,9 @@ import (
 	"github.com/docker/docker/client"
 )
 
-// Logger is the default log instance
-var Logger Logging = log.New(os.Stderr, "", log.LstdFlags)
+// Logger is the default log instance. In verbose mode, it prints the messages
+// of level LevelInfo and above to the standard error.
+var Logger Logging = NewLeveledLogger(log.New(os.Stderr, "", log.LstdFlags), LevelInfo)
 
 func init() {
 	for _, arg := range os.Args {
@@ -27,6 +29,7 @@ func init() {
 // Validate our types implement the required interfaces.
 var (
 	_ Logging               = (*log.Logger)(nil)
+	_ LeveledLogging        = (*printfLogger)(nil)
 	_ ContainerCustomizer   = LoggerOption{}
 	_ GenericProviderOption = LoggerOption{}
 	_ DockerProviderOption  = LoggerOption{}
@@ -37,6 +40,155 @@ type Logging interface {
 	Printf(format string, v ...interface{})
 }
 
+// Level is the severity of a log message.
+type Level int
+
+const (
+	// LevelDebug is the level of the detailed messages, only useful to troubleshoot Testcontainers.
+	LevelDebug Level = iota
+	// LevelInfo is the level of the messages describing the lifecycle of the containers.
+	LevelInfo
+	// LevelWarn is the level of the messages reporting recoverable failures, e.g. retried operations.
+	LevelWarn
+	// LevelError is the level of the messages reporting failures which could not be recovered.
+	LevelError
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// LeveledLogging defines a Logger whose messages have a level. Printf logs at LevelInfo.
+//
+// The messages of Testcontainers are logged with the method of their level if the logger
+// implements LeveledLogging, otherwise they are all logged with Printf.
+type LeveledLogging interface {
+	Logging
+	Debugf(format string, v ...any)
+	Infof(format string, v ...any)
+	Warnf(format string, v ...any)
+	Errorf(format string, v ...any)
+}
+
+// ModuleLogging is an optional interface of the leveled loggers, adding the name
+// of a module to the messages logged by that module, e.g. as a structured field.
+type ModuleLogging interface {
+	WithModule(module string) LeveledLogging
+}
+
+// SetLogger replaces the default logger, used by the providers, the containers and the modules
+// unless another logger is set with WithLogger. It's not safe for concurrent use, so it must be
+// called before creating any container, e.g. in TestMain.
+func SetLogger(l LeveledLogging) {
+	Logger = l
+}
+
+// NewLeveledLogger adapts a printf-style logger to the LeveledLogging interface,
+// discarding the messages of a lower level than minLevel.
+func NewLeveledLogger(l Logging, minLevel Level) LeveledLogging {
+	return &printfLogger{logger: l, minLevel: minLevel}
+}
+
+// ModuleLogger returns the logger to be used by the given module: the default logger, with the name of the module
+// in its messages. If the default logger implements ModuleLogging, the name of the module is added by the logger.
+// Otherwise, the messages are prefixed with it.
+func ModuleLogger(module string) LeveledLogging {
+	return NewModuleLogger(Logger, module)
+}
+
+// NewModuleLogger returns the given logger, with the name of the module in its messages, e.g. for the logger
+// passed to a module with WithLogger. If the logger implements ModuleLogging, the name of the module is added
+// by the logger. Otherwise, the messages are prefixed with it, and logged with the method of their level
+// if the logger implements LeveledLogging, or with Printf otherwise.
+func NewModuleLogger(l Logging, module string) LeveledLogging {
+	if ml, ok := l.(ModuleLogging); ok {
+		return ml.WithModule(module)
+	}
+
+	return &printfLogger{logger: l, prefix: "[" + module + "] "}
+}
+
+// printfLogger is a LeveledLogging printing the messages of at least minLevel with a printf-style logger.
+type printfLogger struct {
+	logger   Logging
+	minLevel Level
+	prefix   string
+}
+
+func (p *printfLogger) logf(level Level, format string, v ...any) {
+	if level < p.minLevel {
+		return
+	}
+
+	logf(p.logger, level, p.prefix+format, v...)
+}
+
+// Printf implements Logging, logging at LevelInfo.
+func (p *printfLogger) Printf(format string, v ...any) {
+	p.logf(LevelInfo, format, v...)
+}
+
+// Debugf implements LeveledLogging.
+func (p *printfLogger) Debugf(format string, v ...any) {
+	p.logf(LevelDebug, format, v...)
+}
+
+// Infof implements LeveledLogging.
+func (p *printfLogger) Infof(format string, v ...any) {
+	p.logf(LevelInfo, format, v...)
+}
+
+// Warnf implements LeveledLogging.
+func (p *printfLogger) Warnf(format string, v ...any) {
+	p.logf(LevelWarn, format, v...)
+}
+
+// Errorf implements LeveledLogging.
+func (p *printfLogger) Errorf(format string, v ...any) {
+	p.logf(LevelError, format, v...)
+}
+
+// logf logs the message with the method of its level if the logger implements LeveledLogging,
+// or with Printf otherwise.
+func logf(l Logging, level Level, format string, v ...any) {
+	ll, ok := l.(LeveledLogging)
+	if !ok {
+		l.Printf(format, v...)
+		return
+	}
+
+	switch level {
+	case LevelDebug:
+		ll.Debugf(format, v...)
+	case LevelInfo:
+		ll.Infof(format, v...)
+	case LevelWarn:
+		ll.Warnf(format, v...)
+	default:
+		ll.Errorf(format, v...)
+	}
+}
+
+func debugf(l Logging, format string, v ...any) { logf(l, LevelDebug, format, v...) }
+
+func infof(l Logging, format string, v ...any) { logf(l, LevelInfo, format, v...) }
+
+func warnf(l Logging, format string, v ...any) { logf(l, LevelWarn, format, v...) }
+
+func errorf(l Logging, format string, v ...any) { logf(l, LevelError, format, v...) }
+
 type noopLogger struct{}
 
 // Printf implements Logging.
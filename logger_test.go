@@ -1,6 +1,8 @@
 package testcontainers
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -29,3 +31,142 @@ func TestWithLogger(t *testing.T) {
 		require.Equal(t, logger, opts.Logger)
 	})
 }
+
+// recordingLogger is a LeveledLogging recording the messages by level.
+type recordingLogger struct {
+	mtx  sync.Mutex
+	logs map[Level][]string
+}
+
+func (r *recordingLogger) record(level Level, format string, v ...any) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.logs == nil {
+		r.logs = map[Level][]string{}
+	}
+	r.logs[level] = append(r.logs[level], fmt.Sprintf(format, v...))
+}
+
+// messages returns the messages recorded at the given level.
+func (r *recordingLogger) messages(level Level) []string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.logs[level]
+}
+
+func (r *recordingLogger) Printf(format string, v ...any) { r.record(LevelInfo, format, v...) }
+func (r *recordingLogger) Debugf(format string, v ...any) { r.record(LevelDebug, format, v...) }
+func (r *recordingLogger) Infof(format string, v ...any)  { r.record(LevelInfo, format, v...) }
+func (r *recordingLogger) Warnf(format string, v ...any)  { r.record(LevelWarn, format, v...) }
+func (r *recordingLogger) Errorf(format string, v ...any) { r.record(LevelError, format, v...) }
+
+// printfRecorder is a printf-style Logging recording the messages.
+type printfRecorder struct {
+	msgs []string
+}
+
+func (p *printfRecorder) Printf(format string, v ...any) {
+	p.msgs = append(p.msgs, fmt.Sprintf(format, v...))
+}
+
+func TestNewLeveledLogger(t *testing.T) {
+	rec := &printfRecorder{}
+	logger := NewLeveledLogger(rec, LevelWarn)
+
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Printf("printf %d", 3)
+	logger.Warnf("warn %d", 4)
+	logger.Errorf("error %d", 5)
+
+	require.Equal(t, []string{"warn 4", "error 5"}, rec.msgs)
+}
+
+func TestLogf(t *testing.T) {
+	t.Run("leveled", func(t *testing.T) {
+		rec := &recordingLogger{}
+
+		debugf(rec, "debug")
+		infof(rec, "info")
+		warnf(rec, "warn")
+		errorf(rec, "error")
+
+		require.Equal(t, []string{"debug"}, rec.messages(LevelDebug))
+		require.Equal(t, []string{"info"}, rec.messages(LevelInfo))
+		require.Equal(t, []string{"warn"}, rec.messages(LevelWarn))
+		require.Equal(t, []string{"error"}, rec.messages(LevelError))
+	})
+
+	t.Run("printf", func(t *testing.T) {
+		rec := &printfRecorder{}
+
+		debugf(rec, "debug")
+		errorf(rec, "error")
+
+		require.Equal(t, []string{"debug", "error"}, rec.msgs)
+	})
+}
+
+// moduleRecordingLogger is a recordingLogger implementing ModuleLogging.
+type moduleRecordingLogger struct {
+	*recordingLogger
+	module string
+}
+
+func (m moduleRecordingLogger) WithModule(module string) LeveledLogging {
+	m.module = module
+	return m
+}
+
+func TestModuleLogger(t *testing.T) {
+	previousLogger := Logger
+	t.Cleanup(func() { Logger = previousLogger })
+
+	t.Run("prefix", func(t *testing.T) {
+		rec := &recordingLogger{}
+		SetLogger(rec)
+
+		ModuleLogger("postgres").Warnf("could not connect: %s", "refused")
+
+		require.Equal(t, []string{"[postgres] could not connect: refused"}, rec.messages(LevelWarn))
+	})
+
+	t.Run("module-logging", func(t *testing.T) {
+		rec := &recordingLogger{}
+		SetLogger(moduleRecordingLogger{recordingLogger: rec})
+
+		logger := ModuleLogger("postgres")
+		logger.Infof("ready")
+
+		require.Equal(t, "postgres", logger.(moduleRecordingLogger).module)
+		require.Equal(t, []string{"ready"}, rec.messages(LevelInfo))
+	})
+}
+
+func TestNewModuleLogger(t *testing.T) {
+	t.Run("leveled", func(t *testing.T) {
+		rec := &recordingLogger{}
+
+		NewModuleLogger(rec, "neo4j").Warnf("setting %q is overwritten", "some.key")
+
+		require.Equal(t, []string{`[neo4j] setting "some.key" is overwritten`}, rec.messages(LevelWarn))
+	})
+
+	t.Run("printf", func(t *testing.T) {
+		rec := &printfRecorder{}
+
+		NewModuleLogger(rec, "localstack").Debugf("debug")
+
+		require.Equal(t, []string{"[localstack] debug"}, rec.msgs)
+	})
+
+	t.Run("module-logging", func(t *testing.T) {
+		rec := &recordingLogger{}
+
+		logger := NewModuleLogger(moduleRecordingLogger{recordingLogger: rec}, "neo4j")
+
+		require.Equal(t, "neo4j", logger.(moduleRecordingLogger).module)
+	})
+}
@@ -0,0 +1,178 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go/internal"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// modulesPath is the import path of the modules of Testcontainers for Go.
+const modulesPath = "github.com/testcontainers/testcontainers-go/modules/"
+
+// ModuleInfo identifies the module which created a container, read from its provenance labels.
+// It's empty for the containers not created by a module.
+type ModuleInfo struct {
+	Name    string // name of the module, e.g. "minio"
+	Version string // version of the module, e.g. "0.34.0"
+}
+
+// WithModuleInfo stamps the container with the provenance labels identifying the module which creates it,
+// so that leaked containers can be traced back to it. The version is the one of the module in the build
+// info of the binary, or the version of Testcontainers for Go, as the modules are released along with it.
+// It's used by the Run function of the modules after applying the options of the caller, and returns an
+// error wrapping ErrReservedLabel if the request already defines a provenance label with another value.
+func WithModuleInfo(name string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		labels := map[string]string{
+			core.LabelModule:        name,
+			core.LabelModuleVersion: moduleVersion(name),
+		}
+
+		for k, v := range labels {
+			if existing, ok := req.Labels[k]; ok && existing != v {
+				return fmt.Errorf("%w: %s is %q, not %q", ErrReservedLabel, k, existing, v)
+			}
+		}
+
+		if req.Labels == nil {
+			req.Labels = make(map[string]string)
+		}
+
+		for k, v := range labels {
+			req.Labels[k] = v
+		}
+
+		return nil
+	}
+}
+
+// moduleVersion returns the version of the given module in the build info of the binary,
+// or the version of Testcontainers for Go if it's not a dependency of the binary, e.g. in
+// the tests of the module itself.
+func moduleVersion(name string) string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == modulesPath+name && dep.Version != "" && dep.Version != "(devel)" {
+				return strings.TrimPrefix(dep.Version, "v")
+			}
+		}
+	}
+
+	return internal.Version
+}
+
+// moduleInfoFromLabels returns the module which created a container, from its labels.
+func moduleInfoFromLabels(labels map[string]string) ModuleInfo {
+	return ModuleInfo{
+		Name:    labels[core.LabelModule],
+		Version: labels[core.LabelModuleVersion],
+	}
+}
+
+// ModuleInfo returns the module which created the container, see WithModuleInfo.
+// It's empty if the container was not created by a module.
+func (c *DockerContainer) ModuleInfo() ModuleInfo {
+	return c.moduleInfo
+}
+
+// EnvValidator checks the value of an env var required by a module, returning an error describing
+// the expected value, e.g. "must not be empty". The value is empty if the env var is not set.
+type EnvValidator func(value string) error
+
+// EnvNotEmpty is an EnvValidator requiring the value not to be empty or blank.
+func EnvNotEmpty(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("must not be empty")
+	}
+
+	return nil
+}
+
+// InvalidEnvError is returned when an env var required by a module has an invalid value,
+// see WithEnvValidators.
+type InvalidEnvError struct {
+	Module string // name of the module, e.g. "minio"
+	Key    string // name of the env var, e.g. "MINIO_ROOT_PASSWORD"
+	Err    error  // error of the validator
+
+	// Overridden is true if the value was changed by an option of the caller,
+	// from the default value of the module.
+	Overridden bool
+}
+
+func (e *InvalidEnvError) Error() string {
+	msg := fmt.Sprintf("%s: %s %s", e.Module, e.Key, e.Err)
+	if e.Overridden {
+		msg += " (overridden by a customizer)"
+	}
+
+	return msg
+}
+
+func (e *InvalidEnvError) Unwrap() error {
+	return e.Err
+}
+
+// envValidators are the validators of the env vars required by a module, with the default values of
+// the env vars when they were registered, see WithEnvValidators.
+type envValidators struct {
+	module     string
+	validators map[string]EnvValidator
+	defaults   map[string]string
+}
+
+// WithEnvValidators registers validators of the env vars required by a module, keyed by name, so that a value
+// set by a generic option of the caller, e.g. an empty password set with WithEnv, fails clearly instead of
+// crashing the container. It's used by the Run function of the modules before applying the options of the
+// caller, so that the values changed by them are reported as overridden. The env vars are validated once all
+// the options are applied, before the container is created, returning an *InvalidEnvError.
+func WithEnvValidators(module string, validators map[string]EnvValidator) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		defaults := make(map[string]string, len(validators))
+		for key := range validators {
+			if v, ok := req.Env[key]; ok {
+				defaults[key] = v
+			}
+		}
+
+		req.envValidators = append(req.envValidators, envValidators{
+			module:     module,
+			validators: validators,
+			defaults:   defaults,
+		})
+
+		return nil
+	}
+}
+
+// validateEnv runs the validators of the env vars registered with WithEnvValidators.
+func (c *ContainerRequest) validateEnv() error {
+	for _, ev := range c.envValidators {
+		keys := make([]string, 0, len(ev.validators))
+		for key := range ev.validators {
+			keys = append(keys, key)
+		}
+		// validate in a stable order, for a stable error.
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value, ok := c.Env[key]
+			if err := ev.validators[key](value); err != nil {
+				def, hasDefault := ev.defaults[key]
+				return &InvalidEnvError{
+					Module:     ev.module,
+					Key:        key,
+					Err:        err,
+					Overridden: ok != hasDefault || value != def,
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestWithModuleInfo(t *testing.T) {
+	t.Run("nil-labels", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		require.NoError(t, WithModuleInfo("minio")(&req))
+
+		require.Equal(t, "minio", req.Labels[core.LabelModule])
+		require.Equal(t, internal.Version, req.Labels[core.LabelModuleVersion])
+		require.Equal(t, ModuleInfo{Name: "minio", Version: internal.Version}, moduleInfoFromLabels(req.Labels))
+	})
+
+	t.Run("same-labels", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		require.NoError(t, WithModuleInfo("minio")(&req))
+		require.NoError(t, WithModuleInfo("minio")(&req))
+
+		require.Equal(t, "minio", req.Labels[core.LabelModule])
+	})
+
+	t.Run("conflicting-labels", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Labels: map[string]string{
+					"foo":            "bar",
+					core.LabelModule: "openldap",
+				},
+			},
+		}
+
+		err := WithModuleInfo("minio")(&req)
+		require.ErrorIs(t, err, ErrReservedLabel)
+		require.ErrorContains(t, err, core.LabelModule)
+
+		// the labels are left untouched.
+		require.Equal(t, map[string]string{"foo": "bar", core.LabelModule: "openldap"}, req.Labels)
+	})
+
+	t.Run("not-a-module", func(t *testing.T) {
+		require.Equal(t, ModuleInfo{}, moduleInfoFromLabels(map[string]string{"foo": "bar"}))
+	})
+}
+
+func TestWithEnvValidators(t *testing.T) {
+	newRequest := func(t *testing.T, env map[string]string) GenericContainerRequest {
+		t.Helper()
+
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Env: map[string]string{"MINIO_ROOT_USER": "minioadmin", "MINIO_ROOT_PASSWORD": "minioadmin"},
+			},
+		}
+		require.NoError(t, WithEnvValidators("minio", map[string]EnvValidator{
+			"MINIO_ROOT_USER":     EnvNotEmpty,
+			"MINIO_ROOT_PASSWORD": EnvNotEmpty,
+		})(&req))
+		require.NoError(t, WithEnv(env)(&req))
+
+		return req
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		req := newRequest(t, nil)
+		require.NoError(t, req.validateEnv())
+	})
+
+	t.Run("valid-override", func(t *testing.T) {
+		req := newRequest(t, map[string]string{"MINIO_ROOT_PASSWORD": "secret"})
+		require.NoError(t, req.validateEnv())
+	})
+
+	t.Run("empty-override", func(t *testing.T) {
+		req := newRequest(t, map[string]string{"MINIO_ROOT_PASSWORD": " "})
+
+		err := req.validateEnv()
+		var envErr *InvalidEnvError
+		require.ErrorAs(t, err, &envErr)
+		require.Equal(t, "minio", envErr.Module)
+		require.Equal(t, "MINIO_ROOT_PASSWORD", envErr.Key)
+		require.True(t, envErr.Overridden)
+		require.EqualError(t, err, "minio: MINIO_ROOT_PASSWORD must not be empty (overridden by a customizer)")
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		req := newRequest(t, nil)
+		delete(req.Env, "MINIO_ROOT_USER")
+
+		var envErr *InvalidEnvError
+		require.ErrorAs(t, req.validateEnv(), &envErr)
+		require.Equal(t, "MINIO_ROOT_USER", envErr.Key)
+		require.True(t, envErr.Overridden)
+	})
+
+	t.Run("invalid-default", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		require.NoError(t, WithEnvValidators("minio", map[string]EnvValidator{"MINIO_ROOT_USER": EnvNotEmpty})(&req))
+
+		err := req.validateEnv()
+		var envErr *InvalidEnvError
+		require.ErrorAs(t, err, &envErr)
+		require.False(t, envErr.Overridden)
+		require.EqualError(t, err, "minio: MINIO_ROOT_USER must not be empty")
+	})
+
+	t.Run("before-provider", func(t *testing.T) {
+		req := newRequest(t, map[string]string{"MINIO_ROOT_PASSWORD": ""})
+		// an unknown provider fails if it's resolved.
+		req.ProviderType = ProviderType(-1)
+
+		ctr, err := GenericContainer(context.Background(), req)
+		require.Nil(t, ctr)
+
+		var envErr *InvalidEnvError
+		require.ErrorAs(t, err, &envErr)
+		require.Equal(t, "MINIO_ROOT_PASSWORD", envErr.Key)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/testcontainers/testcontainers-go/modulegen/cmd/modules"
+	"github.com/testcontainers/testcontainers-go/modulegen/cmd/verify"
 )
 
 var NewRootCmd = &cobra.Command{
@@ -14,4 +15,5 @@ var NewRootCmd = &cobra.Command{
 
 func init() {
 	NewRootCmd.AddCommand(modules.NewCmd)
+	NewRootCmd.AddCommand(verify.VerifyModuleCmd)
 }
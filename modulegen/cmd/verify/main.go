@@ -0,0 +1,59 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/verify"
+)
+
+const (
+	imageFlag = "image"
+	nameFlag  = "name"
+)
+
+var (
+	moduleName  string
+	moduleImage string
+)
+
+var VerifyModuleCmd = &cobra.Command{
+	Use:   "verify-module",
+	Short: "Verify a Module end-to-end",
+	Long:  "Run the self-test of a Module, which runs it with its defaults and executes its smoke check, reporting the result with timings in JSON",
+	// the JSON report is the only output of the command, the error is printed by the caller
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := context.GetRootContext()
+		if err != nil {
+			return fmt.Errorf(">> could not get the root dir: %w", err)
+		}
+
+		result := verify.Module(ctx, moduleName, moduleImage)
+
+		bs, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf(">> could not marshal the result: %w", err)
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), string(bs))
+
+		if result.Status == verify.StatusFail {
+			return fmt.Errorf(">> module %s failed verification: %s", result.Module, result.Error)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	VerifyModuleCmd.Flags().StringVarP(&moduleName, nameFlag, "n", "", "Name of the module to verify, as in the modules directory.")
+	VerifyModuleCmd.Flags().StringVarP(&moduleImage, imageFlag, "i", "", "Fully-qualified name of the Docker image to be used by the module")
+
+	_ = VerifyModuleCmd.MarkFlagRequired(imageFlag)
+	_ = VerifyModuleCmd.MarkFlagRequired(nameFlag)
+}
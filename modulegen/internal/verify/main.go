@@ -0,0 +1,224 @@
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/context"
+)
+
+const (
+	// selfTestFile is the file of a module providing its self-test, in the TestSelfTest function.
+	selfTestFile = "selftest_test.go"
+
+	// selfTestName is the name of the test running the self-test of a module, with the
+	// run, self-test and terminate subtests.
+	selfTestName = "TestSelfTest"
+
+	// selfTestImageEnv is the environment variable passing the image to the self-test of a module.
+	selfTestImageEnv = "TESTCONTAINERS_SELFTEST_IMAGE"
+)
+
+// Status represents the outcome of the verification of a module
+type Status string
+
+const (
+	StatusPass    Status = "pass"
+	StatusFail    Status = "fail"
+	StatusSkipped Status = "skipped"
+)
+
+// Result represents the outcome of the verification of a module, including the timings
+// of each step, in milliseconds. It is meant to be serialised as JSON.
+type Result struct {
+	Module  string  `json:"module"`
+	Image   string  `json:"image"`
+	Status  Status  `json:"status"`
+	Error   string  `json:"error,omitempty"`
+	Timings Timings `json:"timings"`
+}
+
+// Timings represents the time spent in each step of the verification of a module, in milliseconds
+type Timings struct {
+	Run       int64 `json:"run_ms"`
+	SelfTest  int64 `json:"self_test_ms"`
+	Terminate int64 `json:"terminate_ms"`
+	Total     int64 `json:"total_ms"`
+}
+
+// testStatuses maps the actions of the events ending a test to the status of the verification.
+var testStatuses = map[string]Status{
+	"pass": StatusPass,
+	"fail": StatusFail,
+	"skip": StatusSkipped,
+}
+
+// testEvent is an event of the JSON output of go test, see go doc test2json.
+type testEvent struct {
+	Action  string  `json:"Action"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// Modules returns the names of the modules providing a self-test, sorted alphabetically
+func Modules(ctx context.Context) ([]string, error) {
+	modules, err := ctx.GetModules()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(modules))
+	for _, name := range modules {
+		if hasSelfTest(ctx, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// hasSelfTest returns true if the module with the given name provides a self-test.
+func hasSelfTest(ctx context.Context, name string) bool {
+	_, err := os.Stat(filepath.Join(ctx.RootDir, "modules", name, selfTestFile))
+	return err == nil
+}
+
+// Module runs the self-test of the module with the given name using the given image, with
+// go test in the directory of the module, so that modulegen doesn't depend on the modules.
+// The self-test is discovered by convention: the TestSelfTest function of the selftest_test.go
+// file of the module, which runs the module, executes its SelfTest function and terminates
+// the container in the run, self-test and terminate subtests, timed by go test.
+// Modules without a self-test are reported as skipped.
+func Module(ctx context.Context, name string, img string) (result Result) {
+	result = Result{
+		Module: name,
+		Image:  img,
+	}
+
+	if !hasSelfTest(ctx, name) {
+		result.Status = StatusSkipped
+		result.Error = fmt.Sprintf("module %s does not provide a self-test", name)
+		return result
+	}
+
+	start := time.Now()
+	defer func() {
+		result.Timings.Total = time.Since(start).Milliseconds()
+	}()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "test", "-json", "-count=1", "-run", "^"+selfTestName+"$", ".")
+	cmd.Dir = filepath.Join(ctx.RootDir, "modules", name)
+	cmd.Env = append(os.Environ(), selfTestImageEnv+"="+img)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	status, err := parseTestEvents(&stdout, &result.Timings)
+	switch {
+	case err != nil:
+		result.Status = StatusFail
+		result.Error = err.Error()
+	case status == "":
+		// the test didn't run, e.g. the module doesn't compile.
+		result.Status = StatusFail
+		result.Error = fmt.Sprintf("go test: %s", strings.TrimSpace(stderr.String()))
+		if runErr != nil && stderr.Len() == 0 {
+			result.Error = fmt.Sprintf("go test: %s", runErr)
+		}
+	case status == StatusSkipped:
+		result.Status = StatusFail
+		result.Error = fmt.Sprintf("%s was skipped", selfTestName)
+	default:
+		result.Status = status
+	}
+
+	return result
+}
+
+// parseTestEvents reads the JSON output of go test for the self-test of a module, setting the
+// timings of its subtests. It returns the status of the self-test, which is empty if it didn't
+// run, and an error with the output of the first failed subtest if the self-test failed.
+func parseTestEvents(r *bytes.Buffer, timings *Timings) (Status, error) {
+	var (
+		status  Status
+		outputs = map[string][]string{}
+		failed  []string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var ev testEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			// not an event, e.g. the build output.
+			continue
+		}
+
+		if ev.Test == "" || (ev.Test != selfTestName && !strings.HasPrefix(ev.Test, selfTestName+"/")) {
+			continue
+		}
+
+		if ev.Action == "output" {
+			outputs[ev.Test] = append(outputs[ev.Test], ev.Output)
+			continue
+		}
+
+		if _, ok := testStatuses[ev.Action]; !ok {
+			continue
+		}
+
+		elapsed := int64(ev.Elapsed * 1000)
+		switch ev.Test {
+		case selfTestName:
+			status = testStatuses[ev.Action]
+		case selfTestName + "/run":
+			timings.Run = elapsed
+		case selfTestName + "/self-test":
+			timings.SelfTest = elapsed
+		case selfTestName + "/terminate":
+			timings.Terminate = elapsed
+		}
+
+		if ev.Action == "fail" && ev.Test != selfTestName {
+			failed = append(failed, ev.Test)
+		}
+	}
+
+	if status != StatusFail {
+		return status, nil
+	}
+
+	if len(failed) == 0 {
+		return status, fmt.Errorf("%s: %s", selfTestName, failureOutput(outputs[selfTestName]))
+	}
+
+	// the steps are reported in the order they failed, the first one being the cause.
+	step := strings.TrimPrefix(failed[0], selfTestName+"/")
+	return status, errors.New(step + ": " + failureOutput(outputs[failed[0]]))
+}
+
+// failureOutput returns the output of a failed test, without the lines reported by go test
+// itself, such as the === RUN and --- FAIL ones.
+func failureOutput(lines []string) string {
+	var msgs []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "===") || strings.HasPrefix(line, "---") {
+			continue
+		}
+
+		msgs = append(msgs, line)
+	}
+
+	return strings.Join(msgs, " ")
+}
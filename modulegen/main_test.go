@@ -430,7 +430,8 @@ func assertModuleContent(t *testing.T, module context.TestcontainersModule, exam
 	assert.Equal(t, "\t\tImage: img,", data[17])
 	assert.Equal(t, "\t\tif err := opt.Customize(&genericContainerReq); err != nil {", data[26])
 	assert.Equal(t, "\t\t\treturn nil, fmt.Errorf(\"customize: %w\", err)", data[27])
-	assert.Equal(t, "\treturn &"+containerName+"{Container: container}, nil", data[36])
+	assert.Equal(t, "\tif err := testcontainers.WithModuleInfo(\""+lower+"\").Customize(&genericContainerReq); err != nil {", data[31])
+	assert.Equal(t, "\treturn &"+containerName+"{Container: container}, nil", data[40])
 }
 
 // assert content GitHub workflow for the module
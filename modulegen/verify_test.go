@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modulegen/cmd"
+	"github.com/testcontainers/testcontainers-go/modulegen/internal/verify"
+)
+
+func runVerifyModule(t *testing.T, name string, image string) (verify.Result, error) {
+	t.Helper()
+
+	out := &bytes.Buffer{}
+	cmd.NewRootCmd.SetOut(out)
+	cmd.NewRootCmd.SetArgs([]string{"verify-module", "--name", name, "--image", image})
+
+	err := cmd.NewRootCmd.Execute()
+
+	var result verify.Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result), out.String())
+
+	return result, err
+}
+
+func TestVerifyModule(t *testing.T) {
+	tests := []struct {
+		name  string
+		image string
+	}{
+		{name: "minio", image: "minio/minio:RELEASE.2024-01-16T16-07-38Z"},
+		{name: "openldap", image: "bitnami/openldap:2.6.6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runVerifyModule(t, tt.name, tt.image)
+			require.NoError(t, err)
+			require.Equal(t, verify.StatusPass, result.Status, result.Error)
+			require.Equal(t, tt.name, result.Module)
+			require.Equal(t, tt.image, result.Image)
+			require.Positive(t, result.Timings.Run)
+			require.GreaterOrEqual(t, result.Timings.Total, result.Timings.Run+result.Timings.SelfTest)
+		})
+	}
+
+	t.Run("without-self-test", func(t *testing.T) {
+		result, err := runVerifyModule(t, "redis", "redis:7")
+		require.NoError(t, err)
+		require.Equal(t, verify.StatusSkipped, result.Status)
+		require.Zero(t, result.Timings.Total)
+	})
+
+	t.Run("failing-module", func(t *testing.T) {
+		result, err := runVerifyModule(t, "minio", "minio/minio:this-tag-does-not-exist")
+		require.Error(t, err)
+		require.Equal(t, verify.StatusFail, result.Status)
+		require.NotEmpty(t, result.Error)
+	})
+}
@@ -108,6 +108,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("artemis").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
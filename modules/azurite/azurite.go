@@ -120,6 +120,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("azurite").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -113,6 +113,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("cassandra").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -487,6 +487,7 @@ func (d *dockerCompose) lookupNetworks(ctx context.Context) error {
 			ID:     n.ID,
 			Name:   n.Name,
 			Driver: n.Driver,
+			Labels: n.Labels,
 		}
 
 		d.networks[n.ID] = dn
@@ -112,6 +112,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("couchbase").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -298,59 +302,58 @@ func (c *CouchbaseContainer) configureAdminUser(ctx context.Context) error {
 
 func (c *CouchbaseContainer) configureExternalPorts(ctx context.Context) error {
 	host, _ := c.Host(ctx)
-	mgmt, _ := c.MappedPort(ctx, MGMT_PORT)
-	mgmtSSL, _ := c.MappedPort(ctx, MGMT_SSL_PORT)
-	body := map[string]string{
-		"hostname": host,
-		"mgmt":     mgmt.Port(),
-		"mgmtSSL":  mgmtSSL.Port(),
+
+	// the ports of the enabled services, keyed by their name in the alternate addresses.
+	ports := map[string]nat.Port{
+		"mgmt":    MGMT_PORT,
+		"mgmtSSL": MGMT_SSL_PORT,
 	}
 
 	if contains(c.config.enabledServices, kv) {
-		kv, _ := c.MappedPort(ctx, KV_PORT)
-		kvSSL, _ := c.MappedPort(ctx, KV_SSL_PORT)
-		capi, _ := c.MappedPort(ctx, VIEW_PORT)
-		capiSSL, _ := c.MappedPort(ctx, VIEW_SSL_PORT)
-
-		body["kv"] = kv.Port()
-		body["kvSSL"] = kvSSL.Port()
-		body["capi"] = capi.Port()
-		body["capiSSL"] = capiSSL.Port()
+		ports["kv"] = KV_PORT
+		ports["kvSSL"] = KV_SSL_PORT
+		ports["capi"] = VIEW_PORT
+		ports["capiSSL"] = VIEW_SSL_PORT
 	}
 
 	if contains(c.config.enabledServices, query) {
-		n1ql, _ := c.MappedPort(ctx, QUERY_PORT)
-		n1qlSSL, _ := c.MappedPort(ctx, QUERY_SSL_PORT)
-
-		body["n1ql"] = n1ql.Port()
-		body["n1qlSSL"] = n1qlSSL.Port()
+		ports["n1ql"] = QUERY_PORT
+		ports["n1qlSSL"] = QUERY_SSL_PORT
 	}
 
 	if contains(c.config.enabledServices, search) {
-		fts, _ := c.MappedPort(ctx, SEARCH_PORT)
-		ftsSSL, _ := c.MappedPort(ctx, SEARCH_SSL_PORT)
-
-		body["fts"] = fts.Port()
-		body["ftsSSL"] = ftsSSL.Port()
+		ports["fts"] = SEARCH_PORT
+		ports["ftsSSL"] = SEARCH_SSL_PORT
 	}
 
 	if contains(c.config.enabledServices, analytics) {
-		cbas, _ := c.MappedPort(ctx, ANALYTICS_PORT)
-		cbasSSL, _ := c.MappedPort(ctx, ANALYTICS_SSL_PORT)
-
-		body["cbas"] = cbas.Port()
-		body["cbasSSL"] = cbasSSL.Port()
+		ports["cbas"] = ANALYTICS_PORT
+		ports["cbasSSL"] = ANALYTICS_SSL_PORT
 	}
 
 	if contains(c.config.enabledServices, eventing) {
-		eventingAdminPort, _ := c.MappedPort(ctx, EVENTING_PORT)
-		eventingSSL, _ := c.MappedPort(ctx, EVENTING_SSL_PORT)
+		ports["eventingAdminPort"] = EVENTING_PORT
+		ports["eventingSSL"] = EVENTING_SSL_PORT
+	}
+
+	containerPorts := make([]nat.Port, 0, len(ports))
+	for _, port := range ports {
+		containerPorts = append(containerPorts, port)
+	}
 
-		body["eventingAdminPort"] = eventingAdminPort.Port()
-		body["eventingSSL"] = eventingSSL.Port()
+	mappedPorts, err := c.MappedPorts(ctx, containerPorts...)
+	if err != nil {
+		return fmt.Errorf("mapped ports: %w", err)
+	}
+
+	body := map[string]string{
+		"hostname": host,
+	}
+	for name, port := range ports {
+		body[name] = mappedPorts[port].Port()
 	}
 
-	_, err := c.doHttpRequest(ctx, MGMT_PORT, "/node/controller/setupAlternateAddresses/external", http.MethodPut, body, true)
+	_, err = c.doHttpRequest(ctx, MGMT_PORT, "/node/controller/setupAlternateAddresses/external", http.MethodPut, body, true)
 
 	return err
 }
@@ -94,6 +94,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		req.LifecycleHooks[0].PostCreates = append(req.LifecycleHooks[0].PostCreates, configureJvmOpts)
 	}
 
+	if err := testcontainers.WithModuleInfo("elasticsearch").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
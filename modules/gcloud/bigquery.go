@@ -33,6 +33,10 @@ func RunBigQuery(ctx context.Context, img string, opts ...testcontainers.Contain
 
 	req.Cmd = []string{"--project", settings.ProjectID}
 
+	if err := testcontainers.WithModuleInfo("gcloud").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
@@ -36,6 +36,10 @@ func RunBigTable(ctx context.Context, img string, opts ...testcontainers.Contain
 		"gcloud beta emulators bigtable start --host-port 0.0.0.0:9000 " + fmt.Sprintf("--project=%s", settings.ProjectID),
 	}
 
+	if err := testcontainers.WithModuleInfo("gcloud").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
@@ -36,6 +36,10 @@ func RunDatastore(ctx context.Context, img string, opts ...testcontainers.Contai
 		"gcloud beta emulators datastore start --host-port 0.0.0.0:8081 " + fmt.Sprintf("--project=%s", settings.ProjectID),
 	}
 
+	if err := testcontainers.WithModuleInfo("gcloud").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
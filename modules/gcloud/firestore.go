@@ -36,6 +36,10 @@ func RunFirestore(ctx context.Context, img string, opts ...testcontainers.Contai
 		"gcloud beta emulators firestore start --host-port 0.0.0.0:8080 " + fmt.Sprintf("--project=%s", settings.ProjectID),
 	}
 
+	if err := testcontainers.WithModuleInfo("gcloud").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
@@ -36,6 +36,10 @@ func RunPubsub(ctx context.Context, img string, opts ...testcontainers.Container
 		"gcloud beta emulators pubsub start --host-port 0.0.0.0:8085 " + fmt.Sprintf("--project=%s", settings.ProjectID),
 	}
 
+	if err := testcontainers.WithModuleInfo("gcloud").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
@@ -29,6 +29,10 @@ func RunSpanner(ctx context.Context, img string, opts ...testcontainers.Containe
 		return nil, err
 	}
 
+	if err := testcontainers.WithModuleInfo("gcloud").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
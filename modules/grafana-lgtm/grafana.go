@@ -43,6 +43,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("grafana-lgtm").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -56,7 +60,7 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		return c, err
 	}
 
-	testcontainers.Logger.Printf("Access to the Grafana dashboard: %s", url)
+	testcontainers.ModuleLogger("grafana-lgtm").Infof("Access to the Grafana dashboard: %s", url)
 
 	return c, nil
 }
@@ -108,6 +108,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 
 	configureControllerQuorumVoters(&genericContainerReq)
 
+	if err := testcontainers.WithModuleInfo("kafka").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -113,7 +113,11 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 	if err != nil {
 		return nil, err
 	}
-	localStackReq.GenericContainerRequest.Logger.Printf("Setting %s to %s (%s)\n", envVar, req.Env[envVar], hostnameExternalReason)
+	testcontainers.NewModuleLogger(localStackReq.GenericContainerRequest.Logger, "localstack").Infof("Setting %s to %s (%s)", envVar, req.Env[envVar], hostnameExternalReason)
+
+	if err := testcontainers.WithModuleInfo("localstack").Customize(&localStackReq.GenericContainerRequest); err != nil {
+		return nil, err
+	}
 
 	container, err := testcontainers.GenericContainer(ctx, localStackReq.GenericContainerRequest)
 	if err != nil {
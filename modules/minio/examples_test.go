@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"log"
 
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
 	"github.com/testcontainers/testcontainers-go/modules/minio"
 )
 
@@ -35,3 +38,50 @@ func ExampleRun() {
 	// Output:
 	// true
 }
+
+func ExampleRun_connect() {
+	ctx := context.Background()
+
+	minioContainer, err := minio.Run(ctx, "minio/minio:RELEASE.2024-01-16T16-07-38Z")
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := minioContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err)
+		}
+	}()
+
+	// connectToMinio {
+	connectionString, err := minioContainer.ConnectionString(ctx)
+	if err != nil {
+		log.Fatalf("failed to get connection string: %s", err) // nolint:gocritic
+	}
+
+	client, err := miniogo.New(connectionString, &miniogo.Options{
+		Creds:  credentials.NewStaticV4(minioContainer.Username, minioContainer.Password, ""),
+		Secure: false,
+	})
+	if err != nil {
+		log.Fatalf("failed to create client: %s", err)
+	}
+	// }
+
+	if err := client.MakeBucket(ctx, "testcontainers", miniogo.MakeBucketOptions{}); err != nil {
+		log.Fatalf("failed to create bucket: %s", err)
+	}
+
+	buckets, err := client.ListBuckets(ctx)
+	if err != nil {
+		log.Fatalf("failed to list buckets: %s", err)
+	}
+
+	for _, bucket := range buckets {
+		fmt.Println(bucket.Name)
+	}
+
+	// Output:
+	// testcontainers
+}
@@ -2,7 +2,6 @@ package minio
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -13,6 +12,12 @@ const (
 	defaultPassword = "minioadmin"
 )
 
+// requiredEnv validates the env vars required by the container, once the options of the caller are applied.
+var requiredEnv = map[string]testcontainers.EnvValidator{
+	"MINIO_ROOT_USER":     testcontainers.EnvNotEmpty,
+	"MINIO_ROOT_PASSWORD": testcontainers.EnvNotEmpty,
+}
+
 // MinioContainer represents the Minio container type used in the module
 type MinioContainer struct {
 	testcontainers.Container
@@ -45,15 +50,7 @@ func WithPassword(password string) testcontainers.CustomizeRequestOption {
 // ConnectionString returns the connection string for the minio container, using the default 9000 port, and
 // obtaining the host and exposed port from the container.
 func (c *MinioContainer) ConnectionString(ctx context.Context) (string, error) {
-	host, err := c.Host(ctx)
-	if err != nil {
-		return "", err
-	}
-	port, err := c.MappedPort(ctx, "9000/tcp")
-	if err != nil {
-		return "", err
-	}
-	return fmt.Sprintf("%s:%s", host, port.Port()), nil
+	return c.PortEndpoint(ctx, "9000/tcp", "")
 }
 
 // Deprecated: use Run instead
@@ -80,16 +77,18 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	if err := testcontainers.WithEnvValidators("minio", requiredEnv).Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	for _, opt := range opts {
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, err
 		}
 	}
 
-	username := req.Env["MINIO_ROOT_USER"]
-	password := req.Env["MINIO_ROOT_PASSWORD"]
-	if username == "" || password == "" {
-		return nil, fmt.Errorf("username or password has not been set")
+	if err := testcontainers.WithModuleInfo("minio").Customize(&genericContainerReq); err != nil {
+		return nil, err
 	}
 
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
@@ -97,5 +96,9 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		return nil, err
 	}
 
-	return &MinioContainer{Container: container, Username: username, Password: password}, nil
+	return &MinioContainer{
+		Container: container,
+		Username:  genericContainerReq.Env["MINIO_ROOT_USER"],
+		Password:  genericContainerReq.Env["MINIO_ROOT_PASSWORD"],
+	}, nil
 }
@@ -2,6 +2,7 @@ package minio_test
 
 import (
 	"context"
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 
+	"github.com/testcontainers/testcontainers-go"
 	tcminio "github.com/testcontainers/testcontainers-go/modules/minio"
 )
 
@@ -29,6 +31,30 @@ func TestMinio(t *testing.T) {
 		}
 	})
 
+	// the credentials are set in the env of the container.
+	user, ok, err := container.EnvValue(ctx, "MINIO_ROOT_USER")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || user != "thisismyuser" {
+		t.Fatalf("expected MINIO_ROOT_USER to be %q, got %q", "thisismyuser", user)
+	}
+
+	// the container is labeled with the module which created it.
+	inspect, err := container.Inspect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if module := inspect.Config.Labels["org.testcontainers.module"]; module != "minio" {
+		t.Fatalf("expected the module label to be %q, got %q", "minio", module)
+	}
+	if version := inspect.Config.Labels["org.testcontainers.module.version"]; version == "" {
+		t.Fatal("expected the module version label to be set")
+	}
+	if info := container.ModuleInfo(); info.Name != "minio" || info.Version != inspect.Config.Labels["org.testcontainers.module.version"] {
+		t.Fatalf("unexpected module info: %+v", info)
+	}
+
 	// perform assertions
 	// connectionString {
 	url, err := container.ConnectionString(ctx)
@@ -80,3 +106,37 @@ func TestMinio(t *testing.T) {
 		t.Fatalf("expected %d; got %d", contentLength, n)
 	}
 }
+
+func TestMinio_invalidEnv(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		key string
+		msg string
+	}{
+		{key: "MINIO_ROOT_USER", msg: "minio: MINIO_ROOT_USER must not be empty (overridden by a customizer)"},
+		{key: "MINIO_ROOT_PASSWORD", msg: "minio: MINIO_ROOT_PASSWORD must not be empty (overridden by a customizer)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			container, err := tcminio.Run(ctx, "minio/minio:RELEASE.2024-01-16T16-07-38Z", testcontainers.WithEnv(map[string]string{tt.key: ""}))
+			if container != nil {
+				_ = container.Terminate(ctx)
+			}
+
+			var envErr *testcontainers.InvalidEnvError
+			if !errors.As(err, &envErr) {
+				t.Fatalf("expected an InvalidEnvError, got: %v", err)
+			}
+
+			if envErr.Key != tt.key || !envErr.Overridden {
+				t.Fatalf("unexpected error: %#v", envErr)
+			}
+
+			if err.Error() != tt.msg {
+				t.Fatalf("expected error %q, got %q", tt.msg, err.Error())
+			}
+		})
+	}
+}
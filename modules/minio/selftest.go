@@ -0,0 +1,44 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// selfTestBucket is the name of the bucket created by SelfTest.
+const selfTestBucket = "testcontainers-selftest"
+
+// SelfTest verifies that the Minio container is able to serve requests end-to-end,
+// creating a bucket with the container credentials and checking that it exists.
+func SelfTest(ctx context.Context, ctr *MinioContainer) error {
+	url, err := ctr.ConnectionString(ctx)
+	if err != nil {
+		return fmt.Errorf("connection string: %w", err)
+	}
+
+	client, err := minio.New(url, &minio.Options{
+		Creds:  credentials.NewStaticV4(ctr.Username, ctr.Password, ""),
+		Secure: false,
+	})
+	if err != nil {
+		return fmt.Errorf("new client: %w", err)
+	}
+
+	if err := client.MakeBucket(ctx, selfTestBucket, minio.MakeBucketOptions{}); err != nil {
+		return fmt.Errorf("make bucket: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, selfTestBucket)
+	if err != nil {
+		return fmt.Errorf("bucket exists: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("bucket %s not found after creation", selfTestBucket)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package minio_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	tcminio "github.com/testcontainers/testcontainers-go/modules/minio"
+)
+
+// TestSelfTest runs the module with the image in the TESTCONTAINERS_SELFTEST_IMAGE environment variable,
+// and executes SelfTest against it. It's run by the verify-module command of modulegen, which reports
+// the outcome and the timings of the run, self-test and terminate subtests.
+func TestSelfTest(t *testing.T) {
+	img := os.Getenv("TESTCONTAINERS_SELFTEST_IMAGE")
+	if img == "" {
+		t.Skip("TESTCONTAINERS_SELFTEST_IMAGE is not set")
+	}
+
+	ctx := context.Background()
+
+	var ctr *tcminio.MinioContainer
+	ok := t.Run("run", func(t *testing.T) {
+		var err error
+		ctr, err = tcminio.Run(ctx, img)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !ok {
+		return
+	}
+
+	t.Run("self-test", func(t *testing.T) {
+		if err := tcminio.SelfTest(ctx, ctr); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("terminate", func(t *testing.T) {
+		if err := ctr.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -49,6 +49,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		return nil, fmt.Errorf("if you specify username or password, you must provide both of them")
 	}
 
+	if err := testcontainers.WithModuleInfo("mongodb").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -58,6 +58,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		genericContainerReq.Cmd = append(genericContainerReq.Cmd, []string{"--" + k, v}...)
 	}
 
+	if err := testcontainers.WithModuleInfo("nats").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
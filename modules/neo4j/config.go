@@ -109,7 +109,7 @@ func addSetting(req *testcontainers.GenericContainerRequest, key string, newVal
 			return fmt.Errorf("setting %q is not permitted, WithAdminPassword has already been set", normalizedKey)
 		}
 
-		req.Logger.Printf("setting %q with value %q is now overwritten with value %q\n", []any{key, oldVal, newVal}...)
+		testcontainers.NewModuleLogger(req.Logger, "neo4j").Warnf("setting %q with value %q is now overwritten with value %q", key, oldVal, newVal)
 	}
 
 	req.Env[normalizedKey] = newVal
@@ -150,8 +150,8 @@ func TestNeo4jWithWrongSettings(outer *testing.T) {
 		})
 
 		errorLogs := logger.Logs()
-		if !Contains(errorLogs, `setting "some.key" with value "value1" is now overwritten with value "value2"`+"\n") ||
-			!Contains(errorLogs, `setting "some.key" with value "value2" is now overwritten with value "value3"`+"\n") {
+		if !Contains(errorLogs, `[neo4j] setting "some.key" with value "value1" is now overwritten with value "value2"`) ||
+			!Contains(errorLogs, `[neo4j] setting "some.key" with value "value2" is now overwritten with value "value3"`) {
 			t.Fatalf("expected setting overwrites to be logged")
 		}
 		if !strings.Contains(getContainerEnv(t, ctx, container), "NEO4J_some_key=value3") {
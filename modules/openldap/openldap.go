@@ -2,12 +2,10 @@ package openldap
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"io"
-	"net"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -16,6 +14,10 @@ const (
 	defaultPassword = "adminpassword"
 	defaultRoot     = "dc=example,dc=org"
 	defaultAdminDn  = "cn=admin,dc=example,dc=org"
+
+	// ldapUser is the non-root user the Bitnami image runs OpenLDAP with,
+	// used to run the LDAP client tools in the container.
+	ldapUser = "1001"
 )
 
 // OpenLDAPContainer represents the OpenLDAP container type used in the module
@@ -28,18 +30,7 @@ type OpenLDAPContainer struct {
 
 // ConnectionString returns the connection string for the OpenLDAP container
 func (c *OpenLDAPContainer) ConnectionString(ctx context.Context, args ...string) (string, error) {
-	containerPort, err := c.MappedPort(ctx, "1389/tcp")
-	if err != nil {
-		return "", err
-	}
-
-	host, err := c.Host(ctx)
-	if err != nil {
-		return "", err
-	}
-
-	connStr := fmt.Sprintf("ldap://%s", net.JoinHostPort(host, containerPort.Port()))
-	return connStr, nil
+	return c.PortEndpoint(ctx, "1389/tcp", "ldap")
 }
 
 // LoadLdif loads an ldif file into the OpenLDAP container
@@ -48,15 +39,8 @@ func (c *OpenLDAPContainer) LoadLdif(ctx context.Context, ldif []byte) error {
 	if err != nil {
 		return err
 	}
-	code, output, err := c.Exec(ctx, []string{"ldapadd", "-H", "ldap://localhost:1389", "-x", "-D", fmt.Sprintf("cn=%s,%s", c.adminUsername, c.rootDn), "-w", c.adminPassword, "-f", "/tmp/ldif.ldif"})
-	if err != nil {
-		return err
-	}
-	if code != 0 {
-		data, _ := io.ReadAll(output)
-		return errors.New(string(data))
-	}
-	return nil
+	_, err = c.Run(ctx, []string{"ldapadd", "-H", "ldap://localhost:1389", "-x", "-D", fmt.Sprintf("cn=%s,%s", c.adminUsername, c.rootDn), "-w", c.adminPassword, "-f", "/tmp/ldif.ldif"}, tcexec.ExecOptions{User: ldapUser}.ProcessOptions()...)
+	return err
 }
 
 // WithAdminUsername sets the initial admin username to be created when the container starts
@@ -105,15 +89,8 @@ func WithInitialLdif(ldif string) testcontainers.CustomizeRequestOption {
 					username := req.Env["LDAP_ADMIN_USERNAME"]
 					rootDn := req.Env["LDAP_ROOT"]
 					password := req.Env["LDAP_ADMIN_PASSWORD"]
-					code, output, err := container.Exec(ctx, []string{"ldapadd", "-H", "ldap://localhost:1389", "-x", "-D", fmt.Sprintf("cn=%s,%s", username, rootDn), "-w", password, "-f", "/initial_ldif.ldif"})
-					if err != nil {
-						return err
-					}
-					if code != 0 {
-						data, _ := io.ReadAll(output)
-						return errors.New(string(data))
-					}
-					return nil
+					_, err := container.Run(ctx, []string{"ldapadd", "-H", "ldap://localhost:1389", "-x", "-D", fmt.Sprintf("cn=%s,%s", username, rootDn), "-w", password, "-f", "/initial_ldif.ldif"}, tcexec.ExecOptions{User: ldapUser}.ProcessOptions()...)
+					return err
 				},
 			},
 		})
@@ -122,6 +99,13 @@ func WithInitialLdif(ldif string) testcontainers.CustomizeRequestOption {
 	}
 }
 
+// requiredEnv validates the env vars required by the container, once the options of the caller are applied.
+var requiredEnv = map[string]testcontainers.EnvValidator{
+	"LDAP_ADMIN_USERNAME": testcontainers.EnvNotEmpty,
+	"LDAP_ADMIN_PASSWORD": testcontainers.EnvNotEmpty,
+	"LDAP_ROOT":           testcontainers.EnvNotEmpty,
+}
+
 // Deprecated: use Run instead
 // RunContainer creates an instance of the OpenLDAP container type
 func RunContainer(ctx context.Context, opts ...testcontainers.ContainerCustomizer) (*OpenLDAPContainer, error) {
@@ -154,12 +138,20 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		Started:          true,
 	}
 
+	if err := testcontainers.WithEnvValidators("openldap", requiredEnv).Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	for _, opt := range opts {
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, err
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("openldap").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
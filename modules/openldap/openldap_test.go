@@ -2,11 +2,13 @@ package openldap_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
 	"github.com/go-ldap/ldap/v3"
 
+	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/openldap"
 )
 
@@ -24,6 +26,34 @@ func TestOpenLDAP(t *testing.T) {
 			t.Fatalf("failed to terminate container: %s", err)
 		}
 	})
+
+	// the container is labeled with the module which created it.
+	inspect, err := container.Inspect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if module := inspect.Config.Labels["org.testcontainers.module"]; module != "openldap" {
+		t.Fatalf("expected the module label to be %q, got %q", "openldap", module)
+	}
+	if info := container.ModuleInfo(); info.Name != "openldap" {
+		t.Fatalf("unexpected module info: %+v", info)
+	}
+}
+
+func TestOpenLDAPWithConflictingModuleLabel(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := openldap.Run(ctx, "bitnami/openldap:2.6.6", testcontainers.CustomizeRequest(testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Labels: map[string]string{"org.testcontainers.module": "minio"},
+		},
+	}))
+	if !errors.Is(err, testcontainers.ErrReservedLabel) {
+		t.Fatalf("expected an error wrapping ErrReservedLabel, got: %v", err)
+	}
+	if container != nil {
+		t.Fatal("expected no container")
+	}
 }
 
 func TestOpenLDAPWithAdminUsernameAndPassword(t *testing.T) {
@@ -78,6 +108,14 @@ func TestOpenLDAPWithDifferentRoot(t *testing.T) {
 		}
 	})
 
+	root, ok, err := container.EnvValue(ctx, "LDAP_ROOT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || root != "dc=mydomain,dc=com" {
+		t.Fatalf("expected LDAP_ROOT to be %q, got %q", "dc=mydomain,dc=com", root)
+	}
+
 	// connectionString {
 	connectionString, err := container.ConnectionString(ctx)
 	// }
@@ -237,3 +275,38 @@ userPassword: Password1
 		t.Fatal("Invalid entry returned", result.Entries[0].DN)
 	}
 }
+
+func TestOpenLDAP_invalidEnv(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		key string
+		msg string
+	}{
+		{key: "LDAP_ADMIN_USERNAME", msg: "openldap: LDAP_ADMIN_USERNAME must not be empty (overridden by a customizer)"},
+		{key: "LDAP_ADMIN_PASSWORD", msg: "openldap: LDAP_ADMIN_PASSWORD must not be empty (overridden by a customizer)"},
+		{key: "LDAP_ROOT", msg: "openldap: LDAP_ROOT must not be empty (overridden by a customizer)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			container, err := openldap.Run(ctx, "bitnami/openldap:2.6.6", testcontainers.WithEnv(map[string]string{tt.key: ""}))
+			if container != nil {
+				_ = container.Terminate(ctx)
+			}
+
+			var envErr *testcontainers.InvalidEnvError
+			if !errors.As(err, &envErr) {
+				t.Fatalf("expected an InvalidEnvError, got: %v", err)
+			}
+
+			if envErr.Key != tt.key || !envErr.Overridden {
+				t.Fatalf("unexpected error: %#v", envErr)
+			}
+
+			if err.Error() != tt.msg {
+				t.Fatalf("expected error %q, got %q", tt.msg, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package openldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// SelfTest verifies that the OpenLDAP container is able to serve requests end-to-end,
+// binding with the admin credentials and querying the root DSE.
+func SelfTest(ctx context.Context, ctr *OpenLDAPContainer) error {
+	connStr, err := ctr.ConnectionString(ctx)
+	if err != nil {
+		return fmt.Errorf("connection string: %w", err)
+	}
+
+	client, err := ldap.DialURL(connStr)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.Bind(fmt.Sprintf("cn=%s,%s", ctr.adminUsername, ctr.rootDn), ctr.adminPassword); err != nil {
+		return fmt.Errorf("bind: %w", err)
+	}
+
+	result, err := client.Search(ldap.NewSearchRequest(
+		"", ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", []string{"namingContexts"}, nil,
+	))
+	if err != nil {
+		return fmt.Errorf("root DSE search: %w", err)
+	}
+
+	if len(result.Entries) != 1 {
+		return fmt.Errorf("root DSE search: expected 1 entry, got %d", len(result.Entries))
+	}
+
+	return nil
+}
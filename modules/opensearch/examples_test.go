@@ -2,8 +2,10 @@ package opensearch_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 
 	"github.com/testcontainers/testcontainers-go/modules/opensearch"
 )
@@ -42,3 +44,58 @@ func ExampleRun() {
 	// true
 	// new-username : new-password
 }
+
+func ExampleRun_connect() {
+	ctx := context.Background()
+
+	opensearchContainer, err := opensearch.Run(ctx, "opensearchproject/opensearch:2.11.1")
+	if err != nil {
+		log.Fatalf("failed to start container: %s", err)
+	}
+
+	// Clean up the container
+	defer func() {
+		if err := opensearchContainer.Terminate(ctx); err != nil {
+			log.Fatalf("failed to terminate container: %s", err) // nolint:gocritic
+		}
+	}()
+
+	// connectToOpenSearch {
+	address, err := opensearchContainer.Address(ctx)
+	if err != nil {
+		log.Fatalf("failed to get address: %s", err) // nolint:gocritic
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		log.Fatalf("failed to create request: %s", err)
+	}
+	req.SetBasicAuth(opensearchContainer.User, opensearchContainer.Password)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatalf("failed to perform request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var info struct {
+		Version struct {
+			Distribution string `json:"distribution"`
+			Number       string `json:"number"`
+		} `json:"version"`
+		Tagline string `json:"tagline"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		log.Fatalf("failed to decode response: %s", err)
+	}
+	// }
+
+	fmt.Println(resp.StatusCode)
+	fmt.Println(info.Version.Distribution, info.Version.Number)
+	fmt.Println(info.Tagline)
+
+	// Output:
+	// 200
+	// opensearch 2.11.1
+	// The OpenSearch Project: https://opensearch.org/
+}
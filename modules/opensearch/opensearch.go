@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -25,6 +26,8 @@ type OpenSearchContainer struct {
 	testcontainers.Container
 	User     string
 	Password string
+	// SecurityEnabled is true if the security plugin of OpenSearch is enabled.
+	SecurityEnabled bool
 }
 
 // Deprecated: use Run instead
@@ -45,21 +48,21 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 			"OPENSEARCH_USERNAME":         defaultUsername,
 			"OPENSEARCH_PASSWORD":         defaultPassword,
 		},
-		HostConfigModifier: func(hc *container.HostConfig) {
-			hc.Ulimits = []*units.Ulimit{
-				{
-					Name: "memlock",
-					Soft: -1, // Set memlock to unlimited (no soft or hard limit)
-					Hard: -1,
-				},
-				{
-					Name: "nofile",
-					Soft: 65536, // Maximum number of open files for the opensearch user - set to at least 65536
-					Hard: 65536,
-				},
-			}
-		},
 	}
+	req.AddHostConfigModifier(func(hc *container.HostConfig) {
+		hc.Ulimits = append(hc.Ulimits,
+			&units.Ulimit{
+				Name: "memlock",
+				Soft: -1, // Set memlock to unlimited (no soft or hard limit)
+				Hard: -1,
+			},
+			&units.Ulimit{
+				Name: "nofile",
+				Soft: 65536, // Maximum number of open files for the opensearch user - set to at least 65536
+				Hard: 65536,
+			},
+		)
+	})
 
 	genericContainerReq := testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
@@ -67,37 +70,58 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 	}
 
 	// Gather all config options (defaults and then apply provided options)
-	settings := defaultOptions()
-	for _, opt := range opts {
+	settings := defaultOptions(img)
+
+	if err := testcontainers.WithEnvValidators("opensearch", requiredEnv()).Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
+	// the credentials are resolved after the options of the caller.
+	opts = append(slices.Clone(opts), testcontainers.WithLast(withCredentials(settings)))
+
+	for _, opt := range testcontainers.SortOptions(opts) {
 		if apply, ok := opt.(Option); ok {
-			apply(settings)
+			if err := apply(settings); err != nil {
+				return nil, fmt.Errorf("apply option: %w", err)
+			}
 		}
 		if err := opt.Customize(&genericContainerReq); err != nil {
 			return nil, err
 		}
 	}
 
-	// set credentials if they are provided, otherwise use the defaults
-	if settings.Username != "" {
-		genericContainerReq.Env["OPENSEARCH_USERNAME"] = settings.Username
+	genericContainerReq.WaitingFor = waitStrategy(settings)
+
+	if err := testcontainers.WithModuleInfo("opensearch").Customize(&genericContainerReq); err != nil {
+		return nil, err
 	}
-	if settings.Password != "" {
-		genericContainerReq.Env["OPENSEARCH_PASSWORD"] = settings.Password
+
+	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
+	if err != nil {
+		return nil, err
 	}
 
-	username := genericContainerReq.Env["OPENSEARCH_USERNAME"]
-	password := genericContainerReq.Env["OPENSEARCH_PASSWORD"]
+	return &OpenSearchContainer{
+		Container:       container,
+		User:            settings.Username,
+		Password:        settings.Password,
+		SecurityEnabled: securityEnabled(genericContainerReq.Env),
+	}, nil
+}
 
+// waitStrategy returns the strategy waiting for the OpenSearch API to be ready,
+// authenticating with the credentials of the settings.
+func waitStrategy(settings *Options) wait.Strategy {
 	// the wat strategy does not support TLS at the moment,
 	// so we need to disable it in the strategy for now.
-	genericContainerReq.WaitingFor = wait.ForHTTP("/").
+	return wait.ForHTTP("/").
 		WithPort("9200").
 		WithTLS(false).
 		WithStartupTimeout(120*time.Second).
 		WithStatusCodeMatcher(func(status int) bool {
 			return status == 200
 		}).
-		WithBasicAuth(username, password).
+		WithBasicAuth(settings.Username, settings.Password).
 		WithResponseMatcher(func(body io.Reader) bool {
 			bs, err := io.ReadAll(body)
 			if err != nil {
@@ -116,27 +140,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 
 			return r.Tagline == "The OpenSearch Project: https://opensearch.org/"
 		})
-
-	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
-	if err != nil {
-		return nil, err
-	}
-
-	return &OpenSearchContainer{Container: container, User: username, Password: password}, nil
 }
 
 // Address retrieves the address of the OpenSearch container.
 // It will use http as protocol, as TLS is not supported at the moment.
 func (c *OpenSearchContainer) Address(ctx context.Context) (string, error) {
-	containerPort, err := c.MappedPort(ctx, defaultHTTPPort)
-	if err != nil {
-		return "", err
-	}
-
-	host, err := c.Host(ctx)
-	if err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("http://%s:%s", host, containerPort.Port()), nil
+	return c.PortEndpoint(ctx, defaultHTTPPort, "http")
 }
@@ -2,9 +2,18 @@ package opensearch_test
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/opensearch"
 )
 
@@ -43,3 +52,112 @@ func TestOpenSearch(t *testing.T) {
 		defer resp.Body.Close()
 	})
 }
+
+func TestOpenSearch_invalidOption(t *testing.T) {
+	ctx := context.Background()
+
+	// the password is only required to be strong if the security plugin is enabled.
+	container, err := opensearch.Run(ctx, "opensearchproject/opensearch:2.12.0",
+		testcontainers.WithEnv(map[string]string{"DISABLE_SECURITY_PLUGIN": "false"}),
+		opensearch.WithPassword("admin"),
+	)
+	if err == nil {
+		_ = container.Terminate(ctx)
+		t.Fatal("expected an error for a weak password")
+	}
+
+	if !strings.Contains(err.Error(), "weak password for OpenSearch 2.12+") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestOpenSearch_hostConfigModifiers(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the module sets the ulimits, the host access ports set an extra host, and so does the user.
+	ctr, err := opensearch.Run(ctx, "opensearchproject/opensearch:2.11.1",
+		testcontainers.WithHostPortAccess(port),
+		testcontainers.WithHostConfigModifier(func(hc *container.HostConfig) {
+			hc.ExtraHosts = []string{"custom.host:10.0.0.1"}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := ctr.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	inspect, err := ctr.Inspect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ulimits := map[string]int64{}
+	for _, u := range inspect.HostConfig.Ulimits {
+		ulimits[u.Name] = u.Soft
+	}
+	if ulimits["memlock"] != -1 || ulimits["nofile"] != 65536 {
+		t.Fatalf("expected the ulimits of the module, got %v", ulimits)
+	}
+
+	if !slices.Contains(inspect.HostConfig.ExtraHosts, "custom.host:10.0.0.1") {
+		t.Fatalf("expected the extra host of the user, got %v", inspect.HostConfig.ExtraHosts)
+	}
+
+	hostAccess := slices.ContainsFunc(inspect.HostConfig.ExtraHosts, func(h string) bool {
+		return strings.HasPrefix(h, testcontainers.HostInternal+":")
+	})
+	if !hostAccess {
+		t.Fatalf("expected the extra host of the host access ports, got %v", inspect.HostConfig.ExtraHosts)
+	}
+}
+
+func TestOpenSearch_invalidEnv(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		key string
+		msg string
+	}{
+		{key: "discovery.type", msg: "opensearch: discovery.type must be \"single-node\" (overridden by a customizer)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			container, err := opensearch.Run(ctx, "opensearchproject/opensearch:2.11.1", testcontainers.WithEnv(map[string]string{tt.key: ""}))
+			if container != nil {
+				_ = container.Terminate(ctx)
+			}
+
+			var envErr *testcontainers.InvalidEnvError
+			if !errors.As(err, &envErr) {
+				t.Fatalf("expected an InvalidEnvError, got: %v", err)
+			}
+
+			if envErr.Key != tt.key || !envErr.Overridden {
+				t.Fatalf("unexpected error: %#v", envErr)
+			}
+
+			if err.Error() != tt.msg {
+				t.Fatalf("expected error %q, got %q", tt.msg, err.Error())
+			}
+		})
+	}
+}
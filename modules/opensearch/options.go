@@ -1,17 +1,33 @@
 package opensearch
 
-import "github.com/testcontainers/testcontainers-go"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// minStrongPasswordLength is the minimum length of the passwords accepted by OpenSearch 2.12+.
+const minStrongPasswordLength = 8
 
 // Options is a struct for specifying options for the OpenSearch container.
 type Options struct {
 	Password string
 	Username string
+
+	// strongPassword is true if the image requires a strong password, i.e. OpenSearch 2.12+.
+	strongPassword bool
 }
 
-func defaultOptions() *Options {
+func defaultOptions(img string) *Options {
 	return &Options{
-		Username: defaultUsername,
-		Password: defaultPassword,
+		Username:       defaultUsername,
+		Password:       defaultPassword,
+		strongPassword: requiresStrongPassword(img),
 	}
 }
 
@@ -19,7 +35,8 @@ func defaultOptions() *Options {
 var _ testcontainers.ContainerCustomizer = (*Option)(nil)
 
 // Option is an option for the OpenSearch container.
-type Option func(*Options)
+// It returns an error if the value it sets is not valid.
+type Option func(*Options) error
 
 // Customize is a NOOP. It's defined to satisfy the testcontainers.ContainerCustomizer interface.
 func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
@@ -27,16 +44,143 @@ func (o Option) Customize(*testcontainers.GenericContainerRequest) error {
 	return nil
 }
 
-// WithPassword sets the password for the OpenSearch container.
+// WithPassword sets the password for the OpenSearch container. It must not be empty and,
+// if the security plugin is enabled in OpenSearch 2.12+ images, it must be a strong password:
+// at least 8 characters, with at least an uppercase letter, a lowercase letter, a digit and
+// a special character. The security plugin is disabled by default, see Run.
 func WithPassword(password string) Option {
-	return func(o *Options) {
+	return func(o *Options) error {
+		if password == "" {
+			return errors.New("empty password")
+		}
+
 		o.Password = password
+		return nil
 	}
 }
 
-// WithUsername sets the username for the OpenSearch container.
+// WithUsername sets the username for the OpenSearch container. It must not be empty.
 func WithUsername(username string) Option {
-	return func(o *Options) {
+	return func(o *Options) error {
+		if strings.TrimSpace(username) == "" {
+			return errors.New("empty username")
+		}
+
 		o.Username = username
+		return nil
 	}
 }
+
+// withCredentials sets the credentials of the settings in the env of the container, so that they are
+// the effective ones no matter the order of the options of the caller. It's applied last, see Run,
+// so that the password is only required to be strong if the security plugin is enabled in the end.
+func withCredentials(settings *Options) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if settings.strongPassword && securityEnabled(req.Env) {
+			if err := validateStrongPassword(settings.Password); err != nil {
+				return fmt.Errorf("weak password for OpenSearch 2.12+: %w", err)
+			}
+		}
+
+		req.Env["OPENSEARCH_USERNAME"] = settings.Username
+		req.Env["OPENSEARCH_PASSWORD"] = settings.Password
+
+		return nil
+	}
+}
+
+// securityEnabled returns true if the security plugin of OpenSearch is enabled in the env,
+// i.e. the DISABLE_SECURITY_PLUGIN environment variable is not set to true.
+func securityEnabled(env map[string]string) bool {
+	return env["DISABLE_SECURITY_PLUGIN"] != "true"
+}
+
+// requiredEnv returns the validators of the env vars required by the container, once the options of the caller
+// are applied, so that they can't be blanked out with a generic option, e.g. testcontainers.WithEnv.
+func requiredEnv() map[string]testcontainers.EnvValidator {
+	return map[string]testcontainers.EnvValidator{
+		"discovery.type": func(value string) error {
+			if value != "single-node" {
+				return errors.New(`must be "single-node"`)
+			}
+			return nil
+		},
+		"OPENSEARCH_USERNAME": testcontainers.EnvNotEmpty,
+		"OPENSEARCH_PASSWORD": testcontainers.EnvNotEmpty,
+	}
+}
+
+// validateStrongPassword checks the password against the requirements of OpenSearch 2.12+.
+func validateStrongPassword(password string) error {
+	if len(password) < minStrongPasswordLength {
+		return fmt.Errorf("at least %d characters are required", minStrongPasswordLength)
+	}
+
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			special = true
+		}
+	}
+
+	var missing []string
+	if !upper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if !lower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if !digit {
+		missing = append(missing, "a digit")
+	}
+	if !special {
+		missing = append(missing, "a special character")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("at least %s is required", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// versionRegex matches the major and, optionally, the minor version at the start of an image tag.
+var versionRegex = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?`)
+
+// requiresStrongPassword returns true if the image runs OpenSearch 2.12+, which requires a strong password.
+// The version is detected from the image tag: images without a tag, or whose tag doesn't start with a
+// version, like latest, are considered recent.
+func requiresStrongPassword(img string) bool {
+	// the digest, if any, doesn't tell the version.
+	img, _, _ = strings.Cut(img, "@")
+
+	var tag string
+	if idx := strings.LastIndex(img, ":"); idx > strings.LastIndex(img, "/") {
+		tag = img[idx+1:]
+	}
+
+	matches := versionRegex.FindStringSubmatch(tag)
+	if matches == nil {
+		return true
+	}
+
+	major, _ := strconv.Atoi(matches[1])
+	if major != 2 {
+		return major > 2
+	}
+
+	if matches[2] == "" {
+		// the latest 2.x release.
+		return true
+	}
+
+	minor, _ := strconv.Atoi(matches[2])
+	return minor >= 12
+}
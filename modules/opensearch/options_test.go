@@ -0,0 +1,178 @@
+package opensearch
+
+import (
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestRequiresStrongPassword(t *testing.T) {
+	tests := []struct {
+		img      string
+		expected bool
+	}{
+		{img: "opensearchproject/opensearch:2.11.1", expected: false},
+		{img: "opensearchproject/opensearch:2.12.0", expected: true},
+		{img: "opensearchproject/opensearch:2.15", expected: true},
+		{img: "opensearchproject/opensearch:2", expected: true},
+		{img: "opensearchproject/opensearch:1.3.18", expected: false},
+		{img: "opensearchproject/opensearch:3.0.0", expected: true},
+		{img: "opensearchproject/opensearch:latest", expected: true},
+		{img: "opensearchproject/opensearch", expected: true},
+		{img: "localhost:5000/opensearchproject/opensearch", expected: true},
+		{img: "localhost:5000/opensearchproject/opensearch:2.11.0", expected: false},
+		{img: "opensearchproject/opensearch:2.11.1@sha256:0123456789abcdef", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.img, func(t *testing.T) {
+			if got := requiresStrongPassword(tt.img); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		img         string
+		opts        []Option
+		expected    Options
+		expectedErr string
+	}{
+		{
+			name:     "defaults",
+			img:      "opensearchproject/opensearch:2.11.1",
+			expected: Options{Username: defaultUsername, Password: defaultPassword},
+		},
+		{
+			name:     "custom-credentials",
+			img:      "opensearchproject/opensearch:2.11.1",
+			opts:     []Option{WithUsername("new-username"), WithPassword("new-password")},
+			expected: Options{Username: "new-username", Password: "new-password"},
+		},
+		{
+			name:        "empty-username",
+			img:         "opensearchproject/opensearch:2.11.1",
+			opts:        []Option{WithUsername(" ")},
+			expectedErr: "empty username",
+		},
+		{
+			name:        "empty-password",
+			img:         "opensearchproject/opensearch:2.11.1",
+			opts:        []Option{WithPassword("")},
+			expectedErr: "empty password",
+		},
+		{
+			name:     "weak-password",
+			img:      "opensearchproject/opensearch:2.12.0",
+			opts:     []Option{WithPassword("new-password")},
+			expected: Options{Username: defaultUsername, Password: "new-password", strongPassword: true},
+		},
+		{
+			name:     "last-option-wins",
+			img:      "opensearchproject/opensearch:2.11.1",
+			opts:     []Option{WithPassword("first"), WithUsername("user"), WithPassword("second")},
+			expected: Options{Username: "user", Password: "second"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := defaultOptions(tt.img)
+
+			var err error
+			for _, opt := range tt.opts {
+				if err = opt(settings); err != nil {
+					break
+				}
+			}
+
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *settings != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, *settings)
+			}
+		})
+	}
+}
+
+func TestWithCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		img         string
+		password    string
+		env         map[string]string
+		expectedErr string
+	}{
+		{
+			name:     "security-disabled",
+			img:      "opensearchproject/opensearch:2.12.0",
+			password: defaultPassword,
+			env:      map[string]string{"DISABLE_SECURITY_PLUGIN": "true"},
+		},
+		{
+			name:     "strong-password",
+			img:      "opensearchproject/opensearch:2.12.0",
+			password: "Str0ng-Passw0rd",
+		},
+		{
+			name:     "weak-password-before-2.12",
+			img:      "opensearchproject/opensearch:2.11.1",
+			password: defaultPassword,
+		},
+		{
+			name:        "short-password",
+			img:         "opensearchproject/opensearch:2.12.0",
+			password:    "Sh0rt!",
+			expectedErr: "weak password for OpenSearch 2.12+: at least 8 characters are required",
+		},
+		{
+			name:        "weak-password",
+			img:         "opensearchproject/opensearch:latest",
+			password:    "new-password",
+			env:         map[string]string{"DISABLE_SECURITY_PLUGIN": "false"},
+			expectedErr: "weak password for OpenSearch 2.12+: at least an uppercase letter, a digit is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := defaultOptions(tt.img)
+			if err := WithPassword(tt.password)(settings); err != nil {
+				t.Fatal(err)
+			}
+
+			req := testcontainers.GenericContainerRequest{
+				ContainerRequest: testcontainers.ContainerRequest{Env: map[string]string{}},
+			}
+			for k, v := range tt.env {
+				req.Env[k] = v
+			}
+
+			err := withCredentials(settings)(&req)
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.Env["OPENSEARCH_PASSWORD"] != tt.password {
+				t.Errorf("expected password %q, got %q", tt.password, req.Env["OPENSEARCH_PASSWORD"])
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SelfTest verifies that the OpenSearch container is able to serve requests end-to-end,
+// checking that the cluster health is either green or yellow, as a single-node cluster
+// cannot allocate replica shards.
+func SelfTest(ctx context.Context, ctr *OpenSearchContainer) error {
+	address, err := ctr.Address(ctx)
+	if err != nil {
+		return fmt.Errorf("address: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address+"/_cluster/health", nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.SetBasicAuth(ctr.User, ctr.Password)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cluster health: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cluster health: unexpected status code %d", resp.StatusCode)
+	}
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return fmt.Errorf("decode cluster health: %w", err)
+	}
+
+	if health.Status != "green" && health.Status != "yellow" {
+		return fmt.Errorf("cluster health: unexpected status %q", health.Status)
+	}
+
+	return nil
+}
@@ -168,6 +168,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("postgres").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -259,7 +263,7 @@ func (c *PostgresContainer) checkSnapshotConfig(opts []SnapshotOption) (string,
 func (c *PostgresContainer) execCommandsSQL(ctx context.Context, cmds ...string) error {
 	conn, cleanup, err := c.snapshotConnection(ctx)
 	if err != nil {
-		testcontainers.Logger.Printf("Could not connect to database to restore snapshot, falling back to `docker exec psql`: %v", err)
+		testcontainers.ModuleLogger("postgres").Warnf("Could not connect to database to restore snapshot, falling back to `docker exec psql`: %v", err)
 		return c.execCommandsFallback(ctx, cmds)
 	}
 	if cleanup != nil {
@@ -296,7 +300,7 @@ func (c *PostgresContainer) snapshotConnection(ctx context.Context) (*sql.Conn,
 
 	cleanupPool := func() {
 		if err := pool.Close(); err != nil {
-			testcontainers.Logger.Printf("Could not close database connection pool after restoring snapshot: %v", err)
+			testcontainers.ModuleLogger("postgres").Warnf("Could not close database connection pool after restoring snapshot: %v", err)
 		}
 	}
 
@@ -164,6 +164,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		}
 	}
 
+	if err := testcontainers.WithModuleInfo("pulsar").Customize(&genericContainerReq); err != nil {
+		return nil, err
+	}
+
 	c, err := testcontainers.GenericContainer(ctx, genericContainerReq)
 	if err != nil {
 		return nil, err
@@ -179,6 +179,10 @@ func Run(ctx context.Context, img string, opts ...testcontainers.ContainerCustom
 		)
 	}
 
+	if err := testcontainers.WithModuleInfo("redpanda").Customize(&req); err != nil {
+		return nil, err
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, req)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,12 @@
 package testcontainers
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/api/types/mount"
+)
 
 const (
 	MountTypeBind MountType = iota // Deprecated: Use MountTypeVolume instead
@@ -110,6 +116,21 @@ func VolumeMount(volumeName string, mountTarget ContainerMountTarget) ContainerM
 	}
 }
 
+// TmpfsMount returns a new ContainerMount with a DockerTmpfsMountSource as source, limited to the given
+// size in bytes, or unlimited if the size is not positive.
+// This is a convenience method to cover typical use cases.
+func TmpfsMount(mountTarget ContainerMountTarget, size int64) ContainerMount {
+	source := DockerTmpfsMountSource{}
+	if size > 0 {
+		source.TmpfsOptions = &mount.TmpfsOptions{SizeBytes: size}
+	}
+
+	return ContainerMount{
+		Source: source,
+		Target: mountTarget,
+	}
+}
+
 // Mounts returns a ContainerMounts to support a more fluent API
 func Mounts(mounts ...ContainerMount) ContainerMounts {
 	return mounts
@@ -123,4 +144,47 @@ type ContainerMount struct {
 	Target ContainerMountTarget
 	// ReadOnly determines if the mount should be read-only
 	ReadOnly bool
+
+	// allowMissingSource skips the check of the host path of a bind mount by WithMounts.
+	allowMissingSource bool
+}
+
+// AsReadOnly returns a copy of the mount, mounted read-only into the container.
+func (m ContainerMount) AsReadOnly() ContainerMount {
+	m.ReadOnly = true
+	return m
+}
+
+// AllowMissingSource returns a copy of the mount which WithMounts accepts even if its host path
+// doesn't exist yet, e.g. when it's created by another container, or when the daemon doesn't run
+// on the host of the tests.
+func (m ContainerMount) AllowMissingSource() ContainerMount {
+	m.allowMissingSource = true
+	return m
+}
+
+// validateSource returns an error wrapping ErrInvalidBindMount if the mount is a bind mount
+// whose host path doesn't exist, unless its source is allowed to be missing.
+func (m ContainerMount) validateSource() error {
+	if m.Source == nil {
+		return fmt.Errorf("mount to %s has no source", m.Target)
+	}
+
+	if m.Source.Type() != MountTypeBind || m.allowMissingSource {
+		return nil
+	}
+
+	if m.Source.Source() == "" {
+		return fmt.Errorf("%w: mount to %s has no host path", ErrInvalidBindMount, m.Target)
+	}
+
+	if _, err := os.Stat(m.Source.Source()); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: host path %s of the mount to %s doesn't exist, create it first or use AllowMissingSource",
+				ErrInvalidBindMount, m.Source.Source(), m.Target)
+		}
+		return fmt.Errorf("%w: host path %s of the mount to %s: %w", ErrInvalidBindMount, m.Source.Source(), m.Target, err)
+	}
+
+	return nil
 }
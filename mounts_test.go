@@ -238,3 +238,93 @@ func TestMountsReceiveRyukLabels(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, testcontainers.GenericLabels(), volume.Labels)
 }
+
+func TestTmpfsMount(t *testing.T) {
+	t.Run("size", func(t *testing.T) {
+		m := testcontainers.TmpfsMount("/cache", 1<<20)
+		require.Equal(t, []mount.Mount{
+			{
+				Type:         mount.TypeTmpfs,
+				Target:       "/cache",
+				TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 1 << 20},
+			},
+		}, testcontainers.Mounts(m).PrepareMounts())
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		m := testcontainers.TmpfsMount("/cache", 0).AsReadOnly()
+		require.Equal(t, []mount.Mount{
+			{
+				Type:     mount.TypeTmpfs,
+				Target:   "/cache",
+				ReadOnly: true,
+			},
+		}, testcontainers.Mounts(m).PrepareMounts())
+	})
+}
+
+func TestWithMounts(t *testing.T) {
+	t.Run("bind-source", func(t *testing.T) {
+		req := testcontainers.GenericContainerRequest{}
+		require.NoError(t, testcontainers.WithMounts(testcontainers.BindMount(t.TempDir(), "/data"))(&req))
+		require.Len(t, req.Mounts, 1)
+	})
+
+	t.Run("missing-bind-source", func(t *testing.T) {
+		req := testcontainers.GenericContainerRequest{}
+		err := testcontainers.WithMounts(testcontainers.BindMount("/does/not/exist", "/data"))(&req)
+		require.ErrorIs(t, err, testcontainers.ErrInvalidBindMount)
+		require.ErrorContains(t, err, "/does/not/exist")
+		require.Empty(t, req.Mounts)
+	})
+
+	t.Run("allow-missing-bind-source", func(t *testing.T) {
+		req := testcontainers.GenericContainerRequest{}
+		err := testcontainers.WithMounts(testcontainers.BindMount("/does/not/exist", "/data").AllowMissingSource())(&req)
+		require.NoError(t, err)
+		require.Len(t, req.Mounts, 1)
+	})
+
+	t.Run("no-source", func(t *testing.T) {
+		req := testcontainers.GenericContainerRequest{}
+		require.Error(t, testcontainers.WithMounts(testcontainers.ContainerMount{Target: "/data"})(&req))
+	})
+
+	t.Run("container", func(t *testing.T) {
+		ctx := context.Background()
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "alpine",
+				Cmd:   []string{"sleep", "60"},
+			},
+			Started: true,
+		}
+
+		// withMounts {
+		err := testcontainers.WithMounts(
+			testcontainers.VolumeMount("with-mounts-data", "/data").AsReadOnly(),
+			testcontainers.TmpfsMount("/cache", 16<<20),
+		)(&req)
+		// }
+		require.NoError(t, err)
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+
+		inspect, err := c.Inspect(ctx)
+		require.NoError(t, err)
+		require.Len(t, inspect.HostConfig.Mounts, 2)
+
+		require.Equal(t, mount.TypeVolume, inspect.HostConfig.Mounts[0].Type)
+		require.True(t, inspect.HostConfig.Mounts[0].ReadOnly)
+		require.Equal(t, mount.TypeTmpfs, inspect.HostConfig.Mounts[1].Type)
+		require.Equal(t, int64(16<<20), inspect.HostConfig.Mounts[1].TmpfsOptions.SizeBytes)
+
+		// the volume is read-only.
+		code, _, err := c.Exec(ctx, []string{"touch", "/data/file"})
+		require.NoError(t, err)
+		require.NotZero(t, code)
+	})
+}
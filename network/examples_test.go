@@ -7,6 +7,7 @@ import (
 
 	dockernetwork "github.com/docker/docker/api/types/network"
 
+	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/network"
 )
 
@@ -74,3 +75,88 @@ func ExampleNew_withOptions() {
 	// true
 	// bridge
 }
+
+func ExampleNew_withIPv6() {
+	// newNetworkWithIPv6 {
+	ctx := context.Background()
+
+	net, err := network.New(ctx,
+		network.WithIPv6Subnet("fd00:dead:beef::/64", "fd00:dead:beef::1"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer func() {
+		if err := net.Remove(ctx); err != nil {
+			log.Fatalf("failed to remove network: %s", err)
+		}
+	}()
+	// }
+
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer cli.Close()
+
+	resource, err := cli.NetworkInspect(ctx, net.ID, dockernetwork.InspectOptions{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(resource.EnableIPv6)
+	for _, cfg := range resource.IPAM.Config {
+		if cfg.Subnet == "fd00:dead:beef::/64" {
+			fmt.Println(cfg.Gateway)
+		}
+	}
+
+	// Output:
+	// true
+	// fd00:dead:beef::1
+}
+
+func ExampleNew_withInternal() {
+	// newInternalNetwork {
+	ctx := context.Background()
+
+	// the containers attached to an internal network cannot reach the outside world.
+	net, err := network.New(ctx,
+		network.WithInternal(),
+		network.WithAttachable(),
+		network.WithDriver("bridge"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer func() {
+		if err := net.Remove(ctx); err != nil {
+			log.Fatalf("failed to remove network: %s", err)
+		}
+	}()
+	// }
+
+	cli, err := testcontainers.NewDockerClientWithOpts(ctx)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer cli.Close()
+
+	resource, err := cli.NetworkInspect(ctx, net.ID, dockernetwork.InspectOptions{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(resource.Internal)
+	fmt.Println(resource.Attachable)
+
+	// Output:
+	// true
+	// true
+}
@@ -3,6 +3,7 @@ package network
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/docker/docker/api/types/network"
 	"github.com/google/uuid"
@@ -104,6 +105,42 @@ func WithEnableIPv6() CustomizeNetworkOption {
 	}
 }
 
+// WithIPv6Subnet allows to set the network as IPv6 enabled, adding the given IPv6 subnet, and gateway
+// if not empty, to its IPAM configuration, so that a dual-stack network can be created without building
+// the IPAM configuration. It returns an error if the subnet is not an IPv6 CIDR, or if the gateway is
+// not an address of the subnet.
+func WithIPv6Subnet(subnet string, gateway string) CustomizeNetworkOption {
+	return func(original *network.CreateOptions) error {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return fmt.Errorf("parse IPv6 subnet: %w", err)
+		}
+		if ipNet.IP.To4() != nil {
+			return fmt.Errorf("not an IPv6 subnet: %s", subnet)
+		}
+
+		if gateway != "" {
+			ip := net.ParseIP(gateway)
+			if ip == nil || !ipNet.Contains(ip) {
+				return fmt.Errorf("gateway %s not in the IPv6 subnet %s", gateway, subnet)
+			}
+		}
+
+		enableIPv6 := true
+		original.EnableIPv6 = &enableIPv6
+
+		if original.IPAM == nil {
+			original.IPAM = &network.IPAM{Driver: "default"}
+		}
+		original.IPAM.Config = append(original.IPAM.Config, network.IPAMConfig{
+			Subnet:  subnet,
+			Gateway: gateway,
+		})
+
+		return nil
+	}
+}
+
 // WithInternal allows to set the network as internal.
 func WithInternal() CustomizeNetworkOption {
 	return func(original *network.CreateOptions) error {
@@ -114,9 +151,15 @@ func WithInternal() CustomizeNetworkOption {
 }
 
 // WithLabels allows to set the network labels, adding the new ones
-// to the default Testcontainers for Go labels.
+// to the default Testcontainers for Go labels, which cannot be overridden:
+// creating the network fails if a label collides with one of them.
+// The labels are exposed in the Labels field of the returned network.
 func WithLabels(labels map[string]string) CustomizeNetworkOption {
 	return func(original *network.CreateOptions) error {
+		if original.Labels == nil {
+			original.Labels = make(map[string]string, len(labels))
+		}
+
 		for k, v := range labels {
 			original.Labels[k] = v
 		}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	dockernetwork "github.com/docker/docker/api/types/network"
 	"github.com/stretchr/testify/assert"
@@ -197,6 +198,18 @@ func TestContainerIPs(t *testing.T) {
 	if len(ips) != 2 {
 		t.Errorf("Expected two IP addresses, got %v", len(ips))
 	}
+	require.Contains(t, ips, "bridge")
+	require.Contains(t, ips, networkName)
+
+	ip, err := nginx.ContainerIPByNetwork(ctx, networkName)
+	require.NoError(t, err)
+	require.Equal(t, ips[networkName], ip)
+
+	_, err = nginx.ContainerIPByNetwork(ctx, "missing")
+	var notFound *testcontainers.NetworkNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	require.Equal(t, "missing", notFound.Network)
+	require.ElementsMatch(t, []string{"bridge", networkName}, notFound.Networks)
 }
 
 func TestContainerWithReaperNetwork(t *testing.T) {
@@ -516,3 +529,136 @@ func TestWithNewNetworkContextTimeout(t *testing.T) {
 	assert.Empty(t, req.Networks)
 	assert.Empty(t, req.NetworkAliases)
 }
+
+func TestWithIPv6Subnet(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var req dockernetwork.CreateOptions
+		require.NoError(t, network.WithIPv6Subnet("fd00:1::/64", "fd00:1::1").Customize(&req))
+		require.NoError(t, network.WithIPv6Subnet("fd00:2::/64", "").Customize(&req))
+
+		require.NotNil(t, req.EnableIPv6)
+		require.True(t, *req.EnableIPv6)
+		require.Equal(t, &dockernetwork.IPAM{
+			Driver: "default",
+			Config: []dockernetwork.IPAMConfig{
+				{Subnet: "fd00:1::/64", Gateway: "fd00:1::1"},
+				{Subnet: "fd00:2::/64"},
+			},
+		}, req.IPAM)
+	})
+
+	t.Run("ipv4-subnet", func(t *testing.T) {
+		var req dockernetwork.CreateOptions
+		require.ErrorContains(t, network.WithIPv6Subnet("10.1.1.0/24", "").Customize(&req), "not an IPv6 subnet")
+	})
+
+	t.Run("invalid-subnet", func(t *testing.T) {
+		var req dockernetwork.CreateOptions
+		require.Error(t, network.WithIPv6Subnet("fd00:1::", "").Customize(&req))
+	})
+
+	t.Run("gateway-outside-subnet", func(t *testing.T) {
+		var req dockernetwork.CreateOptions
+		require.ErrorContains(t, network.WithIPv6Subnet("fd00:1::/64", "fd00:2::1").Customize(&req), "not in the IPv6 subnet")
+	})
+}
+
+func TestWithLabels(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx, network.WithLabels(map[string]string{"com.example.owner": "team-a"}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	require.Equal(t, "team-a", nw.Labels["com.example.owner"])
+	require.Equal(t, core.SessionID(), nw.Labels[core.LabelSessionID])
+
+	client, err := testcontainers.NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	defer client.Close()
+
+	inspect, err := client.NetworkInspect(ctx, nw.ID, dockernetwork.InspectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, nw.Labels, inspect.Labels)
+
+	t.Run("nil-labels", func(t *testing.T) {
+		var req dockernetwork.CreateOptions
+		require.NoError(t, network.WithLabels(map[string]string{"com.example.owner": "team-a"}).Customize(&req))
+		require.Equal(t, map[string]string{"com.example.owner": "team-a"}, req.Labels)
+	})
+
+	t.Run("reserved-label", func(t *testing.T) {
+		_, err := network.New(ctx, network.WithLabels(map[string]string{core.LabelSessionID: "other-session"}))
+		require.ErrorIs(t, err, core.ErrReservedLabel)
+	})
+}
+
+func TestDockerNetwork_ConnectDisconnect(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	nginx, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nginx.Terminate(ctx))
+	})
+
+	// connectDisconnect {
+	err = nw.Connect(ctx, nginx.GetContainerID(), "nginx")
+	require.NoError(t, err)
+
+	aliases, err := nginx.NetworkAliases(ctx)
+	require.NoError(t, err)
+	require.Contains(t, aliases[nw.Name], "nginx")
+
+	// simulate a network partition.
+	err = nw.Disconnect(ctx, nginx.GetContainerID())
+	require.NoError(t, err)
+	// }
+
+	_, err = nginx.ContainerIPByNetwork(ctx, nw.Name)
+	var notFound *testcontainers.NetworkNotFoundError
+	require.ErrorAs(t, err, &notFound)
+
+	// reconnect the container.
+	require.NoError(t, nw.Connect(ctx, nginx.GetContainerID()))
+
+	ip, err := nginx.ContainerIPByNetwork(ctx, nw.Name)
+	require.NoError(t, err)
+	require.NotEmpty(t, ip)
+
+	t.Run("not-attachable", func(t *testing.T) {
+		isolated, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "docker.io/alpine",
+				Cmd:   []string{"sleep", "60"},
+				HostConfigModifier: func(hc *container.HostConfig) {
+					hc.NetworkMode = "none"
+				},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, isolated.Terminate(ctx))
+		})
+
+		err = nw.Connect(ctx, isolated.GetContainerID())
+		require.ErrorIs(t, err, testcontainers.ErrNetworkNotAttachable)
+
+		err = nw.Disconnect(ctx, isolated.GetContainerID())
+		require.ErrorIs(t, err, testcontainers.ErrNetworkNotAttachable)
+	})
+}
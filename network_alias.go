@@ -0,0 +1,79 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrDuplicateAlias is returned when creating a container with a network alias already used by another
+// running container of the session on the same network, as the DNS of Docker would round-robin between them.
+var ErrDuplicateAlias = errors.New("duplicate network alias")
+
+var (
+	// uniqueAliases counts the aliases generated by UniqueAlias, keyed by base.
+	uniqueAliases   = map[string]int{}
+	uniqueAliasesMx sync.Mutex
+)
+
+// UniqueAlias returns a network alias derived from the given base, unique in the test session, e.g.
+// "openldap-1a2b3c4d-1" for "openldap", so that several instances of the same module can be attached
+// to the same network without sharing an alias. The aliases are numbered in the order they are
+// generated, and the short ID of the test process tells apart the processes sharing the session.
+func UniqueAlias(base string) string {
+	uniqueAliasesMx.Lock()
+	defer uniqueAliasesMx.Unlock()
+
+	uniqueAliases[base]++
+
+	return fmt.Sprintf("%s-%s-%d", base, core.ProcessID()[:8], uniqueAliases[base])
+}
+
+// validateNetworkAliases returns an error wrapping ErrDuplicateAlias if one of the aliases of the request
+// is already used by another running container of the session on the same network. The stopped containers
+// are not listed, as they are not resolved by the DNS of Docker.
+func (p *DockerProvider) validateNetworkAliases(ctx context.Context, req ContainerRequest, sessionID string) error {
+	for network, aliases := range req.NetworkAliases {
+		if len(aliases) == 0 || !slices.Contains(req.Networks, network) {
+			continue
+		}
+
+		containers, err := p.client.ContainerList(ctx, container.ListOptions{
+			Filters: filters.NewArgs(
+				filters.Arg("network", network),
+				filters.Arg("label", core.LabelSessionID+"="+sessionID),
+			),
+		})
+		if err != nil {
+			return fmt.Errorf("list containers of network %s: %w", network, err)
+		}
+
+		for _, c := range containers {
+			if c.NetworkSettings == nil {
+				continue
+			}
+
+			endpoint, ok := c.NetworkSettings.Networks[network]
+			if !ok || endpoint == nil {
+				continue
+			}
+
+			for _, alias := range aliases {
+				if slices.Contains(endpoint.Aliases, alias) {
+					return fmt.Errorf("%w: %q on network %s, used by container %s %s",
+						ErrDuplicateAlias, alias, network, c.ID[:12], strings.Join(c.Names, ","))
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package testcontainers_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/network"
+)
+
+func TestUniqueAlias(t *testing.T) {
+	first := testcontainers.UniqueAlias("openldap")
+	second := testcontainers.UniqueAlias("openldap")
+
+	require.NotEqual(t, first, second)
+	require.True(t, strings.HasPrefix(first, "openldap-"), first)
+	require.True(t, strings.HasPrefix(second, "openldap-"), second)
+	require.True(t, strings.HasPrefix(testcontainers.UniqueAlias("ldap"), "ldap-"))
+}
+
+func TestUniqueAlias_resolution(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	// run starts an instance of the service on the network, with a unique alias.
+	run := func(t *testing.T) (testcontainers.Container, string) {
+		t.Helper()
+
+		// uniqueAlias {
+		alias := testcontainers.UniqueAlias("service")
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "docker.io/busybox",
+				Cmd:   []string{"sleep", "60"},
+			},
+			Started: true,
+		}
+		require.NoError(t, network.WithNetwork([]string{alias}, nw)(&req))
+
+		ctr, err := testcontainers.GenericContainer(ctx, req)
+		// }
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		return ctr, alias
+	}
+
+	first, firstAlias := run(t)
+	second, secondAlias := run(t)
+	require.NotEqual(t, firstAlias, secondAlias)
+
+	// the client resolves the aliases from a third container on the network.
+	client, _ := run(t)
+
+	// each alias resolves to the IP of its own instance.
+	for ctr, alias := range map[testcontainers.Container]string{first: firstAlias, second: secondAlias} {
+		ip, err := ctr.ContainerIPByNetwork(ctx, nw.Name)
+		require.NoError(t, err)
+
+		code, r, err := client.Exec(ctx, []string{"nslookup", alias}, tcexec.Multiplexed())
+		require.NoError(t, err)
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Zero(t, code, string(output))
+
+		require.Contains(t, string(output), ip)
+	}
+}
+
+func TestNetworkAliases_duplicate(t *testing.T) {
+	ctx := context.Background()
+
+	nw, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	newRequest := func(alias string) testcontainers.GenericContainerRequest {
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: "docker.io/busybox",
+				Cmd:   []string{"sleep", "60"},
+			},
+			Started: true,
+		}
+		require.NoError(t, network.WithNetwork([]string{alias}, nw)(&req))
+
+		return req
+	}
+
+	first, err := testcontainers.GenericContainer(ctx, newRequest("service"))
+	terminateContainerOnEnd(t, ctx, first)
+	require.NoError(t, err)
+
+	// the same alias on the same network is rejected.
+	ctr, err := testcontainers.GenericContainer(ctx, newRequest("service"))
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.ErrorIs(t, err, testcontainers.ErrDuplicateAlias)
+
+	// a unique alias is accepted.
+	ctr, err = testcontainers.GenericContainer(ctx, newRequest(testcontainers.UniqueAlias("service")))
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// the alias of a stopped container is accepted.
+	require.NoError(t, first.Stop(ctx, nil))
+	ctr, err = testcontainers.GenericContainer(ctx, newRequest("service"))
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+}
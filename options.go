@@ -1,14 +1,22 @@
 package testcontainers
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"dario.cat/mergo"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/internal/core"
@@ -29,6 +37,60 @@ func (opt CustomizeRequestOption) Customize(req *GenericContainerRequest) error
 	return opt(req)
 }
 
+// OrderedRequestOption is a ContainerCustomizer declaring the priority it's applied with by the modules
+// sorting their options with SortOptions: the options with a lower priority are applied first, and the
+// options without a priority have a priority of 0. See WithFirst and WithLast.
+type OrderedRequestOption interface {
+	ContainerCustomizer
+	Priority() int
+}
+
+// orderedOption is a ContainerCustomizer with a priority.
+type orderedOption struct {
+	ContainerCustomizer
+	priority int
+}
+
+// Priority implements OrderedRequestOption.
+func (o orderedOption) Priority() int {
+	return o.priority
+}
+
+// WithFirst returns the option with a priority of -1, so that it's applied before the options without
+// a priority, e.g. to set defaults the user options can override. The wrapped option is only seen as
+// a ContainerCustomizer, so module-specific options must not be wrapped.
+func WithFirst(opt ContainerCustomizer) OrderedRequestOption {
+	return orderedOption{ContainerCustomizer: opt, priority: -1}
+}
+
+// WithLast returns the option with a priority of 1, so that it's applied after the options without
+// a priority, e.g. to resolve a setting from the user options. The wrapped option is only seen as
+// a ContainerCustomizer, so module-specific options must not be wrapped.
+func WithLast(opt ContainerCustomizer) OrderedRequestOption {
+	return orderedOption{ContainerCustomizer: opt, priority: 1}
+}
+
+// SortOptions returns the options sorted by priority, see OrderedRequestOption. The sort is stable,
+// so the options with the same priority, such as the options without a priority, keep their order.
+// The given slice is not modified.
+func SortOptions(opts []ContainerCustomizer) []ContainerCustomizer {
+	sorted := slices.Clone(opts)
+	slices.SortStableFunc(sorted, func(a, b ContainerCustomizer) int {
+		return cmp.Compare(optionPriority(a), optionPriority(b))
+	})
+
+	return sorted
+}
+
+// optionPriority returns the priority of the option, 0 if it doesn't declare one.
+func optionPriority(opt ContainerCustomizer) int {
+	if o, ok := opt.(OrderedRequestOption); ok {
+		return o.Priority()
+	}
+
+	return 0
+}
+
 // CustomizeRequest returns a function that can be used to merge the passed container request with the one that is used by the container.
 // Slices and Maps will be appended.
 func CustomizeRequest(src GenericContainerRequest) CustomizeRequestOption {
@@ -41,6 +103,24 @@ func CustomizeRequest(src GenericContainerRequest) CustomizeRequestOption {
 	}
 }
 
+// WithCmd completely replaces the command for a container
+func WithCmd(cmd ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.Cmd = cmd
+
+		return nil
+	}
+}
+
+// WithCmdArgs appends the command arguments to the command for a container
+func WithCmdArgs(cmdArgs ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.Cmd = append(req.Cmd, cmdArgs...)
+
+		return nil
+	}
+}
+
 // WithConfigModifier allows to override the default container config
 func WithConfigModifier(modifier func(config *container.Config)) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) error {
@@ -53,7 +133,45 @@ func WithConfigModifier(modifier func(config *container.Config)) CustomizeReques
 // WithEndpointSettingsModifier allows to override the default endpoint settings
 func WithEndpointSettingsModifier(modifier func(settings map[string]*network.EndpointSettings)) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) error {
-		req.EnpointSettingsModifier = modifier
+		req.EndpointSettingsModifier = modifier
+
+		return nil
+	}
+}
+
+// WithPreCreateInspector sets the hook observing the final configs right before the container is created.
+// It's a last-resort API, e.g. to debug the configs assembled from the request, the modifiers and the hooks.
+func WithPreCreateInspector(inspector func(config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig)) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.PreCreateInspector = inspector
+
+		return nil
+	}
+}
+
+// WithPreCreateHostHooks appends hooks run on the host right before the image is pulled and the container
+// is created, after the customizers and the validation of the request, see ContainerRequest.PreCreateHostHooks.
+func WithPreCreateHostHooks(hooks ...func(ctx context.Context, req *ContainerRequest) error) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.PreCreateHostHooks = append(req.PreCreateHostHooks, hooks...)
+
+		return nil
+	}
+}
+
+// WithEntrypoint completely replaces the entrypoint of a container
+func WithEntrypoint(entrypoint ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.Entrypoint = entrypoint
+
+		return nil
+	}
+}
+
+// WithEntrypointArgs appends the entrypoint arguments to the entrypoint of a container
+func WithEntrypointArgs(entrypointArgs ...string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.Entrypoint = append(req.Entrypoint, entrypointArgs...)
 
 		return nil
 	}
@@ -75,10 +193,64 @@ func WithEnv(envs map[string]string) CustomizeRequestOption {
 	}
 }
 
-// WithHostConfigModifier allows to override the default host config
+// WithTmpfs mounts a tmpfs at the given path of the container, with the given mount options,
+// e.g. "rw,size=256m,mode=1777", or the default ones of the daemon if empty. The content of
+// a tmpfs is kept in memory and lost when the container stops, e.g. for the data dir of a database.
+func WithTmpfs(path string, options string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if req.Tmpfs == nil {
+			req.Tmpfs = map[string]string{}
+		}
+
+		req.Tmpfs[path] = options
+
+		return nil
+	}
+}
+
+// WithMounts appends the given mounts to the container request, e.g. built with VolumeMount or TmpfsMount.
+// The host paths of the bind mounts must exist on the host of the tests, unless they are marked with
+// AllowMissingSource.
+func WithMounts(mounts ...ContainerMount) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		for _, m := range mounts {
+			if err := m.validateSource(); err != nil {
+				return err
+			}
+		}
+
+		req.Mounts = append(req.Mounts, mounts...)
+
+		return nil
+	}
+}
+
+// WithHostConfigModifier allows to override the default host config. The modifier is composed with
+// the HostConfigModifier already set, e.g. by a module or by another option, instead of replacing it,
+// and the modifiers are run in the order they were registered.
 func WithHostConfigModifier(modifier func(hostConfig *container.HostConfig)) CustomizeRequestOption {
 	return func(req *GenericContainerRequest) error {
-		req.HostConfigModifier = modifier
+		req.HostConfigModifier = composeHostConfigModifiers(req.HostConfigModifier, modifier)
+
+		return nil
+	}
+}
+
+// WithHostConfigOverride sets a modifier of the host config applied after all the other modifiers, without
+// their append semantics, so that it can remove the entries set by the modules or Testcontainers, e.g. a
+// capability or an extra host. The override is composed with the HostConfigOverride already set, if any.
+func WithHostConfigOverride(override func(hostConfig *container.HostConfig)) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.HostConfigOverride = composeHostConfigModifiers(req.HostConfigOverride, override)
+
+		return nil
+	}
+}
+
+// WithResourceLimits sets the limits of the CPU, memory and PIDs of the container, see ResourceLimits.
+func WithResourceLimits(limits ResourceLimits) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.ResourceLimits = limits
 
 		return nil
 	}
@@ -96,6 +268,78 @@ func WithHostPortAccess(ports ...int) CustomizeRequestOption {
 	}
 }
 
+// ErrPortInUse is returned when a container requests a fixed host port that is already
+// in use in the host.
+var ErrPortInUse = errors.New("port already in use")
+
+// WithExposedHostPort exposes the container port, binding it to the given fixed host port,
+// instead of a random one. It is useful for clients that need a well-known port in the host,
+// e.g. a hardcoded callback port. Before the container is created, the host port is checked
+// to be free when the Docker daemon runs locally, returning an error wrapping [ErrPortInUse] if not.
+// MappedPort returns the fixed host port for the container port.
+func WithExposedHostPort(hostPort int, containerPort nat.Port) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if hostPort < 1 || hostPort > 65535 {
+			return fmt.Errorf("invalid host port: %d", hostPort)
+		}
+
+		proto, port := nat.SplitProtoPort(string(containerPort))
+		if _, err := nat.ParsePort(port); err != nil || port == "" {
+			return fmt.Errorf("invalid container port: %s", containerPort)
+		}
+
+		req.ExposedPorts = append(req.ExposedPorts, fmt.Sprintf("%d:%s/%s", hostPort, port, proto))
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PreCreates: []ContainerRequestHook{
+				func(ctx context.Context, _ ContainerRequest) error {
+					if !isLocalDockerHost(core.ExtractDockerHost(ctx)) {
+						// the host port is bound in a remote host, so it cannot be checked from here.
+						return nil
+					}
+
+					if err := checkHostPortAvailable(hostPort, proto); err != nil {
+						if isAddrInUseErr(err) {
+							return fmt.Errorf("%w: host port %d for container port %s/%s: %w", ErrPortInUse, hostPort, port, proto, err)
+						}
+						return err
+					}
+
+					return nil
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// isLocalDockerHost returns true if the Docker host is reached through a local socket,
+// which means published ports are bound in the current host.
+func isLocalDockerHost(dockerHost string) bool {
+	return dockerHost == "" || strings.HasPrefix(dockerHost, "unix://") || strings.HasPrefix(dockerHost, "npipe://")
+}
+
+// checkHostPortAvailable checks if the host port is free for the given protocol,
+// binding it and releasing it right away.
+func checkHostPortAvailable(hostPort int, proto string) error {
+	addr := net.JoinHostPort("", strconv.Itoa(hostPort))
+
+	if proto == "udp" {
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return l.Close()
+}
+
 // Deprecated: the modules API forces passing the image as part of the signature of the Run function.
 // WithImage sets the image for a container
 func WithImage(image string) CustomizeRequestOption {
@@ -106,6 +350,22 @@ func WithImage(image string) CustomizeRequestOption {
 	}
 }
 
+// WithReuseByName sets the name of the container, reusing the container with that name if it's running,
+// instead of failing with a name conflict. A stopped container with that name, e.g. a leftover of a
+// previous run, is removed and recreated. See GenericContainerRequest.Reuse.
+func WithReuseByName(name string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if name == "" {
+			return ErrReuseEmptyName
+		}
+
+		req.Name = name
+		req.Reuse = true
+
+		return nil
+	}
+}
+
 // imageSubstitutor {
 
 // ImageSubstitutor represents a way to substitute container image names
@@ -233,6 +493,18 @@ func WithLogConsumers(consumer ...LogConsumer) CustomizeRequestOption {
 	}
 }
 
+// WithAttachedOutput streams the stdout and stderr of the container process to the given writers
+// as it happens, like docker run does, e.g. to os.Stdout and os.Stderr for local debugging.
+// A nil writer discards the output of its stream.
+func WithAttachedOutput(stdout io.Writer, stderr io.Writer) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		req.AttachStdout = stdout
+		req.AttachStderr = stderr
+
+		return nil
+	}
+}
+
 // Executable represents an executable command to be sent to a container, including options,
 // as part of the different lifecycle hooks.
 type Executable interface {
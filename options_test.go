@@ -3,8 +3,12 @@ package testcontainers_test
 import (
 	"context"
 	"io"
+	"net"
+	"slices"
+	"strconv"
 	"testing"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -213,6 +217,184 @@ func TestWithEnv(t *testing.T) {
 	}
 }
 
+func TestWithCmd(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Cmd: []string{"echo", "hello"},
+		},
+	}
+
+	require.NoError(t, testcontainers.WithCmd("sleep", "10").Customize(req))
+	require.Equal(t, []string{"sleep", "10"}, req.Cmd)
+
+	require.NoError(t, testcontainers.WithCmd().Customize(req))
+	require.Empty(t, req.Cmd)
+}
+
+func TestWithCmdArgs(t *testing.T) {
+	t.Run("add-nil", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+		require.NoError(t, testcontainers.WithCmdArgs("--verbose").Customize(req))
+		require.Equal(t, []string{"--verbose"}, req.Cmd)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Cmd: []string{"server"},
+			},
+		}
+		require.NoError(t, testcontainers.WithCmdArgs("--port", "8080").Customize(req))
+		require.Equal(t, []string{"server", "--port", "8080"}, req.Cmd)
+	})
+}
+
+func TestWithEntrypoint(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Entrypoint: []string{"/docker-entrypoint.sh"},
+		},
+	}
+
+	require.NoError(t, testcontainers.WithEntrypoint("/bin/sh", "-c").Customize(req))
+	require.Equal(t, []string{"/bin/sh", "-c"}, req.Entrypoint)
+
+	require.NoError(t, testcontainers.WithEntrypoint().Customize(req))
+	require.Empty(t, req.Entrypoint)
+}
+
+func TestWithEntrypointArgs(t *testing.T) {
+	t.Run("add-nil", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+		require.NoError(t, testcontainers.WithEntrypointArgs("/bin/sh").Customize(req))
+		require.Equal(t, []string{"/bin/sh"}, req.Entrypoint)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Entrypoint: []string{"/bin/sh"},
+			},
+		}
+		require.NoError(t, testcontainers.WithEntrypointArgs("-c", "exit 0").Customize(req))
+		require.Equal(t, []string{"/bin/sh", "-c", "exit 0"}, req.Entrypoint)
+	})
+}
+
+func TestWithTmpfs(t *testing.T) {
+	t.Run("add-nil", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+		require.NoError(t, testcontainers.WithTmpfs("/data", "rw,size=64m").Customize(req))
+		require.Equal(t, map[string]string{"/data": "rw,size=64m"}, req.Tmpfs)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Tmpfs: map[string]string{"/run": "rw"},
+			},
+		}
+		require.NoError(t, testcontainers.WithTmpfs("/data", "").Customize(req))
+		require.Equal(t, map[string]string{"/run": "rw", "/data": ""}, req.Tmpfs)
+	})
+}
+
+func TestWithHostConfigModifier(t *testing.T) {
+	var calls []string
+
+	req := &testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			// set directly by a module.
+			HostConfigModifier: func(hc *container.HostConfig) {
+				calls = append(calls, "module")
+				hc.CapAdd = []string{"IPC_LOCK"}
+			},
+		},
+	}
+
+	// stackHostConfigModifiers {
+	opts := []testcontainers.ContainerCustomizer{
+		testcontainers.WithHostConfigModifier(func(hc *container.HostConfig) {
+			calls = append(calls, "first")
+			hc.ShmSize = 1024 * 1024 * 1024
+		}),
+		testcontainers.WithHostConfigModifier(func(hc *container.HostConfig) {
+			calls = append(calls, "second")
+			hc.Privileged = true
+		}),
+	}
+	// }
+
+	for _, opt := range opts {
+		require.NoError(t, opt.Customize(req))
+	}
+
+	hc := &container.HostConfig{}
+	req.HostConfigModifier(hc)
+
+	// the modifiers are run in the order they were registered.
+	require.Equal(t, []string{"module", "first", "second"}, calls)
+	require.Equal(t, []string{"IPC_LOCK"}, []string(hc.CapAdd))
+	require.Equal(t, int64(1024*1024*1024), hc.ShmSize)
+	require.True(t, hc.Privileged)
+}
+
+func TestWithHostConfigOverride(t *testing.T) {
+	req := &testcontainers.GenericContainerRequest{}
+	req.AddHostConfigModifier(func(hc *container.HostConfig) {
+		// set by a module.
+		hc.CapAdd = append(hc.CapAdd, "IPC_LOCK", "SYS_NICE")
+	})
+
+	// overrideHostConfig {
+	opt := testcontainers.WithHostConfigOverride(func(hc *container.HostConfig) {
+		hc.CapAdd = slices.DeleteFunc(hc.CapAdd, func(c string) bool { return c == "SYS_NICE" })
+	})
+	// }
+	require.NoError(t, opt.Customize(req))
+
+	hc := &container.HostConfig{}
+	for _, modifier := range req.HostConfigModifiers {
+		modifier(hc)
+	}
+	req.HostConfigOverride(hc)
+
+	require.Equal(t, []string{"IPC_LOCK"}, []string(hc.CapAdd))
+}
+
+func TestSortOptions(t *testing.T) {
+	var applied []string
+	option := func(name string) testcontainers.CustomizeRequestOption {
+		return func(req *testcontainers.GenericContainerRequest) error {
+			applied = append(applied, name)
+			return nil
+		}
+	}
+
+	// sortOptions {
+	opts := []testcontainers.ContainerCustomizer{
+		option("user-1"),
+		testcontainers.WithLast(option("last-1")),
+		option("user-2"),
+		testcontainers.WithFirst(option("first")),
+		testcontainers.WithLast(option("last-2")),
+		option("user-3"),
+	}
+
+	req := &testcontainers.GenericContainerRequest{}
+	for _, opt := range testcontainers.SortOptions(opts) {
+		require.NoError(t, opt.Customize(req))
+	}
+	// }
+
+	// the options with the same priority keep their order.
+	require.Equal(t, []string{"first", "user-1", "user-2", "user-3", "last-1", "last-2"}, applied)
+
+	// the options of the caller are not modified.
+	require.Equal(t, -1, opts[3].(testcontainers.OrderedRequestOption).Priority())
+	require.Equal(t, 1, opts[1].(testcontainers.OrderedRequestOption).Priority())
+}
+
 func TestWithHostPortAccess(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -245,3 +427,83 @@ func TestWithHostPortAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestWithExposedHostPort(t *testing.T) {
+	t.Run("exposes-port-with-binding", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				ExposedPorts: []string{"9090/tcp"},
+			},
+		}
+
+		require.NoError(t, testcontainers.WithExposedHostPort(8080, "80/tcp").Customize(req))
+		require.NoError(t, testcontainers.WithExposedHostPort(5353, "53/udp").Customize(req))
+		require.Equal(t, []string{"9090/tcp", "8080:80/tcp", "5353:53/udp"}, req.ExposedPorts)
+		require.Len(t, req.LifecycleHooks, 2)
+	})
+
+	t.Run("invalid-ports", func(t *testing.T) {
+		req := &testcontainers.GenericContainerRequest{}
+
+		require.Error(t, testcontainers.WithExposedHostPort(0, "80/tcp").Customize(req))
+		require.Error(t, testcontainers.WithExposedHostPort(70000, "80/tcp").Customize(req))
+		require.Error(t, testcontainers.WithExposedHostPort(8080, "http").Customize(req))
+		require.Empty(t, req.ExposedPorts)
+	})
+
+	t.Run("fixed-host-port", func(t *testing.T) {
+		ctx := context.Background()
+
+		hostPort := freeHostPort(t)
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      nginxAlpineImage,
+				WaitingFor: wait.ForListeningPort(nginxDefaultPort),
+			},
+			Started: true,
+		}
+		require.NoError(t, testcontainers.WithExposedHostPort(hostPort, nginxDefaultPort).Customize(&req))
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, c)
+		require.NoError(t, err)
+
+		mappedPort, err := c.MappedPort(ctx, nginxDefaultPort)
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(hostPort), mappedPort.Port())
+	})
+
+	t.Run("host-port-in-use", func(t *testing.T) {
+		ctx := context.Background()
+
+		l, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, l.Close()) })
+
+		hostPort := l.Addr().(*net.TCPAddr).Port
+
+		req := testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image: nginxAlpineImage,
+			},
+			Started: true,
+		}
+		require.NoError(t, testcontainers.WithExposedHostPort(hostPort, nginxDefaultPort).Customize(&req))
+
+		c, err := testcontainers.GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, c)
+		require.ErrorIs(t, err, testcontainers.ErrPortInUse)
+	})
+}
+
+// freeHostPort returns a TCP port that is free in the host at the moment of the call.
+func freeHostPort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
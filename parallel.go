@@ -3,11 +3,20 @@ package testcontainers
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"slices"
 	"sync"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
 )
 
 const (
 	defaultWorkersCount = 8
+
+	// staggerJitter is the jitter of the delays between the creations of the containers
+	// started in parallel, as a fraction of the stagger interval.
+	staggerJitter = 0.25
 )
 
 type ParallelContainerRequest []GenericContainerRequest
@@ -15,6 +24,14 @@ type ParallelContainerRequest []GenericContainerRequest
 // ParallelContainersOptions represents additional options for parallel running
 type ParallelContainersOptions struct {
 	WorkersCount int // count of parallel workers. If field empty(zero), default value will be 'defaultWorkersCount'
+
+	// StaggerInterval is the mean delay between the creations of the containers, jittered by ±25%,
+	// so that the Docker daemon and its disk aren't thrashed by many containers created at once.
+	// Only the creations, and so the starts, are delayed: the pulls of the images happen before,
+	// and overlap with the waits of the containers. If zero, the value of the
+	// parallel.stagger.interval property is used, and a negative value disables it. It doesn't
+	// apply to a single container.
+	StaggerInterval time.Duration
 }
 
 // ParallelContainersRequestError represents error from parallel request
@@ -31,18 +48,97 @@ func (gpe ParallelContainersError) Error() string {
 	return fmt.Sprintf("%v", gpe.Errors)
 }
 
+// parallelTask is a request to run in parallel, with the offset from the start of the parallel
+// run its container is created at.
+type parallelTask struct {
+	req    GenericContainerRequest
+	offset time.Duration
+}
+
+// staggerClock abstracts the time for the staggered start, so that it can be faked in the tests.
+type staggerClock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the staggerClock of the actual time.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// stagger delays the creations of the containers until their offset from the start of the parallel run.
+type stagger struct {
+	clock staggerClock
+	start time.Time
+}
+
+// wait waits until the given offset from the start, or until the context is done.
+func (s stagger) wait(ctx context.Context, offset time.Duration) error {
+	d := offset - s.clock.Now().Sub(s.start)
+	if d <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.clock.After(d):
+		return nil
+	}
+}
+
+// request returns the request of the task, delaying the creation of its container until the offset
+// of the task with a PreCreates hook, so that the pull of its image, which happens before, isn't delayed.
+// The hooks of the task's request aren't modified.
+func (s stagger) request(task parallelTask) GenericContainerRequest {
+	req := task.req
+	if task.offset <= 0 {
+		return req
+	}
+
+	req.LifecycleHooks = append(slices.Clone(req.LifecycleHooks), ContainerLifecycleHooks{
+		PreCreates: []ContainerRequestHook{
+			func(ctx context.Context, _ ContainerRequest) error {
+				return s.wait(ctx, task.offset)
+			},
+		},
+	})
+
+	return req
+}
+
+// staggerOffsets returns the offsets from the start of the parallel run the n containers are created at:
+// the first one right away, and each next one after a delay of the interval jittered by ±25%, with the
+// random numbers in [0, 1) returned by random. All the offsets are zero for a single container, or if
+// the interval is not positive.
+func staggerOffsets(n int, interval time.Duration, random func() float64) []time.Duration {
+	offsets := make([]time.Duration, n)
+	if n <= 1 || interval <= 0 {
+		return offsets
+	}
+
+	for i := 1; i < n; i++ {
+		delay := float64(interval) * (1 + staggerJitter*(2*random()-1))
+		offsets[i] = offsets[i-1] + time.Duration(delay)
+	}
+
+	return offsets
+}
+
 func parallelContainersRunner(
 	ctx context.Context,
-	requests <-chan GenericContainerRequest,
+	tasks <-chan parallelTask,
+	s stagger,
 	errors chan<- ParallelContainersRequestError,
 	containers chan<- Container,
 	wg *sync.WaitGroup,
 ) {
-	for req := range requests {
-		c, err := GenericContainer(ctx, req)
+	for task := range tasks {
+		c, err := GenericContainer(ctx, s.request(task))
 		if err != nil {
 			errors <- ParallelContainersRequestError{
-				Request: req,
+				Request: task.req,
 				Error:   err,
 			}
 			continue
@@ -58,12 +154,16 @@ func ParallelContainers(ctx context.Context, reqs ParallelContainerRequest, opt
 		opt.WorkersCount = defaultWorkersCount
 	}
 
+	if opt.StaggerInterval == 0 {
+		opt.StaggerInterval = config.Read().ParallelStaggerInterval
+	}
+
 	tasksChanSize := opt.WorkersCount
 	if tasksChanSize > len(reqs) {
 		tasksChanSize = len(reqs)
 	}
 
-	tasksChan := make(chan GenericContainerRequest, tasksChanSize)
+	tasksChan := make(chan parallelTask, tasksChanSize)
 	errsChan := make(chan ParallelContainersRequestError)
 	resChan := make(chan Container)
 	waitRes := make(chan struct{})
@@ -74,9 +174,12 @@ func ParallelContainers(ctx context.Context, reqs ParallelContainerRequest, opt
 	wg := sync.WaitGroup{}
 	wg.Add(tasksChanSize)
 
+	offsets := staggerOffsets(len(reqs), opt.StaggerInterval, rand.Float64)
+	s := stagger{clock: realClock{}, start: time.Now()}
+
 	// run workers
 	for i := 0; i < tasksChanSize; i++ {
-		go parallelContainersRunner(ctx, tasksChan, errsChan, resChan, &wg)
+		go parallelContainersRunner(ctx, tasksChan, s, errsChan, resChan, &wg)
 	}
 
 	go func() {
@@ -103,8 +206,8 @@ func ParallelContainers(ctx context.Context, reqs ParallelContainerRequest, opt
 		}
 	}()
 
-	for _, req := range reqs {
-		tasksChan <- req
+	for i, req := range reqs {
+		tasksChan <- parallelTask{req: req, offset: offsets[i]}
 	}
 	close(tasksChan)
 	wg.Wait()
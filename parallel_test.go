@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -165,3 +166,189 @@ func TestParallelContainersWithReuse(t *testing.T) {
 	// Container is reused, only terminate first container
 	terminateContainerOnEnd(t, ctx, res[0])
 }
+
+func TestStaggerOffsets(t *testing.T) {
+	constant := func(v float64) func() float64 {
+		return func() float64 { return v }
+	}
+
+	t.Run("no-jitter", func(t *testing.T) {
+		offsets := staggerOffsets(4, 100*time.Millisecond, constant(0.5))
+		require.Equal(t, []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}, offsets)
+	})
+
+	t.Run("jitter-bounds", func(t *testing.T) {
+		require.Equal(t, []time.Duration{0, 75 * time.Millisecond, 150 * time.Millisecond}, staggerOffsets(3, 100*time.Millisecond, constant(0)))
+		require.Equal(t, []time.Duration{0, 125 * time.Millisecond, 250 * time.Millisecond}, staggerOffsets(3, 100*time.Millisecond, constant(1)))
+	})
+
+	t.Run("single-container", func(t *testing.T) {
+		require.Equal(t, []time.Duration{0}, staggerOffsets(1, time.Second, constant(1)))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		require.Equal(t, []time.Duration{0, 0, 0}, staggerOffsets(3, 0, constant(1)))
+		require.Equal(t, []time.Duration{0, 0, 0}, staggerOffsets(3, -time.Second, constant(1)))
+	})
+}
+
+// fakeClock is a staggerClock whose time only moves when advanced, recording the waited durations.
+type fakeClock struct {
+	mtx    sync.Mutex
+	now    time.Time
+	waited []time.Duration
+	timers []chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.waited = append(c.waited, d)
+	c.timers = append(c.timers, ch)
+	return ch
+}
+
+// fire advances the clock by the given duration and fires the pending timers.
+func (c *fakeClock) fire(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, ch := range c.timers {
+		ch <- c.now
+	}
+	c.timers = nil
+}
+
+func TestStagger_wait(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("waits-for-offset", func(t *testing.T) {
+		clock := &fakeClock{now: start.Add(30 * time.Millisecond)}
+		s := stagger{clock: clock, start: start}
+
+		done := make(chan error)
+		go func() { done <- s.wait(context.Background(), 100*time.Millisecond) }()
+
+		require.Eventually(t, func() bool {
+			clock.mtx.Lock()
+			defer clock.mtx.Unlock()
+			return len(clock.timers) == 1
+		}, time.Second, time.Millisecond)
+		clock.fire(70 * time.Millisecond)
+
+		require.NoError(t, <-done)
+		require.Equal(t, []time.Duration{70 * time.Millisecond}, clock.waited)
+	})
+
+	t.Run("offset-passed", func(t *testing.T) {
+		clock := &fakeClock{now: start.Add(time.Second)}
+		s := stagger{clock: clock, start: start}
+
+		require.NoError(t, s.wait(context.Background(), 100*time.Millisecond))
+		require.Empty(t, clock.waited)
+	})
+
+	t.Run("context-done", func(t *testing.T) {
+		clock := &fakeClock{now: start}
+		s := stagger{clock: clock, start: start}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.ErrorIs(t, s.wait(ctx, time.Hour), context.Canceled)
+	})
+}
+
+func TestStagger_request(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("first-container", func(t *testing.T) {
+		s := stagger{clock: &fakeClock{now: start}, start: start}
+		req := GenericContainerRequest{}
+
+		require.Equal(t, req, s.request(parallelTask{req: req}))
+	})
+
+	t.Run("pre-create-hook", func(t *testing.T) {
+		clock := &fakeClock{now: start}
+		s := stagger{clock: clock, start: start}
+
+		userHooks := []ContainerLifecycleHooks{{PreCreates: []ContainerRequestHook{
+			func(context.Context, ContainerRequest) error { return nil },
+		}}}
+		req := GenericContainerRequest{ContainerRequest: ContainerRequest{LifecycleHooks: userHooks}}
+
+		staggered := s.request(parallelTask{req: req, offset: 100 * time.Millisecond})
+		require.Len(t, req.LifecycleHooks, 1)
+		require.Len(t, staggered.LifecycleHooks, 2)
+
+		// the creation is delayed by the hook, run once the image is pulled.
+		done := make(chan error)
+		go func() {
+			done <- staggered.LifecycleHooks[1].PreCreates[0](context.Background(), staggered.ContainerRequest)
+		}()
+
+		require.Eventually(t, func() bool {
+			clock.mtx.Lock()
+			defer clock.mtx.Unlock()
+			return len(clock.timers) == 1
+		}, time.Second, time.Millisecond)
+		clock.fire(100 * time.Millisecond)
+
+		require.NoError(t, <-done)
+		require.Equal(t, []time.Duration{100 * time.Millisecond}, clock.waited)
+	})
+}
+
+// BenchmarkParallelContainersStagger compares the wall time of starting 10 trivial containers
+// in parallel, with and without a staggered start, e.g. on a runner with a constrained daemon:
+//
+//	go test -run '^$' -bench BenchmarkParallelContainersStagger -benchtime 3x
+func BenchmarkParallelContainersStagger(b *testing.B) {
+	reqs := make(ParallelContainerRequest, 10)
+	for i := range reqs {
+		reqs[i] = GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image:      "docker.io/alpine",
+				Cmd:        []string{"sh", "-c", "echo ready && sleep 30"},
+				WaitingFor: wait.ForLog("ready"),
+			},
+			Started: true,
+		}
+	}
+
+	for _, interval := range []time.Duration{-1, 100 * time.Millisecond, 250 * time.Millisecond} {
+		name := "no-stagger"
+		if interval > 0 {
+			name = "stagger-" + interval.String()
+		}
+
+		b.Run(name, func(b *testing.B) {
+			ctx := context.Background()
+			for i := 0; i < b.N; i++ {
+				// staggeredStart {
+				res, err := ParallelContainers(ctx, reqs, ParallelContainersOptions{
+					WorkersCount:    10,
+					StaggerInterval: interval,
+				})
+				// }
+				b.StopTimer()
+				for _, c := range res {
+					require.NoError(b, c.Terminate(ctx))
+				}
+				require.NoError(b, err)
+				b.StartTimer()
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+)
+
+// ErrInvalidHostPortBindingIP is returned when the host IP the ports of a container request
+// are published on is not an IP address.
+var ErrInvalidHostPortBindingIP = errors.New("invalid host port binding IP")
+
+// validateHostPortBindingIP ensures that the host IP the ports are published on, if set, is an IP address.
+func (c *ContainerRequest) validateHostPortBindingIP() error {
+	if c.HostPortBindingIP == "" || net.ParseIP(c.HostPortBindingIP) != nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", ErrInvalidHostPortBindingIP, c.HostPortBindingIP)
+}
+
+// applyHostPortBindingIP publishes the ports without a host IP, e.g. "8080/tcp" but not
+// "127.0.0.1::8080/tcp", on the given host IP, instead of the default one of the daemon.
+func applyHostPortBindingIP(portMap nat.PortMap, ip string) {
+	if ip == "" {
+		return
+	}
+
+	for port, bindings := range portMap {
+		for i := range bindings {
+			if bindings[i].HostIP == "" {
+				bindings[i].HostIP = ip
+			}
+		}
+		portMap[port] = bindings
+	}
+}
+
+// portsBindingIP returns the host IP all the ports are published on, if it's a specific one,
+// or an empty string if any port is published on all the interfaces, or on different IPs.
+func portsBindingIP(ports nat.PortMap) string {
+	var bindings []nat.PortBinding
+	for _, b := range ports {
+		bindings = append(bindings, b...)
+	}
+
+	return bindingsIP(bindings)
+}
+
+// publishedPortsBindingIP returns the host IP all the published ports of a listed container are
+// published on, if it's a specific one, see portsBindingIP.
+func publishedPortsBindingIP(ports []types.Port) string {
+	var bindings []nat.PortBinding
+	for _, p := range ports {
+		if p.PublicPort != 0 {
+			bindings = append(bindings, nat.PortBinding{HostIP: p.IP})
+		}
+	}
+
+	return bindingsIP(bindings)
+}
+
+// portBindingIP returns the specific host IP the given host port is published on, if any.
+func portBindingIP(ports nat.PortMap, hostPort nat.Port) string {
+	var bindings []nat.PortBinding
+	for port, b := range ports {
+		if port.Proto() != hostPort.Proto() {
+			continue
+		}
+
+		for _, binding := range b {
+			if binding.HostPort == hostPort.Port() {
+				bindings = append(bindings, binding)
+			}
+		}
+	}
+
+	return bindingsIP(bindings)
+}
+
+// bindingsIP returns the host IP shared by all the bindings, if it's a specific one.
+func bindingsIP(bindings []nat.PortBinding) string {
+	ip := ""
+	for _, b := range bindings {
+		parsed := net.ParseIP(b.HostIP)
+		if parsed == nil || parsed.IsUnspecified() {
+			return ""
+		}
+
+		if ip != "" && ip != b.HostIP {
+			return ""
+		}
+		ip = b.HostIP
+	}
+
+	return ip
+}
+
+// hostForBindingIP returns the host the ports published on the given IP are reached on: the IP
+// itself if it's a specific one, unless it's a loopback IP of a daemon which is not local, or the
+// host is overridden with TESTCONTAINERS_HOST_OVERRIDE, in which case it's the given daemon host.
+func hostForBindingIP(daemonHost string, ip string) string {
+	if _, overridden := os.LookupEnv("TESTCONTAINERS_HOST_OVERRIDE"); ip == "" || overridden {
+		return daemonHost
+	}
+
+	if net.ParseIP(ip).IsLoopback() && daemonHost != "localhost" {
+		return daemonHost
+	}
+
+	return ip
+}
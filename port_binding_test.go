@@ -0,0 +1,218 @@
+package testcontainers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestContainerRequest_validateHostPortBindingIP(t *testing.T) {
+	for _, ip := range []string{"", "127.0.0.1", "::1", "10.0.0.5"} {
+		t.Run("valid/"+ip, func(t *testing.T) {
+			req := ContainerRequest{HostPortBindingIP: ip}
+			require.NoError(t, req.validateHostPortBindingIP())
+		})
+	}
+
+	for _, ip := range []string{"localhost", "127.0.0.1:80", "eth0"} {
+		t.Run("invalid/"+ip, func(t *testing.T) {
+			req := ContainerRequest{Image: nginxAlpineImage, HostPortBindingIP: ip}
+			require.ErrorIs(t, req.validateHostPortBindingIP(), ErrInvalidHostPortBindingIP)
+			require.ErrorIs(t, req.Validate(), ErrInvalidHostPortBindingIP)
+		})
+	}
+}
+
+func TestApplyHostPortBindingIP(t *testing.T) {
+	_, portMap, err := nat.ParsePortSpecs([]string{"80/tcp", "10.0.0.5::443/tcp", "53/udp"})
+	require.NoError(t, err)
+
+	applyHostPortBindingIP(portMap, "127.0.0.1")
+
+	require.Equal(t, nat.PortMap{
+		"80/tcp":  {{HostIP: "127.0.0.1"}},
+		"443/tcp": {{HostIP: "10.0.0.5"}},
+		"53/udp":  {{HostIP: "127.0.0.1"}},
+	}, nat.PortMap(portMap))
+}
+
+func TestPortsBindingIP(t *testing.T) {
+	tests := []struct {
+		name  string
+		ports nat.PortMap
+		want  string
+	}{
+		{name: "no-ports", ports: nat.PortMap{}, want: ""},
+		{
+			name: "all-interfaces",
+			ports: nat.PortMap{
+				"80/tcp": {{HostIP: "0.0.0.0", HostPort: "32768"}, {HostIP: "::", HostPort: "32768"}},
+			},
+			want: "",
+		},
+		{
+			name: "loopback",
+			ports: nat.PortMap{
+				"80/tcp":  {{HostIP: "127.0.0.1", HostPort: "32768"}},
+				"443/tcp": {{HostIP: "127.0.0.1", HostPort: "32769"}},
+			},
+			want: "127.0.0.1",
+		},
+		{
+			name: "mixed",
+			ports: nat.PortMap{
+				"80/tcp":  {{HostIP: "127.0.0.1", HostPort: "32768"}},
+				"443/tcp": {{HostIP: "0.0.0.0", HostPort: "32769"}},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, portsBindingIP(tt.ports))
+		})
+	}
+
+	t.Run("per-port", func(t *testing.T) {
+		ports := nat.PortMap{
+			"80/tcp":  {{HostIP: "127.0.0.1", HostPort: "32768"}},
+			"443/tcp": {{HostIP: "0.0.0.0", HostPort: "32769"}},
+		}
+
+		require.Equal(t, "127.0.0.1", portBindingIP(ports, "32768/tcp"))
+		require.Equal(t, "", portBindingIP(ports, "32769/tcp"))
+		require.Equal(t, "", portBindingIP(ports, "32768/udp"))
+	})
+
+	t.Run("published-ports", func(t *testing.T) {
+		require.Equal(t, "127.0.0.1", publishedPortsBindingIP([]types.Port{
+			{IP: "127.0.0.1", PrivatePort: 80, PublicPort: 32768, Type: "tcp"},
+			{PrivatePort: 443, Type: "tcp"},
+		}))
+		require.Equal(t, "", publishedPortsBindingIP([]types.Port{
+			{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 32768, Type: "tcp"},
+			{IP: "::", PrivatePort: 80, PublicPort: 32768, Type: "tcp"},
+		}))
+	})
+}
+
+func TestHostForBindingIP(t *testing.T) {
+	require.Equal(t, "localhost", hostForBindingIP("localhost", ""))
+	require.Equal(t, "127.0.0.1", hostForBindingIP("localhost", "127.0.0.1"))
+	require.Equal(t, "10.0.0.5", hostForBindingIP("remote.example.com", "10.0.0.5"))
+	// the loopback of a remote daemon is not reachable.
+	require.Equal(t, "remote.example.com", hostForBindingIP("remote.example.com", "127.0.0.1"))
+
+	t.Setenv("TESTCONTAINERS_HOST_OVERRIDE", "override.example.com")
+	require.Equal(t, "override.example.com", hostForBindingIP("override.example.com", "127.0.0.1"))
+}
+
+func TestHostPortBindingIP(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	daemonHost, err := provider.DaemonHost(ctx)
+	require.NoError(t, err)
+	if daemonHost != "localhost" {
+		t.Skip("the ports published on the loopback interface are only reachable with a local daemon")
+	}
+
+	tests := []struct {
+		name string
+		req  ContainerRequest
+	}{
+		{
+			name: "request",
+			// hostPortBindingIP {
+			req: ContainerRequest{
+				Image:             nginxAlpineImage,
+				ExposedPorts:      []string{nginxDefaultPort},
+				HostPortBindingIP: "127.0.0.1",
+				WaitingFor:        wait.ForListeningPort(nginxDefaultPort),
+			},
+			// }
+		},
+		{
+			name: "port-spec",
+			req: ContainerRequest{
+				Image:        nginxAlpineImage,
+				ExposedPorts: []string{"127.0.0.1::" + nginxDefaultPort},
+				WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctr, err := GenericContainer(ctx, GenericContainerRequest{
+				ProviderType:     providerType,
+				ContainerRequest: tt.req,
+				Started:          true,
+			})
+			terminateContainerOnEnd(t, ctx, ctr)
+			require.NoError(t, err)
+
+			inspect, err := ctr.Inspect(ctx)
+			require.NoError(t, err)
+			for port, bindings := range inspect.NetworkSettings.Ports {
+				for _, b := range bindings {
+					require.Equal(t, "127.0.0.1", b.HostIP, port)
+				}
+			}
+
+			host, err := ctr.Host(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "127.0.0.1", host)
+
+			endpoint, err := ctr.PortEndpoint(ctx, nginxDefaultPort, "http")
+			require.NoError(t, err)
+
+			mapped, err := ctr.MappedPort(ctx, nginxDefaultPort)
+			require.NoError(t, err)
+			require.Equal(t, "http://127.0.0.1:"+mapped.Port(), endpoint)
+
+			resp, err := http.Get(endpoint)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			// the port is not published on the other interfaces.
+			ip := nonLoopbackIP(t)
+			conn, err := net.DialTimeout("tcp", net.JoinHostPort(ip, mapped.Port()), time.Second)
+			if err == nil {
+				conn.Close()
+			}
+			require.Error(t, err, "connection from %s", ip)
+		})
+	}
+}
+
+// nonLoopbackIP returns an IPv4 address of an interface of the host other than the loopback one.
+func nonLoopbackIP(t *testing.T) string {
+	t.Helper()
+
+	addrs, err := net.InterfaceAddrs()
+	require.NoError(t, err)
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			return ipNet.IP.String()
+		}
+	}
+
+	t.Skip("no interface other than the loopback one")
+	return ""
+}
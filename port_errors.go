@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package testcontainers
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isAddrInUseErr returns true if the error reports that the address is already in use.
+func isAddrInUseErr(err error) bool {
+	return errors.Is(err, syscall.EADDRINUSE)
+}
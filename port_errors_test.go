@@ -0,0 +1,21 @@
+package testcontainers
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHostPortAvailable_inUse(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, l.Close()) })
+
+	err = checkHostPortAvailable(l.Addr().(*net.TCPAddr).Port, "tcp")
+	require.Error(t, err)
+	require.True(t, isAddrInUseErr(err), err)
+
+	require.False(t, isAddrInUseErr(errors.New("permission denied")))
+}
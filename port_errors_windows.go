@@ -0,0 +1,12 @@
+package testcontainers
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+)
+
+// isAddrInUseErr returns true if the error reports that the address is already in use.
+func isAddrInUseErr(err error) bool {
+	return errors.Is(err, windows.WSAEADDRINUSE)
+}
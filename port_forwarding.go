@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -64,8 +65,9 @@ func exposeHostPorts(ctx context.Context, req *ContainerRequest, ports ...int) (
 		}
 
 		dockerNw := DockerNetwork{
-			ID:   nw.ID,
-			Name: nw.Name,
+			ID:     nw.ID,
+			Name:   nw.Name,
+			Labels: nw.Labels,
 		}
 
 		// WithNetwork reuses an already existing network, attaching the container to it.
@@ -95,19 +97,18 @@ func exposeHostPorts(ctx context.Context, req *ContainerRequest, ports ...int) (
 		return sshdConnectHook, fmt.Errorf("new sshd container: %w", err)
 	}
 
-	// IP in the first network of the container
-	sshdIP, err := sshdContainer.ContainerIP(context.Background())
+	// IP in the first network of the container, or in the default network if the container has none.
+	var sshdIP string
+	if sshdFirstNetwork != "" {
+		sshdIP, err = sshdContainer.ContainerIPByNetwork(context.Background(), sshdFirstNetwork)
+	} else {
+		sshdIP, err = sshdContainer.ContainerIP(context.Background())
+	}
 	if err != nil {
 		return sshdConnectHook, fmt.Errorf("get sshd container IP: %w", err)
 	}
 
-	if req.HostConfigModifier == nil {
-		req.HostConfigModifier = func(hostConfig *container.HostConfig) {}
-	}
-
-	// do not override the original HostConfigModifier
-	originalHCM := req.HostConfigModifier
-	req.HostConfigModifier = func(hostConfig *container.HostConfig) {
+	req.AddHostConfigModifier(func(hostConfig *container.HostConfig) {
 		// adding the host internal alias to the container as an extra host
 		// to allow the container to reach the SSHD container.
 		hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, fmt.Sprintf("%s:%s", HostInternal, sshdIP))
@@ -124,23 +125,22 @@ func exposeHostPorts(ctx context.Context, req *ContainerRequest, ports ...int) (
 		if !found {
 			req.Networks = append(req.Networks, sshdFirstNetwork)
 		}
+	})
 
-		// invoke the original HostConfigModifier with the updated hostConfig
-		originalHCM(hostConfig)
-	}
-
-	// after the container is ready, create the SSH tunnel
-	// for each exposed port from the host.
+	// after the container is ready, create the SSH tunnel for each exposed port from the host,
+	// or re-establish the tunnels which were torn down once the container is restarted.
 	sshdConnectHook = ContainerLifecycleHooks{
 		PostReadies: []ContainerHook{
 			func(ctx context.Context, c Container) error {
 				return sshdContainer.exposeHostPort(ctx, req.HostAccessPorts...)
 			},
 		},
-		PreTerminates: []ContainerHook{
-			func(ctx context.Context, _ Container) error {
-				// before killing the container, close the SSH sessions
-				return sshdContainer.Terminate(ctx)
+		PostCreates: []ContainerHook{
+			func(_ context.Context, c Container) error {
+				// the SSH sessions are closed, and the SSHD container terminated, once the
+				// PreTerminates hooks of the container are executed, before removing it.
+				c.(*DockerContainer).addHelper(sshdContainer)
+				return nil
 			},
 		},
 	}
@@ -178,7 +178,7 @@ func newSshdContainer(ctx context.Context, opts ...ContainerCustomizer) (*sshdCo
 
 	sshd := &sshdContainer{
 		DockerContainer: dc,
-		portForwarders:  []PortForwarder{},
+		portForwarders:  map[int]*PortForwarder{},
 	}
 
 	sshClientConfig, err := configureSSHConfig(ctx, sshd)
@@ -196,18 +196,25 @@ func newSshdContainer(ctx context.Context, opts ...ContainerCustomizer) (*sshdCo
 // It's an internal type that extends the DockerContainer type, to add the SSH tunneling capabilities.
 type sshdContainer struct {
 	*DockerContainer
-	port           string
-	sshConfig      *ssh.ClientConfig
-	portForwarders []PortForwarder
+	port      string
+	sshConfig *ssh.ClientConfig
+
+	// portForwarders are the forwarders of the tunnels, keyed by host port, kept across
+	// the restarts of the container.
+	portForwarders   map[int]*PortForwarder
+	portForwardersMx sync.Mutex
 }
 
-// Terminate stops the container and closes the SSH session
-func (sshdC *sshdContainer) Terminate(ctx context.Context) error {
+// Terminate closes the SSH sessions and terminates the container.
+// It's safe to call it more than once, and concurrently.
+func (sshdC *sshdContainer) Terminate(ctx context.Context, opts ...TerminateOption) error {
+	sshdC.portForwardersMx.Lock()
 	for _, pfw := range sshdC.portForwarders {
 		pfw.Close(ctx)
 	}
+	sshdC.portForwardersMx.Unlock()
 
-	return sshdC.DockerContainer.Terminate(ctx)
+	return sshdC.DockerContainer.Terminate(ctx, opts...)
 }
 
 func configureSSHConfig(ctx context.Context, sshdC *sshdContainer) (*ssh.ClientConfig, error) {
@@ -227,10 +234,33 @@ func configureSSHConfig(ctx context.Context, sshdC *sshdContainer) (*ssh.ClientC
 	return &sshConfig, nil
 }
 
+// exposeHostPort creates the tunnels of the given host ports, and checks that the SSHD container
+// accepts connections on them. It's idempotent: the tunnels already created are reused, unless
+// they were torn down, e.g. because the context they were created with is done, in which case
+// they are created again, so that the host ports are still exposed once the container is restarted.
 func (sshdC *sshdContainer) exposeHostPort(ctx context.Context, ports ...int) error {
+	sshdC.portForwardersMx.Lock()
+	defer sshdC.portForwardersMx.Unlock()
+
+	var created []*PortForwarder
 	for _, port := range ports {
+		if pfw, ok := sshdC.portForwarders[port]; ok {
+			if pfw.running() && sshdC.checkTunnel(port) == nil {
+				continue
+			}
+
+			// the tunnel is broken: close it so that the remote port is released.
+			pfw.Close(ctx)
+			select {
+			case <-pfw.stopped:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
 		pw := NewPortForwarder(fmt.Sprintf("localhost:%s", sshdC.port), sshdC.sshConfig, port, port)
-		sshdC.portForwarders = append(sshdC.portForwarders, *pw)
+		sshdC.portForwarders[port] = pw
+		created = append(created, pw)
 
 		go pw.Forward(ctx) //nolint:errcheck // Nothing we can usefully do with the error
 	}
@@ -238,13 +268,38 @@ func (sshdC *sshdContainer) exposeHostPort(ctx context.Context, ports ...int) er
 	var err error
 
 	// continue when all port forwarders have created the connection
-	for _, pfw := range sshdC.portForwarders {
+	for _, pfw := range created {
 		err = errors.Join(err, <-pfw.connectionCreated)
 	}
+	if err != nil {
+		return err
+	}
+
+	// the container is ready once the tunnels accept connections.
+	for _, pfw := range created {
+		err = errors.Join(err, sshdC.checkTunnel(pfw.remotePort))
+	}
 
 	return err
 }
 
+// checkTunnel checks that the SSHD container accepts connections on the tunnel of the given
+// host port, as the container connects to it.
+func (sshdC *sshdContainer) checkTunnel(port int) error {
+	client, err := ssh.Dial("tcp", fmt.Sprintf("localhost:%s", sshdC.port), sshdC.sshConfig)
+	if err != nil {
+		return fmt.Errorf("dial ssh server: %w", err)
+	}
+	defer client.Close()
+
+	conn, err := client.Dial("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return fmt.Errorf("dial tunnel of host port %d: %w", port, err)
+	}
+
+	return conn.Close()
+}
+
 type PortForwarder struct {
 	sshDAddr          string
 	sshConfig         *ssh.ClientConfig
@@ -252,6 +307,8 @@ type PortForwarder struct {
 	localPort         int
 	connectionCreated chan error    // used to signal that the connection has been created, so the caller can proceed
 	terminateChan     chan struct{} // used to signal that the connection has been terminated
+	closeOnce         *sync.Once    // shared by the copies of the forwarder, so that it's closed just once
+	stopped           chan struct{} // closed once Forward returns, as the tunnel is torn down
 }
 
 func NewPortForwarder(sshDAddr string, sshConfig *ssh.ClientConfig, remotePort, localPort int) *PortForwarder {
@@ -262,15 +319,32 @@ func NewPortForwarder(sshDAddr string, sshConfig *ssh.ClientConfig, remotePort,
 		localPort:         localPort,
 		connectionCreated: make(chan error),
 		terminateChan:     make(chan struct{}),
+		closeOnce:         &sync.Once{},
+		stopped:           make(chan struct{}),
 	}
 }
 
+// running returns true if the tunnel of the forwarder is not torn down.
+func (pf *PortForwarder) running() bool {
+	select {
+	case <-pf.stopped:
+		return false
+	default:
+		return true
+	}
+}
+
+// Close terminates the connection of the forwarder. It's safe to call it more than once, and concurrently.
 func (pf *PortForwarder) Close(ctx context.Context) {
-	close(pf.terminateChan)
-	close(pf.connectionCreated)
+	pf.closeOnce.Do(func() {
+		// the connectionCreated channel is not closed, as Forward may still signal the connection.
+		close(pf.terminateChan)
+	})
 }
 
 func (pf *PortForwarder) Forward(ctx context.Context) error {
+	defer close(pf.stopped)
+
 	client, err := ssh.Dial("tcp", pf.sshDAddr, pf.sshConfig)
 	if err != nil {
 		err = fmt.Errorf("error dialing ssh server: %w", err)
@@ -290,30 +364,30 @@ func (pf *PortForwarder) Forward(ctx context.Context) error {
 	// signal that the connection has been created
 	pf.connectionCreated <- nil
 
-	// check if the context or the terminateChan has been closed
-	select {
-	case <-ctx.Done():
-		if err := listener.Close(); err != nil {
-			return fmt.Errorf("error closing listener: %w", err)
-		}
-		if err := client.Close(); err != nil {
-			return fmt.Errorf("error closing client: %w", err)
-		}
-		return nil
-	case <-pf.terminateChan:
-		if err := listener.Close(); err != nil {
-			return fmt.Errorf("error closing listener: %w", err)
-		}
-		if err := client.Close(); err != nil {
-			return fmt.Errorf("error closing client: %w", err)
+	// close the listener and the client, unblocking Accept, once the context or the terminateChan is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-pf.terminateChan:
+		case <-done:
+			return
 		}
-		return nil
-	default:
-	}
+		listener.Close()
+		client.Close()
+	}()
 
 	for {
 		remote, err := listener.Accept()
 		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-pf.terminateChan:
+				return nil
+			default:
+			}
 			return fmt.Errorf("error accepting connection: %w", err)
 		}
 
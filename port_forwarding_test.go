@@ -7,6 +7,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -116,6 +118,114 @@ func TestExposeHostPorts(t *testing.T) {
 	}
 }
 
+func TestExposeHostPorts_concurrentTerminate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, expectedResponse)
+	}))
+	t.Cleanup(server.Close)
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	var preTerminates atomic.Int32
+	ctx := context.Background()
+	c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:           "alpine:3.17",
+			HostAccessPorts: []int{port},
+			Cmd:             []string{"top"},
+			LifecycleHooks: []testcontainers.ContainerLifecycleHooks{
+				{
+					PreTerminates: []testcontainers.ContainerHook{
+						func(ctx context.Context, c testcontainers.Container) error {
+							preTerminates.Add(1)
+
+							// the host ports are still exposed when the PreTerminates hooks are executed.
+							assertContainerHasHostAccess(t, c, port)
+							return nil
+						},
+					},
+				},
+			},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.Terminate(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), preTerminates.Load())
+
+	// terminating the container again is a no-op.
+	require.NoError(t, c.Terminate(ctx))
+}
+
+func TestExposeHostPorts_restart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, expectedResponse)
+	}))
+	t.Cleanup(server.Close)
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	// the tunnels are torn down once the context they were created with is done.
+	startCtx, cancel := context.WithCancel(context.Background())
+	c, err := testcontainers.GenericContainer(startCtx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:           "alpine:3.17",
+			HostAccessPorts: []int{port},
+			Cmd:             []string{"top"},
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Terminate(context.Background()))
+	})
+	assertContainerHasHostAccess(t, c, port)
+
+	ctx := context.Background()
+	timeout := time.Second
+
+	t.Run("tunnels-reused", func(t *testing.T) {
+		require.NoError(t, c.Stop(ctx, &timeout))
+		require.NoError(t, c.Start(ctx))
+
+		assertContainerHasHostAccess(t, c, port)
+	})
+
+	t.Run("tunnels-re-established", func(t *testing.T) {
+		cancel()
+		require.Eventually(t, func() bool {
+			code, _ := httpRequest(t, c, port)
+			return code != 0
+		}, 10*time.Second, 100*time.Millisecond)
+
+		require.NoError(t, c.Stop(ctx, &timeout))
+		require.NoError(t, c.Start(ctx))
+
+		assertContainerHasHostAccess(t, c, port)
+
+		// starting the container again is idempotent.
+		require.NoError(t, c.Stop(ctx, &timeout))
+		require.NoError(t, c.Start(ctx))
+
+		assertContainerHasHostAccess(t, c, port)
+	})
+}
+
 func httpRequest(t *testing.T, c testcontainers.Container, port int) (int, string) {
 	// wgetHostInternal {
 	code, reader, err := c.Exec(
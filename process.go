@@ -0,0 +1,122 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Process is a process running in a container, as listed by Top.
+type Process struct {
+	PID     int    // the process ID, in the PID namespace of the host
+	User    string // the user running the process
+	Command string // the command line of the process
+
+	// Fields are the values of all the columns of the row, keyed by title, e.g. "PPID" or "STIME",
+	// including the ones of the PID, user and command columns.
+	Fields map[string]string
+}
+
+// ProcessList is the list of the processes running in a container, as listed by Top.
+type ProcessList struct {
+	Titles    []string // the titles of the columns, as printed by ps
+	Processes []Process
+}
+
+// Titles of the columns of ps holding the PID, the user and the command of a process,
+// depending on the arguments passed to it.
+var (
+	pidTitles     = []string{"PID"}
+	userTitles    = []string{"UID", "USER"}
+	commandTitles = []string{"CMD", "COMMAND", "ARGS"}
+)
+
+// Top lists the processes running in the container, with the top endpoint of the Docker API,
+// so that no ps command is needed in the image. The arguments are passed to the ps command run
+// by the daemon on the host, "-ef" by default, and must list the PID column.
+func (c *DockerContainer) Top(ctx context.Context, args ...string) (ProcessList, error) {
+	defer c.provider.Close()
+
+	resp, err := c.provider.client.ContainerTop(ctx, c.ID, args)
+	if err != nil {
+		return ProcessList{}, err
+	}
+
+	return newProcessList(resp.Titles, resp.Processes)
+}
+
+// ProcessCount returns the number of processes running in the container whose command line
+// contains the given string, or the number of all the processes if it's empty.
+func (c *DockerContainer) ProcessCount(ctx context.Context, matching string) (int, error) {
+	list, err := c.Top(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, p := range list.Processes {
+		if strings.Contains(p.Command, matching) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// newProcessList returns the typed process list of the rows returned by the top endpoint.
+func newProcessList(titles []string, rows [][]string) (ProcessList, error) {
+	pidIdx := titleIndex(titles, pidTitles)
+	if pidIdx < 0 {
+		return ProcessList{}, fmt.Errorf("no PID column in the process list: %v", titles)
+	}
+	userIdx := titleIndex(titles, userTitles)
+	commandIdx := titleIndex(titles, commandTitles)
+
+	list := ProcessList{
+		Titles:    titles,
+		Processes: make([]Process, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		if len(row) != len(titles) {
+			return ProcessList{}, fmt.Errorf("process %v doesn't match the titles %v", row, titles)
+		}
+
+		pid, err := strconv.Atoi(row[pidIdx])
+		if err != nil {
+			return ProcessList{}, fmt.Errorf("parse PID %q: %w", row[pidIdx], err)
+		}
+
+		p := Process{
+			PID:    pid,
+			Fields: make(map[string]string, len(titles)),
+		}
+		if userIdx >= 0 {
+			p.User = row[userIdx]
+		}
+		if commandIdx >= 0 {
+			p.Command = row[commandIdx]
+		}
+		for i, title := range titles {
+			p.Fields[title] = row[i]
+		}
+
+		list.Processes = append(list.Processes, p)
+	}
+
+	return list, nil
+}
+
+// titleIndex returns the index of the first column with one of the given titles, or -1.
+func titleIndex(titles []string, candidates []string) int {
+	for i, title := range titles {
+		for _, candidate := range candidates {
+			if title == candidate {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,107 @@
+package testcontainers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestNewProcessList(t *testing.T) {
+	t.Run("ps-ef", func(t *testing.T) {
+		list, err := newProcessList(
+			[]string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"},
+			[][]string{
+				{"root", "1234", "1210", "0", "10:00", "?", "00:00:00", "nginx: master process nginx -g daemon off;"},
+				{"101", "1290", "1234", "0", "10:00", "?", "00:00:00", "nginx: worker process"},
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, list.Processes, 2)
+
+		require.Equal(t, 1234, list.Processes[0].PID)
+		require.Equal(t, "root", list.Processes[0].User)
+		require.Equal(t, "nginx: master process nginx -g daemon off;", list.Processes[0].Command)
+		require.Equal(t, "1210", list.Processes[0].Fields["PPID"])
+
+		require.Equal(t, 1290, list.Processes[1].PID)
+		require.Equal(t, "101", list.Processes[1].User)
+	})
+
+	t.Run("ps-aux", func(t *testing.T) {
+		list, err := newProcessList(
+			[]string{"USER", "PID", "%CPU", "COMMAND"},
+			[][]string{{"nginx", "42", "0.1", "nginx: worker process"}},
+		)
+		require.NoError(t, err)
+		require.Equal(t, Process{
+			PID:     42,
+			User:    "nginx",
+			Command: "nginx: worker process",
+			Fields:  map[string]string{"USER": "nginx", "PID": "42", "%CPU": "0.1", "COMMAND": "nginx: worker process"},
+		}, list.Processes[0])
+	})
+
+	t.Run("no-pid", func(t *testing.T) {
+		_, err := newProcessList([]string{"USER", "COMMAND"}, [][]string{{"root", "sh"}})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid-pid", func(t *testing.T) {
+		_, err := newProcessList([]string{"PID", "CMD"}, [][]string{{"abc", "sh"}})
+		require.Error(t, err)
+	})
+}
+
+func TestDockerContainer_Top(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	// processList {
+	list, err := ctr.Top(ctx)
+	// }
+	require.NoError(t, err)
+
+	var master, workers int
+	for _, p := range list.Processes {
+		require.Positive(t, p.PID)
+		require.NotEmpty(t, p.User)
+
+		switch {
+		case p.Command == "":
+			t.Fatalf("empty command for process %d", p.PID)
+		case strings.Contains(p.Command, "nginx: master process"):
+			master++
+		case strings.Contains(p.Command, "nginx: worker process"):
+			workers++
+		}
+	}
+	require.Equal(t, 1, master, list.Processes)
+	require.Positive(t, workers, list.Processes)
+
+	// processCount {
+	count, err := ctr.ProcessCount(ctx, "nginx: worker process")
+	// }
+	require.NoError(t, err)
+	require.Equal(t, workers, count)
+
+	// custom ps arguments, with other columns.
+	list, err = ctr.Top(ctx, "-eo", "pid,user,args")
+	require.NoError(t, err)
+	require.Equal(t, []string{"PID", "USER", "COMMAND"}, list.Titles)
+	require.Len(t, list.Processes, master+workers)
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/docker/docker/client"
+
 	"github.com/testcontainers/testcontainers-go/internal/config"
 	"github.com/testcontainers/testcontainers-go/internal/core"
 )
@@ -40,6 +42,7 @@ type (
 	// DockerProviderOptions defines options applicable to DockerProvider
 	DockerProviderOptions struct {
 		defaultBridgeNetworkName string
+		dockerClient             client.APIClient
 		*GenericProviderOptions
 	}
 
@@ -79,6 +82,35 @@ func WithDefaultBridgeNetwork(bridgeNetworkName string) DockerProviderOption {
 	})
 }
 
+// WithDockerClient sets the Docker client to be used, instead of the one resolved from the
+// environment and the properties file, e.g. to talk to a remote daemon or to a fake one.
+//
+// It can be used both as a provider option and as a container customizer.
+func WithDockerClient(c client.APIClient) DockerClientOption {
+	return DockerClientOption{
+		client: c,
+	}
+}
+
+// DockerClientOption is an option that sets the Docker client to be used.
+type DockerClientOption struct {
+	client client.APIClient
+}
+
+// ApplyGenericTo implements GenericProviderOption. The client only applies to the Docker providers.
+func (o DockerClientOption) ApplyGenericTo(_ *GenericProviderOptions) {}
+
+// ApplyDockerTo implements DockerProviderOption.
+func (o DockerClientOption) ApplyDockerTo(opts *DockerProviderOptions) {
+	opts.dockerClient = o.client
+}
+
+// Customize implements ContainerCustomizer.
+func (o DockerClientOption) Customize(req *GenericContainerRequest) error {
+	req.DockerClient = o.client
+	return nil
+}
+
 func (f GenericProviderOptionFunc) ApplyGenericTo(opts *GenericProviderOptions) {
 	f(opts)
 }
@@ -140,15 +172,30 @@ func NewDockerProvider(provOpts ...DockerProviderOption) (*DockerProvider, error
 	}
 
 	ctx := context.Background()
-	c, err := NewDockerClientWithOpts(ctx)
-	if err != nil {
-		return nil, err
+
+	var c client.APIClient
+	var host string
+	if o.dockerClient != nil {
+		c = o.dockerClient
+		host = c.DaemonHost()
+	} else {
+		dc, err := NewDockerClientWithOpts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c = dc
+		host = core.ExtractDockerHost(ctx)
 	}
 
+	cfg := config.Read()
+
+	// remove the resources left behind by crashed sessions, if enabled.
+	pruneStaleSessionsOnce(ctx, cfg)
+
 	return &DockerProvider{
 		DockerProviderOptions: o,
-		host:                  core.ExtractDockerHost(ctx),
+		host:                  host,
 		client:                c,
-		config:                config.Read(),
+		config:                cfg,
 	}, nil
 }
@@ -0,0 +1,141 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+const (
+	envHTTPProxy  = "HTTP_PROXY"
+	envHTTPSProxy = "HTTPS_PROXY"
+	envNoProxy    = "NO_PROXY"
+)
+
+// proxyInternalHosts are the host names used to reach the Docker host from a container,
+// which must not go through the proxy.
+var proxyInternalHosts = []string{"localhost", "127.0.0.1", "host.docker.internal", HostInternal}
+
+// proxySettings returns the proxy settings of the host, keyed by the upper case name of the
+// standard proxy environment variables. The values set in the properties file take precedence
+// over the environment variables, which are read both in upper and lower case.
+// Only the proxy settings with a value are returned.
+func proxySettings(cfg config.Config) map[string]string {
+	settings := map[string]string{}
+
+	for key, override := range map[string]string{
+		envHTTPProxy:  cfg.HTTPProxy,
+		envHTTPSProxy: cfg.HTTPSProxy,
+		envNoProxy:    cfg.NoProxy,
+	} {
+		value := override
+		if value == "" {
+			value = os.Getenv(key)
+		}
+		if value == "" {
+			value = os.Getenv(strings.ToLower(key))
+		}
+
+		if value != "" {
+			settings[key] = value
+		}
+	}
+
+	return settings
+}
+
+// appendNoProxy appends the hosts to the comma-separated no proxy list, skipping the ones already present.
+func appendNoProxy(noProxy string, hosts ...string) string {
+	var entries []string
+	seen := map[string]struct{}{}
+
+	for _, entry := range append(strings.Split(noProxy, ","), hosts...) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if _, ok := seen[entry]; ok {
+			continue
+		}
+
+		seen[entry] = struct{}{}
+		entries = append(entries, entry)
+	}
+
+	return strings.Join(entries, ",")
+}
+
+// proxyEnv returns the proxy settings to propagate to the container, in both upper and lower case.
+// The no proxy list is augmented with the host names used to reach the Docker host and with the subnets
+// of the networks the container is attached to, so the traffic in between containers bypasses the proxy.
+// It returns an empty map if there are no proxy settings in the host.
+func (p *DockerProvider) proxyEnv(ctx context.Context, networks []string) (map[string]string, error) {
+	settings := proxySettings(p.config)
+	if settings[envHTTPProxy] == "" && settings[envHTTPSProxy] == "" {
+		return map[string]string{}, nil
+	}
+
+	noProxyHosts := append([]string{}, proxyInternalHosts...)
+	for _, name := range networks {
+		nw, err := p.GetNetwork(ctx, NetworkRequest{Name: name})
+		if err != nil {
+			return nil, fmt.Errorf("get network %s: %w", name, err)
+		}
+
+		for _, cfg := range nw.IPAM.Config {
+			if cfg.Subnet != "" {
+				noProxyHosts = append(noProxyHosts, cfg.Subnet)
+			}
+		}
+	}
+
+	settings[envNoProxy] = appendNoProxy(settings[envNoProxy], noProxyHosts...)
+
+	env := make(map[string]string, len(settings)*2)
+	for k, v := range settings {
+		env[k] = v
+		env[strings.ToLower(k)] = v
+	}
+
+	return env, nil
+}
+
+// propagateProxy adds the proxy settings of the host to the container environment and,
+// when the image is built from a Dockerfile, to its build args. The values already
+// defined in the request are never overridden.
+func (p *DockerProvider) propagateProxy(ctx context.Context, req *ContainerRequest) error {
+	proxyEnv, err := p.proxyEnv(ctx, req.Networks)
+	if err != nil {
+		return fmt.Errorf("proxy env: %w", err)
+	}
+
+	if len(proxyEnv) == 0 {
+		return nil
+	}
+
+	env := make(map[string]string, len(req.Env)+len(proxyEnv))
+	for k, v := range proxyEnv {
+		env[k] = v
+	}
+	for k, v := range req.Env {
+		env[k] = v
+	}
+	req.Env = env
+
+	if req.ShouldBuildImage() {
+		buildArgs := make(map[string]*string, len(req.BuildArgs)+len(proxyEnv))
+		for k, v := range proxyEnv {
+			buildArgs[k] = &v
+		}
+		for k, v := range req.BuildArgs {
+			buildArgs[k] = v
+		}
+		req.BuildArgs = buildArgs
+	}
+
+	return nil
+}
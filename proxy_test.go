@@ -0,0 +1,159 @@
+package testcontainers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+)
+
+func TestProxySettings(t *testing.T) {
+	t.Run("from-env", func(t *testing.T) {
+		t.Setenv("HTTP_PROXY", "http://proxy.example.com:3128")
+		t.Setenv("HTTPS_PROXY", "")
+		t.Setenv("https_proxy", "http://proxy.example.com:3129")
+		t.Setenv("NO_PROXY", "")
+		t.Setenv("no_proxy", "")
+
+		require.Equal(t, map[string]string{
+			"HTTP_PROXY":  "http://proxy.example.com:3128",
+			"HTTPS_PROXY": "http://proxy.example.com:3129",
+		}, proxySettings(config.Config{}))
+	})
+
+	t.Run("properties-override-env", func(t *testing.T) {
+		t.Setenv("HTTP_PROXY", "http://proxy.example.com:3128")
+		t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3129")
+		t.Setenv("NO_PROXY", "localhost")
+
+		require.Equal(t, map[string]string{
+			"HTTP_PROXY":  "http://other-proxy.example.com:8080",
+			"HTTPS_PROXY": "http://proxy.example.com:3129",
+			"NO_PROXY":    ".example.com",
+		}, proxySettings(config.Config{
+			HTTPProxy: "http://other-proxy.example.com:8080",
+			NoProxy:   ".example.com",
+		}))
+	})
+}
+
+func TestAppendNoProxy(t *testing.T) {
+	require.Equal(t, "localhost,172.18.0.0/16", appendNoProxy("", "localhost", "172.18.0.0/16"))
+	require.Equal(t, ".example.com,localhost,172.18.0.0/16", appendNoProxy(" .example.com, localhost,", "localhost", "172.18.0.0/16"))
+}
+
+func TestPropagateProxy(t *testing.T) {
+	const (
+		httpProxy  = "http://proxy.example.com:3128"
+		httpsProxy = "http://proxy.example.com:3129"
+	)
+
+	t.Setenv("HTTP_PROXY", httpProxy)
+	t.Setenv("HTTPS_PROXY", httpsProxy)
+	t.Setenv("NO_PROXY", ".example.com")
+	t.Setenv("http_proxy", "")
+	t.Setenv("https_proxy", "")
+	t.Setenv("no_proxy", "")
+
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	networkName := "proxy-network"
+	nw, err := provider.CreateNetwork(ctx, NetworkRequest{
+		Name: networkName,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, nw.Remove(ctx))
+	})
+
+	dockerNetwork, err := provider.GetNetwork(ctx, NetworkRequest{
+		Name: networkName,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, dockerNetwork.IPAM.Config)
+	subnet := dockerNetwork.IPAM.Config[0].Subnet
+
+	t.Run("container-env", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:          nginxAlpineImage,
+				Networks:       []string{networkName},
+				PropagateProxy: true,
+				Env: map[string]string{
+					"https_proxy": "http://user-defined.example.com:3129",
+				},
+			},
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+
+		env := map[string]string{}
+		for _, kv := range inspect.Config.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			env[k] = v
+		}
+
+		require.Equal(t, httpProxy, env["HTTP_PROXY"])
+		require.Equal(t, httpProxy, env["http_proxy"])
+		require.Equal(t, httpsProxy, env["HTTPS_PROXY"])
+		// user-defined values are not overridden
+		require.Equal(t, "http://user-defined.example.com:3129", env["https_proxy"])
+
+		for _, key := range []string{"NO_PROXY", "no_proxy"} {
+			noProxy := strings.Split(env[key], ",")
+			require.Contains(t, noProxy, ".example.com")
+			require.Contains(t, noProxy, "host.docker.internal")
+			require.Contains(t, noProxy, HostInternal)
+			require.Contains(t, noProxy, subnet)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:    nginxAlpineImage,
+				Networks: []string{networkName},
+			},
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		inspect, err := ctr.Inspect(ctx)
+		require.NoError(t, err)
+
+		for _, kv := range inspect.Config.Env {
+			require.NotContains(t, kv, "PROXY=")
+		}
+	})
+
+	t.Run("build-args", func(t *testing.T) {
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context: "testdata",
+				BuildArgs: map[string]*string{
+					"NO_PROXY": nil,
+				},
+			},
+			Networks: []string{networkName},
+		}
+
+		require.NoError(t, provider.propagateProxy(ctx, &req))
+		require.Equal(t, httpProxy, *req.BuildArgs["HTTP_PROXY"])
+		require.Equal(t, httpsProxy, *req.BuildArgs["https_proxy"])
+		// user-defined values are not overridden
+		require.Nil(t, req.BuildArgs["NO_PROXY"])
+		require.Contains(t, strings.Split(*req.BuildArgs["no_proxy"], ","), subnet)
+	})
+}
@@ -0,0 +1,190 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// defaultPruneStaleOlderThan is the minimum age of the stale resources removed
+// at the start of the session if it's not configured.
+const defaultPruneStaleOlderThan = time.Hour
+
+// pruneStaleOnce makes sure the stale resources are swept just once per test session.
+var pruneStaleOnce sync.Once
+
+// PruneStaleSessions removes the containers, networks, volumes and images created by
+// Testcontainers in previous test sessions that crashed before their reaper could clean
+// them up, e.g. because the reaper was killed along with the Docker daemon or the CI runner.
+//
+// A resource is stale if it was created more than olderThan ago and its session has no running
+// reaper container. The resources of the current session and of the sessions with a live reaper
+// are never removed, so that test processes running concurrently are not affected. Note that the
+// sessions running with the reaper disabled can't be told apart from the crashed ones, so olderThan
+// must exceed the duration of such sessions.
+//
+// The resources are removed in dependency order: containers first, along with their anonymous
+// volumes, then networks, volumes and images.
+//
+// The sweep can also run automatically once per test session, when the first provider is created,
+// by setting the TESTCONTAINERS_PRUNE_STALE environment variable or the prune.stale property to true.
+func PruneStaleSessions(ctx context.Context, olderThan time.Duration) error {
+	cli, err := NewDockerClientWithOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("new docker client: %w", err)
+	}
+	defer cli.Close()
+
+	return pruneStaleSessions(ctx, cli, olderThan)
+}
+
+// pruneStaleSessionsOnce runs the stale resources sweep if it's enabled in the configuration,
+// just once per test session. Errors are logged, as they must not fail the tests.
+func pruneStaleSessionsOnce(ctx context.Context, cfg config.Config) {
+	if !cfg.PruneStale {
+		return
+	}
+
+	pruneStaleOnce.Do(func() {
+		olderThan := cfg.PruneStaleOlderThan
+		if olderThan <= 0 {
+			olderThan = defaultPruneStaleOlderThan
+		}
+
+		if err := PruneStaleSessions(ctx, olderThan); err != nil {
+			errorf(Logger, "🔥 Failed to prune stale resources: %v", err)
+		}
+	})
+}
+
+// pruneStaleSessions removes the stale resources, restricted to the ones matching the extra
+// filters, if any. See PruneStaleSessions for the details.
+func pruneStaleSessions(ctx context.Context, cli client.APIClient, olderThan time.Duration, extra ...filters.KeyValuePair) error {
+	live, err := liveSessions(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	threshold := time.Now().Add(-olderThan)
+
+	// stale reports whether the resource with the given labels and creation time is stale.
+	stale := func(labels map[string]string, created time.Time) bool {
+		sessionID, ok := labels[core.LabelSessionID]
+		if !ok || live[sessionID] {
+			return false
+		}
+
+		return created.Before(threshold)
+	}
+
+	args := append([]filters.KeyValuePair{filters.Arg("label", core.LabelSessionID)}, extra...)
+	filter := filters.NewArgs(args...)
+
+	var errs []error
+
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true, Filters: filter})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	for _, c := range containers {
+		if !stale(c.Labels, time.Unix(c.Created, 0)) {
+			continue
+		}
+
+		err := cli.ContainerRemove(ctx, c.ID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("remove container %s: %w", c.ID, err))
+		}
+	}
+
+	networks, err := cli.NetworkList(ctx, network.ListOptions{Filters: filter})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list networks: %w", err))...)
+	}
+
+	for _, n := range networks {
+		if !stale(n.Labels, n.Created) {
+			continue
+		}
+
+		if err := cli.NetworkRemove(ctx, n.ID); err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("remove network %s: %w", n.Name, err))
+		}
+	}
+
+	volumes, err := cli.VolumeList(ctx, volume.ListOptions{Filters: filter})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list volumes: %w", err))...)
+	}
+
+	for _, v := range volumes.Volumes {
+		created, err := time.Parse(time.RFC3339, v.CreatedAt)
+		if err != nil {
+			// the creation time is unknown, so the volume is kept.
+			continue
+		}
+
+		if !stale(v.Labels, created) {
+			continue
+		}
+
+		if err := cli.VolumeRemove(ctx, v.Name, true); err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("remove volume %s: %w", v.Name, err))
+		}
+	}
+
+	images, err := cli.ImageList(ctx, image.ListOptions{Filters: filter})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list images: %w", err))...)
+	}
+
+	for _, img := range images {
+		if !stale(img.Labels, time.Unix(img.Created, 0)) {
+			continue
+		}
+
+		_, err := cli.ImageRemove(ctx, img.ID, image.RemoveOptions{Force: true, PruneChildren: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("remove image %s: %w", img.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// liveSessions returns the IDs of the sessions with a running reaper container,
+// including the current session, which is always considered live.
+func liveSessions(ctx context.Context, cli client.APIClient) (map[string]bool, error) {
+	reapers, err := cli.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("label", fmt.Sprintf("%s=%t", core.LabelReaper, true)),
+			filters.Arg("status", "running"),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list reapers: %w", err)
+	}
+
+	live := map[string]bool{core.SessionID(): true}
+	for _, r := range reapers {
+		if sessionID, ok := r.Labels[core.LabelSessionID]; ok {
+			live[sessionID] = true
+		}
+	}
+
+	return live, nil
+}
@@ -0,0 +1,155 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// createSessionContainer creates a container labeled as created by the given session,
+// as if it was left behind by a crashed test session, running it if requested.
+func createSessionContainer(t *testing.T, ctx context.Context, cli client.APIClient, sessionID string, labels map[string]string, run bool) string {
+	t.Helper()
+
+	allLabels := core.DefaultLabels(sessionID)
+	for k, v := range labels {
+		allLabels[k] = v
+	}
+
+	resp, err := cli.ContainerCreate(ctx, &container.Config{
+		Image:  "docker.io/busybox",
+		Cmd:    []string{"sleep", "60"},
+		Labels: allLabels,
+	}, nil, nil, nil, "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := cli.ContainerRemove(context.Background(), resp.ID, container.RemoveOptions{Force: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			t.Error(err)
+		}
+	})
+
+	if run {
+		require.NoError(t, cli.ContainerStart(ctx, resp.ID, container.StartOptions{}))
+	}
+
+	return resp.ID
+}
+
+func requireRemoved(t *testing.T, ctx context.Context, cli client.APIClient, containerID string) {
+	t.Helper()
+
+	_, err := cli.ContainerInspect(ctx, containerID)
+	require.True(t, errdefs.IsNotFound(err), err)
+}
+
+func requireKept(t *testing.T, ctx context.Context, cli client.APIClient, containerID string) {
+	t.Helper()
+
+	_, err := cli.ContainerInspect(ctx, containerID)
+	require.NoError(t, err)
+}
+
+func TestPruneStaleSessions(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	require.NoError(t, provider.PullImage(ctx, "docker.io/busybox"))
+
+	cli := provider.Client()
+
+	// testLabel restricts the sweep to the resources created by each test,
+	// so that the resources of other test processes are never touched.
+	testLabel := func(t *testing.T) (map[string]string, filters.KeyValuePair) {
+		t.Helper()
+
+		id := uuid.NewString()
+		return map[string]string{"test.prune": id}, filters.Arg("label", "test.prune="+id)
+	}
+
+	t.Run("crashed-session", func(t *testing.T) {
+		labels, filter := testLabel(t)
+
+		stale := createSessionContainer(t, ctx, cli, uuid.NewString(), labels, false)
+
+		require.NoError(t, pruneStaleSessions(ctx, cli, 0, filter))
+		requireRemoved(t, ctx, cli, stale)
+	})
+
+	t.Run("live-reaper", func(t *testing.T) {
+		labels, filter := testLabel(t)
+
+		sessionID := uuid.NewString()
+		reaperLabels := map[string]string{core.LabelReaper: "true"}
+		for k, v := range labels {
+			reaperLabels[k] = v
+		}
+
+		reaper := createSessionContainer(t, ctx, cli, sessionID, reaperLabels, true)
+		live := createSessionContainer(t, ctx, cli, sessionID, labels, false)
+		stale := createSessionContainer(t, ctx, cli, uuid.NewString(), labels, false)
+
+		require.NoError(t, pruneStaleSessions(ctx, cli, 0, filter))
+		requireKept(t, ctx, cli, reaper)
+		requireKept(t, ctx, cli, live)
+		requireRemoved(t, ctx, cli, stale)
+	})
+
+	t.Run("current-session", func(t *testing.T) {
+		labels, filter := testLabel(t)
+
+		current := createSessionContainer(t, ctx, cli, core.SessionID(), labels, false)
+
+		require.NoError(t, pruneStaleSessions(ctx, cli, 0, filter))
+		requireKept(t, ctx, cli, current)
+	})
+
+	t.Run("recent-resources", func(t *testing.T) {
+		labels, filter := testLabel(t)
+
+		recent := createSessionContainer(t, ctx, cli, uuid.NewString(), labels, false)
+
+		require.NoError(t, pruneStaleSessions(ctx, cli, time.Hour, filter))
+		requireKept(t, ctx, cli, recent)
+	})
+
+	t.Run("networks", func(t *testing.T) {
+		labels, filter := testLabel(t)
+
+		allLabels := core.DefaultLabels(uuid.NewString())
+		for k, v := range labels {
+			allLabels[k] = v
+		}
+
+		resp, err := cli.NetworkCreate(ctx, "tc-prune-"+uuid.NewString(), network.CreateOptions{Labels: allLabels})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			if err := cli.NetworkRemove(context.Background(), resp.ID); err != nil && !errdefs.IsNotFound(err) {
+				t.Error(err)
+			}
+		})
+
+		// the container of the stale session is removed first, so the network can be removed.
+		stale := createSessionContainer(t, ctx, cli, allLabels[core.LabelSessionID], labels, false)
+		require.NoError(t, cli.NetworkConnect(ctx, resp.ID, stale, nil))
+
+		require.NoError(t, pruneStaleSessions(ctx, cli, 0, filter))
+		requireRemoved(t, ctx, cli, stale)
+
+		_, err = cli.NetworkInspect(ctx, resp.ID, network.InspectOptions{})
+		require.True(t, errdefs.IsNotFound(err), err)
+	})
+}
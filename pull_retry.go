@@ -0,0 +1,87 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/errdefs"
+)
+
+// ErrPullTimeout is returned when the image pull doesn't complete within the PullTimeout of the request.
+var ErrPullTimeout = errors.New("image pull timed out")
+
+// PullRetryPolicy configures the retries of the image pulls failing with a transient error,
+// such as a network error, a 5xx response or a rate limit of the registry.
+// Authentication and authorization failures, or missing images, are never retried.
+//
+// Without a policy, the failed pulls are retried with an exponential backoff for up to
+// 15 minutes, unless the daemon reports a permanent client error, e.g. a missing image,
+// while the errors reported during the download are not retried.
+type PullRetryPolicy struct {
+	// MaxAttempts is the maximum number of pull attempts, including the first one.
+	// Zero or negative values mean a single attempt.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, which grows exponentially on each retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two retries.
+	MaxDelay time.Duration
+}
+
+// backOff returns the exponential backoff of the policy, bound to the context.
+// A nil policy retries for up to 15 minutes, with the default exponential backoff.
+func (p *PullRetryPolicy) backOff(ctx context.Context) backoff.BackOff {
+	if p == nil {
+		return backoff.WithContext(backoff.NewExponentialBackOff(), ctx)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = p.BaseDelay
+	b.MaxInterval = p.MaxDelay
+	// the number of attempts bounds the retries.
+	b.MaxElapsedTime = 0
+
+	var retries uint64
+	if p.MaxAttempts > 1 {
+		retries = uint64(p.MaxAttempts - 1)
+	}
+
+	return backoff.WithContext(backoff.WithMaxRetries(b, retries), ctx)
+}
+
+// retryable returns true if the image pull failed with an error worth retrying under the policy.
+// A nil policy retries any error but the permanent client errors.
+func (p *PullRetryPolicy) retryable(err error) bool {
+	if p == nil {
+		return !isPermanentClientError(err)
+	}
+
+	return isTransientPullError(err)
+}
+
+// pullAuthErrorRegex matches the messages of the authentication and authorization failures
+// reported by the registries, which the daemon may return as a generic error.
+var pullAuthErrorRegex = regexp.MustCompile(`(?i)unauthorized|authentication required|access denied|denied:|incorrect username or password`)
+
+// pullTransientErrorRegex matches the messages of the transient failures reported by the registries,
+// which the daemon returns as a generic error: 5xx responses, rate limits and network errors.
+var pullTransientErrorRegex = regexp.MustCompile(`(?i)\b5\d\d\b|toomanyrequests|too many requests|rate limit|timeout|connection reset|connection refused|unexpected EOF|temporar`)
+
+// isTransientPullError returns true if the image pull failed with an error worth retrying.
+func isTransientPullError(err error) bool {
+	switch {
+	case err == nil, errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case pullAuthErrorRegex.MatchString(err.Error()):
+		return false
+	case errdefs.IsSystem(err):
+		// the errors of the registry are reported by the daemon as internal errors.
+		return pullTransientErrorRegex.MatchString(err.Error())
+	case isPermanentClientError(err):
+		return false
+	default:
+		return true
+	}
+}
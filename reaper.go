@@ -8,6 +8,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -131,7 +132,7 @@ func lookUpReaperContainer(ctx context.Context, sessionID string) (*DockerContai
 		},
 		backoff.WithContext(exp, ctx),
 		func(err error, duration time.Duration) {
-			Logger.Printf("Error looking up reaper container, will retry: %v", err)
+			warnf(Logger, "Error looking up reaper container, will retry: %v", err)
 		},
 	)
 }
@@ -152,6 +153,7 @@ func reuseOrCreateReaper(ctx context.Context, sessionID string, provider ReaperP
 				return nil, err
 			}
 		} else if state.Running {
+			reaperInstance.observer().ReaperReused(reaperInstance.event(int(reaperInstance.connections.Load())))
 			return reaperInstance, nil
 		}
 		// else: the reaper instance has been terminated, so we need to create a new one
@@ -165,7 +167,7 @@ func reuseOrCreateReaper(ctx context.Context, sessionID string, provider ReaperP
 	reaperContainer, err := lookUpReaperContainer(context.Background(), sessionID)
 	if err == nil && reaperContainer != nil {
 		// The reaper container exists as a Docker container: re-use it
-		Logger.Printf("🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID)
+		infof(Logger, "🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID)
 		reaperInstance, err = reuseReaperContainer(ctx, sessionID, provider, reaperContainer)
 		if err != nil {
 			return nil, err
@@ -202,7 +204,7 @@ func reuseReaperContainer(ctx context.Context, sessionID string, provider Reaper
 		return nil, err
 	}
 
-	Logger.Printf("⏳ Waiting for Reaper port to be ready")
+	debugf(Logger, "⏳ Waiting for Reaper port to be ready")
 
 	var containerJson *types.ContainerJSON
 
@@ -222,12 +224,15 @@ func reuseReaperContainer(ctx context.Context, sessionID string, provider Reaper
 		}
 	}
 
-	return &Reaper{
+	r := &Reaper{
 		Provider:  provider,
 		SessionID: sessionID,
 		Endpoint:  endpoint,
 		container: reaperContainer,
-	}, nil
+	}
+	r.observer().ReaperReused(r.event(0))
+
+	return r, nil
 }
 
 // newReaper creates a Reaper with a sessionID to identify containers and a
@@ -251,13 +256,13 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 		Privileged:   tcConfig.RyukPrivileged,
 		WaitingFor:   wait.ForListeningPort(listeningPort),
 		Name:         reaperContainerNameFromSessionID(sessionID),
-		HostConfigModifier: func(hc *container.HostConfig) {
-			hc.AutoRemove = true
-			hc.Binds = []string{dockerHostMount + ":/var/run/docker.sock"}
-			hc.NetworkMode = Bridge
-		},
-		Env: map[string]string{},
+		Env:          map[string]string{},
 	}
+	req.AddHostConfigModifier(func(hc *container.HostConfig) {
+		hc.AutoRemove = true
+		hc.Binds = append(hc.Binds, dockerHostMount+":/var/run/docker.sock")
+		hc.NetworkMode = Bridge
+	})
 	if to := tcConfig.RyukConnectionTimeout; to > time.Duration(0) {
 		req.Env["RYUK_CONNECTION_TIMEOUT"] = to.String()
 	}
@@ -314,7 +319,7 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 			if reaperContainer == nil {
 				return nil, fmt.Errorf("look up reaper container returned nil although creation failed due to name conflict")
 			}
-			Logger.Printf("🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID)
+			infof(Logger, "🔥 Reaper obtained from Docker for this test session %s", reaperContainer.ID)
 			reaper, err := reuseReaperContainer(ctx, sessionID, provider, reaperContainer)
 			if err != nil {
 				return nil, err
@@ -330,6 +335,7 @@ func newReaper(ctx context.Context, sessionID string, provider ReaperProvider) (
 		return nil, err
 	}
 	reaper.Endpoint = endpoint
+	reaper.observer().ReaperCreated(reaper.event(0))
 
 	return reaper, nil
 }
@@ -340,6 +346,8 @@ type Reaper struct {
 	SessionID string
 	Endpoint  string
 	container Container
+
+	connections atomic.Int32 // the number of connections opened to Ryuk
 }
 
 // Connect runs a goroutine which can be terminated by sending true into the returned channel
@@ -349,14 +357,18 @@ func (r *Reaper) Connect() (chan bool, error) {
 		return nil, fmt.Errorf("%w: Connecting to Ryuk on %s failed", err, r.Endpoint)
 	}
 
+	connected := time.Now()
+	event := r.event(int(r.connections.Add(1)))
+	r.observer().ReaperReconnected(event)
+
 	terminationSignal := make(chan bool)
 	go func(conn net.Conn) {
 		sock := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
 		defer conn.Close()
 
 		labelFilters := []string{}
-		for l, v := range core.DefaultLabels(r.SessionID) {
-			labelFilters = append(labelFilters, fmt.Sprintf("label=%s=%s", l, v))
+		for _, l := range r.filters().Get("label") {
+			labelFilters = append(labelFilters, "label="+l)
 		}
 
 		retryLimit := 3
@@ -386,10 +398,24 @@ func (r *Reaper) Connect() (chan bool, error) {
 		}
 
 		<-terminationSignal
+
+		event.Duration = time.Since(connected)
+		r.observer().ReaperTerminated(event)
 	}(conn)
 	return terminationSignal, nil
 }
 
+// filters returns the filters of the resources removed by the reaper, which are
+// the ones labeled with the session of the reaper.
+func (r *Reaper) filters() filters.Args {
+	args := filters.NewArgs()
+	for l, v := range core.DefaultLabels(r.SessionID) {
+		args.Add("label", fmt.Sprintf("%s=%s", l, v))
+	}
+
+	return args
+}
+
 // Labels returns the container labels to use so that this Reaper cleans them up
 // Deprecated: internally replaced by core.DefaultLabels(sessionID)
 func (r *Reaper) Labels() map[string]string {
@@ -0,0 +1,85 @@
+package testcontainers
+
+import "time"
+
+// ReaperEvent describes an event of the lifecycle of a reaper, passed to the ReaperObserver.
+type ReaperEvent struct {
+	SessionID   string        // the session of the reaper
+	ContainerID string        // the ID of the reaper container
+	Endpoint    string        // the endpoint of Ryuk, as host:port
+	Connections int           // the number of connections opened to Ryuk by the reaper, including the current one
+	Duration    time.Duration // how long the connection lasted, set for ReaperTerminated only
+}
+
+// ReaperObserver is notified of the lifecycle of the reapers, e.g. to export metrics on how
+// often the reapers are created or reused, and on how long the connections to Ryuk last.
+// The callbacks are called synchronously, so they must not block.
+type ReaperObserver interface {
+	// ReaperCreated is called when a reaper container is created for a session.
+	ReaperCreated(event ReaperEvent)
+
+	// ReaperReused is called when a reaper is reused instead of being created: the reaper of
+	// the session obtained by this process before, or the reaper container found in Docker.
+	ReaperReused(event ReaperEvent)
+
+	// ReaperReconnected is called when a connection is opened to Ryuk, once per container,
+	// network or compose stack, so that it keeps the reaper alive.
+	ReaperReconnected(event ReaperEvent)
+
+	// ReaperTerminated is called when a connection to Ryuk is terminated, with how long it lasted.
+	ReaperTerminated(event ReaperEvent)
+}
+
+// reaperObserver is the observer of the reapers, which does nothing by default.
+var reaperObserver ReaperObserver = noopReaperObserver{}
+
+// SetReaperObserver sets the observer of the reapers, returned in TestcontainersConfig by the providers.
+// A nil observer restores the default one, which does nothing. It's not safe for concurrent use, so it
+// must be called before creating any container, e.g. in TestMain.
+func SetReaperObserver(o ReaperObserver) {
+	if o == nil {
+		o = noopReaperObserver{}
+	}
+
+	reaperObserver = o
+}
+
+// observerOf returns the reaper observer set in the config of the provider, or the default one.
+func observerOf(provider ReaperProvider) ReaperObserver {
+	if o := provider.Config().ReaperObserver; o != nil {
+		return o
+	}
+
+	return reaperObserver
+}
+
+// noopReaperObserver is a ReaperObserver doing nothing.
+type noopReaperObserver struct{}
+
+func (noopReaperObserver) ReaperCreated(ReaperEvent)     {}
+func (noopReaperObserver) ReaperReused(ReaperEvent)      {}
+func (noopReaperObserver) ReaperReconnected(ReaperEvent) {}
+func (noopReaperObserver) ReaperTerminated(ReaperEvent)  {}
+
+// event returns an event of the reaper, for the given connection.
+func (r *Reaper) event(connections int) ReaperEvent {
+	e := ReaperEvent{
+		SessionID:   r.SessionID,
+		Endpoint:    r.Endpoint,
+		Connections: connections,
+	}
+	if r.container != nil {
+		e.ContainerID = r.container.GetContainerID()
+	}
+
+	return e
+}
+
+// observer returns the observer set in the config of the provider of the reaper, or the default one.
+func (r *Reaper) observer() ReaperObserver {
+	if r.Provider != nil {
+		return observerOf(r.Provider)
+	}
+
+	return reaperObserver
+}
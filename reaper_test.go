@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -66,15 +67,16 @@ func (m *mockReaperProvider) RunContainer(ctx context.Context, req ContainerRequ
 	m.hostConfig = &container.HostConfig{}
 	m.enpointSettings = map[string]*network.EndpointSettings{}
 
-	if req.HostConfigModifier == nil {
-		req.HostConfigModifier = defaultHostConfigModifier(req)
-	}
-	req.HostConfigModifier(m.hostConfig)
+	req.applyHostConfigModifiers(m.hostConfig)
 
 	if req.EnpointSettingsModifier != nil {
 		req.EnpointSettingsModifier(m.enpointSettings)
 	}
 
+	if req.EndpointSettingsModifier != nil {
+		req.EndpointSettingsModifier(m.enpointSettings)
+	}
+
 	// we're only interested in the request, so instead of mocking the Docker client
 	// we'll error out here
 	return nil, errExpected
@@ -566,8 +568,14 @@ func TestReaper_reuseItFromOtherTestProgramUsingDocker(t *testing.T) {
 	reaperInstance = nil
 	reaperOnce = sync.Once{}
 
+	logger := &recordingLogger{}
+	previousLogger := Logger
+	SetLogger(logger)
+	t.Cleanup(func() { Logger = previousLogger })
+
 	reaperReused, err := reuseOrCreateReaper(context.WithValue(ctx, core.DockerHostContextKey, provider.(*DockerProvider).host), testSessionID, provider)
 	require.NoError(t, err, "reusing the Reaper should not error")
+	require.Contains(t, logger.messages(LevelInfo), "🔥 Reaper obtained from Docker for this test session "+reaper.container.GetContainerID())
 	// assert that the internal state of both reaper instances is the same
 	assert.Equal(t, reaper.SessionID, reaperReused.SessionID, "expecting the same SessionID")
 	assert.Equal(t, reaper.Endpoint, reaperReused.Endpoint, "expecting the same reaper endpoint")
@@ -610,6 +618,13 @@ func TestReaper_ReuseRunning(t *testing.T) {
 	dockerProvider, err := NewDockerProvider()
 	require.NoError(t, err, "new docker provider should not fail")
 
+	// reaperObserver {
+	observer := &countingReaperObserver{}
+	SetReaperObserver(observer)
+	// }
+	t.Cleanup(func() { SetReaperObserver(nil) })
+
+	var newReaperCalls atomic.Int32
 	obtainedReaperContainerIDs := make([]string, concurrency)
 	var wg sync.WaitGroup
 	for i := 0; i < concurrency; i++ {
@@ -624,6 +639,7 @@ func TestReaper_ReuseRunning(t *testing.T) {
 				return
 			}
 			// Not found -> create.
+			newReaperCalls.Add(1)
 			createdReaper, err := newReaper(timeout, sessionID, dockerProvider)
 			require.NoError(t, err, "new reaper should not fail")
 			obtainedReaperContainerIDs[i] = createdReaper.container.GetContainerID()
@@ -636,4 +652,40 @@ func TestReaper_ReuseRunning(t *testing.T) {
 	for i, containerID := range obtainedReaperContainerIDs {
 		assert.Equal(t, firstContainerID, containerID, "call %d should have returned same container id", i)
 	}
+
+	// Assure that the reaper was created at most once, and reused by the other calls to newReaper.
+	created, reused := observer.created.Load(), observer.reused.Load()
+	assert.LessOrEqual(t, created, int32(1), "reaper should have been created at most once")
+	assert.Equal(t, newReaperCalls.Load(), created+reused, "each call to newReaper should have created or reused the reaper")
+}
+
+// countingReaperObserver is a ReaperObserver counting the events of the reapers.
+type countingReaperObserver struct {
+	created     atomic.Int32
+	reused      atomic.Int32
+	reconnected atomic.Int32
+	terminated  atomic.Int32
+}
+
+func (o *countingReaperObserver) ReaperCreated(ReaperEvent)     { o.created.Add(1) }
+func (o *countingReaperObserver) ReaperReused(ReaperEvent)      { o.reused.Add(1) }
+func (o *countingReaperObserver) ReaperReconnected(ReaperEvent) { o.reconnected.Add(1) }
+func (o *countingReaperObserver) ReaperTerminated(ReaperEvent)  { o.terminated.Add(1) }
+
+func TestReaperObserver_Config(t *testing.T) {
+	observer := &countingReaperObserver{}
+	provider := newMockReaperProvider(t)
+	provider.RestoreReaperState()
+	provider.config.ReaperObserver = observer
+
+	r := &Reaper{Provider: provider, SessionID: testSessionID}
+	require.Same(t, observer, r.observer())
+
+	r = &Reaper{SessionID: testSessionID}
+	require.Equal(t, noopReaperObserver{}, r.observer())
+
+	SetReaperObserver(observer)
+	t.Cleanup(func() { SetReaperObserver(nil) })
+	require.Same(t, observer, r.observer())
+	require.Same(t, observer, ReadConfig().ReaperObserver)
 }
@@ -0,0 +1,171 @@
+package testcontainers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// redactedValue replaces the sensitive values in the rendered requests, errors and logs.
+const redactedValue = "******"
+
+// minRedactedValueLen is the minimum length of the sensitive values masked in free text,
+// such as the logs of a container, so that short values don't mask unrelated text.
+// The values of the sensitive keys are always masked in the rendered env and build args.
+const minRedactedValueLen = 4
+
+// defaultRedactedKeyPatterns are the patterns of the sensitive env var and build arg names.
+var defaultRedactedKeyPatterns = []string{"PASSWORD", "SECRET", "TOKEN", "KEY"}
+
+// redactedKeyPatterns are the patterns set with SetRedactedKeyPatterns.
+var redactedKeyPatterns = defaultRedactedKeyPatterns
+
+// SetRedactedKeyPatterns replaces the patterns of the names of the env vars and build args whose values
+// are sensitive, matched case-insensitively as substrings of the names: PASSWORD, SECRET, TOKEN and KEY
+// by default. The sensitive values are redacted wherever a request is rendered, e.g. in the errors of the
+// containers failing to start, including their logs. It's not safe for concurrent use, so it must be
+// called before creating any container, e.g. in TestMain.
+func SetRedactedKeyPatterns(patterns ...string) {
+	redactedKeyPatterns = patterns
+}
+
+// isSensitiveKey returns true if the name of the env var or build arg matches a redacted key pattern.
+func isSensitiveKey(key string) bool {
+	key = strings.ToUpper(key)
+	for _, pattern := range redactedKeyPatterns {
+		if pattern != "" && strings.Contains(key, strings.ToUpper(pattern)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RedactEnv returns a copy of the env, with the values of the sensitive keys redacted.
+// The env is not modified.
+func RedactEnv(env map[string]string) map[string]string {
+	if env == nil {
+		return nil
+	}
+
+	redacted := make(map[string]string, len(env))
+	for k, v := range env {
+		if isSensitiveKey(k) {
+			v = redactedValue
+		}
+		redacted[k] = v
+	}
+
+	return redacted
+}
+
+// sensitiveValues returns the values of the sensitive env vars and build args, and the build secret
+// values, of the request, to be masked in the rendered texts, the longest first, so that they are
+// fully masked.
+func (c *ContainerRequest) sensitiveValues() []string {
+	var values []string
+	for k, v := range c.Env {
+		if isSensitiveKey(k) && len(v) >= minRedactedValueLen {
+			values = append(values, v)
+		}
+	}
+
+	if c.FromDockerfile.BuildArgs != nil {
+		for k, v := range c.FromDockerfile.BuildArgs {
+			if isSensitiveKey(k) && v != nil && len(*v) >= minRedactedValueLen {
+				values = append(values, *v)
+			}
+		}
+	}
+
+	for _, s := range c.FromDockerfile.Secrets {
+		if len(s.Value) >= minRedactedValueLen {
+			values = append(values, s.Value)
+		}
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+
+	return slices.Compact(values)
+}
+
+// redactValues returns s with the occurrences of the sensitive values masked.
+func redactValues(s string, values []string) string {
+	for _, v := range values {
+		s = strings.ReplaceAll(s, v, redactedValue)
+	}
+
+	return s
+}
+
+// String renders the main fields of the request, such as the image, the command and the env,
+// with the values of the sensitive env vars and build args redacted, so that the request can be
+// logged safely. The request is not modified.
+func (c ContainerRequest) String() string {
+	values := c.sensitiveValues()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "image: %q", c.Image)
+	if c.ShouldBuildImage() {
+		fmt.Fprintf(&sb, ", dockerfile: %q, context: %q", c.GetDockerfile(), c.FromDockerfile.Context)
+	}
+	if c.Name != "" {
+		fmt.Fprintf(&sb, ", name: %q", c.Name)
+	}
+	if len(c.Entrypoint) > 0 {
+		fmt.Fprintf(&sb, ", entrypoint: %q", redactArgs(c.Entrypoint, values))
+	}
+	if len(c.Cmd) > 0 {
+		fmt.Fprintf(&sb, ", cmd: %q", redactArgs(c.Cmd, values))
+	}
+	if len(c.Env) > 0 {
+		fmt.Fprintf(&sb, ", env: %q", sortedPairs(RedactEnv(c.Env)))
+	}
+	if len(c.FromDockerfile.BuildArgs) > 0 {
+		args := make(map[string]string, len(c.FromDockerfile.BuildArgs))
+		for k, v := range c.FromDockerfile.BuildArgs {
+			if v != nil {
+				args[k] = *v
+			}
+		}
+		fmt.Fprintf(&sb, ", build args: %q", sortedPairs(RedactEnv(args)))
+	}
+	if len(c.ExposedPorts) > 0 {
+		fmt.Fprintf(&sb, ", exposed ports: %q", c.ExposedPorts)
+	}
+	if len(c.Networks) > 0 {
+		fmt.Fprintf(&sb, ", networks: %q", c.Networks)
+	}
+	if c.WaitingFor != nil {
+		fmt.Fprintf(&sb, ", waiting for: %s", redactValues(fmt.Sprintf("%+v", c.WaitingFor), values))
+	}
+
+	return sb.String()
+}
+
+// redactArgs returns a copy of the arguments with the occurrences of the sensitive values masked.
+func redactArgs(args []string, values []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = redactValues(arg, values)
+	}
+
+	return redacted
+}
+
+// sortedPairs returns the key=value pairs of the map, sorted by key.
+func sortedPairs(m map[string]string) []string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return pairs
+}
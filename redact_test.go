@@ -0,0 +1,125 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestRedactEnv(t *testing.T) {
+	env := map[string]string{
+		"MINIO_ROOT_PASSWORD": "minio-password",
+		"LDAP_ADMIN_PASSWORD": "admin",
+		"api_token":           "token",
+		"AWS_SECRET":          "secret",
+		"PRIVATE_KEY":         "key",
+		"LOG_LEVEL":           "debug",
+	}
+
+	redacted := RedactEnv(env)
+	require.Equal(t, map[string]string{
+		"MINIO_ROOT_PASSWORD": redactedValue,
+		"LDAP_ADMIN_PASSWORD": redactedValue,
+		"api_token":           redactedValue,
+		"AWS_SECRET":          redactedValue,
+		"PRIVATE_KEY":         redactedValue,
+		"LOG_LEVEL":           "debug",
+	}, redacted)
+
+	// the env is not modified
+	require.Equal(t, "minio-password", env["MINIO_ROOT_PASSWORD"])
+
+	require.Nil(t, RedactEnv(nil))
+}
+
+func TestSetRedactedKeyPatterns(t *testing.T) {
+	t.Cleanup(func() { SetRedactedKeyPatterns(defaultRedactedKeyPatterns...) })
+
+	SetRedactedKeyPatterns("credential")
+
+	require.True(t, isSensitiveKey("DB_CREDENTIALS"))
+	require.False(t, isSensitiveKey("DB_PASSWORD"))
+}
+
+func TestContainerRequest_String(t *testing.T) {
+	password := "s3cr3t-value"
+
+	req := ContainerRequest{
+		Image: "docker.io/alpine",
+		Cmd:   []string{"sh", "-c", "login --password=" + password},
+		Env: map[string]string{
+			"DB_PASSWORD": password,
+			"DB_USER":     "user",
+		},
+		ExposedPorts: []string{"5432/tcp"},
+	}
+
+	s := req.String()
+	require.NotContains(t, s, password)
+	require.Contains(t, s, `cmd: ["sh" "-c" "login --password=******"]`)
+	require.Contains(t, s, `env: ["DB_PASSWORD=******" "DB_USER=user"]`)
+	require.Contains(t, s, `exposed ports: ["5432/tcp"]`)
+
+	// the request is not modified
+	require.Equal(t, password, req.Env["DB_PASSWORD"])
+	require.Equal(t, "login --password="+password, req.Cmd[2])
+
+	t.Run("build-args", func(t *testing.T) {
+		token := "build-token"
+		req := ContainerRequest{
+			FromDockerfile: FromDockerfile{
+				Context:   "testdata",
+				BuildArgs: map[string]*string{"NPM_TOKEN": &token},
+			},
+		}
+
+		s := req.String()
+		require.NotContains(t, s, token)
+		require.Contains(t, s, `build args: ["NPM_TOKEN=******"]`)
+	})
+
+	t.Run("build-secrets", func(t *testing.T) {
+		secret := "build-secret"
+		req := ContainerRequest{
+			Cmd: []string{"echo", secret},
+			FromDockerfile: FromDockerfile{
+				Context: "testdata",
+				Secrets: map[string]BuildSecret{"npmrc": {Value: secret}},
+			},
+		}
+
+		s := req.String()
+		require.NotContains(t, s, secret)
+		require.Contains(t, s, `cmd: ["echo" "******"]`)
+	})
+}
+
+func TestStartupError_redacted(t *testing.T) {
+	ctx := context.Background()
+
+	password := "s3cr3t-value"
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:      "docker.io/alpine",
+			Env:        map[string]string{"DB_PASSWORD": password},
+			Cmd:        []string{"sh", "-c", "echo the password is $DB_PASSWORD; sleep 60"},
+			WaitingFor: wait.ForLog("never logged").WithStartupTimeout(3 * time.Second),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.ErrorIs(t, err, ErrWaitStrategyTimeout)
+
+	require.NotContains(t, err.Error(), password)
+	require.Contains(t, err.Error(), "the password is ******")
+
+	var startupErr *StartupError
+	require.ErrorAs(t, err, &startupErr)
+	require.Equal(t, []string{"the password is ******"}, startupErr.Logs)
+}
@@ -0,0 +1,100 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ErrContainerNotRunning is returned when an operation that requires a running container,
+// such as updating its resources, is performed on a container that is not running.
+var ErrContainerNotRunning = errors.New("container not running")
+
+// defaultCPUPeriod is the default CFS scheduler period, in microseconds, used by Docker.
+const defaultCPUPeriod = 100000
+
+// ResourceLimits are the limits of the resources of a container, set when it's created, e.g. to
+// test the behaviour of an application running out of memory or starved of CPU. The zero value
+// of a field leaves the limit unset.
+type ResourceLimits struct {
+	MemoryBytes     int64 // memory limit, in bytes
+	MemorySwapBytes int64 // limit of the memory plus the swap, in bytes, -1 for unlimited swap. The same as MemoryBytes disables the swap
+	NanoCPUs        int64 // CPU quota, in units of 1e-9 CPUs, e.g. 500000000 for half a CPU
+	PidsLimit       int64 // maximum number of processes, -1 for unlimited
+}
+
+// hostConfigModifier returns the modifier of the host config setting the limits which are set,
+// keeping the other resources of the host config.
+func (l ResourceLimits) hostConfigModifier() func(hostConfig *container.HostConfig) {
+	return func(hostConfig *container.HostConfig) {
+		if l.MemoryBytes != 0 {
+			hostConfig.Memory = l.MemoryBytes
+		}
+		if l.MemorySwapBytes != 0 {
+			hostConfig.MemorySwap = l.MemorySwapBytes
+		}
+		if l.NanoCPUs != 0 {
+			hostConfig.NanoCPUs = l.NanoCPUs
+		}
+		if l.PidsLimit != 0 {
+			pidsLimit := l.PidsLimit
+			hostConfig.PidsLimit = &pidsLimit
+		}
+	}
+}
+
+// ResourceUpdateOption is a function that modifies the resources to be updated in a running container.
+type ResourceUpdateOption func(update *container.UpdateConfig)
+
+// UpdateMemory sets the memory limit of the container, in bytes. The swap limit is set to the
+// same value, so that the container cannot use swap, and the memory limit is a hard limit.
+func UpdateMemory(bytes int64) ResourceUpdateOption {
+	return func(update *container.UpdateConfig) {
+		update.Memory = bytes
+		update.MemorySwap = bytes
+	}
+}
+
+// UpdateCPUQuota sets the CPU quota of the container, in microseconds per CPU period of 100ms.
+// E.g. a quota of 50000 limits the container to half a CPU.
+func UpdateCPUQuota(quota int64) ResourceUpdateOption {
+	return func(update *container.UpdateConfig) {
+		update.CPUPeriod = defaultCPUPeriod
+		update.CPUQuota = quota
+	}
+}
+
+// UpdateResources updates the resources of the running container, e.g. to shrink its memory limit
+// and observe the behaviour of the application under pressure. It returns the warnings of the
+// Docker daemon, and an error wrapping [ErrContainerNotRunning] if the container is not running.
+func (c *DockerContainer) UpdateResources(ctx context.Context, update container.UpdateConfig) ([]string, error) {
+	state, err := c.State(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("container state: %w", err)
+	}
+
+	if !state.Running {
+		return nil, fmt.Errorf("%w: status %q", ErrContainerNotRunning, state.Status)
+	}
+
+	resp, err := c.provider.client.ContainerUpdate(ctx, c.ID, update)
+	if err != nil {
+		return nil, fmt.Errorf("container update: %w", err)
+	}
+	defer c.provider.Close()
+
+	return resp.Warnings, nil
+}
+
+// UpdateResourcesWith updates the resources of the running container using the given options,
+// like [DockerContainer.UpdateResources] does.
+func (c *DockerContainer) UpdateResourcesWith(ctx context.Context, opts ...ResourceUpdateOption) ([]string, error) {
+	var update container.UpdateConfig
+	for _, opt := range opts {
+		opt(&update)
+	}
+
+	return c.UpdateResources(ctx, update)
+}
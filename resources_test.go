@@ -0,0 +1,170 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceUpdateOptions(t *testing.T) {
+	var update container.UpdateConfig
+	UpdateMemory(64 * 1024 * 1024)(&update)
+	UpdateCPUQuota(50000)(&update)
+
+	require.Equal(t, int64(64*1024*1024), update.Memory)
+	require.Equal(t, int64(64*1024*1024), update.MemorySwap)
+	require.Equal(t, int64(100000), update.CPUPeriod)
+	require.Equal(t, int64(50000), update.CPUQuota)
+}
+
+func TestResourceLimits_hostConfig(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		req := ContainerRequest{}
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		require.Equal(t, container.Resources{}, hc.Resources)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		req := ContainerRequest{
+			ResourceLimits: ResourceLimits{
+				MemoryBytes:     64 * 1024 * 1024,
+				MemorySwapBytes: 128 * 1024 * 1024,
+				NanoCPUs:        500000000,
+				PidsLimit:       100,
+			},
+		}
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		require.Equal(t, int64(64*1024*1024), hc.Memory)
+		require.Equal(t, int64(128*1024*1024), hc.MemorySwap)
+		require.Equal(t, int64(500000000), hc.NanoCPUs)
+		require.NotNil(t, hc.PidsLimit)
+		require.Equal(t, int64(100), *hc.PidsLimit)
+	})
+
+	t.Run("composing", func(t *testing.T) {
+		req := ContainerRequest{
+			ResourceLimits: ResourceLimits{
+				MemoryBytes: 64 * 1024 * 1024,
+				NanoCPUs:    500000000,
+			},
+			HostConfigModifier: func(hc *container.HostConfig) {
+				hc.NanoCPUs = 250000000
+				hc.Ulimits = []*container.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}}
+			},
+		}
+		req.AddHostConfigModifier(func(hc *container.HostConfig) {
+			hc.CPUShares = 512
+		})
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		require.Equal(t, int64(64*1024*1024), hc.Memory)
+		// the user modifier is applied last, overriding the limit.
+		require.Equal(t, int64(250000000), hc.NanoCPUs)
+		require.Equal(t, int64(512), hc.CPUShares)
+		require.Equal(t, []*container.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}}, hc.Ulimits)
+	})
+
+	t.Run("deprecated-field", func(t *testing.T) {
+		req := ContainerRequest{
+			Resources:      container.Resources{Memory: 32 * 1024 * 1024, CPUShares: 512},
+			ResourceLimits: ResourceLimits{MemoryBytes: 64 * 1024 * 1024},
+		}
+
+		hc := &container.HostConfig{}
+		req.applyHostConfigModifiers(hc)
+
+		// the limits are applied after the deprecated field, keeping its other resources.
+		require.Equal(t, int64(64*1024*1024), hc.Memory)
+		require.Equal(t, int64(512), hc.CPUShares)
+	})
+}
+
+func TestWithResourceLimits(t *testing.T) {
+	ctx := context.Background()
+
+	// withResourceLimits {
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/busybox",
+			Cmd:   []string{"sleep", "60"},
+			ResourceLimits: ResourceLimits{
+				MemoryBytes:     64 * 1024 * 1024,
+				MemorySwapBytes: 64 * 1024 * 1024,
+				NanoCPUs:        500000000,
+				PidsLimit:       100,
+			},
+		},
+		Started: true,
+	})
+	// }
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	inspect, err := ctr.Inspect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(64*1024*1024), inspect.HostConfig.Memory)
+	require.Equal(t, int64(64*1024*1024), inspect.HostConfig.MemorySwap)
+	require.Equal(t, int64(500000000), inspect.HostConfig.NanoCPUs)
+	require.NotNil(t, inspect.HostConfig.PidsLimit)
+	require.Equal(t, int64(100), *inspect.HostConfig.PidsLimit)
+}
+
+func TestDockerContainerUpdateResources(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/busybox",
+			Cmd:   []string{"sleep", "60"},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	dockerContainer := ctr.(*DockerContainer)
+
+	// updateResources {
+	warnings, err := dockerContainer.UpdateResourcesWith(ctx,
+		UpdateMemory(64*1024*1024),
+		UpdateCPUQuota(50000),
+	)
+	// }
+	require.NoError(t, err)
+	for _, w := range warnings {
+		t.Log(w)
+	}
+
+	inspect, err := dockerContainer.Inspect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(64*1024*1024), inspect.HostConfig.Memory)
+	require.Equal(t, int64(50000), inspect.HostConfig.CPUQuota)
+
+	_, err = dockerContainer.UpdateResources(ctx, container.UpdateConfig{
+		Resources: container.Resources{Memory: 32 * 1024 * 1024, MemorySwap: 32 * 1024 * 1024},
+	})
+	require.NoError(t, err)
+
+	inspect, err = dockerContainer.Inspect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(32*1024*1024), inspect.HostConfig.Memory)
+
+	t.Run("not-running", func(t *testing.T) {
+		require.NoError(t, dockerContainer.Stop(ctx, nil))
+
+		_, err := dockerContainer.UpdateResourcesWith(ctx, UpdateMemory(16*1024*1024))
+		require.ErrorIs(t, err, ErrContainerNotRunning)
+	})
+}
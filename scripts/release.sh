@@ -29,6 +29,9 @@ function main() {
   local vVersion="v${version}"
   echo "Current version: ${vVersion}"
 
+  # The modules report the version in their provenance labels, so it must match the tag.
+  checkModuleVersions "${version}"
+
   # Get the version to bump to from the semver-tool and the bump type
   local newVersion=$(docker run --rm --platform=linux/amd64 -i "${DOCKER_IMAGE_SEMVER}" bump "${BUMP_TYPE}" "${vVersion}")
   if [[ "${newVersion}" == "" ]]; then
@@ -102,6 +105,33 @@ function curlGolangProxy() {
   curl "https://proxy.golang.org/${module_path}/@v/${module_version}.info"
 }
 
+# This function checks that the modules and examples require the version of the core module being tagged,
+# as set by the pre-release script, so that the version stamped by the modules in the provenance labels of
+# their containers matches the tagged version. It fails listing the modules requiring another version.
+function checkModuleVersions() {
+  local version="${1}"
+  local mismatches=()
+
+  for directory in "${DIRECTORIES[@]}"
+  do
+    cd "${ROOT_DIR}/${directory}"
+
+    while read -r module; do
+      module="${module%?}" # remove trailing slash
+      module_mod_file="${directory}/${module}/go.mod" # e.g. modules/mongodb/go.mod
+      if ! grep -q "testcontainers-go v${version}$" "${ROOT_DIR}/${module_mod_file}"; then
+        mismatches+=("${module_mod_file}")
+      fi
+    done < <(ls -d */ | grep -v "_template")
+  done
+
+  if [[ ${#mismatches[@]} -gt 0 ]]; then
+    echo "The following modules do not require testcontainers-go v${version}, please run the pre-release script:"
+    printf '  %s\n' "${mismatches[@]}"
+    exit 1
+  fi
+}
+
 # This function reads the version.go file and extracts the current version.
 function extractCurrentVersion() {
   cat "${VERSION_FILE}" | grep 'const Version = ' | cut -d '"' -f 2
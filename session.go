@@ -0,0 +1,93 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/docker/docker/errdefs"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrInvalidSessionID is returned when the session ID of a container request can't identify a session.
+var ErrInvalidSessionID = errors.New("invalid session ID")
+
+// sessionIDRegex matches the session IDs which can be set on a request, limited to 120 characters,
+// as they are part of the name of the reaper container of the session, e.g. "reaper_<session ID>",
+// which is kept below 128 characters.
+var sessionIDRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,119}$`)
+
+// sessionReapers are the reapers of the sessions set with ContainerRequest.SessionID,
+// keyed by session ID. The reaper of the test session is reaperInstance.
+var sessionReapers = map[string]*Reaper{}
+
+// sessionID returns the session of the container, which is the test session
+// unless it's overridden with the SessionID field.
+func (c *ContainerRequest) sessionID() string {
+	if c.SessionID != "" {
+		return c.SessionID
+	}
+
+	return core.SessionID()
+}
+
+// validateSessionID ensures that the session ID, if set, can be used in the
+// labels of the container and in the name of the reaper of the session.
+func (c *ContainerRequest) validateSessionID() error {
+	if c.SessionID == "" || sessionIDRegex.MatchString(c.SessionID) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q must match %s", ErrInvalidSessionID, c.SessionID, sessionIDRegex)
+}
+
+// sessionReaper returns the reaper of the given session: the reaper of the test session,
+// or the one of a session set with ContainerRequest.SessionID.
+func (p *DockerProvider) sessionReaper(ctx context.Context, sessionID string) (*Reaper, error) {
+	ctx = context.WithValue(ctx, core.DockerHostContextKey, p.host)
+
+	if sessionID != core.SessionID() {
+		return reuseOrCreateSessionReaper(ctx, sessionID, p)
+	}
+
+	return reuseOrCreateReaper(ctx, sessionID, p)
+}
+
+// reuseOrCreateSessionReaper returns the reaper of a session other than the test session,
+// creating it on demand, or adopting the reaper container of the session if it has been
+// created by another process, e.g. by another CI job sharing the session.
+func reuseOrCreateSessionReaper(ctx context.Context, sessionID string, provider ReaperProvider) (*Reaper, error) {
+	reaperMutex.Lock()
+	defer reaperMutex.Unlock()
+
+	if r, ok := sessionReapers[sessionID]; ok {
+		state, err := r.container.State(ctx)
+		if err != nil {
+			if !errdefs.IsNotFound(err) {
+				return nil, err
+			}
+		} else if state.Running {
+			return r, nil
+		}
+		// the reaper has been terminated, so it's looked up or created again.
+		delete(sessionReapers, sessionID)
+	}
+
+	var r *Reaper
+	reaperContainer, err := lookUpReaperContainer(context.Background(), sessionID)
+	if err == nil && reaperContainer != nil {
+		infof(Logger, "🔥 Reaper obtained from Docker for the session %s %s", sessionID, reaperContainer.ID)
+		r, err = reuseReaperContainer(ctx, sessionID, provider, reaperContainer)
+	} else {
+		r, err = newReaper(ctx, sessionID, provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sessionReapers[sessionID] = r
+
+	return r, nil
+}
@@ -0,0 +1,136 @@
+package testcontainers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/config"
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+func TestContainerRequest_validateSessionID(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		req := ContainerRequest{}
+		require.NoError(t, req.validateSessionID())
+		require.Equal(t, core.SessionID(), req.sessionID())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		req := ContainerRequest{SessionID: "ci-pipeline-1234.shared"}
+		require.NoError(t, req.validateSessionID())
+		require.Equal(t, "ci-pipeline-1234.shared", req.sessionID())
+	})
+
+	t.Run("max-length", func(t *testing.T) {
+		req := ContainerRequest{SessionID: strings.Repeat("a", 120)}
+		require.NoError(t, req.validateSessionID())
+	})
+
+	for _, id := range []string{" ", "-leading-dash", "with space", "with/slash", "label=injection", strings.Repeat("a", 121)} {
+		t.Run("invalid/"+id, func(t *testing.T) {
+			req := ContainerRequest{Image: nginxAlpineImage, SessionID: id}
+			require.ErrorIs(t, req.validateSessionID(), ErrInvalidSessionID)
+			require.ErrorIs(t, req.Validate(), ErrInvalidSessionID)
+		})
+	}
+}
+
+func TestContainerRequest_SessionID(t *testing.T) {
+	config.Reset() // reset the config using the internal method to avoid the sync.Once
+	tcConfig := config.Read()
+	if tcConfig.RyukDisabled {
+		t.Skip("Ryuk is disabled, skipping test")
+	}
+
+	ctx := context.Background()
+
+	defaultCtr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: nginxAlpineImage,
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, defaultCtr)
+	require.NoError(t, err)
+
+	// customSessionID {
+	customSessionID := "shared-" + uuid.NewString()
+
+	customCtr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:     nginxAlpineImage,
+			SessionID: customSessionID,
+		},
+		Started: true,
+	})
+	// }
+	terminateContainerOnEnd(t, ctx, customCtr)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		reaperMutex.Lock()
+		defer reaperMutex.Unlock()
+		delete(sessionReapers, customSessionID)
+	})
+
+	require.Equal(t, core.SessionID(), defaultCtr.SessionID())
+	require.Equal(t, customSessionID, customCtr.SessionID())
+
+	inspect, err := customCtr.Inspect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, customSessionID, inspect.Config.Labels[core.LabelSessionID])
+
+	provider, err := NewDockerProvider()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	defaultReaper, err := provider.sessionReaper(ctx, core.SessionID())
+	require.NoError(t, err)
+
+	customReaper, err := provider.sessionReaper(ctx, customSessionID)
+	require.NoError(t, err)
+
+	require.NotEqual(t, defaultReaper.container.GetContainerID(), customReaper.container.GetContainerID())
+
+	// each reaper only removes the containers of its own session.
+	reaped := func(r *Reaper) []string {
+		t.Helper()
+
+		resp, err := provider.client.ContainerList(ctx, container.ListOptions{All: true, Filters: r.filters()})
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(resp))
+		for _, c := range resp {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+
+	defaultReaped := reaped(defaultReaper)
+	require.Contains(t, defaultReaped, defaultCtr.GetContainerID())
+	require.NotContains(t, defaultReaped, customCtr.GetContainerID())
+
+	customReaped := reaped(customReaper)
+	require.Contains(t, customReaped, customCtr.GetContainerID())
+	require.NotContains(t, customReaped, defaultCtr.GetContainerID())
+}
+
+func TestContainerRequest_SessionID_invalid(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:     nginxAlpineImage,
+			SessionID: "not a session",
+		},
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.ErrorIs(t, err, ErrInvalidSessionID)
+}
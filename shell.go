@@ -0,0 +1,50 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// ErrNoShell is returned by the features executing shell commands in a container
+// that does not include any shell, such as distroless images.
+var ErrNoShell = wait.ErrNoShell
+
+// detectShell returns the path of the first shell available in the container, or ErrNoShell
+// if none is available. The result is cached on the container, so the container is only probed once,
+// unless the probe fails.
+func detectShell(ctx context.Context, ctr *DockerContainer) (string, error) {
+	ctr.shellMtx.Lock()
+	defer ctr.shellMtx.Unlock()
+
+	if !ctr.shellDetected {
+		shell, err := core.DetectShell(ctx, func(ctx context.Context, cmd []string) (int, error) {
+			exitCode, _, err := ctr.Exec(ctx, cmd)
+			return exitCode, err
+		})
+		if err != nil && !errors.Is(err, ErrNoShell) {
+			return "", err
+		}
+
+		ctr.shell = shell
+		ctr.shellErr = err
+		ctr.shellDetected = true
+	}
+
+	return ctr.shell, ctr.shellErr
+}
+
+// Shell returns the path of the shell available in the container, checking /bin/sh, /bin/bash
+// and /bin/ash in this order. It returns ErrNoShell if the container does not include any of them.
+func (c *DockerContainer) Shell(ctx context.Context) (string, error) {
+	return detectShell(ctx, c)
+}
+
+// HasShell returns true if the container includes a shell, so that shell commands can be
+// executed in it, e.g. with sh -c. Distroless images usually don't.
+func (c *DockerContainer) HasShell(ctx context.Context) bool {
+	_, err := detectShell(ctx, c)
+	return err == nil
+}
@@ -0,0 +1,74 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDockerContainer_HasShell(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("alpine", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/alpine",
+				Cmd:   []string{"sleep", "30"},
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		// hasShell {
+		dc := ctr.(*DockerContainer)
+		if dc.HasShell(ctx) {
+			shell, err := dc.Shell(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "/bin/sh", shell)
+		}
+		// }
+		require.True(t, dc.HasShell(ctx))
+	})
+
+	t.Run("distroless", func(t *testing.T) {
+		// the pause image only includes the pause binary
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "registry.k8s.io/pause:3.9",
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		dc := ctr.(*DockerContainer)
+		require.False(t, dc.HasShell(ctx))
+
+		_, err = dc.Shell(ctx)
+		require.ErrorIs(t, err, ErrNoShell)
+		require.ErrorContains(t, err, "checked /bin/sh, /bin/bash, /bin/ash")
+
+		// the result is cached
+		require.True(t, dc.shellDetected)
+	})
+
+	t.Run("distroless-wait-for-process", func(t *testing.T) {
+		// the strategies requiring a shell fail right away
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:      "registry.k8s.io/pause:3.9",
+				WaitingFor: wait.ForProcess("^pause"),
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.ErrorIs(t, err, ErrNoShell)
+	})
+}
@@ -0,0 +1,93 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownSignal is returned when stopping a container with a signal which is not a Linux signal.
+var ErrUnknownSignal = errors.New("unknown signal")
+
+// linuxSignals are the numbers of the Linux signals, keyed by name without the SIG prefix.
+var linuxSignals = map[string]int{
+	"HUP":    1,
+	"INT":    2,
+	"QUIT":   3,
+	"ILL":    4,
+	"TRAP":   5,
+	"ABRT":   6,
+	"IOT":    6,
+	"BUS":    7,
+	"FPE":    8,
+	"KILL":   9,
+	"USR1":   10,
+	"SEGV":   11,
+	"USR2":   12,
+	"PIPE":   13,
+	"ALRM":   14,
+	"TERM":   15,
+	"STKFLT": 16,
+	"CHLD":   17,
+	"CONT":   18,
+	"STOP":   19,
+	"TSTP":   20,
+	"TTIN":   21,
+	"TTOU":   22,
+	"URG":    23,
+	"XCPU":   24,
+	"XFSZ":   25,
+	"VTALRM": 26,
+	"PROF":   27,
+	"WINCH":  28,
+	"IO":     29,
+	"POLL":   29,
+	"PWR":    30,
+	"SYS":    31,
+}
+
+// sigRTMin and sigRTMax are the range of the Linux real-time signals, e.g. SIGRTMIN+3.
+const (
+	sigRTMin = 34
+	sigRTMax = 64
+)
+
+// parseSignal validates the given signal, as accepted by the Docker engine: a Linux signal
+// name, with or without the SIG prefix and in any case, e.g. "SIGINT" or "int", a real-time
+// signal relative to SIGRTMIN or SIGRTMAX, e.g. "SIGRTMIN+3", or a signal number, e.g. "2".
+// It returns the normalized signal, or an error wrapping ErrUnknownSignal.
+func parseSignal(signal string) (string, error) {
+	if n, err := strconv.Atoi(signal); err == nil {
+		if n <= 0 || n > sigRTMax {
+			return "", fmt.Errorf("%w: %d", ErrUnknownSignal, n)
+		}
+		return signal, nil
+	}
+
+	name := strings.TrimPrefix(strings.ToUpper(signal), "SIG")
+	if _, ok := linuxSignals[name]; ok {
+		return "SIG" + name, nil
+	}
+
+	for rt, base := range map[string]int{"RTMIN": sigRTMin, "RTMAX": sigRTMax} {
+		offset, ok := strings.CutPrefix(name, rt)
+		if !ok {
+			continue
+		}
+
+		n := 0
+		if offset != "" {
+			var err error
+			if n, err = strconv.Atoi(offset); err != nil || (offset[0] != '+' && offset[0] != '-') {
+				break
+			}
+		}
+
+		if base+n >= sigRTMin && base+n <= sigRTMax {
+			return "SIG" + name, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownSignal, signal)
+}
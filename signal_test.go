@@ -0,0 +1,89 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestParseSignal(t *testing.T) {
+	valid := map[string]string{
+		"SIGINT":      "SIGINT",
+		"int":         "SIGINT",
+		"Term":        "SIGTERM",
+		"SIGKILL":     "SIGKILL",
+		"SIGRTMIN":    "SIGRTMIN",
+		"SIGRTMIN+3":  "SIGRTMIN+3",
+		"sigrtmax-2":  "SIGRTMAX-2",
+		"2":           "2",
+		"64":          "64",
+		"SIGUSR1":     "SIGUSR1",
+		"sigwinch":    "SIGWINCH",
+		"SIGRTMAX-30": "SIGRTMAX-30",
+	}
+	for signal, expected := range valid {
+		t.Run(signal, func(t *testing.T) {
+			actual, err := parseSignal(signal)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+	}
+
+	for _, signal := range []string{"", "SIG", "SIGFOO", "0", "65", "-1", "SIGRTMIN+31", "SIGRTMAX+1", "SIGRTMIN3", "SIG INT"} {
+		t.Run("invalid/"+signal, func(t *testing.T) {
+			_, err := parseSignal(signal)
+			require.ErrorIs(t, err, ErrUnknownSignal)
+		})
+	}
+}
+
+func TestDockerContainer_StopWithSignal(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/alpine",
+			// the process only shuts down cleanly on SIGINT, flushing its state.
+			Cmd:        []string{"sh", "-c", `trap 'echo flushed; exit 0' INT; echo started; while true; do sleep 0.1; done`},
+			WaitingFor: wait.ForLog("started"),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	t.Run("unknown-signal", func(t *testing.T) {
+		err := ctr.StopWithSignal(ctx, "SIGFOO", nil)
+		require.ErrorIs(t, err, ErrUnknownSignal)
+		require.True(t, ctr.IsRunning())
+	})
+
+	t.Run("sigint", func(t *testing.T) {
+		// stopWithSignal {
+		timeout := 10 * time.Second
+		err := ctr.StopWithSignal(ctx, "SIGINT", &timeout)
+		// }
+		require.NoError(t, err)
+		require.False(t, ctr.IsRunning())
+
+		state, err := ctr.State(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "exited", state.Status)
+		// the process exited on its own, instead of being killed after the timeout.
+		require.Equal(t, 0, state.ExitCode)
+
+		r, err := ctr.Logs(ctx)
+		require.NoError(t, err)
+		defer r.Close()
+
+		logs, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Contains(t, string(logs), "flushed")
+	})
+}
@@ -0,0 +1,169 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrSnapshotNotFound is returned when restoring a snapshot that was not taken on the container.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// snapshotRepository is the repository of the images committed by Snapshot.
+const snapshotRepository = "testcontainers/snapshot"
+
+// snapshotNameRegex matches the names of the snapshots, which are part of the tag of their images.
+var snapshotNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,99}$`)
+
+// Snapshot commits the filesystem of the container to an image, referenced by name,
+// so that the container can be brought back to this state with Restore, e.g. to reset
+// a seeded database between tests without re-running the seeding.
+//
+// The image is labeled with the session of the container, so it's removed by the
+// garbage collector. Taking a snapshot with the name of an existing one replaces it.
+//
+// The data of the volumes of the container is not part of the image, as for any Docker
+// commit: e.g. for the Postgres image, which declares a volume for /var/lib/postgresql/data,
+// the data directory must be moved out of the volume, setting the PGDATA env var, to be snapshotted.
+func (c *DockerContainer) Snapshot(ctx context.Context, name string) error {
+	if !snapshotNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name %q: it must match %s", name, snapshotNameRegex)
+	}
+
+	labels := core.DefaultLabels(c.sessionID)
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	changes := make([]string, 0, len(keys))
+	for _, k := range keys {
+		changes = append(changes, fmt.Sprintf("LABEL %q=%q", k, labels[k]))
+	}
+
+	resp, err := c.provider.client.ContainerCommit(ctx, c.ID, container.CommitOptions{
+		Reference: snapshotRepository + ":" + c.ID[:12] + "-" + name,
+		Changes:   changes,
+	})
+	if err != nil {
+		return fmt.Errorf("container commit: %w", err)
+	}
+	defer c.provider.Close()
+
+	if c.snapshots == nil {
+		c.snapshots = make(map[string]string)
+	}
+	c.snapshots[name] = resp.ID
+
+	return nil
+}
+
+// Restore replaces the container with a new one, created from the image of the snapshot
+// taken with Snapshot, keeping its name, configuration, networks, network aliases and
+// the host ports of its exposed ports, so that MappedPort returns the same ports.
+//
+// The container is stopped and removed, then the new one is created and started, running
+// the lifecycle hooks of Stop and Start, hence the wait strategy and the log consumers.
+// The ID of the container is updated to the ID of the new container.
+// It returns an error wrapping [ErrSnapshotNotFound] if there is no snapshot with that name.
+func (c *DockerContainer) Restore(ctx context.Context, name string) error {
+	image, ok := c.snapshots[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, name)
+	}
+
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("inspect: %w", err)
+	}
+
+	cfg := inspect.Config
+	cfg.Image = image
+
+	hostConfig := inspect.HostConfig
+	if len(inspect.NetworkSettings.Ports) > 0 {
+		// pin the host ports assigned to the running container.
+		hostConfig.PortBindings = inspect.NetworkSettings.Ports
+	}
+
+	// the container is created in its network mode, then connected to the other networks.
+	mode := string(hostConfig.NetworkMode)
+	if hostConfig.NetworkMode.IsDefault() {
+		mode = network.NetworkBridge
+	}
+
+	var endpoints []string
+	for nw := range inspect.NetworkSettings.Networks {
+		if nw != mode {
+			endpoints = append(endpoints, nw)
+		}
+	}
+	sort.Strings(endpoints)
+	if _, ok := inspect.NetworkSettings.Networks[mode]; ok {
+		endpoints = append([]string{mode}, endpoints...)
+	}
+
+	networkingConfig := &network.NetworkingConfig{}
+	if len(endpoints) > 0 {
+		networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{
+			endpoints[0]: restoredEndpoint(inspect.NetworkSettings.Networks[endpoints[0]]),
+		}
+	}
+
+	if err := c.Stop(ctx, nil); err != nil {
+		return fmt.Errorf("stop: %w", err)
+	}
+
+	err = c.provider.client.ContainerRemove(ctx, c.ID, container.RemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	})
+	if err != nil {
+		return fmt.Errorf("container remove: %w", err)
+	}
+
+	resp, err := c.provider.client.ContainerCreate(ctx, cfg, hostConfig, networkingConfig, nil, strings.TrimPrefix(inspect.Name, "/"))
+	if err != nil {
+		return fmt.Errorf("container create: %w", err)
+	}
+	defer c.provider.Close()
+
+	containerLeaks.replace(c.ID, resp.ID)
+	c.ID = resp.ID
+
+	for i := 1; i < len(endpoints); i++ {
+		nw := endpoints[i]
+		err := c.provider.client.NetworkConnect(ctx, nw, c.ID, restoredEndpoint(inspect.NetworkSettings.Networks[nw]))
+		if err != nil {
+			return fmt.Errorf("network connect %s: %w", nw, err)
+		}
+	}
+
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	return nil
+}
+
+// restoredEndpoint returns the settings of the endpoint of a restored container,
+// keeping the user-defined aliases of the endpoint of the replaced container.
+func restoredEndpoint(ep *network.EndpointSettings) *network.EndpointSettings {
+	if ep == nil {
+		return &network.EndpointSettings{}
+	}
+
+	return &network.EndpointSettings{
+		Aliases:   ep.Aliases,
+		NetworkID: ep.NetworkID,
+	}
+}
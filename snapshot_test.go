@@ -0,0 +1,102 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDockerContainer_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{nginxDefaultPort},
+			WaitingFor:   wait.ForListeningPort(nginxDefaultPort),
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	dockerContainer := ctr.(*DockerContainer)
+
+	readIndex := func(t *testing.T) string {
+		t.Helper()
+
+		rc, err := dockerContainer.CopyFileFromContainer(ctx, "/usr/share/nginx/html/index.html")
+		require.NoError(t, err)
+		defer rc.Close()
+
+		bs, err := io.ReadAll(rc)
+		require.NoError(t, err)
+
+		return string(bs)
+	}
+
+	writeIndex := func(t *testing.T, content string) {
+		t.Helper()
+
+		code, _, err := dockerContainer.Exec(ctx, []string{"sh", "-c", "echo " + content + " > /usr/share/nginx/html/index.html"})
+		require.NoError(t, err)
+		require.Zero(t, code)
+	}
+
+	id := dockerContainer.GetContainerID()
+	port, err := dockerContainer.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+
+	// snapshotRestore {
+	writeIndex(t, "seeded")
+	err = dockerContainer.Snapshot(ctx, "seeded")
+	require.NoError(t, err)
+
+	writeIndex(t, "dirty")
+
+	err = dockerContainer.Restore(ctx, "seeded")
+	require.NoError(t, err)
+	// }
+
+	require.NotEqual(t, id, dockerContainer.GetContainerID())
+	require.Equal(t, "seeded\n", readIndex(t))
+
+	restoredPort, err := dockerContainer.MappedPort(ctx, nginxDefaultPort)
+	require.NoError(t, err)
+	require.Equal(t, port, restoredPort)
+
+	state, err := dockerContainer.State(ctx)
+	require.NoError(t, err)
+	require.True(t, state.Running)
+
+	t.Run("image-labels", func(t *testing.T) {
+		img, _, err := dockerContainer.provider.client.ImageInspectWithRaw(ctx, dockerContainer.snapshots["seeded"])
+		require.NoError(t, err)
+		require.Equal(t, dockerContainer.SessionID(), img.Config.Labels[core.LabelSessionID])
+	})
+
+	t.Run("restore-twice", func(t *testing.T) {
+		id := dockerContainer.GetContainerID()
+		writeIndex(t, "dirty")
+
+		require.NoError(t, dockerContainer.Restore(ctx, "seeded"))
+		require.NotEqual(t, id, dockerContainer.GetContainerID())
+		require.Equal(t, "seeded\n", readIndex(t))
+	})
+
+	t.Run("unknown-snapshot", func(t *testing.T) {
+		err := dockerContainer.Restore(ctx, "unknown")
+		require.ErrorIs(t, err, ErrSnapshotNotFound)
+	})
+
+	t.Run("invalid-name", func(t *testing.T) {
+		err := dockerContainer.Snapshot(ctx, "invalid name")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,142 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// defaultFailureLogLines is the default number of log lines attached to a StartupError.
+const defaultFailureLogLines = 100
+
+// StartupError is returned when a container fails to start or to become ready,
+// including the last lines of its logs, so that the failure is self-diagnosing.
+// The number of lines is set with the FailureLogLines field of the container request.
+type StartupError struct {
+	// ContainerID is the ID of the container that failed to start.
+	ContainerID string
+	// Err is the error of the container startup.
+	Err error
+	// Logs are the last lines of the logs of the container, from both stdout and stderr,
+	// with the sensitive values of the request redacted.
+	Logs []string
+
+	// secrets are the sensitive values of the request, redacted in the error message.
+	secrets []string
+}
+
+// Error returns the startup error followed by the last log lines of the container.
+func (e *StartupError) Error() string {
+	if len(e.Logs) == 0 {
+		return redactValues(e.Err.Error(), e.secrets)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v\n  last %d log lines:\n", e.Err, len(e.Logs))
+	for _, line := range e.Logs {
+		fmt.Fprintf(&sb, "    %s\n", line)
+	}
+
+	return redactValues(sb.String(), e.secrets)
+}
+
+// Unwrap returns the startup error.
+func (e *StartupError) Unwrap() error {
+	return e.Err
+}
+
+// withStartupLogs wraps err in a StartupError with the last log lines of the container.
+// If the logs are disabled, err is returned unchanged.
+func (c *DockerContainer) withStartupLogs(ctx context.Context, err error) error {
+	n := c.failureLogLines
+	switch {
+	case n < 0:
+		return err
+	case n == 0:
+		n = defaultFailureLogLines
+	}
+
+	// the error is reported without the logs if they cannot be read.
+	lines, _ := c.lastLogLines(ctx, n)
+
+	for i, line := range lines {
+		lines[i] = redactValues(line, c.secrets)
+	}
+
+	return &StartupError{ContainerID: c.ID, Err: err, Logs: lines, secrets: c.secrets}
+}
+
+// lastLogLines returns the last n lines of the logs of the container. Only these lines are
+// requested to the daemon, the ring keeping the last n lines of the lines split from its stream.
+func (c *DockerContainer) lastLogLines(ctx context.Context, n int) ([]string, error) {
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect: %w", err)
+	}
+
+	rc, err := c.provider.client.ContainerLogs(ctx, c.ID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(n),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("container logs: %w", err)
+	}
+	defer c.provider.Close()
+	defer rc.Close()
+
+	ring := newLogRing(n)
+	add := func(line string) bool {
+		ring.add(line)
+		return false
+	}
+	stdout := &lineWriter{fn: add}
+	stderr := &lineWriter{fn: add}
+
+	if inspect.Config != nil && inspect.Config.Tty {
+		_, err = io.Copy(stdout, rc)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, rc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read logs: %w", err)
+	}
+	stdout.flush()
+	stderr.flush()
+
+	return ring.lines(), nil
+}
+
+// logRing is a ring buffer of log lines, keeping the last lines added to it.
+type logRing struct {
+	buf  []string
+	next int
+	full bool
+}
+
+func newLogRing(size int) *logRing {
+	return &logRing{buf: make([]string, size)}
+}
+
+// add adds the line to the ring, discarding the oldest line if the ring is full.
+func (r *logRing) add(line string) {
+	r.buf[r.next] = line
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// lines returns the lines of the ring, from the oldest to the newest.
+func (r *logRing) lines() []string {
+	if !r.full {
+		return append([]string(nil), r.buf[:r.next]...)
+	}
+
+	return append(append([]string(nil), r.buf[r.next:]...), r.buf[:r.next]...)
+}
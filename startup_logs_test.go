@@ -0,0 +1,78 @@
+package testcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestStartupError(t *testing.T) {
+	ctx := context.Background()
+
+	run := func(t *testing.T, failureLogLines int) error {
+		t.Helper()
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/alpine",
+				// 150 lines, half of them on stderr, before the container stays idle.
+				Cmd:             []string{"sh", "-c", `for i in $(seq 1 150); do if [ $((i % 2)) -eq 0 ]; then echo "line $i" >&2; else echo "line $i"; fi; done; sleep 60`},
+				WaitingFor:      wait.ForLog("never logged").WithStartupTimeout(3 * time.Second),
+				FailureLogLines: failureLogLines,
+			},
+			Started: true,
+		})
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.Error(t, err)
+
+		return err
+	}
+
+	t.Run("default", func(t *testing.T) {
+		err := run(t, 0)
+
+		var startupErr *StartupError
+		require.ErrorAs(t, err, &startupErr)
+		require.NotEmpty(t, startupErr.ContainerID)
+		require.ErrorIs(t, err, ErrWaitStrategyTimeout)
+		require.Len(t, startupErr.Logs, defaultFailureLogLines)
+		require.ElementsMatch(t, []string{"line 149", "line 150"}, startupErr.Logs[len(startupErr.Logs)-2:])
+		require.ErrorContains(t, err, "last 100 log lines:\n")
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		err := run(t, 5)
+
+		var startupErr *StartupError
+		require.ErrorAs(t, err, &startupErr)
+		require.Len(t, startupErr.Logs, 5)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		err := run(t, -1)
+
+		var startupErr *StartupError
+		require.False(t, errors.As(err, &startupErr))
+	})
+}
+
+func TestLogRing(t *testing.T) {
+	ring := newLogRing(3)
+	require.Empty(t, ring.lines())
+
+	ring.add("1")
+	ring.add("2")
+	require.Equal(t, []string{"1", "2"}, ring.lines())
+
+	for i := 3; i <= 7; i++ {
+		ring.add(fmt.Sprint(i))
+	}
+	require.Equal(t, []string{"5", "6", "7"}, ring.lines())
+}
@@ -0,0 +1,148 @@
+package testcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ContainerStats is a sample of the resource usage of a container, decoded from the stats
+// endpoint of the Docker API, as printed by the docker stats command.
+type ContainerStats struct {
+	Read          time.Time // the time the sample was read at
+	CPUPercent    float64   // the CPU usage since the previous sample, 100% per CPU, e.g. 150% for one CPU and a half
+	MemoryUsage   uint64    // the memory usage, in bytes, excluding the inactive page cache
+	MemoryLimit   uint64    // the memory limit, in bytes, which is the memory of the host if the container is not limited
+	MemoryPercent float64   // the memory usage relative to the memory limit
+	NetworkRx     uint64    // the bytes received, on all the networks of the container
+	NetworkTx     uint64    // the bytes sent, on all the networks of the container
+	Pids          uint64    // the number of processes and threads of the container
+
+	// Raw is the sample as returned by the Docker API, for the other fields.
+	Raw container.StatsResponse
+}
+
+// Stats streams the samples of the resource usage of the container, about one per second,
+// until the context is cancelled or the stream is closed by the daemon, closing the channel then.
+// The CPU usage of each sample is computed since the previous one.
+func (c *DockerContainer) Stats(ctx context.Context) (<-chan ContainerStats, error) {
+	resp, err := c.provider.client.ContainerStats(ctx, c.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("container stats: %w", err)
+	}
+
+	ch := make(chan ContainerStats)
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+
+		dec := json.NewDecoder(resp.Body)
+		var previous *container.CPUStats
+		for {
+			var raw container.StatsResponse
+			if err := dec.Decode(&raw); err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					warnf(c.logger, "Failed to decode the stats of container %s: %v", c.ID, err)
+				}
+				return
+			}
+
+			stats := newContainerStats(raw, previous)
+			previous = &raw.CPUStats
+
+			select {
+			case ch <- stats:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// StatsNow returns a single sample of the resource usage of the container. As the CPU usage
+// is computed between two samples read by the Docker daemon, it takes about a second.
+func (c *DockerContainer) StatsNow(ctx context.Context) (ContainerStats, error) {
+	resp, err := c.provider.client.ContainerStats(ctx, c.ID, false)
+	if err != nil {
+		return ContainerStats{}, fmt.Errorf("container stats: %w", err)
+	}
+	defer c.provider.Close()
+	defer resp.Body.Close()
+
+	var raw container.StatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return ContainerStats{}, fmt.Errorf("decode stats: %w", err)
+	}
+
+	return newContainerStats(raw, nil), nil
+}
+
+// newContainerStats decodes a sample of the stats endpoint. The CPU usage is computed against
+// the previous CPU stats set by the daemon, or against the given previous ones if the daemon
+// didn't set them, e.g. for the first sample of a stream.
+func newContainerStats(raw container.StatsResponse, previous *container.CPUStats) ContainerStats {
+	stats := ContainerStats{
+		Read:        raw.Read,
+		MemoryUsage: memoryUsage(raw.MemoryStats),
+		MemoryLimit: raw.MemoryStats.Limit,
+		Pids:        raw.PidsStats.Current,
+		Raw:         raw,
+	}
+
+	preCPU := raw.PreCPUStats
+	if raw.PreRead.IsZero() && previous != nil {
+		preCPU = *previous
+	}
+	if !raw.PreRead.IsZero() || previous != nil {
+		stats.CPUPercent = cpuPercent(raw.CPUStats, preCPU)
+	}
+
+	if stats.MemoryLimit > 0 {
+		stats.MemoryPercent = float64(stats.MemoryUsage) / float64(stats.MemoryLimit) * 100
+	}
+
+	for _, n := range raw.Networks {
+		stats.NetworkRx += n.RxBytes
+		stats.NetworkTx += n.TxBytes
+	}
+
+	return stats
+}
+
+// cpuPercent returns the CPU usage between the two samples of the cumulative CPU times: the
+// time spent by the container relative to the time spent by the whole system, times the CPUs.
+func cpuPercent(cpu container.CPUStats, preCPU container.CPUStats) float64 {
+	// the cumulative times are reset if the container restarted.
+	if cpu.CPUUsage.TotalUsage <= preCPU.CPUUsage.TotalUsage || cpu.SystemUsage <= preCPU.SystemUsage {
+		return 0
+	}
+
+	cpuDelta := float64(cpu.CPUUsage.TotalUsage - preCPU.CPUUsage.TotalUsage)
+	systemDelta := float64(cpu.SystemUsage - preCPU.SystemUsage)
+
+	onlineCPUs := float64(cpu.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(cpu.CPUUsage.PercpuUsage))
+	}
+
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+// memoryUsage returns the memory usage without the inactive page cache, which can be reclaimed,
+// read from the stats of cgroup v1 or v2.
+func memoryUsage(mem container.MemoryStats) uint64 {
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if cache, ok := mem.Stats[key]; ok && cache < mem.Usage {
+			return mem.Usage - cache
+		}
+	}
+
+	return mem.Usage
+}
@@ -0,0 +1,146 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContainerStats(t *testing.T) {
+	now := time.Now()
+
+	raw := container.StatsResponse{
+		Stats: container.Stats{
+			Read:    now,
+			PreRead: now.Add(-time.Second),
+			CPUStats: container.CPUStats{
+				CPUUsage:    container.CPUUsage{TotalUsage: 3_000_000_000},
+				SystemUsage: 20_000_000_000,
+				OnlineCPUs:  4,
+			},
+			PreCPUStats: container.CPUStats{
+				CPUUsage:    container.CPUUsage{TotalUsage: 2_000_000_000},
+				SystemUsage: 16_000_000_000,
+				OnlineCPUs:  4,
+			},
+			MemoryStats: container.MemoryStats{
+				Usage: 100 * 1024 * 1024,
+				Limit: 200 * 1024 * 1024,
+				Stats: map[string]uint64{"inactive_file": 20 * 1024 * 1024},
+			},
+			PidsStats: container.PidsStats{Current: 3},
+		},
+		Networks: map[string]container.NetworkStats{
+			"eth0": {RxBytes: 100, TxBytes: 10},
+			"eth1": {RxBytes: 50, TxBytes: 5},
+		},
+	}
+
+	t.Run("sample", func(t *testing.T) {
+		stats := newContainerStats(raw, nil)
+
+		// 1s of CPU time over 4s of system time, on 4 CPUs.
+		require.InDelta(t, 100.0, stats.CPUPercent, 0.001)
+		require.Equal(t, uint64(80*1024*1024), stats.MemoryUsage)
+		require.Equal(t, uint64(200*1024*1024), stats.MemoryLimit)
+		require.InDelta(t, 40.0, stats.MemoryPercent, 0.001)
+		require.Equal(t, uint64(150), stats.NetworkRx)
+		require.Equal(t, uint64(15), stats.NetworkTx)
+		require.Equal(t, uint64(3), stats.Pids)
+		require.Equal(t, now, stats.Read)
+	})
+
+	t.Run("first-sample", func(t *testing.T) {
+		first := raw
+		first.PreRead = time.Time{}
+		first.PreCPUStats = container.CPUStats{}
+
+		// the usage since the start of the container is not a meaningful percentage.
+		require.Zero(t, newContainerStats(first, nil).CPUPercent)
+
+		previous := container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 2_500_000_000},
+			SystemUsage: 18_000_000_000,
+		}
+		require.InDelta(t, 100.0, newContainerStats(first, &previous).CPUPercent, 0.001)
+	})
+
+	t.Run("per-cpu", func(t *testing.T) {
+		perCPU := raw
+		perCPU.CPUStats.OnlineCPUs = 0
+		perCPU.CPUStats.CPUUsage.PercpuUsage = []uint64{1, 1}
+
+		require.InDelta(t, 50.0, newContainerStats(perCPU, nil).CPUPercent, 0.001)
+	})
+
+	t.Run("restarted", func(t *testing.T) {
+		restarted := raw
+		restarted.CPUStats.CPUUsage.TotalUsage = 1_000_000_000
+
+		require.Zero(t, newContainerStats(restarted, nil).CPUPercent)
+	})
+
+	t.Run("cgroup-v1", func(t *testing.T) {
+		v1 := raw
+		v1.MemoryStats.Stats = map[string]uint64{"total_inactive_file": 10 * 1024 * 1024}
+
+		require.Equal(t, uint64(90*1024*1024), newContainerStats(v1, nil).MemoryUsage)
+	})
+}
+
+func TestDockerContainer_Stats(t *testing.T) {
+	ctx := context.Background()
+
+	ctr, err := GenericContainer(ctx, GenericContainerRequest{
+		ProviderType: providerType,
+		ContainerRequest: ContainerRequest{
+			Image: "docker.io/busybox",
+			Cmd:   []string{"sleep", "60"},
+			ResourceLimits: ResourceLimits{
+				MemoryBytes: 64 * 1024 * 1024,
+			},
+		},
+		Started: true,
+	})
+	terminateContainerOnEnd(t, ctx, ctr)
+	require.NoError(t, err)
+
+	dockerContainer := ctr.(*DockerContainer)
+
+	t.Run("now", func(t *testing.T) {
+		// statsNow {
+		stats, err := dockerContainer.StatsNow(ctx)
+		require.NoError(t, err)
+		require.Less(t, stats.MemoryUsage, uint64(32*1024*1024))
+		// }
+
+		require.Equal(t, uint64(64*1024*1024), stats.MemoryLimit)
+		require.Positive(t, stats.Pids)
+		require.False(t, stats.Read.IsZero())
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// statsStream {
+		ch, err := dockerContainer.Stats(ctx)
+		require.NoError(t, err)
+
+		samples := 0
+		for stats := range ch {
+			require.Less(t, stats.MemoryUsage, uint64(32*1024*1024))
+
+			samples++
+			if samples == 2 {
+				cancel()
+			}
+		}
+		// }
+
+		require.GreaterOrEqual(t, samples, 2)
+	})
+}
@@ -0,0 +1,85 @@
+package testcontainers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/mount"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// TerminateOption is a type that represents an option for terminating a container.
+type TerminateOption func(*terminateOptions)
+
+// terminateOptions holds the options for terminating a container.
+// The zero value keeps the default behavior: the container is killed and removed
+// along with its anonymous volumes.
+type terminateOptions struct {
+	stopTimeout   *time.Duration
+	removeVolumes *bool
+	force         *bool
+}
+
+// noForce reports whether the container is stopped gracefully, instead of being killed, when it's removed.
+func (o terminateOptions) noForce() bool {
+	return o.force != nil && !*o.force
+}
+
+// WithStopTimeout stops the container gracefully before removing it, giving its process the
+// given duration to exit after receiving the stop signal, before it is killed. This allows
+// e.g. a database to flush its write-ahead log. See [DockerContainer.Stop] for the meaning of a
+// negative duration. By default, the container is killed without being stopped gracefully.
+func WithStopTimeout(timeout time.Duration) TerminateOption {
+	return func(o *terminateOptions) {
+		o.stopTimeout = &timeout
+	}
+}
+
+// WithRemoveVolumes sets whether the volumes of the container are removed along with it.
+// By default, only the anonymous volumes are removed. If false, the anonymous volumes are kept,
+// e.g. for post-mortem inspection. If true, the named volumes created by Testcontainers in this
+// session and mounted by the container are removed too.
+func WithRemoveVolumes(remove bool) TerminateOption {
+	return func(o *terminateOptions) {
+		o.removeVolumes = &remove
+	}
+}
+
+// WithForce sets whether a running container is killed when it is removed, which is the default.
+// If false, the container is stopped gracefully first, using the timeout set with WithStopTimeout,
+// if any, or the stop timeout of the container otherwise.
+// It's required to terminate a container adopted with ContainerFromID, with either value.
+func WithForce(force bool) TerminateOption {
+	return func(o *terminateOptions) {
+		o.force = &force
+	}
+}
+
+// sessionVolumes returns the names of the named volumes mounted by the container
+// that were created by Testcontainers in the current session.
+func (c *DockerContainer) sessionVolumes(ctx context.Context) ([]string, error) {
+	inspect, err := c.inspectRawContainer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect container: %w", err)
+	}
+
+	var volumes []string
+	for _, m := range inspect.Mounts {
+		if m.Type != mount.TypeVolume || m.Name == "" {
+			continue
+		}
+
+		vol, err := c.provider.client.VolumeInspect(ctx, m.Name)
+		if err != nil {
+			return nil, fmt.Errorf("inspect volume %s: %w", m.Name, err)
+		}
+
+		if vol.Labels[core.LabelSessionID] == core.SessionID() {
+			volumes = append(volumes, m.Name)
+		}
+	}
+
+	return volumes, nil
+}
@@ -0,0 +1,171 @@
+package testcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/errdefs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// volumeNames returns the names of the volumes mounted by the container.
+func volumeNames(t *testing.T, ctx context.Context, ctr Container) []string {
+	t.Helper()
+
+	inspect, err := ctr.Inspect(ctx)
+	require.NoError(t, err)
+
+	var names []string
+	for _, m := range inspect.Mounts {
+		if m.Type == mount.TypeVolume {
+			names = append(names, m.Name)
+		}
+	}
+
+	return names
+}
+
+func TestTerminateOptions(t *testing.T) {
+	ctx := context.Background()
+
+	cli, err := NewDockerClientWithOpts(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cli.Close()) })
+
+	// anonymousVolume mounts an anonymous volume in /data.
+	anonymousVolume := func(hc *container.HostConfig) {
+		hc.Mounts = append(hc.Mounts, mount.Mount{Type: mount.TypeVolume, Target: "/data"})
+	}
+
+	t.Run("stop-timeout", func(t *testing.T) {
+		var (
+			calls    []string
+			exitCode = -1
+		)
+		record := func(name string) ContainerHook {
+			return func(_ context.Context, _ Container) error {
+				calls = append(calls, name)
+				return nil
+			}
+		}
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/busybox",
+				// exits cleanly on SIGTERM
+				Cmd: []string{"sh", "-c", "trap 'exit 0' TERM; while true; do sleep 1; done"},
+				LifecycleHooks: []ContainerLifecycleHooks{{
+					PreTerminates: []ContainerHook{record("pre-terminate")},
+					PreStops:      []ContainerHook{record("pre-stop")},
+					PostStops: []ContainerHook{
+						record("post-stop"),
+						func(ctx context.Context, c Container) error {
+							state, err := c.State(ctx)
+							if err != nil {
+								return err
+							}
+							exitCode = state.ExitCode
+							return nil
+						},
+					},
+					PostTerminates: []ContainerHook{record("post-terminate")},
+				}},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+
+		// terminateWithStopTimeout {
+		err = ctr.Terminate(ctx, WithStopTimeout(30*time.Second))
+		// }
+		require.NoError(t, err)
+		require.Equal(t, []string{"pre-terminate", "pre-stop", "post-stop", "post-terminate"}, calls)
+		require.Zero(t, exitCode)
+	})
+
+	t.Run("default-removes-anonymous-volumes", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:              "docker.io/busybox",
+				Cmd:                []string{"sleep", "30"},
+				HostConfigModifier: anonymousVolume,
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+
+		volumes := volumeNames(t, ctx, ctr)
+		require.Len(t, volumes, 1)
+
+		require.NoError(t, ctr.Terminate(ctx))
+
+		_, err = cli.VolumeInspect(ctx, volumes[0])
+		require.True(t, errdefs.IsNotFound(err), err)
+	})
+
+	t.Run("keep-anonymous-volumes", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:              "docker.io/busybox",
+				Cmd:                []string{"sleep", "30"},
+				HostConfigModifier: anonymousVolume,
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+
+		volumes := volumeNames(t, ctx, ctr)
+		require.Len(t, volumes, 1)
+
+		require.NoError(t, ctr.Terminate(ctx, WithRemoveVolumes(false)))
+		t.Cleanup(func() { require.NoError(t, cli.VolumeRemove(ctx, volumes[0], true)) })
+
+		_, err = cli.VolumeInspect(ctx, volumes[0])
+		require.NoError(t, err)
+	})
+
+	t.Run("remove-named-volumes", func(t *testing.T) {
+		volumeName := "tc-terminate-" + uuid.NewString()
+
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image:  "docker.io/busybox",
+				Cmd:    []string{"sleep", "30"},
+				Mounts: Mounts(VolumeMount(volumeName, "/data")),
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ctr.Terminate(ctx, WithRemoveVolumes(true)))
+
+		_, err = cli.VolumeInspect(ctx, volumeName)
+		require.True(t, errdefs.IsNotFound(err), err)
+	})
+
+	t.Run("not-forced", func(t *testing.T) {
+		ctr, err := GenericContainer(ctx, GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/busybox",
+				Cmd:   []string{"sleep", "30"},
+			},
+			Started: true,
+		})
+		require.NoError(t, err)
+
+		// the container is stopped first, as it's not removed forcibly.
+		require.NoError(t, ctr.Terminate(ctx, WithForce(false), WithStopTimeout(time.Second)))
+
+		_, err = cli.ContainerInspect(ctx, ctr.GetContainerID())
+		require.True(t, errdefs.IsNotFound(err), err)
+	})
+}
@@ -0,0 +1,128 @@
+package testcontainers
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+)
+
+var (
+	// ErrInvalidTimezone is returned by WithTimezone when the time zone is not an IANA time zone name.
+	ErrInvalidTimezone = errors.New("invalid timezone")
+
+	// ErrInvalidLocale is returned by WithLocale when the locale is not a POSIX locale name.
+	ErrInvalidLocale = errors.New("invalid locale")
+)
+
+// zoneinfoDir is the directory of the time zone database in the container.
+const zoneinfoDir = "/usr/share/zoneinfo"
+
+// localeRegex matches the POSIX locale names, e.g. "C", "POSIX", "C.UTF-8", "de_DE.UTF-8" or "sr_RS@latin".
+var localeRegex = regexp.MustCompile(`^(C|POSIX|[a-z]{2,3}(_[A-Z]{2})?)(\.[A-Za-z0-9-]+)?(@[A-Za-z0-9]+)?$`)
+
+// WithTimezone runs the container in the given IANA time zone, e.g. "Europe/Berlin", validated
+// against the time zone database of Go. It sets the TZ env var and, if the image ships the time
+// zone database in /usr/share/zoneinfo, links /etc/localtime to the time zone and writes it to
+// /etc/timezone before the container starts, for the processes ignoring TZ. Otherwise, only the
+// env var is set, which is logged.
+func WithTimezone(tz string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if tz == "" || tz == "Local" {
+			return fmt.Errorf("%w: %q", ErrInvalidTimezone, tz)
+		}
+
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTimezone, err)
+		}
+
+		if err := WithEnv(map[string]string{"TZ": tz})(req); err != nil {
+			return err
+		}
+
+		req.LifecycleHooks = append(req.LifecycleHooks, ContainerLifecycleHooks{
+			PreStarts: []ContainerHook{
+				func(ctx context.Context, c Container) error {
+					dc, ok := c.(*DockerContainer)
+					if !ok {
+						return nil
+					}
+
+					return dc.linkLocaltime(ctx, tz)
+				},
+			},
+		})
+
+		return nil
+	}
+}
+
+// WithLocale runs the container with the given POSIX locale, e.g. "de_DE.UTF-8", setting the
+// LANG env var. The locale must be available in the image for the processes to use it.
+func WithLocale(locale string) CustomizeRequestOption {
+	return func(req *GenericContainerRequest) error {
+		if !localeRegex.MatchString(locale) {
+			return fmt.Errorf("%w: %q must match %s", ErrInvalidLocale, locale, localeRegex)
+		}
+
+		return WithEnv(map[string]string{"LANG": locale})(req)
+	}
+}
+
+// linkLocaltime links /etc/localtime to the given time zone of the time zone database of the
+// container, and writes it to /etc/timezone, if the image ships the time zone database.
+func (c *DockerContainer) linkLocaltime(ctx context.Context, tz string) error {
+	zoneinfo := path.Join(zoneinfoDir, tz)
+
+	if _, err := c.StatPath(ctx, zoneinfo); err != nil {
+		if errdefs.IsNotFound(err) {
+			infof(c.logger, "🕐 No time zone database in container %s, only the TZ env var is set for %s", c.ID, tz)
+			return nil
+		}
+
+		return fmt.Errorf("stat %s: %w", zoneinfo, err)
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeSymlink,
+		Name:     "etc/localtime",
+		Linkname: zoneinfo,
+		Mode:     0o777,
+	}); err != nil {
+		return fmt.Errorf("tar localtime: %w", err)
+	}
+
+	timezone := []byte(tz + "\n")
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     "etc/timezone",
+		Mode:     0o644,
+		Size:     int64(len(timezone)),
+	}); err != nil {
+		return fmt.Errorf("tar timezone: %w", err)
+	}
+	if _, err := tw.Write(timezone); err != nil {
+		return fmt.Errorf("tar timezone: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("tar close: %w", err)
+	}
+
+	if err := c.provider.client.CopyToContainer(ctx, c.ID, "/", &buf, container.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("copy localtime: %w", err)
+	}
+	defer c.provider.Close()
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package testcontainers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+func TestWithTimezone_validate(t *testing.T) {
+	for _, tz := range []string{"", "Local", "Mars/Olympus_Mons", "../../etc/passwd"} {
+		t.Run("invalid/"+tz, func(t *testing.T) {
+			req := GenericContainerRequest{}
+			require.ErrorIs(t, WithTimezone(tz)(&req), ErrInvalidTimezone)
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		req := GenericContainerRequest{}
+		require.NoError(t, WithTimezone("Asia/Tokyo")(&req))
+		require.Equal(t, "Asia/Tokyo", req.Env["TZ"])
+		require.Len(t, req.LifecycleHooks, 1)
+		require.Len(t, req.LifecycleHooks[0].PreStarts, 1)
+	})
+}
+
+func TestWithLocale(t *testing.T) {
+	for _, locale := range []string{"C", "POSIX", "C.UTF-8", "de_DE.UTF-8", "sr_RS@latin", "en_US"} {
+		t.Run("valid/"+locale, func(t *testing.T) {
+			req := GenericContainerRequest{}
+			require.NoError(t, WithLocale(locale)(&req))
+			require.Equal(t, locale, req.Env["LANG"])
+		})
+	}
+
+	for _, locale := range []string{"", "german", "de_DE.UTF-8; rm -rf /", "DE_de"} {
+		t.Run("invalid/"+locale, func(t *testing.T) {
+			req := GenericContainerRequest{}
+			require.ErrorIs(t, WithLocale(locale)(&req), ErrInvalidLocale)
+		})
+	}
+}
+
+func TestWithTimezone(t *testing.T) {
+	ctx := context.Background()
+
+	output := func(t *testing.T, ctr Container, cmd ...string) string {
+		t.Helper()
+
+		code, r, err := ctr.Exec(ctx, cmd, tcexec.Multiplexed())
+		require.NoError(t, err)
+		require.Zero(t, code)
+
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		return strings.TrimSpace(string(b))
+	}
+
+	t.Run("zoneinfo", func(t *testing.T) {
+		// withTimezone {
+		req := GenericContainerRequest{
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/debian:bookworm-slim",
+				Cmd:   []string{"sleep", "60"},
+			},
+			Started: true,
+		}
+		require.NoError(t, WithTimezone("Asia/Tokyo")(&req))
+		require.NoError(t, WithLocale("C.UTF-8")(&req))
+		// }
+		req.ProviderType = providerType
+
+		ctr, err := GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		require.Equal(t, "JST", output(t, ctr, "date", "+%Z"))
+		require.Equal(t, "/usr/share/zoneinfo/Asia/Tokyo", output(t, ctr, "readlink", "/etc/localtime"))
+		require.Equal(t, "Asia/Tokyo", output(t, ctr, "cat", "/etc/timezone"))
+
+		// the processes ignoring TZ use /etc/localtime.
+		require.Equal(t, "JST", output(t, ctr, "env", "-u", "TZ", "date", "+%Z"))
+	})
+
+	t.Run("env-only", func(t *testing.T) {
+		req := GenericContainerRequest{
+			ProviderType: providerType,
+			ContainerRequest: ContainerRequest{
+				Image: "docker.io/busybox",
+				Cmd:   []string{"sleep", "60"},
+			},
+			Started: true,
+		}
+		require.NoError(t, WithTimezone("Asia/Tokyo")(&req))
+
+		// busybox doesn't ship the time zone database, so only the env var is set.
+		ctr, err := GenericContainer(ctx, req)
+		terminateContainerOnEnd(t, ctx, ctr)
+		require.NoError(t, err)
+
+		require.Equal(t, "Asia/Tokyo", output(t, ctr, "printenv", "TZ"))
+	})
+}
@@ -2,20 +2,26 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/docker/go-connections/nat"
 )
 
 // Implement interface
 var (
 	_ Strategy        = (*MultiStrategy)(nil)
 	_ StrategyTimeout = (*MultiStrategy)(nil)
+	_ StrategyPorts   = (*MultiStrategy)(nil)
 )
 
 type MultiStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
 	timeout  *time.Duration
 	deadline *time.Duration
+	failFast bool
+	observer ProbeObserver
 
 	// additional properties
 	Strategies []Strategy
@@ -40,6 +46,23 @@ func (ms *MultiStrategy) WithDeadline(deadline time.Duration) *MultiStrategy {
 	return ms
 }
 
+// WithObserver sets the observer notified of the attempts of the inner wait strategies
+// without their own observer, see ProbeObserver.
+func (ms *MultiStrategy) WithObserver(observer ProbeObserver) *MultiStrategy {
+	ms.observer = observer
+	return ms
+}
+
+// WithFailFast stops at the first strategy failing, returning its error, instead of running all
+// the strategies and returning their errors joined, which is the default.
+func (ms *MultiStrategy) WithFailFast() *MultiStrategy {
+	ms.failFast = true
+	return ms
+}
+
+// ForAll waits for all the given strategies, in order. By default, all the strategies are run
+// even if one of them fails, and their errors are joined, each one annotated with its strategy,
+// so that a failure doesn't hide whether the other strategies would have passed. See WithFailFast.
 func ForAll(strategies ...Strategy) *MultiStrategy {
 	return &MultiStrategy{
 		Strategies: strategies,
@@ -50,33 +73,71 @@ func (ms *MultiStrategy) Timeout() *time.Duration {
 	return ms.timeout
 }
 
-func (ms *MultiStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	var cancel context.CancelFunc
-	if ms.deadline != nil {
-		ctx, cancel = context.WithTimeout(ctx, *ms.deadline)
-		defer cancel()
+// Ports implements StrategyPorts, returning the ports of the inner wait strategies.
+func (ms *MultiStrategy) Ports() []nat.Port {
+	var ports []nat.Port
+	for _, strategy := range ms.Strategies {
+		ports = append(ports, portsOf(strategy)...)
 	}
 
+	return ports
+}
+
+// startupTimeout implements startupTimeoutStrategy, the strategies being limited by the deadline only,
+// as the timeout is the default one of the inner strategies.
+func (ms *MultiStrategy) startupTimeout(_ context.Context) (time.Duration, bool) {
+	if ms.deadline == nil {
+		return 0, false
+	}
+
+	return *ms.deadline, true
+}
+
+func (ms *MultiStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	ctx, cancel := withEffectiveDeadline(ctx, ms)
+	defer cancel()
+
+	ctx = withObserver(ctx, ms.observer)
+	ctx = withDefaultStartupTimeout(ctx, ms.timeout)
+
 	if len(ms.Strategies) == 0 {
 		return fmt.Errorf("no wait strategy supplied")
 	}
 
-	for _, strategy := range ms.Strategies {
-		strategyCtx := ctx
-
-		// Set default Timeout when strategy implements StrategyTimeout
-		if st, ok := strategy.(StrategyTimeout); ok {
-			if ms.Timeout() != nil && st.Timeout() == nil {
-				strategyCtx, cancel = context.WithTimeout(ctx, *ms.Timeout())
-				defer cancel()
+	var errs []error
+	for i, strategy := range ms.Strategies {
+		if err := ctx.Err(); err != nil {
+			// the deadline is exceeded, so the remaining strategies are not run.
+			if ms.failFast {
+				return err
 			}
+			errs = append(errs, strategyError(i, strategy, err))
+			continue
 		}
 
-		err := strategy.WaitUntilReady(strategyCtx, target)
+		err := strategy.WaitUntilReady(ctx, target)
 		if err != nil {
-			return err
+			if ms.failFast {
+				return err
+			}
+			errs = append(errs, strategyError(i, strategy, err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// strategyError annotates the error of the strategy at the given index with its description,
+// e.g. `strategy #1 (log "ready"): context deadline exceeded`.
+func strategyError(i int, strategy Strategy, err error) error {
+	return fmt.Errorf("strategy #%d (%s): %w", i, describe(strategy), err)
+}
+
+// describe returns the description of the strategy, if it implements fmt.Stringer, or its type.
+func describe(strategy Strategy) string {
+	if s, ok := strategy.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%T", strategy)
 }
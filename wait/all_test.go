@@ -119,3 +119,102 @@ func TestMultiStrategy_WaitUntilReady(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiStrategy_FailureModes(t *testing.T) {
+	errFirst := errors.New("first failure")
+	errThird := errors.New("third failure")
+
+	newStrategies := func(ran *[]int) []Strategy {
+		return []Strategy{
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				*ran = append(*ran, 0)
+				return errFirst
+			}),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				*ran = append(*ran, 1)
+				return nil
+			}),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				*ran = append(*ran, 2)
+				return errThird
+			}),
+		}
+	}
+
+	t.Run("collect", func(t *testing.T) {
+		var ran []int
+		err := ForAll(newStrategies(&ran)...).WaitUntilReady(context.Background(), NopStrategyTarget{})
+
+		if !errors.Is(err, errFirst) || !errors.Is(err, errThird) {
+			t.Fatalf("expected both failures to be joined, got %v", err)
+		}
+		if want := "strategy #0 (*wait.NopStrategy): first failure\nstrategy #2 (*wait.NopStrategy): third failure"; err.Error() != want {
+			t.Fatalf("expected %q, got %q", want, err.Error())
+		}
+		if len(ran) != 3 {
+			t.Fatalf("expected all the strategies to run, ran %v", ran)
+		}
+	})
+
+	t.Run("fail-fast", func(t *testing.T) {
+		var ran []int
+		// waitForAllFailFast {
+		strategy := ForAll(newStrategies(&ran)...).WithFailFast()
+		// }
+		err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{})
+
+		if !errors.Is(err, errFirst) || errors.Is(err, errThird) {
+			t.Fatalf("expected the first failure only, got %v", err)
+		}
+		if len(ran) != 1 {
+			t.Fatalf("expected the strategies to stop at the first failure, ran %v", ran)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		var ran []int
+		strategy := ForAll(
+			// the child timeout is longer than the deadline of the composite.
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				ran = append(ran, 0)
+				<-ctx.Done()
+				return ctx.Err()
+			}).WithStartupTimeout(time.Minute),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				ran = append(ran, 1)
+				return nil
+			}),
+		).WithDeadline(100 * time.Millisecond)
+
+		start := time.Now()
+		err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{})
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Fatalf("expected the deadline to cap the child timeout, waited %s", elapsed)
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the deadline to be exceeded, got %v", err)
+		}
+		if want := "strategy #0 (*wait.NopStrategy): context deadline exceeded\nstrategy #1 (*wait.NopStrategy): context deadline exceeded"; err.Error() != want {
+			t.Fatalf("expected %q, got %q", want, err.Error())
+		}
+		if len(ran) != 1 {
+			t.Fatalf("expected the remaining strategies not to run, ran %v", ran)
+		}
+	})
+}
+
+func TestMultiStrategy_Ports(t *testing.T) {
+	strategy := ForAll(
+		ForListeningPort("80/tcp"),
+		ForLog("ready"),
+		ForAny(ForHTTP("/").WithPort("8080/tcp"), ForListeningPort("")),
+		// checked from inside the container, the port doesn't need to be exposed.
+		ForListeningPort("9090/tcp").WithCheck(PortCheckInternal),
+	)
+
+	ports := strategy.Ports()
+	if len(ports) != 2 || ports[0] != "80/tcp" || ports[1] != "8080/tcp" {
+		t.Fatalf("expected the ports of the inner strategies, got %v", ports)
+	}
+}
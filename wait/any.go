@@ -0,0 +1,127 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*AnyStrategy)(nil)
+	_ StrategyTimeout = (*AnyStrategy)(nil)
+	_ StrategyPorts   = (*AnyStrategy)(nil)
+)
+
+// AnyStrategy waits for the first of its strategies to pass, running them concurrently.
+type AnyStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout  *time.Duration
+	deadline *time.Duration
+	observer ProbeObserver
+
+	// additional properties
+	Strategies []Strategy
+}
+
+// ForAny waits for the first of the given strategies to pass, e.g. for any of the ready log lines
+// of different versions of an image. The strategies are run concurrently, and the remaining ones are
+// cancelled as soon as one of them passes. It only fails when all the strategies fail, joining their
+// errors, each one annotated with its strategy.
+func ForAny(strategies ...Strategy) *AnyStrategy {
+	return &AnyStrategy{
+		Strategies: strategies,
+	}
+}
+
+// WithStartupTimeoutDefault sets the default timeout for all inner wait strategies
+func (as *AnyStrategy) WithStartupTimeoutDefault(timeout time.Duration) *AnyStrategy {
+	as.timeout = &timeout
+	return as
+}
+
+// WithDeadline sets a time.Duration which limits all wait strategies, regardless of their own timeouts
+func (as *AnyStrategy) WithDeadline(deadline time.Duration) *AnyStrategy {
+	as.deadline = &deadline
+	return as
+}
+
+// WithObserver sets the observer notified of the attempts of the inner wait strategies
+// without their own observer, see ProbeObserver. As the strategies run concurrently,
+// the observer must be safe for concurrent use.
+func (as *AnyStrategy) WithObserver(observer ProbeObserver) *AnyStrategy {
+	as.observer = observer
+	return as
+}
+
+func (as *AnyStrategy) Timeout() *time.Duration {
+	return as.timeout
+}
+
+// Ports implements StrategyPorts, returning the ports of the inner wait strategies.
+func (as *AnyStrategy) Ports() []nat.Port {
+	var ports []nat.Port
+	for _, strategy := range as.Strategies {
+		ports = append(ports, portsOf(strategy)...)
+	}
+
+	return ports
+}
+
+// String returns a human-readable description of the wait strategy.
+func (as *AnyStrategy) String() string {
+	return fmt.Sprintf("any of %d strategies", len(as.Strategies))
+}
+
+// anyResult is the result of the strategy at the given index.
+type anyResult struct {
+	index int
+	err   error
+}
+
+// startupTimeout implements startupTimeoutStrategy, the strategies being limited by the deadline only,
+// as the timeout is the default one of the inner strategies.
+func (as *AnyStrategy) startupTimeout(_ context.Context) (time.Duration, bool) {
+	if as.deadline == nil {
+		return 0, false
+	}
+
+	return *as.deadline, true
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (as *AnyStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	if len(as.Strategies) == 0 {
+		return errors.New("no wait strategy supplied")
+	}
+
+	// the context is cancelled once one of the strategies passes, to stop the remaining ones.
+	ctx, cancel := withEffectiveDeadline(ctx, as)
+	defer cancel()
+
+	ctx = withObserver(ctx, as.observer)
+	ctx = withDefaultStartupTimeout(ctx, as.timeout)
+
+	results := make(chan anyResult, len(as.Strategies))
+	for i, strategy := range as.Strategies {
+		go func() {
+			results <- anyResult{index: i, err: strategy.WaitUntilReady(ctx, target)}
+		}()
+	}
+
+	errs := make([]error, len(as.Strategies))
+	for range as.Strategies {
+		res := <-results
+		if res.err == nil {
+			// the cancelled strategies return on their own, the results channel
+			// being buffered for all of them.
+			return nil
+		}
+		errs[res.index] = strategyError(res.index, as.Strategies[res.index], res.err)
+	}
+
+	return errors.Join(errs...)
+}
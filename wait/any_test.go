@@ -0,0 +1,164 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestAnyStrategy_WaitUntilReady(t *testing.T) {
+	t.Run("no-strategies", func(t *testing.T) {
+		if err := ForAny().WaitUntilReady(context.Background(), NopStrategyTarget{}); err == nil {
+			t.Fatal("expected an error when no strategies are passed")
+		}
+	})
+
+	t.Run("fast-failing-and-slow-succeeding", func(t *testing.T) {
+		errFast := errors.New("fast failure")
+
+		strategy := ForAny(
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				return errFast
+			}),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(300 * time.Millisecond):
+					return nil
+				}
+			}),
+		)
+
+		if err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{}); err != nil {
+			t.Fatalf("expected the slow strategy to pass, got %v", err)
+		}
+	})
+
+	t.Run("log-lines", func(t *testing.T) {
+		// the strategies run concurrently, so each one reads its own logs.
+		target := &MockStrategyTarget{
+			LogsImpl: func(_ context.Context) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader([]byte("2024-01-01 00:00:00 ready for connections\n"))), nil
+			},
+			StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+				return &types.ContainerState{Running: true}, nil
+			},
+		}
+
+		// waitForAny {
+		strategy := ForAny(
+			ForLog("Ready to accept connections"),
+			ForLog("ready for connections"),
+		).WithDeadline(5 * time.Second)
+		// }
+
+		if err := strategy.WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatalf("expected one of the log lines to be found, got %v", err)
+		}
+	})
+
+	t.Run("cancels-the-rest", func(t *testing.T) {
+		var cancelled atomic.Bool
+		done := make(chan struct{})
+
+		strategy := ForAny(
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				defer close(done)
+				<-ctx.Done()
+				cancelled.Store(true)
+				return ctx.Err()
+			}),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				return nil
+			}),
+		)
+
+		if err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{}); err != nil {
+			t.Fatalf("expected the strategy to pass, got %v", err)
+		}
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the remaining strategy to be cancelled")
+		}
+		if !cancelled.Load() {
+			t.Fatal("expected the remaining strategy to observe the cancellation")
+		}
+	})
+
+	t.Run("all-failing", func(t *testing.T) {
+		errFirst := errors.New("first failure")
+		errSecond := errors.New("second failure")
+
+		err := ForAny(
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				time.Sleep(100 * time.Millisecond)
+				return errFirst
+			}),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				return errSecond
+			}),
+		).WaitUntilReady(context.Background(), NopStrategyTarget{})
+
+		if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+			t.Fatalf("expected both failures to be joined, got %v", err)
+		}
+		// the errors are in the order of the strategies, not of their failures.
+		if want := "strategy #0 (*wait.NopStrategy): first failure\nstrategy #1 (*wait.NopStrategy): second failure"; err.Error() != want {
+			t.Fatalf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("child-timeout", func(t *testing.T) {
+		// the default timeout only applies to the strategies without their own timeout.
+		err := ForAny(
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				if _, set := ctx.Deadline(); set {
+					return errors.New("expected context.Deadline not to be set")
+				}
+				time.Sleep(300 * time.Millisecond)
+				return nil
+			}).WithStartupTimeout(time.Minute),
+		).WithStartupTimeoutDefault(100*time.Millisecond).
+			WaitUntilReady(context.Background(), NopStrategyTarget{})
+		if err != nil {
+			t.Fatalf("expected the strategy with its own timeout to pass, got %v", err)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		strategy := ForAny(
+			// the child timeout is longer than the deadline of the composite.
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}).WithStartupTimeout(time.Minute),
+			ForNop(func(ctx context.Context, target StrategyTarget) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+		).WithDeadline(100 * time.Millisecond)
+
+		start := time.Now()
+		err := strategy.WaitUntilReady(context.Background(), NopStrategyTarget{})
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Fatalf("expected the deadline to cap the child timeout, waited %s", elapsed)
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the deadline to be exceeded, got %v", err)
+		}
+	})
+}
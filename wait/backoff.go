@@ -0,0 +1,119 @@
+package wait
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff returns the delays between the polls of a strategy. Implementations must be safe
+// for concurrent use, as a backoff can be shared by the strategies of many containers.
+type Backoff interface {
+	// Delay returns the delay before the given poll, starting at 0 for the first one.
+	Delay(attempt int) time.Duration
+}
+
+// Implement interface
+var (
+	_ Backoff = ConstantBackoff(0)
+	_ Backoff = (*ExponentialBackoff)(nil)
+)
+
+// defaultBackoff is the backoff of the strategies without a backoff or a poll interval set
+// with WithBackoff or WithPollInterval. If nil, their default poll interval is used.
+var defaultBackoff Backoff
+
+// SetDefaultBackoff sets the backoff of the strategies without a backoff or a poll interval set
+// with WithBackoff or WithPollInterval, e.g. to switch a whole test suite to an exponential backoff.
+// A nil backoff restores the default poll interval of each strategy. It's not safe for concurrent
+// use, so it must be called before creating any container, e.g. in TestMain.
+func SetDefaultBackoff(b Backoff) {
+	defaultBackoff = b
+}
+
+// ConstantBackoff polls at a fixed interval, which is the default of the strategies.
+type ConstantBackoff time.Duration
+
+// Delay implements Backoff.Delay
+func (b ConstantBackoff) Delay(_ int) time.Duration {
+	return time.Duration(b)
+}
+
+// ExponentialBackoff polls with a delay multiplied after each poll, randomized by the jitter
+// to spread the polls of the strategies started together, and capped by Max.
+type ExponentialBackoff struct {
+	Initial    time.Duration // the delay before the first poll
+	Max        time.Duration // the maximum delay, unlimited if zero
+	Multiplier float64       // the factor applied to the delay after each poll, 2 if lower than 1
+	Jitter     float64       // the randomization factor of the delay, between 0 and 1, e.g. 0.2 for ±20%
+}
+
+// maxDelay is the largest delay, as a float64.
+const maxDelay = float64(math.MaxInt64)
+
+// NewExponentialBackoff returns an exponential backoff starting at the initial delay, doubled after
+// each poll, with a jitter of ±20%, and capped by the max interval.
+func NewExponentialBackoff(initial time.Duration, maxInterval time.Duration) *ExponentialBackoff {
+	return &ExponentialBackoff{
+		Initial:    initial,
+		Max:        maxInterval,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+}
+
+// Delay implements Backoff.Delay
+func (b *ExponentialBackoff) Delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	// the delay is bounded, as it overflows for the large attempts.
+	delay := math.Min(float64(b.Initial)*math.Pow(multiplier, float64(attempt)), maxDelay)
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+
+	if b.Max > 0 && delay > float64(b.Max) {
+		return b.Max
+	}
+	if delay >= maxDelay {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
+
+// backoffOf returns the backoff of a strategy: the one set with WithBackoff or WithPollInterval,
+// the default one set with SetDefaultBackoff, or the default poll interval of the strategy.
+func backoffOf(b Backoff, pollInterval time.Duration) Backoff {
+	if b != nil {
+		return b
+	}
+
+	if defaultBackoff != nil {
+		return defaultBackoff
+	}
+
+	return ConstantBackoff(pollInterval)
+}
+
+// poller returns the delays between the polls of a single wait of a strategy.
+type poller struct {
+	backoff Backoff
+	attempt int
+}
+
+// newPoller returns a poller for the backoff of a strategy, see backoffOf.
+func newPoller(b Backoff, pollInterval time.Duration) *poller {
+	return &poller{backoff: backoffOf(b, pollInterval)}
+}
+
+// next returns the delay before the next poll.
+func (p *poller) next() time.Duration {
+	delay := p.backoff.Delay(p.attempt)
+	p.attempt++
+
+	return delay
+}
@@ -0,0 +1,130 @@
+package wait
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// recordingBackoff is a Backoff recording the polls it's asked the delay of.
+type recordingBackoff struct {
+	mu       sync.Mutex
+	attempts []int
+}
+
+func (b *recordingBackoff) Delay(attempt int) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.attempts = append(b.attempts, attempt)
+	return time.Millisecond
+}
+
+func TestConstantBackoff(t *testing.T) {
+	b := ConstantBackoff(250 * time.Millisecond)
+
+	for attempt := 0; attempt < 5; attempt++ {
+		require.Equal(t, 250*time.Millisecond, b.Delay(attempt))
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Run("without-jitter", func(t *testing.T) {
+		b := &ExponentialBackoff{
+			Initial: 50 * time.Millisecond,
+			Max:     time.Second,
+		}
+
+		want := []time.Duration{
+			50 * time.Millisecond,
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			800 * time.Millisecond,
+			time.Second,
+			time.Second,
+		}
+		for attempt, delay := range want {
+			require.Equal(t, delay, b.Delay(attempt), "attempt %d", attempt)
+		}
+	})
+
+	t.Run("with-jitter", func(t *testing.T) {
+		// exponentialBackoff {
+		b := NewExponentialBackoff(50*time.Millisecond, 2*time.Second)
+		// }
+
+		for attempt := 0; attempt < 10; attempt++ {
+			base := math.Min(float64(50*time.Millisecond)*math.Pow(2, float64(attempt)), float64(2*time.Second))
+			delay := b.Delay(attempt)
+			require.GreaterOrEqual(t, float64(delay), base*0.8, "attempt %d", attempt)
+			require.LessOrEqual(t, delay, 2*time.Second, "attempt %d", attempt)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		b := &ExponentialBackoff{Initial: time.Second}
+		require.Equal(t, time.Duration(math.MaxInt64), b.Delay(1000))
+
+		b.Max = time.Minute
+		require.Equal(t, time.Minute, b.Delay(1000))
+	})
+}
+
+func TestBackoffOf(t *testing.T) {
+	t.Run("poll-interval", func(t *testing.T) {
+		require.Equal(t, ConstantBackoff(time.Second), backoffOf(nil, time.Second))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		// setDefaultBackoff {
+		SetDefaultBackoff(NewExponentialBackoff(50*time.Millisecond, 2*time.Second))
+		// }
+		t.Cleanup(func() { SetDefaultBackoff(nil) })
+
+		require.Equal(t, defaultBackoff, backoffOf(nil, time.Second))
+
+		// the poll interval set with WithPollInterval wins over the default backoff.
+		ws := ForLog("ready").WithPollInterval(time.Second)
+		require.Equal(t, ConstantBackoff(time.Second), backoffOf(ws.backoff, ws.PollInterval))
+	})
+
+	t.Run("strategy", func(t *testing.T) {
+		b := &recordingBackoff{}
+		ws := ForLog("ready").WithPollInterval(time.Second).WithBackoff(b)
+		require.Equal(t, b, backoffOf(ws.backoff, ws.PollInterval))
+	})
+}
+
+func TestExecStrategyWithBackoff(t *testing.T) {
+	b := &recordingBackoff{}
+
+	execs := 0
+	target := &MockStrategyTarget{
+		ExecImpl: func(_ context.Context, _ []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+			execs++
+			if execs < 3 {
+				return 1, nil, nil
+			}
+			return 0, nil, nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+
+	err := ForExec([]string{"true"}).
+		WithBackoff(b).
+		WithStartupTimeout(5*time.Second).
+		WaitUntilReady(context.Background(), target)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2}, b.attempts)
+}
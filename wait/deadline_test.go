@@ -0,0 +1,162 @@
+package wait
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+func TestEffectiveDeadline(t *testing.T) {
+	// within returns whether the deadline is the given duration from now, give or take a second.
+	within := func(deadline time.Time, d time.Duration) bool {
+		return time.Until(deadline) > d-time.Second && time.Until(deadline) <= d
+	}
+
+	t.Run("default-timeout", func(t *testing.T) {
+		deadline, ok := EffectiveDeadline(context.Background(), ForLog("ready"))
+		require.True(t, ok)
+		require.True(t, within(deadline, defaultStartupTimeout()))
+	})
+
+	t.Run("context-first", func(t *testing.T) {
+		// effectiveDeadline {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// the deadline of the context ends before the startup timeout of the strategy.
+		deadline, ok := EffectiveDeadline(ctx, ForLog("ready").WithStartupTimeout(2*time.Minute))
+		// }
+		require.True(t, ok)
+
+		ctxDeadline, _ := ctx.Deadline()
+		require.Equal(t, ctxDeadline, deadline)
+	})
+
+	t.Run("strategy-first", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		deadline, ok := EffectiveDeadline(ctx, ForLog("ready").WithStartupTimeout(10*time.Second))
+		require.True(t, ok)
+		require.True(t, within(deadline, 10*time.Second))
+	})
+
+	t.Run("composite-default-timeout", func(t *testing.T) {
+		ctx := withDefaultStartupTimeout(context.Background(), ForAll().WithStartupTimeoutDefault(2*time.Minute).timeout)
+
+		// the default of the composite applies, even if it's longer than the default startup timeout.
+		deadline, ok := EffectiveDeadline(ctx, ForLog("ready"))
+		require.True(t, ok)
+		require.True(t, within(deadline, 2*time.Minute))
+
+		deadline, ok = EffectiveDeadline(ctx, ForLog("ready").WithStartupTimeout(10*time.Second))
+		require.True(t, ok)
+		require.True(t, within(deadline, 10*time.Second))
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		_, ok := EffectiveDeadline(context.Background(), ForAll(ForLog("ready")).WithStartupTimeoutDefault(time.Minute))
+		require.False(t, ok)
+
+		deadline, ok := EffectiveDeadline(context.Background(), ForAny(ForLog("ready")).WithDeadline(10*time.Second))
+		require.True(t, ok)
+		require.True(t, within(deadline, 10*time.Second))
+	})
+
+	t.Run("exit", func(t *testing.T) {
+		_, ok := EffectiveDeadline(context.Background(), ForExit())
+		require.False(t, ok)
+
+		deadline, ok := EffectiveDeadline(context.Background(), ForExit().WithExitTimeout(10*time.Second))
+		require.True(t, ok)
+		require.True(t, within(deadline, 10*time.Second))
+	})
+}
+
+// neverReadyTarget returns a running target which never becomes ready: its ports are not mapped,
+// its logs don't match, and its commands fail.
+func neverReadyTarget() *MockStrategyTarget {
+	return &MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		InspectImpl: func(_ context.Context) (*types.ContainerJSON, error) {
+			return &types.ContainerJSON{}, nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return "", ErrPortNotFound
+		},
+		LogsImpl: func(_ context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("starting\n")), nil
+		},
+		ExecImpl: func(_ context.Context, _ []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+			return 1, nil, nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+}
+
+// TestWaitUntilReady_deadline checks that the strategies wait until the earliest of the deadline of
+// the context and their startup timeout, whichever comes first.
+func TestWaitUntilReady_deadline(t *testing.T) {
+	const (
+		short = 200 * time.Millisecond
+		long  = time.Minute
+	)
+
+	strategies := map[string]func(timeout time.Duration) Strategy{
+		"log": func(timeout time.Duration) Strategy {
+			return ForLog("ready").WithPollInterval(10 * time.Millisecond).WithStartupTimeout(timeout)
+		},
+		"ordered-logs": func(timeout time.Duration) Strategy {
+			return ForOrderedLogs("starting", "ready").WithPollInterval(10 * time.Millisecond).WithStartupTimeout(timeout)
+		},
+		"http": func(timeout time.Duration) Strategy {
+			return ForHTTP("/").WithPort("8080/tcp").WithPollInterval(10 * time.Millisecond).WithStartupTimeout(timeout)
+		},
+		"exec": func(timeout time.Duration) Strategy {
+			return ForExec([]string{"true"}).WithPollInterval(10 * time.Millisecond).WithStartupTimeout(timeout)
+		},
+		"health": func(timeout time.Duration) Strategy {
+			return ForHealthCheck().WithPollInterval(10 * time.Millisecond).WithStartupTimeout(timeout)
+		},
+		"all": func(timeout time.Duration) Strategy {
+			return ForAll(ForLog("ready").WithPollInterval(10 * time.Millisecond)).WithStartupTimeoutDefault(timeout)
+		},
+		"any": func(timeout time.Duration) Strategy {
+			return ForAny(ForLog("ready").WithPollInterval(10 * time.Millisecond)).WithStartupTimeoutDefault(timeout)
+		},
+	}
+
+	for name, strategy := range strategies {
+		t.Run(name+"/context-first", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), short)
+			defer cancel()
+
+			start := time.Now()
+			err := strategy(long).WaitUntilReady(ctx, neverReadyTarget())
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Less(t, time.Since(start), long/2)
+		})
+
+		t.Run(name+"/strategy-first", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), long)
+			defer cancel()
+
+			start := time.Now()
+			err := strategy(short).WaitUntilReady(ctx, neverReadyTarget())
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			require.Less(t, time.Since(start), long/2)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package wait
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	tcexec "github.com/testcontainers/testcontainers-go/exec"
@@ -23,6 +26,8 @@ type ExecStrategy struct {
 	ExitCodeMatcher func(exitCode int) bool
 	ResponseMatcher func(body io.Reader) bool
 	PollInterval    time.Duration
+	backoff         Backoff
+	observer        ProbeObserver
 }
 
 // NewExecStrategy constructs an Exec strategy ...
@@ -45,17 +50,22 @@ func (ws *ExecStrategy) WithStartupTimeout(startupTimeout time.Duration) *ExecSt
 	return ws
 }
 
+// WithExitCode can be used to wait for the command to exit with the given exit code, instead of 0.
 func (ws *ExecStrategy) WithExitCode(exitCode int) *ExecStrategy {
 	return ws.WithExitCodeMatcher(func(actualCode int) bool {
 		return actualCode == exitCode
 	})
 }
 
+// WithExitCodeMatcher can be used to wait for the command to exit with a code matching the function,
+// e.g. until a CLI stops returning a "warming up" code. It defaults to matching the exit code 0.
 func (ws *ExecStrategy) WithExitCodeMatcher(exitCodeMatcher func(exitCode int) bool) *ExecStrategy {
 	ws.ExitCodeMatcher = exitCodeMatcher
 	return ws
 }
 
+// WithResponseMatcher can be used to wait for the output of the command to match the function,
+// e.g. until pg_isready prints "accepting connections". The exit code must match too.
 func (ws *ExecStrategy) WithResponseMatcher(matcher func(body io.Reader) bool) *ExecStrategy {
 	ws.ResponseMatcher = matcher
 	return ws
@@ -64,9 +74,28 @@ func (ws *ExecStrategy) WithResponseMatcher(matcher func(body io.Reader) bool) *
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *ExecStrategy) WithPollInterval(pollInterval time.Duration) *ExecStrategy {
 	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
 	return ws
 }
 
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *ExecStrategy) WithBackoff(b Backoff) *ExecStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each execution of the command, e.g. with LogObserver
+func (ws *ExecStrategy) WithObserver(o ProbeObserver) *ExecStrategy {
+	ws.observer = o
+	return ws
+}
+
+// String returns a human-readable description of the wait strategy.
+func (ws *ExecStrategy) String() string {
+	return fmt.Sprintf("command %q", strings.Join(ws.cmd, " "))
+}
+
 // ForExec is a convenience method to assign ExecStrategy
 func ForExec(cmd []string) *ExecStrategy {
 	return NewExecStrategy(cmd)
@@ -77,31 +106,70 @@ func (ws *ExecStrategy) Timeout() *time.Duration {
 }
 
 func (ws *ExecStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	timeout := defaultStartupTimeout()
-	if ws.timeout != nil {
-		timeout = *ws.timeout
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
 	defer cancel()
 
+	// the result of the last execution is attached to the error on timeout.
+	prober := newProber(ctx, ws, ws.observer)
+	poller := newPoller(ws.backoff, ws.PollInterval)
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(ws.PollInterval):
+			return prober.timeoutError(ctx.Err())
+		case <-time.After(poller.next()):
+			prober.begin()
 			exitCode, resp, err := target.Exec(ctx, ws.cmd, tcexec.Multiplexed())
 			if err != nil {
+				if ctx.Err() != nil {
+					return prober.timeoutError(ctx.Err())
+				}
 				return err
 			}
-			if !ws.ExitCodeMatcher(exitCode) {
+
+			var output []byte
+			if resp != nil {
+				if output, err = io.ReadAll(resp); err != nil {
+					return fmt.Errorf("read output: %w", err)
+				}
+			}
+			last := &execResult{exitCode: exitCode, output: output}
+
+			exitCodeMatcher := ws.ExitCodeMatcher
+			if exitCodeMatcher == nil {
+				exitCodeMatcher = defaultExitCodeMatcher
+			}
+			if !exitCodeMatcher(exitCode) {
+				prober.observe(last.err())
 				continue
 			}
-			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp) {
+			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(bytes.NewReader(output)) {
+				prober.observe(last.err())
 				continue
 			}
 
+			prober.observe(nil)
 			return nil
 		}
 	}
 }
+
+// maxExecOutput is the maximum length of the output of the command attached to the errors,
+// keeping its end, which usually holds the reason of the failure.
+const maxExecOutput = 1024
+
+// execResult is the result of an execution of the command of an ExecStrategy.
+type execResult struct {
+	exitCode int
+	output   []byte
+}
+
+// err returns an error with the exit code and the output of the execution, which didn't match,
+// so that the reason why the command didn't match is not lost.
+func (r *execResult) err() error {
+	output := strings.TrimSpace(string(r.output))
+	if len(output) > maxExecOutput {
+		output = "..." + output[len(output)-maxExecOutput:]
+	}
+
+	return fmt.Errorf("command exited with code %d: %q", r.exitCode, output)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strings"
 	"testing"
 	"time"
 
@@ -210,3 +211,108 @@ func TestExecStrategyWaitUntilReady_CustomResponseMatcher(t *testing.T) {
 		}
 	})
 }
+
+func TestExecStrategyWaitUntilReady_ExitCodeAndResponseMatchers(t *testing.T) {
+	target := mockExecTarget{
+		exitCode: 0,
+		response: "/var/run/postgresql:5432 - no response",
+	}
+
+	// both the exit code and the response must match.
+	wg := wait.ForExec([]string{"pg_isready"}).
+		WithStartupTimeout(time.Second).
+		WithResponseMatcher(func(body io.Reader) bool {
+			data, _ := io.ReadAll(body)
+			return bytes.Contains(data, []byte("accepting connections"))
+		})
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+
+	target.response = "/var/run/postgresql:5432 - accepting connections"
+	err = wg.WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the response matches, but not the exit code.
+	target.exitCode = 2
+	err = wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+}
+
+func TestExecStrategyWaitUntilReady_NilMatchers(t *testing.T) {
+	target := mockExecTarget{
+		exitCode: 1,
+	}
+
+	wg := wait.ForExec([]string{"true"}).WithStartupTimeout(time.Second)
+	wg.ExitCodeMatcher = nil
+	wg.ResponseMatcher = nil
+
+	// the exit code defaults to 0.
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+
+	target.exitCode = 0
+	err = wg.WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecStrategyWaitUntilReady_CommandAvailableLater(t *testing.T) {
+	// waitForExecCommandAvailableLater {
+	dockerReq := testcontainers.ContainerRequest{
+		Image: "docker.io/alpine:3.17",
+		// the command is only available after a few seconds.
+		Cmd: []string{"sh", "-c", "sleep 3; printf '#!/bin/sh\\necho ready\\n' > /usr/local/bin/probe; chmod +x /usr/local/bin/probe; sleep 60"},
+		WaitingFor: wait.ForExec([]string{"probe"}).
+			WithStartupTimeout(time.Second * 20).
+			WithPollInterval(500 * time.Millisecond).
+			WithResponseMatcher(func(body io.Reader) bool {
+				data, _ := io.ReadAll(body)
+				return bytes.Equal(data, []byte("ready\n"))
+			}),
+	}
+	// }
+
+	ctx := context.Background()
+	start := time.Now()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: dockerReq, Started: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if elapsed := time.Since(start); elapsed < 3*time.Second {
+		t.Fatalf("expected the strategy to wait for the command, waited %s", elapsed)
+	}
+}
+
+func TestExecStrategyWaitUntilReady_LastOutputOnTimeout(t *testing.T) {
+	target := mockExecTarget{
+		exitCode: 1,
+		response: "/var/run/postgresql:5432 - no response\n",
+	}
+
+	wg := wait.ForExec([]string{"pg_isready"}).WithStartupTimeout(time.Second)
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+
+	expected := `attempts: command exited with code 1: "/var/run/postgresql:5432 - no response"`
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected the error to contain %q, got %v", expected, err)
+	}
+}
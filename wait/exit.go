@@ -2,6 +2,9 @@ package wait
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -19,6 +22,15 @@ type ExitStrategy struct {
 
 	// additional properties
 	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+	exitCode     *int
+}
+
+// exitTarget is implemented by the targets waiting for the exit of the container with the
+// wait endpoint of the daemon, such as testcontainers.DockerContainer, instead of polling its state.
+type exitTarget interface {
+	WaitExit(ctx context.Context) (int, error)
 }
 
 // NewExitStrategy constructs with polling interval of 100 milliseconds without timeout by default
@@ -38,12 +50,43 @@ func (ws *ExitStrategy) WithExitTimeout(exitTimeout time.Duration) *ExitStrategy
 	return ws
 }
 
+// WithExitCode can be used to wait for the container to exit with the given code, e.g. 0 for a
+// run-to-completion container such as a migration. The strategy fails as soon as the container
+// exits with another code. By default, any exit code is accepted.
+func (ws *ExitStrategy) WithExitCode(exitCode int) *ExitStrategy {
+	ws.exitCode = &exitCode
+	return ws
+}
+
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *ExitStrategy) WithPollInterval(pollInterval time.Duration) *ExitStrategy {
 	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
+	return ws
+}
+
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *ExitStrategy) WithBackoff(b Backoff) *ExitStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each check of the state of the container, e.g. with LogObserver
+func (ws *ExitStrategy) WithObserver(o ProbeObserver) *ExitStrategy {
+	ws.observer = o
 	return ws
 }
 
+// String returns a human-readable description of the wait strategy.
+func (ws *ExitStrategy) String() string {
+	if ws.exitCode != nil {
+		return fmt.Sprintf("container exit with code %d", *ws.exitCode)
+	}
+
+	return "container exit"
+}
+
 // ForExit is the default construction for the fluid interface.
 //
 // For Example:
@@ -55,36 +98,115 @@ func ForExit() *ExitStrategy {
 	return NewExitStrategy()
 }
 
+// WaitsForExit reports whether the strategy waits for the container to exit, i.e. it's a ForExit
+// strategy, or a strategy combining one, such as ForAll, so that the container is expected to exit.
+func WaitsForExit(s Strategy) bool {
+	switch s := s.(type) {
+	case *ExitStrategy:
+		return true
+	case *MultiStrategy:
+		return slices.ContainsFunc(s.Strategies, WaitsForExit)
+	case *AnyStrategy:
+		return slices.ContainsFunc(s.Strategies, WaitsForExit)
+	default:
+		return false
+	}
+}
+
 func (ws *ExitStrategy) Timeout() *time.Duration {
 	return ws.timeout
 }
 
+// startupTimeout implements startupTimeoutStrategy, the strategy being limited by the exit timeout only,
+// or by the timeout of the strategy combining it, as it waits for the container to run to completion.
+func (ws *ExitStrategy) startupTimeout(ctx context.Context) (time.Duration, bool) {
+	if ws.timeout != nil {
+		return *ws.timeout, true
+	}
+
+	return contextStartupTimeout(ctx)
+}
+
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *ExitStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	if ws.timeout != nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, *ws.timeout)
-		defer cancel()
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
+	defer cancel()
+
+	prober := newProber(ctx, ws, ws.observer)
+
+	if et, ok := target.(exitTarget); ok {
+		prober.begin()
+		exitCode, err := et.WaitExit(ctx)
+		switch {
+		case ctx.Err() != nil:
+			prober.observe(errStillRunning)
+			return prober.timeoutError(ctx.Err())
+		case isNoSuchContainer(err):
+			return ws.removed(prober)
+		case err != nil:
+			return err
+		}
+
+		return ws.exited(prober, exitCode)
 	}
 
+	poller := newPoller(ws.backoff, ws.PollInterval)
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return prober.timeoutError(ctx.Err())
 		default:
+			prober.begin()
 			state, err := target.State(ctx)
 			if err != nil {
-				if !strings.Contains(err.Error(), "No such container") {
+				if !isNoSuchContainer(err) {
 					return err
-				} else {
-					return nil
 				}
+				return ws.removed(prober)
 			}
 			if state.Running {
-				time.Sleep(ws.PollInterval)
+				prober.observe(errStillRunning)
+				time.Sleep(poller.next())
 				continue
 			}
-			return nil
+			return ws.exited(prober, state.ExitCode)
 		}
 	}
 }
+
+// exited checks the exit code of the container, if an exit code is expected.
+func (ws *ExitStrategy) exited(prober *prober, exitCode int) error {
+	if ws.exitCode != nil && exitCode != *ws.exitCode {
+		err := fmt.Errorf("container exited with code %d, expected %d", exitCode, *ws.exitCode)
+		prober.observe(err)
+		return err
+	}
+
+	prober.observe(nil)
+	return nil
+}
+
+// removed handles a container removed before its exit is observed, e.g. with AutoRemove,
+// which can only pass if no exit code is expected, as its exit code is lost.
+func (ws *ExitStrategy) removed(prober *prober) error {
+	if ws.exitCode != nil {
+		prober.observe(errRemovedBeforeExit)
+		return errRemovedBeforeExit
+	}
+
+	prober.observe(nil)
+	return nil
+}
+
+// isNoSuchContainer returns true if the error reports that the container does not exist anymore.
+func isNoSuchContainer(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "No such container")
+}
+
+var (
+	// errStillRunning is the reason why an ExitStrategy is not ready.
+	errStillRunning = errors.New("container is still running")
+
+	// errRemovedBeforeExit is returned when the exit code of a removed container can't be checked.
+	errRemovedBeforeExit = errors.New("container removed before its exit code could be read")
+)
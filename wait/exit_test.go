@@ -2,7 +2,9 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 
 type exitStrategyTarget struct {
 	isRunning bool
+	exitCode  int
 }
 
 func (st exitStrategyTarget) Host(ctx context.Context) (string, error) {
@@ -42,7 +45,7 @@ func (st exitStrategyTarget) Exec(ctx context.Context, cmd []string, options ...
 }
 
 func (st exitStrategyTarget) State(ctx context.Context) (*types.ContainerState, error) {
-	return &types.ContainerState{Running: st.isRunning}, nil
+	return &types.ContainerState{Running: st.isRunning, ExitCode: st.exitCode}, nil
 }
 
 func TestWaitForExit(t *testing.T) {
@@ -55,3 +58,83 @@ func TestWaitForExit(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// waitExitTarget waits for the exit of the container with the wait endpoint.
+type waitExitTarget struct {
+	exitStrategyTarget
+	exitCode int
+	err      error
+	delay    time.Duration
+}
+
+func (st waitExitTarget) WaitExit(ctx context.Context) (int, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(st.delay):
+		return st.exitCode, st.err
+	}
+}
+
+func (st waitExitTarget) State(ctx context.Context) (*types.ContainerState, error) {
+	return nil, errors.New("the state must not be polled")
+}
+
+func TestWaitForExitWithExitCode(t *testing.T) {
+	t.Run("polling", func(t *testing.T) {
+		err := ForExit().WithExitCode(0).WaitUntilReady(context.Background(), exitStrategyTarget{exitCode: 3})
+		if err == nil || err.Error() != "container exited with code 3, expected 0" {
+			t.Fatalf("expected the exit code to be reported, got %v", err)
+		}
+
+		if err := ForExit().WithExitCode(3).WaitUntilReady(context.Background(), exitStrategyTarget{exitCode: 3}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("wait-endpoint", func(t *testing.T) {
+		target := waitExitTarget{exitCode: 0, delay: 100 * time.Millisecond}
+		if err := ForExit().WithExitCode(0).WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatal(err)
+		}
+
+		target.exitCode = 1
+		err := ForExit().WithExitCode(0).WaitUntilReady(context.Background(), target)
+		if err == nil || err.Error() != "container exited with code 1, expected 0" {
+			t.Fatalf("expected the exit code to be reported, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		target := waitExitTarget{delay: time.Minute}
+		err := ForExit().WithExitTimeout(100*time.Millisecond).WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "container is still running") {
+			t.Fatalf("expected a deadline exceeded error, got %v", err)
+		}
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		target := waitExitTarget{err: errors.New("Error response from daemon: No such container: 1234")}
+		if err := ForExit().WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatalf("expected a removed container to be ready without an exit code, got %v", err)
+		}
+
+		if err := ForExit().WithExitCode(0).WaitUntilReady(context.Background(), target); !errors.Is(err, errRemovedBeforeExit) {
+			t.Fatalf("expected the exit code of a removed container to be unknown, got %v", err)
+		}
+	})
+}
+
+func TestWaitsForExit(t *testing.T) {
+	if !WaitsForExit(ForExit()) {
+		t.Fatal("expected ForExit to wait for the exit")
+	}
+
+	if !WaitsForExit(ForAll(ForLog("ready"), ForAny(ForListeningPort("80/tcp"), ForExit()))) {
+		t.Fatal("expected a strategy combining ForExit to wait for the exit")
+	}
+
+	if WaitsForExit(ForAll(ForLog("ready"), ForListeningPort("80/tcp"))) {
+		t.Fatal("expected a strategy without ForExit not to wait for the exit")
+	}
+}
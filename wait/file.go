@@ -0,0 +1,230 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*FileStrategy)(nil)
+	_ StrategyTimeout = (*FileStrategy)(nil)
+)
+
+// FileStrategy waits for a file to exist in the container, e.g. a pid file, a socket
+// or a "ready" sentinel written by the process once it's ready.
+// The file is read through the archive endpoints of the Docker API if the target supports
+// them, so that no command is needed in the image, e.g. for distroless images. Otherwise,
+// it's checked with the test, stat and cat commands, executed in the container.
+type FileStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+	path    string
+
+	// additional properties
+	MinSize      int64
+	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+	Matcher      func(io.Reader) bool // matcher of the content of the file, see WithMatcher
+}
+
+// fileTarget is implemented by the targets reading the files of the container through
+// the archive endpoints of the Docker API, such as testcontainers.DockerContainer.
+type fileTarget interface {
+	StatPath(ctx context.Context, path string) (container.PathStat, error)
+	CopyFileFromContainer(ctx context.Context, path string) (io.ReadCloser, error)
+}
+
+// NewFileStrategy constructs a File strategy waiting for the file at the given path to exist
+func NewFileStrategy(path string) *FileStrategy {
+	return &FileStrategy{
+		path:         path,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// ForFile is a convenience method to assign FileStrategy
+func ForFile(path string) *FileStrategy {
+	return NewFileStrategy(path)
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *FileStrategy) WithStartupTimeout(startupTimeout time.Duration) *FileStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithMinSize can be used to wait for the file to be at least the given size in bytes,
+// e.g. 1 for a pid file to be non-empty. The file can be of any type if not set.
+func (ws *FileStrategy) WithMinSize(minSize int64) *FileStrategy {
+	ws.MinSize = minSize
+	return ws
+}
+
+// WithMatcher can be used to wait for the content of the file to match, e.g. for a status file to
+// read "ready", instead of only existing. The file is read again at each poll until it matches.
+func (ws *FileStrategy) WithMatcher(matcher func(io.Reader) bool) *FileStrategy {
+	ws.Matcher = matcher
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *FileStrategy) WithPollInterval(pollInterval time.Duration) *FileStrategy {
+	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
+	return ws
+}
+
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *FileStrategy) WithBackoff(b Backoff) *FileStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each check of the file, e.g. with LogObserver
+func (ws *FileStrategy) WithObserver(o ProbeObserver) *FileStrategy {
+	ws.observer = o
+	return ws
+}
+
+// String returns a human-readable description of the wait strategy.
+func (ws *FileStrategy) String() string {
+	return fmt.Sprintf("file %q", ws.path)
+}
+
+func (ws *FileStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *FileStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
+	defer cancel()
+
+	poller := newPoller(ws.backoff, ws.PollInterval)
+	prober := newProber(ctx, ws, ws.observer)
+	for {
+		select {
+		case <-ctx.Done():
+			return prober.timeoutError(ctx.Err())
+		case <-time.After(poller.next()):
+			prober.begin()
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+
+			ready, err := ws.fileReady(ctx, target)
+			if err != nil {
+				return err
+			}
+
+			if ready {
+				prober.observe(nil)
+				return nil
+			}
+			prober.observe(errFileNotReady)
+		}
+	}
+}
+
+// errFileNotReady is the reason why a FileStrategy is not ready: the file doesn't exist,
+// or doesn't have the expected size or content yet.
+var errFileNotReady = errors.New("file not found or not ready")
+
+// fileReady reports whether the file exists in the container and, if a minimum size is set,
+// whether it's at least that size, and if a matcher is set, whether its content matches.
+func (ws *FileStrategy) fileReady(ctx context.Context, target StrategyTarget) (bool, error) {
+	if ft, ok := target.(fileTarget); ok {
+		return ws.archiveFileReady(ctx, ft)
+	}
+
+	ready, err := ws.execFileReady(ctx, target)
+	if !ready || err != nil || ws.Matcher == nil {
+		return ready, err
+	}
+
+	exitCode, reader, err := target.Exec(ctx, []string{"cat", ws.path}, tcexec.Multiplexed())
+	if err != nil {
+		return false, err
+	}
+
+	return exitCode == 0 && reader != nil && ws.Matcher(reader), nil
+}
+
+// archiveFileReady checks the file with the archive endpoints of the Docker API.
+func (ws *FileStrategy) archiveFileReady(ctx context.Context, target fileTarget) (bool, error) {
+	stat, err := target.StatPath(ctx, ws.path)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if stat.Size < ws.MinSize {
+		return false, nil
+	}
+
+	if ws.Matcher == nil {
+		return true, nil
+	}
+
+	if stat.Mode.IsDir() {
+		return false, nil
+	}
+
+	r, err := target.CopyFileFromContainer(ctx, ws.path)
+	if err != nil {
+		// the file is being replaced.
+		return false, nil
+	}
+	defer r.Close()
+
+	return ws.Matcher(r), nil
+}
+
+// execFileReady checks the file with the test and stat commands.
+func (ws *FileStrategy) execFileReady(ctx context.Context, target StrategyTarget) (bool, error) {
+	if ws.MinSize <= 0 {
+		exitCode, _, err := target.Exec(ctx, []string{"test", "-e", ws.path}, tcexec.Multiplexed())
+		if err != nil {
+			return false, err
+		}
+
+		return exitCode == 0, nil
+	}
+
+	exitCode, reader, err := target.Exec(ctx, []string{"stat", "-c", "%s", ws.path}, tcexec.Multiplexed())
+	if err != nil {
+		return false, err
+	}
+
+	if exitCode != 0 || reader == nil {
+		return false, nil
+	}
+
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return false, err
+	}
+
+	size, err := strconv.ParseInt(strings.TrimSpace(string(bs)), 10, 64)
+	if err != nil {
+		// the file is being created.
+		return false, nil
+	}
+
+	return size >= ws.MinSize, nil
+}
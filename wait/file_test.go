@@ -0,0 +1,246 @@
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type mockFileTarget struct {
+	// size is the size of the file, which doesn't exist if negative.
+	size    int64
+	content string
+	cmds    [][]string
+}
+
+func (st *mockFileTarget) Host(_ context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (st *mockFileTarget) Inspect(_ context.Context) (*types.ContainerJSON, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Deprecated: use Inspect instead
+func (st *mockFileTarget) Ports(_ context.Context) (nat.PortMap, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (st *mockFileTarget) MappedPort(_ context.Context, n nat.Port) (nat.Port, error) {
+	return n, errors.New("not implemented")
+}
+
+func (st *mockFileTarget) Logs(_ context.Context) (io.ReadCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (st *mockFileTarget) Exec(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	st.cmds = append(st.cmds, cmd)
+
+	if st.size < 0 {
+		return 1, strings.NewReader(""), nil
+	}
+
+	if cmd[0] == "stat" {
+		return 0, strings.NewReader(strconv.FormatInt(st.size, 10) + "\n"), nil
+	}
+
+	if cmd[0] == "cat" {
+		return 0, strings.NewReader(st.content), nil
+	}
+
+	return 0, strings.NewReader(""), nil
+}
+
+func (st *mockFileTarget) State(_ context.Context) (*types.ContainerState, error) {
+	return &types.ContainerState{Running: true}, nil
+}
+
+// mockArchiveFileTarget reads the file through the archive endpoints, without executing any command.
+type mockArchiveFileTarget struct {
+	mockFileTarget
+	copies int
+}
+
+func (st *mockArchiveFileTarget) Exec(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+	st.cmds = append(st.cmds, cmd)
+	return 0, nil, errors.New("no shell in the image")
+}
+
+func (st *mockArchiveFileTarget) StatPath(_ context.Context, path string) (container.PathStat, error) {
+	if st.size < 0 {
+		return container.PathStat{}, errdefs.NotFound(errors.New("no such file: " + path))
+	}
+
+	return container.PathStat{Name: path, Size: st.size}, nil
+}
+
+func (st *mockArchiveFileTarget) CopyFileFromContainer(_ context.Context, _ string) (io.ReadCloser, error) {
+	st.copies++
+	return io.NopCloser(strings.NewReader(st.content)), nil
+}
+
+func TestFileStrategyWaitUntilReady(t *testing.T) {
+	// waitForFile {
+	dockerReq := testcontainers.ContainerRequest{
+		Image: "docker.io/alpine:3.17",
+		// the sentinel file is only written after a few seconds.
+		Cmd: []string{"sh", "-c", "sleep 3; echo ready > /tmp/ready; sleep 60"},
+		WaitingFor: wait.ForFile("/tmp/ready").
+			WithStartupTimeout(time.Second * 20).
+			WithPollInterval(500 * time.Millisecond),
+	}
+	// }
+
+	ctx := context.Background()
+	start := time.Now()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: dockerReq, Started: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if elapsed := time.Since(start); elapsed < 3*time.Second {
+		t.Fatalf("expected the strategy to wait for the file, waited %s", elapsed)
+	}
+}
+
+func TestFileStrategyWaitUntilReady_Missing(t *testing.T) {
+	target := &mockFileTarget{size: -1}
+
+	err := wait.ForFile("/tmp/ready").WithStartupTimeout(time.Second).WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+
+	if len(target.cmds) == 0 || strings.Join(target.cmds[0], " ") != "test -e /tmp/ready" {
+		t.Fatalf("unexpected commands: %v", target.cmds)
+	}
+}
+
+func TestFileStrategyWaitUntilReady_MinSize(t *testing.T) {
+	target := &mockFileTarget{size: 0}
+
+	wg := wait.ForFile("/var/run/app.pid").WithMinSize(1).WithStartupTimeout(time.Second)
+
+	// the file exists, but it's empty.
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+
+	if strings.Join(target.cmds[0], " ") != "stat -c %s /var/run/app.pid" {
+		t.Fatalf("unexpected commands: %v", target.cmds)
+	}
+
+	target.size = 4
+	err = wg.WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileStrategyWaitUntilReady_Matcher(t *testing.T) {
+	target := &mockFileTarget{size: 8, content: "starting"}
+
+	wg := wait.ForFile("/tmp/status").
+		WithMatcher(func(r io.Reader) bool {
+			b, err := io.ReadAll(r)
+			return err == nil && strings.TrimSpace(string(b)) == "ready"
+		}).
+		WithStartupTimeout(time.Second)
+
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+
+	target.content = "ready\n"
+	err = wg.WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileStrategyWaitUntilReady_Archive(t *testing.T) {
+	target := &mockArchiveFileTarget{mockFileTarget: mockFileTarget{size: -1}}
+
+	wg := wait.ForFile("/tmp/status").
+		WithMinSize(1).
+		WithMatcher(func(r io.Reader) bool {
+			b, err := io.ReadAll(r)
+			return err == nil && string(b) == "ready"
+		}).
+		WithStartupTimeout(time.Second)
+
+	// the file doesn't exist yet.
+	err := wg.WaitUntilReady(context.Background(), target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the strategy to time out, got %v", err)
+	}
+
+	if target.copies != 0 {
+		t.Fatalf("expected the file not to be read, read %d times", target.copies)
+	}
+
+	target.size = 5
+	target.content = "ready"
+	err = wg.WaitUntilReady(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(target.cmds) != 0 {
+		t.Fatalf("expected no command to be executed, got %v", target.cmds)
+	}
+}
+
+func TestFileStrategyWaitUntilReady_WithMatcher(t *testing.T) {
+	// waitForFileContent {
+	dockerReq := testcontainers.ContainerRequest{
+		Image: "docker.io/alpine:3.17",
+		// the status file is created first, and only reports ready after a few seconds.
+		Cmd: []string{"sh", "-c", "echo starting > /tmp/status; sleep 3; echo ready > /tmp/status; sleep 60"},
+		WaitingFor: wait.ForFile("/tmp/status").
+			WithMatcher(func(r io.Reader) bool {
+				b, err := io.ReadAll(r)
+				return err == nil && strings.TrimSpace(string(b)) == "ready"
+			}).
+			WithStartupTimeout(time.Second * 20).
+			WithPollInterval(500 * time.Millisecond),
+	}
+	// }
+
+	ctx := context.Background()
+	start := time.Now()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: dockerReq, Started: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if elapsed := time.Since(start); elapsed < 3*time.Second {
+		t.Fatalf("expected the strategy to wait for the content of the file, waited %s", elapsed)
+	}
+}
@@ -0,0 +1,202 @@
+package wait
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*GRPCStrategy)(nil)
+	_ StrategyTimeout = (*GRPCStrategy)(nil)
+	_ StrategyPorts   = (*GRPCStrategy)(nil)
+)
+
+// GRPCStrategy will wait until the standard gRPC health service of the container,
+// grpc.health.v1.Health, reports the service as SERVING.
+type GRPCStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	Port         nat.Port
+	Service      string // name of the checked service, empty for the overall health of the server
+	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+
+	// TransportCredentials secure the connection to the server, plaintext if nil.
+	TransportCredentials credentials.TransportCredentials
+}
+
+// NewGRPCStrategy constructs a gRPC health strategy for the given port, checking the overall health
+// of the server over plaintext, with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
+func NewGRPCStrategy(port nat.Port) *GRPCStrategy {
+	return &GRPCStrategy{
+		Port:         port,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// ForGRPC is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.
+//		ForGRPC("50051/tcp").
+//		WithService("orders.v1.OrderService")
+func ForGRPC(port nat.Port) *GRPCStrategy {
+	return NewGRPCStrategy(port)
+}
+
+// WithService can be used to check the health of the given service, instead of the overall health of the server.
+func (ws *GRPCStrategy) WithService(name string) *GRPCStrategy {
+	ws.Service = name
+	return ws
+}
+
+// WithTLS can be used to connect to the server over TLS with the given config, e.g. with the root CAs verifying
+// the certificate of the server.
+func (ws *GRPCStrategy) WithTLS(config *tls.Config) *GRPCStrategy {
+	ws.TransportCredentials = credentials.NewTLS(config)
+	return ws
+}
+
+// WithTransportCredentials can be used to secure the connection to the server with the given credentials,
+// e.g. loaded with credentials.NewClientTLSFromFile.
+func (ws *GRPCStrategy) WithTransportCredentials(creds credentials.TransportCredentials) *GRPCStrategy {
+	ws.TransportCredentials = creds
+	return ws
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *GRPCStrategy) WithStartupTimeout(timeout time.Duration) *GRPCStrategy {
+	ws.timeout = &timeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *GRPCStrategy) WithPollInterval(pollInterval time.Duration) *GRPCStrategy {
+	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
+	return ws
+}
+
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *GRPCStrategy) WithBackoff(b Backoff) *GRPCStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each health check, e.g. with LogObserver
+func (ws *GRPCStrategy) WithObserver(o ProbeObserver) *GRPCStrategy {
+	ws.observer = o
+	return ws
+}
+
+func (ws *GRPCStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// Ports implements StrategyPorts, returning the port of the strategy, unless it targets the lowest exposed port.
+func (ws *GRPCStrategy) Ports() []nat.Port {
+	return singlePort(ws.Port)
+}
+
+// String returns a human-readable description of the wait strategy.
+func (ws *GRPCStrategy) String() string {
+	service := "the server"
+	if ws.Service != "" {
+		service = fmt.Sprintf("service %q", ws.Service)
+	}
+
+	return fmt.Sprintf("gRPC health of %s on port %s", service, ws.Port)
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *GRPCStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	if ws.Port == "" {
+		return errors.New("no port to check the gRPC health on")
+	}
+
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
+	defer cancel()
+
+	host, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	poller := newPoller(ws.backoff, ws.PollInterval)
+
+	var port nat.Port
+	port, err = target.MappedPort(ctx, ws.Port)
+
+	for port == "" {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-time.After(poller.next()):
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+			port, err = target.MappedPort(ctx, ws.Port)
+		}
+	}
+
+	if port.Proto() != "tcp" {
+		return errors.New("cannot use gRPC client on non-TCP ports")
+	}
+
+	creds := ws.TransportCredentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	// the connection is established lazily, and re-established by the client until the server is up.
+	conn, err := grpc.NewClient(net.JoinHostPort(host, port.Port()), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("grpc client: %w", err)
+	}
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+
+	prober := newProber(ctx, ws, ws.observer)
+	for {
+		select {
+		case <-ctx.Done():
+			return prober.timeoutError(ctx.Err())
+		case <-time.After(poller.next()):
+			prober.begin()
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+
+			resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: ws.Service})
+			if err != nil {
+				prober.observe(fmt.Errorf("health check: %w", err))
+				continue
+			}
+
+			if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+				prober.observe(fmt.Errorf("health status: %s", resp.GetStatus()))
+				continue
+			}
+
+			prober.observe(nil)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package wait_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// startHealthServer starts a gRPC server with the health service, returning it and a target
+// mapping any port to the port of the server.
+func startHealthServer(t *testing.T, opts ...grpc.ServerOption) (*health.Server, wait.StrategyTarget) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(opts...)
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	go srv.Serve(listener) //nolint:errcheck // the error is returned once the server is stopped
+	t.Cleanup(srv.Stop)
+
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	target := &wait.MockStrategyTarget{
+		HostImpl: func(_ context.Context) (string, error) {
+			return "localhost", nil
+		},
+		MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+			return nat.NewPort("tcp", port)
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+
+	return healthSrv, target
+}
+
+func TestGRPCStrategy(t *testing.T) {
+	t.Run("serving", func(t *testing.T) {
+		healthSrv, target := startHealthServer(t)
+		healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+		time.AfterFunc(300*time.Millisecond, func() {
+			healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		})
+
+		err := wait.ForGRPC("50051/tcp").
+			WithStartupTimeout(5*time.Second).
+			WaitUntilReady(context.Background(), target)
+		require.NoError(t, err)
+	})
+
+	t.Run("not-serving", func(t *testing.T) {
+		healthSrv, target := startHealthServer(t)
+		healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+		err := wait.ForGRPC("50051/tcp").
+			WithStartupTimeout(500*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "NOT_SERVING")
+	})
+
+	t.Run("service", func(t *testing.T) {
+		healthSrv, target := startHealthServer(t)
+		healthSrv.SetServingStatus("orders.v1.OrderService", healthpb.HealthCheckResponse_SERVING)
+		healthSrv.SetServingStatus("payments.v1.PaymentService", healthpb.HealthCheckResponse_NOT_SERVING)
+
+		// waitForGRPCService {
+		wg := wait.ForGRPC("50051/tcp").
+			WithService("orders.v1.OrderService")
+		// }
+		wg.WithStartupTimeout(5 * time.Second)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+
+		wg = wait.ForGRPC("50051/tcp").
+			WithService("payments.v1.PaymentService").
+			WithStartupTimeout(500 * time.Millisecond)
+		require.Error(t, wg.WaitUntilReady(context.Background(), target))
+	})
+
+	t.Run("unknown-service", func(t *testing.T) {
+		_, target := startHealthServer(t)
+
+		err := wait.ForGRPC("50051/tcp").
+			WithService("unknown.v1.Service").
+			WithStartupTimeout(500*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.ErrorContains(t, err, "health check")
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		cert, err := tls.LoadX509KeyPair(filepath.Join("testdata", "tls.pem"), filepath.Join("testdata", "tls-key.pem"))
+		require.NoError(t, err)
+
+		_, target := startHealthServer(t, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+
+		caCert, err := os.ReadFile(filepath.Join("testdata", "root.pem"))
+		require.NoError(t, err)
+		certPool := x509.NewCertPool()
+		require.True(t, certPool.AppendCertsFromPEM(caCert))
+
+		// waitForGRPCTLS {
+		wg := wait.ForGRPC("50051/tcp").
+			WithTLS(&tls.Config{RootCAs: certPool})
+		// }
+		wg.WithStartupTimeout(5 * time.Second)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+
+		// the server doesn't accept plaintext connections.
+		err = wait.ForGRPC("50051/tcp").
+			WithStartupTimeout(500*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.Error(t, err)
+	})
+
+	t.Run("no-port", func(t *testing.T) {
+		_, target := startHealthServer(t)
+		require.Error(t, wait.ForGRPC("").WaitUntilReady(context.Background(), target))
+	})
+}
@@ -2,9 +2,13 @@ package wait
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 )
 
 // Implement interface
@@ -13,6 +17,15 @@ var (
 	_ StrategyTimeout = (*HealthStrategy)(nil)
 )
 
+var (
+	// ErrNoHealthCheck is returned by the HealthStrategy when neither the image nor the container
+	// request defines a healthcheck, as the container would never become healthy.
+	ErrNoHealthCheck = errors.New("no healthcheck defined for the container")
+
+	// ErrUnhealthy is returned by the HealthStrategy when the container becomes unhealthy.
+	ErrUnhealthy = errors.New("container unhealthy")
+)
+
 // HealthStrategy will wait until the container becomes healthy
 type HealthStrategy struct {
 	// all Strategies should have a startupTimeout to avoid waiting infinitely
@@ -20,6 +33,12 @@ type HealthStrategy struct {
 
 	// additional properties
 	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+
+	// ConsecutiveSuccesses is the number of consecutive successful healthchecks required
+	// once the container is healthy, see WithConsecutiveSuccesses.
+	ConsecutiveSuccesses int
 }
 
 // NewHealthStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
@@ -42,10 +61,41 @@ func (ws *HealthStrategy) WithStartupTimeout(startupTimeout time.Duration) *Heal
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *HealthStrategy) WithPollInterval(pollInterval time.Duration) *HealthStrategy {
 	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
 	return ws
 }
 
-// ForHealthCheck is the default construction for the fluid interface.
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *HealthStrategy) WithBackoff(b Backoff) *HealthStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithConsecutiveSuccesses can be used to require the given number of consecutive successful
+// healthchecks once the container is healthy, for services flapping while they start, instead of
+// returning as soon as the container is reported as healthy. The healthchecks are observed while
+// polling, so the poll interval must be shorter than the interval of the healthcheck.
+func (ws *HealthStrategy) WithConsecutiveSuccesses(n int) *HealthStrategy {
+	ws.ConsecutiveSuccesses = n
+	return ws
+}
+
+// WithObserver can be used to be notified of each check of the health of the container, e.g. with LogObserver
+func (ws *HealthStrategy) WithObserver(o ProbeObserver) *HealthStrategy {
+	ws.observer = o
+	return ws
+}
+
+// String returns a human-readable description of the wait strategy.
+func (ws *HealthStrategy) String() string {
+	return "healthy container"
+}
+
+// ForHealthCheck is the default construction for the fluid interface. It waits until the
+// healthcheck defined by the image, with the HEALTHCHECK instruction, or by the container
+// request reports the container as healthy. It fails with ErrUnhealthy if the container
+// becomes unhealthy, and with ErrNoHealthCheck if no healthcheck is defined.
 //
 // For Example:
 //
@@ -62,31 +112,102 @@ func (ws *HealthStrategy) Timeout() *time.Duration {
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *HealthStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	timeout := defaultStartupTimeout()
-	if ws.timeout != nil {
-		timeout = *ws.timeout
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
 	defer cancel()
 
+	inspect, err := target.Inspect(ctx)
+	if err != nil {
+		return err
+	}
+	if inspect != nil && inspect.Config != nil && !hasHealthCheck(inspect.Config.Healthcheck) {
+		return ErrNoHealthCheck
+	}
+
+	var (
+		lastProbe time.Time // the start of the last healthcheck counted
+		successes int
+	)
+	// the output of the last healthcheck is attached to the error on timeout.
+	prober := newProber(ctx, ws, ws.observer)
+	poller := newPoller(ws.backoff, ws.PollInterval)
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return prober.timeoutError(ctx.Err())
 		default:
+			prober.begin()
 			state, err := target.State(ctx)
 			if err != nil {
+				if ctx.Err() != nil {
+					return prober.timeoutError(ctx.Err())
+				}
 				return err
 			}
 			if err := checkState(state); err != nil {
 				return err
 			}
-			if state.Health == nil || state.Health.Status != types.Healthy {
-				time.Sleep(ws.PollInterval)
+			if state.Health != nil && state.Health.Status == types.Unhealthy {
+				return unhealthyError(state.Health)
+			}
+			if state.Health == nil {
+				prober.observe(errNoHealthStatus)
+				time.Sleep(poller.next())
+				continue
+			}
+			if state.Health.Status != types.Healthy {
+				prober.observe(healthcheckError(fmt.Errorf("health status %q", state.Health.Status), state.Health))
+				time.Sleep(poller.next())
 				continue
 			}
-			return nil
+			if ws.ConsecutiveSuccesses <= 1 {
+				prober.observe(nil)
+				return nil
+			}
+
+			// count the healthchecks run since the last poll, in order, resetting on a failure.
+			for _, result := range state.Health.Log {
+				if result == nil || !result.Start.After(lastProbe) {
+					continue
+				}
+				lastProbe = result.Start
+				if result.ExitCode == 0 {
+					successes++
+				} else {
+					successes = 0
+				}
+			}
+			if successes >= ws.ConsecutiveSuccesses {
+				prober.observe(nil)
+				return nil
+			}
+			prober.observe(fmt.Errorf("%d of %d consecutive successful healthchecks", successes, ws.ConsecutiveSuccesses))
+			time.Sleep(poller.next())
 		}
 	}
 }
+
+// errNoHealthStatus is the reason why a HealthStrategy is not ready when the state of the container
+// doesn't report its health yet.
+var errNoHealthStatus = errors.New("no health status reported")
+
+// hasHealthCheck returns true if the healthcheck config, merged from the image and the
+// container request, defines a test to run, and it's not disabled with NONE.
+func hasHealthCheck(hc *container.HealthConfig) bool {
+	return hc != nil && len(hc.Test) > 0 && hc.Test[0] != "NONE"
+}
+
+// unhealthyError returns ErrUnhealthy along with the output of the last healthcheck, if any.
+func unhealthyError(health *types.Health) error {
+	return healthcheckError(ErrUnhealthy, health)
+}
+
+// healthcheckError wraps the error with the output of the last healthcheck, if any, e.g.
+// for an attempt timing out, so that the reason of the failure of the probe is reported.
+func healthcheckError(err error, health *types.Health) error {
+	if health == nil || len(health.Log) == 0 || health.Log[len(health.Log)-1] == nil {
+		return err
+	}
+
+	last := health.Log[len(health.Log)-1]
+	return fmt.Errorf("%w: last healthcheck exited with code %d: %s", err, last.ExitCode, strings.TrimSpace(last.Output))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/require"
 
@@ -15,8 +16,9 @@ import (
 )
 
 type healthStrategyTarget struct {
-	state *types.ContainerState
-	mtx   sync.Mutex
+	state   *types.ContainerState
+	inspect *types.ContainerJSON
+	mtx     sync.Mutex
 }
 
 func (st *healthStrategyTarget) Host(ctx context.Context) (string, error) {
@@ -24,7 +26,7 @@ func (st *healthStrategyTarget) Host(ctx context.Context) (string, error) {
 }
 
 func (st *healthStrategyTarget) Inspect(ctx context.Context) (*types.ContainerJSON, error) {
-	return nil, nil
+	return st.inspect, nil
 }
 
 // Deprecated: use Inspect instead
@@ -60,20 +62,155 @@ func (st *healthStrategyTarget) setState(health *types.Health) {
 	st.state.Health = health
 }
 
-// TestWaitForHealthTimesOutForUnhealthy confirms that an unhealthy container will eventually
-// time out.
-func TestWaitForHealthTimesOutForUnhealthy(t *testing.T) {
+// TestWaitForHealthFailsForUnhealthy confirms that an unhealthy container fails
+// without waiting for the timeout, reporting the output of the last healthcheck.
+func TestWaitForHealthFailsForUnhealthy(t *testing.T) {
+	target := &healthStrategyTarget{
+		state: &types.ContainerState{
+			Running: true,
+			Health: &types.Health{
+				Status: types.Unhealthy,
+				Log: []*types.HealthcheckResult{
+					{ExitCode: 1, Output: "connection refused\n"},
+				},
+			},
+		},
+	}
+	wg := NewHealthStrategy().WithStartupTimeout(time.Minute)
+	err := wg.WaitUntilReady(context.Background(), target)
+
+	require.ErrorIs(t, err, ErrUnhealthy)
+	require.EqualError(t, err, "container unhealthy: last healthcheck exited with code 1: connection refused")
+}
+
+// TestWaitForHealthTimesOutForStarting confirms that a container whose health is
+// still starting will eventually time out.
+func TestWaitForHealthTimesOutForStarting(t *testing.T) {
 	target := &healthStrategyTarget{
 		state: &types.ContainerState{
 			Running: true,
-			Health:  &types.Health{Status: types.Unhealthy},
+			Health:  &types.Health{Status: types.Starting},
+		},
+	}
+	wg := NewHealthStrategy().WithStartupTimeout(100 * time.Millisecond)
+	err := wg.WaitUntilReady(context.Background(), target)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestWaitForHealthTimesOutWithLastHealthcheck confirms that the output of the last
+// healthcheck is reported when timing out.
+func TestWaitForHealthTimesOutWithLastHealthcheck(t *testing.T) {
+	target := &healthStrategyTarget{
+		state: &types.ContainerState{
+			Running: true,
+			Health: &types.Health{
+				Status:        types.Starting,
+				FailingStreak: 1,
+				Log: []*types.HealthcheckResult{
+					{ExitCode: 7, Output: "curl: (7) Failed to connect to localhost port 8080\n"},
+				},
+			},
 		},
 	}
 	wg := NewHealthStrategy().WithStartupTimeout(100 * time.Millisecond)
 	err := wg.WaitUntilReady(context.Background(), target)
 
-	require.Error(t, err)
 	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Regexp(t, `^context deadline exceeded after \d+ attempts: health status "starting": last healthcheck exited with code 7: curl: \(7\) Failed to connect to localhost port 8080$`, err.Error())
+}
+
+// TestWaitForHealthConsecutiveSuccesses confirms that a flapping container is only
+// ready after the required number of consecutive successful healthchecks.
+func TestWaitForHealthConsecutiveSuccesses(t *testing.T) {
+	start := time.Now()
+	probe := func(i int, exitCode int) *types.HealthcheckResult {
+		return &types.HealthcheckResult{Start: start.Add(time.Duration(i) * time.Second), ExitCode: exitCode}
+	}
+
+	t.Run("flapping", func(t *testing.T) {
+		target := &healthStrategyTarget{
+			state: &types.ContainerState{
+				Running: true,
+				Health: &types.Health{
+					Status: types.Healthy,
+					Log:    []*types.HealthcheckResult{probe(1, 0), probe(2, 1), probe(3, 0)},
+				},
+			},
+		}
+		wg := NewHealthStrategy().
+			WithConsecutiveSuccesses(3).
+			WithStartupTimeout(200 * time.Millisecond).
+			WithPollInterval(10 * time.Millisecond)
+		err := wg.WaitUntilReady(context.Background(), target)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		target := &healthStrategyTarget{
+			state: &types.ContainerState{
+				Running: true,
+				Health: &types.Health{
+					Status: types.Healthy,
+					Log:    []*types.HealthcheckResult{probe(1, 0), probe(2, 1), probe(3, 0)},
+				},
+			},
+		}
+		// waitForConsecutiveHealthchecks {
+		wg := ForHealthCheck().
+			WithConsecutiveSuccesses(3).
+			WithPollInterval(10 * time.Millisecond)
+		// }
+		wg.WithStartupTimeout(time.Second)
+
+		go func() {
+			// the following healthchecks succeed, the log keeping the last ones.
+			time.Sleep(50 * time.Millisecond)
+			target.setState(&types.Health{
+				Status: types.Healthy,
+				Log:    []*types.HealthcheckResult{probe(2, 1), probe(3, 0), probe(4, 0)},
+			})
+			time.Sleep(50 * time.Millisecond)
+			target.setState(&types.Health{
+				Status: types.Healthy,
+				Log:    []*types.HealthcheckResult{probe(3, 0), probe(4, 0), probe(5, 0)},
+			})
+		}()
+
+		begin := time.Now()
+		err := wg.WaitUntilReady(context.Background(), target)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(begin), 100*time.Millisecond)
+	})
+}
+
+// TestWaitForHealthFailsWithoutHealthCheck confirms that a container without
+// a healthcheck fails without waiting for the timeout.
+func TestWaitForHealthFailsWithoutHealthCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthcheck *container.HealthConfig
+	}{
+		{name: "nil", healthcheck: nil},
+		{name: "empty", healthcheck: &container.HealthConfig{}},
+		{name: "disabled", healthcheck: &container.HealthConfig{Test: []string{"NONE"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &healthStrategyTarget{
+				state: &types.ContainerState{Running: true},
+				inspect: &types.ContainerJSON{
+					Config: &container.Config{Healthcheck: tt.healthcheck},
+				},
+			}
+			wg := NewHealthStrategy().WithStartupTimeout(time.Minute)
+			err := wg.WaitUntilReady(context.Background(), target)
+
+			require.ErrorIs(t, err, ErrNoHealthCheck)
+		})
+	}
 }
 
 // TestWaitForHealthSucceeds ensures that a healthy container always succeeds.
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
 )
 
@@ -17,10 +20,9 @@ import (
 var (
 	_ Strategy        = (*HostPortStrategy)(nil)
 	_ StrategyTimeout = (*HostPortStrategy)(nil)
+	_ StrategyPorts   = (*HostPortStrategy)(nil)
 )
 
-var errShellNotExecutable = errors.New("/bin/sh command not executable")
-
 type HostPortStrategy struct {
 	// Port is a string containing port number and protocol in the format "80/tcp"
 	// which
@@ -28,11 +30,44 @@ type HostPortStrategy struct {
 	// all WaitStrategies should have a startupTimeout to avoid waiting infinitely
 	timeout      *time.Duration
 	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+
+	// check selects the checks of the port: from the host, in the container, or both.
+	check PortCheck
+}
+
+// PortCheck selects how the host port strategy checks that the port is ready.
+type PortCheck int
+
+const (
+	// PortCheckBoth checks that the mapped port is reachable from the host, then that a
+	// process listens to the port in the container. It's the default.
+	PortCheckBoth PortCheck = iota
 
-	// skipInternalCheck is a flag to skip the internal check, which is useful when
-	// a shell is not available in the container or when the container doesn't bind
-	// the port internally until additional conditions are met.
-	skipInternalCheck bool
+	// PortCheckExternal only checks that the mapped port is reachable from the host. The
+	// check may pass before the process listens to the port, e.g. on Docker Desktop, whose
+	// proxy accepts the connections to the mapped ports.
+	PortCheckExternal
+
+	// PortCheckInternal only checks that a process listens to the port in the container,
+	// e.g. when the port is not reachable from the host. See WithCheck for the images
+	// without a shell.
+	PortCheckInternal
+)
+
+// String returns the name of the check, as printed in the errors.
+func (c PortCheck) String() string {
+	switch c {
+	case PortCheckBoth:
+		return "external and internal"
+	case PortCheckExternal:
+		return "external"
+	case PortCheckInternal:
+		return "internal"
+	default:
+		return fmt.Sprintf("PortCheck(%d)", int(c))
+	}
 }
 
 // NewHostPortStrategy constructs a default host port strategy that waits for the given
@@ -50,6 +85,8 @@ func NewHostPortStrategy(port nat.Port) *HostPortStrategy {
 
 // ForListeningPort returns a host port strategy that waits for the given port
 // to be exposed and bound internally the container.
+// For UDP ports, e.g. "8125/udp", the checks are best-effort, as UDP is connectionless:
+// see externalUDPCheck and buildInternalUDPCheckCommand.
 // Alias for `NewHostPortStrategy(port)`.
 func ForListeningPort(port nat.Port) *HostPortStrategy {
 	return NewHostPortStrategy(port)
@@ -64,8 +101,21 @@ func ForExposedPort() *HostPortStrategy {
 // SkipInternalCheck changes the host port strategy to skip the internal check,
 // which is useful when a shell is not available in the container or when the
 // container doesn't bind the port internally until additional conditions are met.
+// Alias for `WithCheck(PortCheckExternal)`.
 func (hp *HostPortStrategy) SkipInternalCheck() *HostPortStrategy {
-	hp.skipInternalCheck = true
+	return hp.WithCheck(PortCheckExternal)
+}
+
+// WithCheck selects how the port is checked: from the host, in the container, or both,
+// which is the default.
+//
+// The internal check reads the sockets of the container from /proc/net through the archive
+// endpoints of the Docker API, so that no shell is needed in the image. However, the Docker
+// daemon archives the files of procfs as empty files, so that the check falls back to a shell
+// command in the container, failing with ErrNoShell if the image has no shell, e.g. a distroless
+// image. Prefer PortCheckExternal for such images.
+func (hp *HostPortStrategy) WithCheck(check PortCheck) *HostPortStrategy {
+	hp.check = check
 
 	return hp
 }
@@ -79,29 +129,50 @@ func (hp *HostPortStrategy) WithStartupTimeout(startupTimeout time.Duration) *Ho
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (hp *HostPortStrategy) WithPollInterval(pollInterval time.Duration) *HostPortStrategy {
 	hp.PollInterval = pollInterval
+	hp.backoff = ConstantBackoff(pollInterval)
 	return hp
 }
 
-func (hp *HostPortStrategy) Timeout() *time.Duration {
-	return hp.timeout
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (hp *HostPortStrategy) WithBackoff(b Backoff) *HostPortStrategy {
+	hp.backoff = b
+	return hp
 }
 
-// WaitUntilReady implements Strategy.WaitUntilReady
-func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	timeout := defaultStartupTimeout()
-	if hp.timeout != nil {
-		timeout = *hp.timeout
+// WithObserver can be used to be notified of each check of the port, e.g. with LogObserver
+func (hp *HostPortStrategy) WithObserver(o ProbeObserver) *HostPortStrategy {
+	hp.observer = o
+	return hp
+}
+
+// String returns a human-readable description of the wait strategy.
+func (hp *HostPortStrategy) String() string {
+	if hp.Port == "" {
+		return "lowest exposed port listening"
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	return fmt.Sprintf("port %s listening", hp.Port)
+}
 
-	ipAddress, err := target.Host(ctx)
-	if err != nil {
-		return err
+func (hp *HostPortStrategy) Timeout() *time.Duration {
+	return hp.timeout
+}
+
+// Ports implements StrategyPorts, returning the port of the strategy, unless it targets the lowest exposed port
+// or it's checked from inside the container, which doesn't need the port to be exposed.
+func (hp *HostPortStrategy) Ports() []nat.Port {
+	if hp.check == PortCheckInternal {
+		return nil
 	}
 
-	waitInterval := hp.PollInterval
+	return singlePort(hp.Port)
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	ctx, cancel := withEffectiveDeadline(ctx, hp)
+	defer cancel()
 
 	internalPort := hp.Port
 	if internalPort == "" {
@@ -121,67 +192,90 @@ func (hp *HostPortStrategy) WaitUntilReady(ctx context.Context, target StrategyT
 		return fmt.Errorf("no port to wait for")
 	}
 
-	var port nat.Port
-	port, err = target.MappedPort(ctx, internalPort)
-	i := 0
+	prober := newProber(ctx, hp, hp.observer)
+	if err := hp.waitUntilReady(ctx, target, internalPort, prober); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return prober.timeoutError(fmt.Errorf("%w: %s check of port %s", err, hp.check, internalPort))
+		}
+		return err
+	}
 
-	for port == "" {
-		i++
+	// the failing attempts are observed by the checks, and the last one passed.
+	prober.observe(nil)
+	return nil
+}
 
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("%w: %w", ctx.Err(), err)
-		case <-time.After(waitInterval):
-			if err := checkTarget(ctx, target); err != nil {
-				return err
-			}
-			port, err = target.MappedPort(ctx, internalPort)
-			if err != nil {
-				log.Printf("(%d) [%s] %s\n", i, port, err)
+// waitUntilReady runs the checks of the port selected with WithCheck.
+func (hp *HostPortStrategy) waitUntilReady(ctx context.Context, target StrategyTarget, internalPort nat.Port, prober *prober) error {
+	poller := newPoller(hp.backoff, hp.PollInterval)
+
+	if hp.check != PortCheckInternal {
+		ipAddress, err := target.Host(ctx)
+		if err != nil {
+			return err
+		}
+
+		var port nat.Port
+		port, err = target.MappedPort(ctx, internalPort)
+		i := 0
+
+		for port == "" {
+			i++
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %w", ctx.Err(), err)
+			case <-time.After(poller.next()):
+				if err := checkTarget(ctx, target); err != nil {
+					return err
+				}
+				port, err = target.MappedPort(ctx, internalPort)
+				if err != nil {
+					log.Printf("(%d) [%s] %s\n", i, port, err)
+				}
 			}
 		}
-	}
 
-	if err := externalCheck(ctx, ipAddress, port, target, waitInterval); err != nil {
-		return err
+		if err := externalCheck(ctx, ipAddress, port, target, poller, prober); err != nil {
+			return err
+		}
 	}
 
-	if hp.skipInternalCheck {
+	if hp.check == PortCheckExternal {
 		return nil
 	}
 
-	err = internalCheck(ctx, internalPort, target)
-	if err != nil && errors.Is(errShellNotExecutable, err) {
-		log.Println("Shell not executable in container, only external port check will be performed")
-	} else {
-		return err
+	err := internalCheck(ctx, internalPort, target, poller, prober)
+	if err != nil && errors.Is(err, ErrNoShell) && hp.check == PortCheckBoth {
+		log.Println("No shell found in container, only external port check will be performed")
+		return nil
 	}
 
-	return nil
+	return err
 }
 
-func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target StrategyTarget, waitInterval time.Duration) error {
+func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target StrategyTarget, poller *poller, prober *prober) error {
 	proto := port.Proto()
 	portNumber := port.Int()
 	portString := strconv.Itoa(portNumber)
 
 	dialer := net.Dialer{}
 	address := net.JoinHostPort(ipAddress, portString)
+	if proto == "udp" {
+		return externalUDPCheck(ctx, &dialer, address, target, poller, prober)
+	}
+
 	for {
+		prober.begin()
 		if err := checkTarget(ctx, target); err != nil {
 			return err
 		}
 		conn, err := dialer.DialContext(ctx, proto, address)
 		if err != nil {
-			var v *net.OpError
-			if errors.As(err, &v) {
-				var v2 *os.SyscallError
-				if errors.As(v.Err, &v2) {
-					if isConnRefusedErr(v2.Err) {
-						time.Sleep(waitInterval)
-						continue
-					}
-				}
+			if isConnRefused(err) {
+				prober.observe(fmt.Errorf("external check: %w", err))
+				time.Sleep(poller.next())
+				continue
 			}
 			return err
 		}
@@ -191,16 +285,118 @@ func externalCheck(ctx context.Context, ipAddress string, port nat.Port, target
 	}
 }
 
-func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget) error {
+// externalUDPCheck is the best-effort external check of a UDP port, as UDP is connectionless:
+// an empty datagram is sent to the port, and the port is considered closed only if the host
+// reports it as unreachable, which only happens if the ICMP error is routed back, e.g. without
+// the userland proxy of Docker. A timeout, or a reply, is considered as the port being open.
+// The reply is awaited for the delay of the poll, before waiting it again if the port is closed.
+func externalUDPCheck(ctx context.Context, dialer *net.Dialer, address string, target StrategyTarget, poller *poller, prober *prober) error {
+	for {
+		prober.begin()
+		delay := poller.next()
+		readTimeout := delay
+		if readTimeout <= 0 {
+			readTimeout = defaultPollInterval()
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+		conn, err := dialer.DialContext(ctx, "udp", address)
+		if err != nil {
+			return err
+		}
+
+		err = func() error {
+			defer conn.Close()
+
+			if err := conn.SetDeadline(time.Now().Add(readTimeout)); err != nil {
+				return err
+			}
+			if _, err := conn.Write([]byte{}); err != nil {
+				return err
+			}
+
+			_, err := conn.Read(make([]byte, 1))
+			return err
+		}()
+
+		var netErr net.Error
+		switch {
+		case err == nil, errors.As(err, &netErr) && netErr.Timeout():
+			return nil
+		case isConnRefused(err):
+			prober.observe(fmt.Errorf("external check: %w", err))
+			time.Sleep(delay)
+			continue
+		default:
+			return err
+		}
+	}
+}
+
+// isConnRefused returns true if the error is a connection refused error, which for UDP means
+// that the port has been reported as unreachable.
+func isConnRefused(err error) bool {
+	var v *net.OpError
+	if errors.As(err, &v) {
+		var v2 *os.SyscallError
+		if errors.As(v.Err, &v2) {
+			return isConnRefusedErr(v2.Err)
+		}
+	}
+
+	return false
+}
+
+// internalCheck checks that a process listens to the port in the container, reading the sockets
+// from /proc/net through the archive endpoints of the Docker API if the target supports them, so
+// that no shell is needed in the image. Otherwise, e.g. as the Docker daemon archives the files of
+// procfs as empty files, it runs a shell command in the container.
+func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget, poller *poller, prober *prober) error {
+	if ft, ok := target.(fileTarget); ok {
+		err := archiveInternalCheck(ctx, internalPort, target, ft, poller, prober)
+		if !errors.Is(err, errProcNetUnavailable) {
+			return err
+		}
+
+		err = shellInternalCheck(ctx, internalPort, target, prober)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w: %w, checked with a shell command", err, errProcNetUnavailable)
+		}
+		return err
+	}
+
+	return shellInternalCheck(ctx, internalPort, target, prober)
+}
+
+// shellInternalCheck runs a shell command in the container until it finds a socket bound to the port.
+func shellInternalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget, prober *prober) error {
 	command := buildInternalCheckCommand(internalPort.Int())
+	if internalPort.Proto() == "udp" {
+		command = buildInternalUDPCheckCommand(internalPort.Int())
+	}
+	var shell string
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
+		prober.begin()
 		if err := checkTarget(ctx, target); err != nil {
 			return err
 		}
-		exitCode, _, err := target.Exec(ctx, []string{"/bin/sh", "-c", command})
+		// the shell is detected once the state of the container is checked,
+		// so that a crashed container is reported as such
+		if shell == "" {
+			var err error
+			if shell, err = detectShell(ctx, target); err != nil {
+				return err
+			}
+		}
+		exitCode, _, err := target.Exec(ctx, []string{shell, "-c", command})
 		if err != nil {
 			return fmt.Errorf("%w, host port waiting failed", err)
 		}
@@ -208,12 +404,121 @@ func internalCheck(ctx context.Context, internalPort nat.Port, target StrategyTa
 		if exitCode == 0 {
 			break
 		} else if exitCode == 126 {
-			return errShellNotExecutable
+			return fmt.Errorf("%w: %s not executable", ErrNoShell, shell)
 		}
+		prober.observe(fmt.Errorf("internal check: command exited with code %d", exitCode))
 	}
 	return nil
 }
 
+// errProcNetUnavailable is returned when the sockets cannot be read from /proc/net through the
+// archive endpoints, as some daemons archive the files of procfs as empty files.
+var errProcNetUnavailable = errors.New("/proc/net is not readable through the archive endpoints")
+
+// tcpListen is the state of the listening TCP sockets in /proc/net/tcp.
+const tcpListen = "0A"
+
+// archiveInternalCheck polls the sockets of the container until one listens to the port.
+func archiveInternalCheck(ctx context.Context, internalPort nat.Port, target StrategyTarget, ft fileTarget, poller *poller, prober *prober) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		prober.begin()
+		if err := checkTarget(ctx, target); err != nil {
+			return err
+		}
+
+		listening, err := procNetListening(ctx, ft, internalPort)
+		if err != nil {
+			return err
+		}
+		if listening {
+			return nil
+		}
+		prober.observe(fmt.Errorf("internal check: no socket listening to port %s", internalPort))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(poller.next()):
+		}
+	}
+}
+
+// procNetListening reads the IPv4 and IPv6 sockets of the protocol of the port from /proc/net,
+// as seen by the first process of the container, and returns true if one listens to the port.
+func procNetListening(ctx context.Context, ft fileTarget, port nat.Port) (bool, error) {
+	read := false
+	for _, file := range []string{port.Proto(), port.Proto() + "6"} {
+		// /proc/net links to the network namespace of the reading process, which is not
+		// the one of the container for the archive endpoints, unlike /proc/1/net.
+		path := "/proc/1/net/" + file
+
+		content, err := readProcNetFile(ctx, ft, path)
+		if err != nil {
+			// e.g. the IPv6 sockets, if IPv6 is disabled.
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return false, fmt.Errorf("read %s: %w", path, err)
+		}
+		if len(content) == 0 {
+			continue
+		}
+
+		read = true
+		if procNetListens(content, port.Int(), port.Proto()) {
+			return true, nil
+		}
+	}
+
+	if !read {
+		return false, errProcNetUnavailable
+	}
+
+	return false, nil
+}
+
+// readProcNetFile reads the file at the given path through the archive endpoints.
+func readProcNetFile(ctx context.Context, ft fileTarget, path string) ([]byte, error) {
+	rc, err := ft.CopyFileFromContainer(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// procNetListens returns true if the content of /proc/net/tcp, /proc/net/udp, or their IPv6
+// counterparts, lists a socket bound to the port, in the listening state for TCP.
+func procNetListens(content []byte, port int, proto string) bool {
+	for i, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		// the first line holds the titles of the columns.
+		if i == 0 || len(fields) < 4 {
+			continue
+		}
+
+		// the local address is the hexadecimal IP and port, e.g. 00000000:1F90 for 0.0.0.0:8080.
+		_, hexPort, ok := strings.Cut(fields[1], ":")
+		if !ok {
+			continue
+		}
+		p, err := strconv.ParseUint(hexPort, 16, 16)
+		if err != nil || int(p) != port {
+			continue
+		}
+
+		if proto == "udp" || fields[3] == tcpListen {
+			return true
+		}
+	}
+
+	return false
+}
+
 func buildInternalCheckCommand(internalPort int) string {
 	command := `(
 					cat /proc/net/tcp* | awk '{print $2}' | grep -i :%04x ||
@@ -223,3 +528,13 @@ func buildInternalCheckCommand(internalPort int) string {
 				`
 	return "true && " + fmt.Sprintf(command, internalPort, internalPort, internalPort)
 }
+
+// buildInternalUDPCheckCommand returns the command checking that a UDP socket is bound to the
+// port in the container, as there is no connection to establish to check it.
+func buildInternalUDPCheckCommand(internalPort int) string {
+	command := `(
+					cat /proc/net/udp* | awk '{print $2}' | grep -i :%04x
+				)
+				`
+	return "true && " + fmt.Sprintf(command, internalPort)
+}
@@ -2,13 +2,17 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go/exec"
@@ -544,3 +548,344 @@ func TestHostPortStrategySucceedsGivenShellIsNotInstalled(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWaitForListeningPortUDP(t *testing.T) {
+	newTarget := func(port nat.Port, cmds *[]string) *MockStrategyTarget {
+		return &MockStrategyTarget{
+			HostImpl: func(_ context.Context) (string, error) {
+				return "127.0.0.1", nil
+			},
+			MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+				return port, nil
+			},
+			StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+				return &types.ContainerState{
+					Running: true,
+				}, nil
+			},
+			ExecImpl: func(_ context.Context, cmd []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+				*cmds = append(*cmds, strings.Join(cmd, " "))
+				return 0, nil, nil
+			},
+		}
+	}
+
+	t.Run("listening", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		rawPort := conn.LocalAddr().(*net.UDPAddr).Port
+		port, err := nat.NewPort("udp", strconv.Itoa(rawPort))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var cmds []string
+		// waitForUDPPort {
+		wg := ForListeningPort("8125/udp").
+			WithStartupTimeout(5 * time.Second)
+		// }
+
+		if err := wg.WaitUntilReady(context.Background(), newTarget(port, &cmds)); err != nil {
+			t.Fatal(err)
+		}
+
+		// the internal check looks for a bound UDP socket, 8125 being 1fbd in hex.
+		last := cmds[len(cmds)-1]
+		if !strings.Contains(last, "/proc/net/udp") || !strings.Contains(last, ":1fbd") {
+			t.Fatalf("expected the internal check to read /proc/net/udp, got %q", last)
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rawPort := conn.LocalAddr().(*net.UDPAddr).Port
+		// nothing listens on the port anymore, so it's reported as unreachable on the loopback interface.
+		conn.Close()
+
+		port, err := nat.NewPort("udp", strconv.Itoa(rawPort))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var cmds []string
+		wg := ForListeningPort("8125/udp").
+			WithStartupTimeout(500 * time.Millisecond).
+			WithPollInterval(100 * time.Millisecond)
+
+		err = wg.WaitUntilReady(context.Background(), newTarget(port, &cmds))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the strategy to time out, got %v", err)
+		}
+	})
+}
+
+// mockProcNetTarget reads the sockets of the container from /proc/net through the archive
+// endpoints, without executing any command.
+type mockProcNetTarget struct {
+	MockStrategyTarget
+	files map[string]string
+	reads int
+}
+
+func (st *mockProcNetTarget) StatPath(_ context.Context, path string) (container.PathStat, error) {
+	return container.PathStat{Name: path}, nil
+}
+
+func (st *mockProcNetTarget) CopyFileFromContainer(_ context.Context, path string) (io.ReadCloser, error) {
+	st.reads++
+	content, ok := st.files[path]
+	if !ok {
+		return nil, errdefs.NotFound(errors.New("no such file: " + path))
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+const procNetTCPHeader = "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"
+
+func TestProcNetListens(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		port    int
+		proto   string
+		want    bool
+	}{
+		{
+			name:    "tcp-listen",
+			content: procNetTCPHeader + "   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1\n",
+			port:    8080,
+			proto:   "tcp",
+			want:    true,
+		},
+		{
+			name:    "tcp-established",
+			content: procNetTCPHeader + "   0: 0100007F:1F90 0100007F:9C40 01 00000000:00000000 00:00000000 00000000     0        0 1\n",
+			port:    8080,
+			proto:   "tcp",
+			want:    false,
+		},
+		{
+			name:    "tcp6-listen",
+			content: procNetTCPHeader + "   0: 00000000000000000000000000000000:1F90 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1\n",
+			port:    8080,
+			proto:   "tcp",
+			want:    true,
+		},
+		{
+			name:    "other-port",
+			content: procNetTCPHeader + "   0: 00000000:1F91 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1\n",
+			port:    8080,
+			proto:   "tcp",
+			want:    false,
+		},
+		{
+			name:    "udp-bound",
+			content: procNetTCPHeader + "   0: 00000000:1FBD 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 1\n",
+			port:    8125,
+			proto:   "udp",
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := procNetListens([]byte(tt.content), tt.port, tt.proto); got != tt.want {
+				t.Errorf("procNetListens() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostPortStrategyWithCheck(t *testing.T) {
+	running := func(_ context.Context) (*types.ContainerState, error) {
+		return &types.ContainerState{Running: true}, nil
+	}
+	noHost := func(_ context.Context) (string, error) {
+		t.Fatal("the host must not be looked up by the internal check")
+		return "", nil
+	}
+	noShell := func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+		return 126, nil, nil
+	}
+
+	t.Run("internal/archive", func(t *testing.T) {
+		target := &mockProcNetTarget{
+			MockStrategyTarget: MockStrategyTarget{
+				HostImpl:  noHost,
+				StateImpl: running,
+				ExecImpl:  noShell,
+			},
+			files: map[string]string{
+				"/proc/1/net/tcp": procNetTCPHeader + "   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1\n",
+			},
+		}
+
+		// waitForInternalPort {
+		wg := ForListeningPort("8080/tcp").
+			WithCheck(PortCheckInternal).
+			WithStartupTimeout(5 * time.Second)
+		// }
+
+		if err := wg.WaitUntilReady(context.Background(), target); err != nil {
+			t.Fatal(err)
+		}
+		if target.reads == 0 {
+			t.Fatal("expected the sockets to be read through the archive endpoints")
+		}
+	})
+
+	t.Run("internal/timeout", func(t *testing.T) {
+		target := &mockProcNetTarget{
+			MockStrategyTarget: MockStrategyTarget{
+				HostImpl:  noHost,
+				StateImpl: running,
+				ExecImpl:  noShell,
+			},
+			files: map[string]string{
+				"/proc/1/net/tcp": procNetTCPHeader,
+			},
+		}
+
+		wg := ForListeningPort("8080/tcp").
+			WithCheck(PortCheckInternal).
+			WithStartupTimeout(500 * time.Millisecond).
+			WithPollInterval(100 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the strategy to time out, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "internal check of port 8080/tcp") {
+			t.Fatalf("expected the error to name the check, got %q", err)
+		}
+	})
+
+	t.Run("internal/no-shell", func(t *testing.T) {
+		// the files of procfs are archived as empty files by some daemons.
+		target := &mockProcNetTarget{
+			MockStrategyTarget: MockStrategyTarget{
+				HostImpl:  noHost,
+				StateImpl: running,
+				ExecImpl:  noShell,
+			},
+			files: map[string]string{
+				"/proc/1/net/tcp": "",
+			},
+		}
+
+		err := ForListeningPort("8080/tcp").
+			WithCheck(PortCheckInternal).
+			WithStartupTimeout(5*time.Second).
+			WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, ErrNoShell) {
+			t.Fatalf("expected %v, got %v", ErrNoShell, err)
+		}
+	})
+
+	t.Run("internal/empty-archive", func(t *testing.T) {
+		// the Docker daemon archives the files of procfs as empty files.
+		var cmds []string
+		target := &mockProcNetTarget{
+			MockStrategyTarget: MockStrategyTarget{
+				HostImpl:  noHost,
+				StateImpl: running,
+				ExecImpl: func(_ context.Context, cmd []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+					cmds = append(cmds, strings.Join(cmd, " "))
+					return 0, nil, nil
+				},
+			},
+			files: map[string]string{
+				"/proc/1/net/tcp":  "",
+				"/proc/1/net/tcp6": "",
+			},
+		}
+
+		err := ForListeningPort("8080/tcp").
+			WithCheck(PortCheckInternal).
+			WithStartupTimeout(5*time.Second).
+			WaitUntilReady(context.Background(), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if target.reads == 0 {
+			t.Fatal("expected the sockets to be read through the archive endpoints first")
+		}
+		// the shell is detected, then the port is checked with it.
+		if len(cmds) != 2 || !strings.Contains(cmds[1], ":1f90") {
+			t.Fatalf("expected the port to be checked with a shell command, got %q", cmds)
+		}
+	})
+
+	t.Run("internal/empty-archive-timeout", func(t *testing.T) {
+		target := &mockProcNetTarget{
+			MockStrategyTarget: MockStrategyTarget{
+				HostImpl:  noHost,
+				StateImpl: running,
+				ExecImpl: func(_ context.Context, cmd []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+					if cmd[len(cmd)-1] == "exit 0" {
+						return 0, nil, nil
+					}
+					time.Sleep(10 * time.Millisecond)
+					return 1, nil, nil
+				},
+			},
+			files: map[string]string{
+				"/proc/1/net/tcp": "",
+			},
+		}
+
+		err := ForListeningPort("8080/tcp").
+			WithCheck(PortCheckInternal).
+			WithStartupTimeout(500*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the strategy to time out, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "/proc/net is not readable through the archive endpoints, checked with a shell command") {
+			t.Fatalf("expected the error to explain the fallback, got %q", err)
+		}
+	})
+
+	t.Run("external", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer listener.Close()
+
+		rawPort := listener.Addr().(*net.TCPAddr).Port
+		port, err := nat.NewPort("tcp", strconv.Itoa(rawPort))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		target := &MockStrategyTarget{
+			HostImpl: func(_ context.Context) (string, error) {
+				return "localhost", nil
+			},
+			MappedPortImpl: func(_ context.Context, _ nat.Port) (nat.Port, error) {
+				return port, nil
+			},
+			StateImpl: running,
+			ExecImpl: func(_ context.Context, _ []string, _ ...exec.ProcessOption) (int, io.Reader, error) {
+				t.Fatal("the internal check must not be run")
+				return 0, nil, nil
+			},
+		}
+
+		err = ForListeningPort("80/tcp").
+			WithCheck(PortCheckExternal).
+			WithStartupTimeout(5*time.Second).
+			WaitUntilReady(context.Background(), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -21,6 +22,7 @@ import (
 var (
 	_ Strategy        = (*HTTPStrategy)(nil)
 	_ StrategyTimeout = (*HTTPStrategy)(nil)
+	_ StrategyPorts   = (*HTTPStrategy)(nil)
 )
 
 type HTTPStrategy struct {
@@ -40,8 +42,23 @@ type HTTPStrategy struct {
 	Headers                map[string]string
 	ResponseHeadersMatcher func(headers http.Header) bool
 	PollInterval           time.Duration
+	backoff                Backoff
+	observer               ProbeObserver
 	UserInfo               *url.Userinfo
 	ForceIPv4LocalHost     bool
+
+	// RequestModifier modifies each request just before it's sent, see WithRequestModifier.
+	RequestModifier func(req *http.Request) error
+
+	// TLSConfigFunc returns the TLS config for HTTPS, evaluated before each poll and overriding TLSConfig.
+	TLSConfigFunc func(ctx context.Context, target StrategyTarget) (*tls.Config, error)
+
+	// RootCAs verify the server certificate, overriding the root CAs of the TLS config.
+	RootCAs *x509.CertPool
+
+	// ClientCertPEM and ClientKeyPEM are the PEM encoded client certificate and key presented to the server.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
 }
 
 // NewHTTPStrategy constructs a HTTP strategy waiting on port 80 and status code 200
@@ -101,6 +118,34 @@ func (ws *HTTPStrategy) WithTLS(useTLS bool, tlsconf ...*tls.Config) *HTTPStrate
 	return ws
 }
 
+// WithClientCertificateFiles enables TLS, presenting the given PEM encoded client certificate and key
+// to the server, e.g. for a health endpoint requiring mTLS.
+func (ws *HTTPStrategy) WithClientCertificateFiles(certPEM, keyPEM []byte) *HTTPStrategy {
+	ws.UseTLS = true
+	ws.ClientCertPEM = certPEM
+	ws.ClientKeyPEM = keyPEM
+	return ws
+}
+
+// WithRootCAs enables TLS, verifying the server certificate with the given root CAs,
+// e.g. a self-signed CA of the service under test.
+func (ws *HTTPStrategy) WithRootCAs(rootCAs *x509.CertPool) *HTTPStrategy {
+	ws.UseTLS = true
+	ws.RootCAs = rootCAs
+	return ws
+}
+
+// WithTLSConfigFunc enables TLS, evaluating the TLS config with the given function right before each poll,
+// so that the certificates generated by the container at startup can be used, e.g. copied out of the
+// container with target.Exec. The poll is retried while the function returns an error.
+// The root CAs and client certificate set with WithRootCAs and WithClientCertificateFiles are
+// applied to the returned config.
+func (ws *HTTPStrategy) WithTLSConfigFunc(fn func(ctx context.Context, target StrategyTarget) (*tls.Config, error)) *HTTPStrategy {
+	ws.UseTLS = true
+	ws.TLSConfigFunc = fn
+	return ws
+}
+
 func (ws *HTTPStrategy) WithAllowInsecure(allowInsecure bool) *HTTPStrategy {
 	ws.AllowInsecure = allowInsecure
 	return ws
@@ -116,11 +161,24 @@ func (ws *HTTPStrategy) WithBody(reqdata io.Reader) *HTTPStrategy {
 	return ws
 }
 
+// WithHeaders can be used to set the given headers on every request, e.g. an Authorization header,
+// adding them to the headers set by previous calls. The Host header overrides the host of the request,
+// e.g. to reach a virtual host of a reverse proxy. A header set here takes precedence over the
+// Authorization header set by WithBasicAuth.
 func (ws *HTTPStrategy) WithHeaders(headers map[string]string) *HTTPStrategy {
-	ws.Headers = headers
+	if ws.Headers == nil {
+		ws.Headers = make(map[string]string, len(headers))
+	}
+
+	for k, v := range headers {
+		ws.Headers[k] = v
+	}
 	return ws
 }
 
+// WithResponseHeadersMatcher can be used to wait for the headers of the response to match the function,
+// e.g. until a reverse proxy returns an X-Ready header. It's evaluated along with the status code matcher,
+// before the response matcher, and all of them must match.
 func (ws *HTTPStrategy) WithResponseHeadersMatcher(matcher func(http.Header) bool) *HTTPStrategy {
 	ws.ResponseHeadersMatcher = matcher
 	return ws
@@ -131,12 +189,55 @@ func (ws *HTTPStrategy) WithBasicAuth(username, password string) *HTTPStrategy {
 	return ws
 }
 
+// WithBearerToken can be used to set the "Authorization: Bearer <token>" header on every request,
+// taking precedence over the Authorization header set by WithBasicAuth.
+func (ws *HTTPStrategy) WithBearerToken(token string) *HTTPStrategy {
+	return ws.WithHeaders(map[string]string{"Authorization": "Bearer " + token})
+}
+
+// WithRequestModifier can be used to modify each request just before it's sent, after the headers
+// and the basic auth are set, e.g. to change the path or the query of the fully built URL at each
+// attempt. The attempt fails if the modifier returns an error, and the request is sent again at the next one.
+func (ws *HTTPStrategy) WithRequestModifier(modifier func(req *http.Request) error) *HTTPStrategy {
+	ws.RequestModifier = modifier
+	return ws
+}
+
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *HTTPStrategy) WithPollInterval(pollInterval time.Duration) *HTTPStrategy {
 	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
+	return ws
+}
+
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *HTTPStrategy) WithBackoff(b Backoff) *HTTPStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each request, e.g. with LogObserver
+func (ws *HTTPStrategy) WithObserver(o ProbeObserver) *HTTPStrategy {
+	ws.observer = o
 	return ws
 }
 
+// String returns a human-readable description of the wait strategy.
+func (ws *HTTPStrategy) String() string {
+	method := ws.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	port := "the lowest exposed port"
+	if ws.Port != "" {
+		port = "port " + string(ws.Port)
+	}
+
+	return fmt.Sprintf("HTTP %s %s on %s", method, ws.Path, port)
+}
+
 // WithForcedIPv4LocalHost forces usage of localhost to be ipv4 127.0.0.1
 // to avoid ipv6 docker bugs https://github.com/moby/moby/issues/42442 https://github.com/moby/moby/issues/42375
 func (ws *HTTPStrategy) WithForcedIPv4LocalHost() *HTTPStrategy {
@@ -154,14 +255,14 @@ func (ws *HTTPStrategy) Timeout() *time.Duration {
 	return ws.timeout
 }
 
+// Ports implements StrategyPorts, returning the port of the strategy, unless it targets the lowest exposed port.
+func (ws *HTTPStrategy) Ports() []nat.Port {
+	return singlePort(ws.Port)
+}
+
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	timeout := defaultStartupTimeout()
-	if ws.timeout != nil {
-		timeout = *ws.timeout
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
 	defer cancel()
 
 	ipAddress, err := target.Host(ctx)
@@ -173,6 +274,8 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		ipAddress = strings.Replace(ipAddress, "localhost", "127.0.0.1", 1)
 	}
 
+	poller := newPoller(ws.backoff, ws.PollInterval)
+
 	var mappedPort nat.Port
 	if ws.Port == "" {
 		// We wait one polling interval before we grab the ports
@@ -180,7 +283,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(ws.PollInterval):
+		case <-time.After(poller.next()):
 			// Port should now be bound so just continue.
 		}
 
@@ -219,7 +322,7 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 			select {
 			case <-ctx.Done():
 				return fmt.Errorf("%w: %w", ctx.Err(), err)
-			case <-time.After(ws.PollInterval):
+			case <-time.After(poller.next()):
 				if err := checkTarget(ctx, target); err != nil {
 					return err
 				}
@@ -244,36 +347,23 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		ws.Method = http.MethodGet
 	}
 
-	tripper := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   time.Second,
-			KeepAlive: 30 * time.Second,
-			DualStack: true,
-		}).DialContext,
-		ForceAttemptHTTP2:     true,
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-		TLSClientConfig:       ws.TLSConfig,
-	}
-
 	var proto string
+	var client *http.Client
 	if ws.UseTLS {
 		proto = "https"
-		if ws.AllowInsecure {
-			if ws.TLSConfig == nil {
-				tripper.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-			} else {
-				ws.TLSConfig.InsecureSkipVerify = true
+
+		if ws.TLSConfigFunc == nil {
+			tlsConfig, err := ws.tlsConfig(ws.TLSConfig)
+			if err != nil {
+				return err
 			}
+			client = newHTTPClient(tlsConfig)
 		}
 	} else {
 		proto = "http"
+		client = newHTTPClient(nil)
 	}
 
-	client := http.Client{Transport: tripper, Timeout: time.Second}
 	address := net.JoinHostPort(ipAddress, strconv.Itoa(mappedPort.Int()))
 
 	endpoint, err := url.Parse(ws.Path)
@@ -296,43 +386,129 @@ func (ws *HTTPStrategy) WaitUntilReady(ctx context.Context, target StrategyTarge
 		}
 	}
 
+	prober := newProber(ctx, ws, ws.observer)
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(ws.PollInterval):
+			return prober.timeoutError(ctx.Err())
+		case <-time.After(poller.next()):
+			prober.begin()
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
+
+			client := client
+			if client == nil {
+				// the TLS config is evaluated before each poll, as the certificates may not be available yet.
+				conf, err := ws.TLSConfigFunc(ctx, target)
+				if err != nil {
+					prober.observe(fmt.Errorf("tls config: %w", err))
+					continue
+				}
+
+				tlsConfig, err := ws.tlsConfig(conf)
+				if err != nil {
+					return err
+				}
+				client = newHTTPClient(tlsConfig)
+				// the client is discarded after the poll, so do not keep its connection open.
+				client.Transport.(*http.Transport).DisableKeepAlives = true
+			}
+
 			req, err := http.NewRequestWithContext(ctx, ws.Method, endpoint.String(), bytes.NewReader(body))
 			if err != nil {
 				return err
 			}
 
 			for k, v := range ws.Headers {
+				if strings.EqualFold(k, "Host") {
+					// the Host header is ignored by the client, which uses the host of the request.
+					req.Host = v
+					continue
+				}
 				req.Header.Set(k, v)
 			}
 
+			if ws.RequestModifier != nil {
+				if err := ws.RequestModifier(req); err != nil {
+					prober.observe(fmt.Errorf("modify request: %w", err))
+					continue
+				}
+			}
+
 			resp, err := client.Do(req)
 			if err != nil {
+				prober.observe(err)
 				continue
 			}
 			if ws.StatusCodeMatcher != nil && !ws.StatusCodeMatcher(resp.StatusCode) {
 				_ = resp.Body.Close()
+				prober.observe(fmt.Errorf("unexpected status %q", resp.Status))
 				continue
 			}
-			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp.Body) {
+			if ws.ResponseHeadersMatcher != nil && !ws.ResponseHeadersMatcher(resp.Header) {
 				_ = resp.Body.Close()
+				prober.observe(errors.New("response headers not matching"))
 				continue
 			}
-			if ws.ResponseHeadersMatcher != nil && !ws.ResponseHeadersMatcher(resp.Header) {
+			if ws.ResponseMatcher != nil && !ws.ResponseMatcher(resp.Body) {
 				_ = resp.Body.Close()
+				prober.observe(errors.New("response body not matching"))
 				continue
 			}
 			if err := resp.Body.Close(); err != nil {
+				prober.observe(fmt.Errorf("close response body: %w", err))
 				continue
 			}
+			prober.observe(nil)
 			return nil
 		}
 	}
 }
+
+// tlsConfig returns a copy of the given TLS config, or a new one if nil, with the root CAs,
+// the client certificate and the insecure mode of the strategy applied.
+func (ws *HTTPStrategy) tlsConfig(base *tls.Config) (*tls.Config, error) {
+	conf := &tls.Config{}
+	if base != nil {
+		conf = base.Clone()
+	}
+
+	if ws.RootCAs != nil {
+		conf.RootCAs = ws.RootCAs
+	}
+
+	if ws.ClientCertPEM != nil || ws.ClientKeyPEM != nil {
+		cert, err := tls.X509KeyPair(ws.ClientCertPEM, ws.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %w", err)
+		}
+		conf.Certificates = append(conf.Certificates, cert)
+	}
+
+	if ws.AllowInsecure {
+		conf.InsecureSkipVerify = true
+	}
+
+	return conf, nil
+}
+
+// newHTTPClient returns the client polling the container, with the given TLS config, if any.
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	tripper := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+
+	return &http.Client{Transport: tripper, Timeout: time.Second}
+}
@@ -3,15 +3,25 @@ package wait_test
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math/big"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +29,7 @@ import (
 	"github.com/docker/go-connections/nat"
 
 	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
@@ -473,6 +484,286 @@ func TestHTTPStrategyWaitUntilReadyNoBasicAuth(t *testing.T) {
 	}
 }
 
+func TestHTTPStrategyWithHeaders(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer token"}
+	ws := wait.ForHTTP("/").
+		WithHeaders(headers).
+		WithHeaders(map[string]string{"X-Request-Id": "1"})
+
+	// the headers of the caller are copied.
+	headers["Authorization"] = "Bearer other"
+
+	expected := map[string]string{"Authorization": "Bearer token", "X-Request-Id": "1"}
+	if !reflect.DeepEqual(expected, ws.Headers) {
+		t.Fatalf("expected headers %v, got %v", expected, ws.Headers)
+	}
+}
+
+func TestHTTPStrategyWaitUntilReadyWithHeaders(t *testing.T) {
+	workdir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cafile, err := os.ReadFile(filepath.Join(workdir, "testdata", "root.pem"))
+	if err != nil {
+		t.Fatalf("can't load ca file: %v", err)
+	}
+
+	certpool := x509.NewCertPool()
+	if !certpool.AppendCertsFromPEM(cafile) {
+		t.Fatal("the ca file isn't valid")
+	}
+
+	// waitForHTTPRequestHeaders {
+	dockerReq := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			Context: filepath.Join(workdir, "testdata"),
+		},
+		ExposedPorts: []string{"6443/tcp"},
+		WaitingFor: wait.ForHTTP("/ready").WithPort("6443/tcp").
+			WithTLS(true, &tls.Config{RootCAs: certpool, ServerName: "testcontainer.go.test"}).
+			WithHeaders(map[string]string{"Authorization": "Bearer token"}).
+			WithHeaders(map[string]string{"Host": "testcontainer.go.test"}).
+			WithResponseHeadersMatcher(func(headers http.Header) bool {
+				return headers.Get("X-Ready") == "true" && headers.Get("X-Request-Host") == "testcontainer.go.test"
+			}).
+			WithResponseMatcher(func(body io.Reader) bool {
+				data, _ := io.ReadAll(body)
+				return bytes.Equal(data, []byte("pong"))
+			}).
+			WithStartupTimeout(time.Second * 20),
+	}
+	// }
+
+	ctx := context.Background()
+	start := time.Now()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: dockerReq, Started: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if elapsed := time.Since(start); elapsed < 3*time.Second {
+		t.Fatalf("expected the strategy to wait for the X-Ready header, waited %s", elapsed)
+	}
+
+	t.Run("without-authorization", func(t *testing.T) {
+		err := wait.ForHTTP("/ready").WithPort("6443/tcp").
+			WithTLS(true, &tls.Config{RootCAs: certpool, ServerName: "testcontainer.go.test"}).
+			WithStartupTimeout(time.Second).
+			WaitUntilReady(ctx, container)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a deadline exceeded error, got: %v", err)
+		}
+	})
+
+	t.Run("bearer-token-and-request-modifier", func(t *testing.T) {
+		var attempts int
+
+		// waitForHTTPBearerToken {
+		strategy := wait.ForHTTP("/").WithPort("6443/tcp").
+			WithTLS(true, &tls.Config{RootCAs: certpool, ServerName: "testcontainer.go.test"}).
+			WithBearerToken("token").
+			WithRequestModifier(func(req *http.Request) error {
+				attempts++
+				// the modifier sees the fully built URL of the request.
+				req.URL.Path = "/ready"
+				req.URL.RawQuery = "attempt=" + strconv.Itoa(attempts)
+				req.Host = "testcontainer.go.test"
+				return nil
+			}).
+			WithResponseHeadersMatcher(func(headers http.Header) bool {
+				return headers.Get("X-Request-Host") == "testcontainer.go.test"
+			}).
+			WithStartupTimeout(time.Second * 5)
+		// }
+
+		if err := strategy.WaitUntilReady(ctx, container); err != nil {
+			t.Fatalf("expected the strategy to pass, got: %v", err)
+		}
+		if attempts == 0 {
+			t.Fatal("expected the request modifier to be called")
+		}
+	})
+
+	t.Run("failing-request-modifier", func(t *testing.T) {
+		errModifier := errors.New("token not minted")
+
+		err := wait.ForHTTP("/ready").WithPort("6443/tcp").
+			WithTLS(true, &tls.Config{RootCAs: certpool, ServerName: "testcontainer.go.test"}).
+			WithRequestModifier(func(req *http.Request) error {
+				return errModifier
+			}).
+			WithStartupTimeout(time.Second).
+			WaitUntilReady(ctx, container)
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errModifier) {
+			t.Fatalf("expected a deadline exceeded error reporting the modifier error, got: %v", err)
+		}
+	})
+}
+
+func TestHTTPStrategyWaitUntilReadyWithClientCertificate(t *testing.T) {
+	workdir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cafile, err := os.ReadFile(filepath.Join(workdir, "testdata", "root.pem"))
+	if err != nil {
+		t.Fatalf("can't load ca file: %v", err)
+	}
+
+	certpool := x509.NewCertPool()
+	if !certpool.AppendCertsFromPEM(cafile) {
+		t.Fatal("the ca file isn't valid")
+	}
+
+	certPEM, keyPEM := newClientCertificate(t)
+
+	// waitForHTTPClientCertificate {
+	dockerReq := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			Context: filepath.Join(workdir, "testdata"),
+		},
+		ExposedPorts: []string{"6443/tcp"},
+		WaitingFor: wait.ForHTTP("/mtls-ping").WithPort("6443/tcp").
+			WithTLS(true, &tls.Config{ServerName: "testcontainer.go.test"}).
+			WithRootCAs(certpool).
+			WithClientCertificateFiles(certPEM, keyPEM).
+			WithResponseMatcher(func(body io.Reader) bool {
+				data, _ := io.ReadAll(body)
+				return bytes.Equal(data, []byte("pong"))
+			}).
+			WithStartupTimeout(time.Second * 10),
+	}
+	// }
+
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: dockerReq, Started: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	t.Run("without-client-certificate", func(t *testing.T) {
+		err := wait.ForHTTP("/mtls-ping").WithPort("6443/tcp").
+			WithTLS(true, &tls.Config{ServerName: "testcontainer.go.test"}).
+			WithRootCAs(certpool).
+			WithStartupTimeout(time.Second).
+			WaitUntilReady(ctx, container)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a deadline exceeded error, got: %v", err)
+		}
+	})
+
+	t.Run("invalid-client-certificate", func(t *testing.T) {
+		err := wait.ForHTTP("/mtls-ping").WithPort("6443/tcp").
+			WithClientCertificateFiles([]byte("invalid"), keyPEM).
+			WaitUntilReady(ctx, container)
+		if err == nil || !strings.Contains(err.Error(), "client certificate") {
+			t.Fatalf("expected a client certificate error, got: %v", err)
+		}
+	})
+}
+
+func TestHTTPStrategyWaitUntilReadyWithTLSConfigFunc(t *testing.T) {
+	workdir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int
+
+	// waitForHTTPTLSConfigFunc {
+	dockerReq := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			Context: filepath.Join(workdir, "testdata"),
+		},
+		ExposedPorts: []string{"6443/tcp"},
+		WaitingFor: wait.ForHTTP("/ping").WithPort("6443/tcp").
+			WithMethod(http.MethodPost).WithBody(bytes.NewReader([]byte("ping"))).
+			WithTLSConfigFunc(func(ctx context.Context, target wait.StrategyTarget) (*tls.Config, error) {
+				calls++
+
+				// trust the certificate served by the container, copied out of it.
+				_, reader, err := target.Exec(ctx, []string{"cat", "/app/tls.pem"}, tcexec.Multiplexed())
+				if err != nil {
+					return nil, err
+				}
+
+				cert, err := io.ReadAll(reader)
+				if err != nil {
+					return nil, err
+				}
+
+				certpool := x509.NewCertPool()
+				if !certpool.AppendCertsFromPEM(cert) {
+					return nil, errors.New("the server certificate isn't available yet")
+				}
+
+				return &tls.Config{RootCAs: certpool, ServerName: "testcontainer.go.test"}, nil
+			}).
+			WithStartupTimeout(time.Second * 10),
+	}
+	// }
+
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{ContainerRequest: dockerReq, Started: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	if calls == 0 {
+		t.Fatal("the TLS config func wasn't called")
+	}
+}
+
+// newClientCertificate returns a self-signed client certificate and its key, PEM encoded.
+func newClientCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "testcontainers-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
 func TestHttpStrategyFailsWhileGettingPortDueToOOMKilledContainer(t *testing.T) {
 	var mappedPortCount int
 	target := &wait.MockStrategyTarget{
@@ -2,6 +2,8 @@ package wait
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"regexp"
 	"strings"
@@ -24,8 +26,54 @@ type LogStrategy struct {
 	IsRegexp     bool
 	Occurrence   int
 	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+	Stream       LogStream // streams of the container where the log is looked for, both by default
+
+	// RestartAware only looks for the log in the output of the current start of the container, so that
+	// the log of a previous start isn't matched once the container is restarted, see WithRestartAware.
+	RestartAware bool
+
+	// ClockSkew is subtracted from the start time of the container when reading the logs since the
+	// current start, see WithClockSkew.
+	ClockSkew time.Duration
+
+	// SubmatchCallback is called with the submatches of the occurrences of the regular expression
+	// when the log is found, see WithSubmatchCallback.
+	SubmatchCallback func(matches [][]string) error
+
+	// re is the regular expression of the log, compiled when the strategy is constructed.
+	re *regexp.Regexp
+}
+
+// LogStream selects the output streams of a container read by a LogStrategy.
+type LogStream int
+
+const (
+	// LogStreamBoth reads the stdout and stderr streams of the container, merged.
+	LogStreamBoth LogStream = iota
+	// LogStreamStdout only reads the stdout stream of the container.
+	LogStreamStdout
+	// LogStreamStderr only reads the stderr stream of the container.
+	LogStreamStderr
+)
+
+// streamLogsTarget is implemented by the targets reading the logs of a single output
+// stream of the container, such as testcontainers.DockerContainer.
+type streamLogsTarget interface {
+	StreamLogs(ctx context.Context, stdout bool, stderr bool) (io.ReadCloser, error)
 }
 
+// logsSinceTarget is implemented by the targets reading the logs of the container since a given
+// time, such as testcontainers.DockerContainer.
+type logsSinceTarget interface {
+	LogsSince(ctx context.Context, since time.Time, stdout bool, stderr bool) (io.ReadCloser, error)
+}
+
+// defaultLogClockSkew is the default clock skew of the log strategy, as the first lines of the output
+// may be timestamped by the daemon before the start time of the container is recorded.
+const defaultLogClockSkew = time.Second
+
 // NewLogStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default
 func NewLogStrategy(log string) *LogStrategy {
 	return &LogStrategy{
@@ -33,6 +81,8 @@ func NewLogStrategy(log string) *LogStrategy {
 		IsRegexp:     false,
 		Occurrence:   1,
 		PollInterval: defaultPollInterval(),
+		RestartAware: true,
+		ClockSkew:    defaultLogClockSkew,
 	}
 }
 
@@ -40,9 +90,56 @@ func NewLogStrategy(log string) *LogStrategy {
 // since go has neither covariance nor generics, the return type must be the type of the concrete implementation
 // this is true for all properties, even the "shared" ones like startupTimeout
 
-// AsRegexp can be used to change the default behavior of the log strategy to use regexp instead of plain text
+// AsRegexp can be used to change the default behavior of the log strategy to use regexp instead of plain text.
+// The regular expression is compiled right away, panicking if it's invalid, as regexp.MustCompile.
 func (ws *LogStrategy) AsRegexp() *LogStrategy {
 	ws.IsRegexp = true
+	ws.re = regexp.MustCompile(ws.Log)
+	return ws
+}
+
+// WithStream can be used to only look for the log in the stdout or stderr stream of the container,
+// e.g. when the other one is too chatty. Both streams are read by default. The output of a container
+// with a TTY is only written to stdout.
+func (ws *LogStrategy) WithStream(stream LogStream) *LogStrategy {
+	ws.Stream = stream
+	return ws
+}
+
+// WithRestartAware can be used to only look for the log in the output of the current start of the container,
+// which is the default, or in all its output with false. Once a container is restarted, or reused, its logs
+// still hold the output of the previous starts, so that a log printed when the service is ready would be
+// matched before the service is actually ready again. The logs are then read since the start time of the
+// container, minus the clock skew, but never before the end of its previous run.
+func (ws *LogStrategy) WithRestartAware(restartAware bool) *LogStrategy {
+	ws.RestartAware = restartAware
+	return ws
+}
+
+// WithClockSkew can be used to change the default clock skew of 1 second, subtracted from the start time
+// of the container when reading its logs since the current start, as the daemon may timestamp the first
+// lines of the output slightly before it records the start time of the container.
+func (ws *LogStrategy) WithClockSkew(skew time.Duration) *LogStrategy {
+	ws.ClockSkew = skew
+	return ws
+}
+
+// WithSubmatchCallback can be used to capture values from the logs during the wait, such as a token printed
+// once at startup, instead of reading the logs again once the container is ready. The callback is called
+// with the submatches of all the occurrences of the regular expression, as regexp.FindAllStringSubmatch,
+// once they reach the expected number, and before the strategy succeeds. An error of the callback fails
+// the wait. It's only supported by the strategies matching a regular expression, see AsRegexp.
+//
+// For Example:
+//
+//	wait.
+//		ForLogRegex(`admin token: (\w+)`).
+//		WithSubmatchCallback(func(matches [][]string) error {
+//			token = matches[0][1]
+//			return nil
+//		})
+func (ws *LogStrategy) WithSubmatchCallback(callback func(matches [][]string) error) *LogStrategy {
+	ws.SubmatchCallback = callback
 	return ws
 }
 
@@ -55,9 +152,32 @@ func (ws *LogStrategy) WithStartupTimeout(timeout time.Duration) *LogStrategy {
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (ws *LogStrategy) WithPollInterval(pollInterval time.Duration) *LogStrategy {
 	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
 	return ws
 }
 
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *LogStrategy) WithBackoff(b Backoff) *LogStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each attempt to find the log, e.g. with LogObserver
+func (ws *LogStrategy) WithObserver(o ProbeObserver) *LogStrategy {
+	ws.observer = o
+	return ws
+}
+
+// String returns a human-readable description of the wait strategy.
+func (ws *LogStrategy) String() string {
+	if ws.IsRegexp {
+		return fmt.Sprintf("log matching %q", ws.Log)
+	}
+
+	return fmt.Sprintf("log %q", ws.Log)
+}
+
 func (ws *LogStrategy) WithOccurrence(o int) *LogStrategy {
 	// the number of occurrence needs to be positive
 	if o <= 0 {
@@ -78,39 +198,61 @@ func ForLog(log string) *LogStrategy {
 	return NewLogStrategy(log)
 }
 
+// ForLogRegex is the construction for the fluid interface of a log strategy matching the
+// given regular expression, as ForLog(pattern).AsRegexp(). The regular expression is compiled
+// right away, panicking if it's invalid, as regexp.MustCompile.
+//
+// For Example:
+//
+//	wait.
+//		ForLogRegex("consumer group .* rebalanced").
+//		WithOccurrence(3).
+//		WithStream(wait.LogStreamStderr)
+func ForLogRegex(pattern string) *LogStrategy {
+	return NewLogStrategy(pattern).AsRegexp()
+}
+
 func (ws *LogStrategy) Timeout() *time.Duration {
 	return ws.timeout
 }
 
 // WaitUntilReady implements Strategy.WaitUntilReady
 func (ws *LogStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	timeout := defaultStartupTimeout()
-	if ws.timeout != nil {
-		timeout = *ws.timeout
+	if ws.SubmatchCallback != nil && !ws.IsRegexp {
+		return errSubmatchCallbackNotRegexp
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
 	defer cancel()
 
 	length := 0
+	poller := newPoller(ws.backoff, ws.PollInterval)
+	prober := newProber(ctx, ws, ws.observer)
+	since := ws.since(ctx, target)
 
 LOOP:
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return prober.timeoutError(ctx.Err())
 		default:
+			prober.begin()
 			checkErr := checkTarget(ctx, target)
 
-			reader, err := target.Logs(ctx)
+			reader, err := ws.logs(ctx, target, since)
+			if errors.Is(err, errLogStreamNotSupported) {
+				return err
+			}
 			if err != nil {
-				time.Sleep(ws.PollInterval)
+				prober.observe(fmt.Errorf("read logs: %w", err))
+				time.Sleep(poller.next())
 				continue
 			}
 
 			b, err := io.ReadAll(reader)
 			if err != nil {
-				time.Sleep(ws.PollInterval)
+				prober.observe(fmt.Errorf("read logs: %w", err))
+				time.Sleep(poller.next())
 				continue
 			}
 
@@ -120,10 +262,17 @@ LOOP:
 			case length == len(logs) && checkErr != nil:
 				return checkErr
 			case checkLogsFn(ws, b):
+				if ws.SubmatchCallback != nil {
+					if err := ws.SubmatchCallback(ws.regexp().FindAllStringSubmatch(logs, -1)); err != nil {
+						return fmt.Errorf("submatch callback: %w", err)
+					}
+				}
+				prober.observe(nil)
 				break LOOP
 			default:
 				length = len(logs)
-				time.Sleep(ws.PollInterval)
+				prober.observe(fmt.Errorf("found %d of %d occurrences", occurrences(ws, b), ws.Occurrence))
+				time.Sleep(poller.next())
 				continue
 			}
 		}
@@ -132,14 +281,83 @@ LOOP:
 	return nil
 }
 
+// errLogStreamNotSupported is returned when waiting for the log of a single stream of a target
+// which can't read it.
+var errLogStreamNotSupported = errors.New("reading a single log stream is not supported by the target")
+
+// errSubmatchCallbackNotRegexp is returned when waiting for a plain text log with a submatch callback.
+var errSubmatchCallbackNotRegexp = errors.New("submatch callback requires a regular expression")
+
+// since returns the time the logs of the current start of the target are read since, or the zero
+// time to read all the logs: if the strategy isn't restart aware, if the container never exited,
+// or if its state can't be read.
+func (ws *LogStrategy) since(ctx context.Context, target StrategyTarget) time.Time {
+	if !ws.RestartAware {
+		return time.Time{}
+	}
+
+	state, err := target.State(ctx)
+	if err != nil || state == nil {
+		return time.Time{}
+	}
+
+	startedAt, err := time.Parse(time.RFC3339Nano, state.StartedAt)
+	if err != nil || startedAt.IsZero() {
+		return time.Time{}
+	}
+	finishedAt, err := time.Parse(time.RFC3339Nano, state.FinishedAt)
+	if err != nil || finishedAt.IsZero() {
+		// never exited: all the logs are from the current start.
+		return time.Time{}
+	}
+
+	since := startedAt.Add(-ws.ClockSkew)
+	if finishedAt.Before(startedAt) && since.Before(finishedAt) {
+		// the output of the previous run ends before it exited.
+		since = finishedAt
+	}
+
+	return since
+}
+
+// logs returns the logs of the selected streams of the target, since the given time if not zero
+// and supported by the target.
+func (ws *LogStrategy) logs(ctx context.Context, target StrategyTarget, since time.Time) (io.ReadCloser, error) {
+	if st, ok := target.(logsSinceTarget); ok && !since.IsZero() {
+		return st.LogsSince(ctx, since, ws.Stream != LogStreamStderr, ws.Stream != LogStreamStdout)
+	}
+
+	if ws.Stream == LogStreamBoth {
+		return target.Logs(ctx)
+	}
+
+	st, ok := target.(streamLogsTarget)
+	if !ok {
+		return nil, errLogStreamNotSupported
+	}
+
+	return st.StreamLogs(ctx, ws.Stream == LogStreamStdout, ws.Stream == LogStreamStderr)
+}
+
+// regexp returns the regular expression of the log.
+func (ws *LogStrategy) regexp() *regexp.Regexp {
+	if ws.re == nil || ws.re.String() != ws.Log {
+		// the fields were set directly, without AsRegexp.
+		return regexp.MustCompile(ws.Log)
+	}
+
+	return ws.re
+}
+
 func checkLogsFn(ws *LogStrategy, b []byte) bool {
-	if ws.IsRegexp {
-		re := regexp.MustCompile(ws.Log)
-		occurrences := re.FindAll(b, -1)
+	return occurrences(ws, b) >= ws.Occurrence
+}
 
-		return len(occurrences) >= ws.Occurrence
+// occurrences returns the number of occurrences of the log in the logs.
+func occurrences(ws *LogStrategy, b []byte) int {
+	if ws.IsRegexp {
+		return len(ws.regexp().FindAll(b, -1))
 	}
 
-	logs := string(b)
-	return strings.Count(logs, ws.Log) >= ws.Occurrence
+	return strings.Count(string(b), ws.Log)
 }
@@ -3,7 +3,9 @@ package wait
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -209,3 +211,223 @@ func TestWaitForLogFailsDueToUnexpectedContainerStatus(t *testing.T) {
 		require.EqualError(t, err, expected)
 	})
 }
+
+// streamLogsNopTarget is a NopStrategyTarget reading the logs of each stream separately.
+type streamLogsNopTarget struct {
+	NopStrategyTarget
+	stdout string
+	stderr string
+}
+
+func (st streamLogsNopTarget) StreamLogs(_ context.Context, stdout bool, stderr bool) (io.ReadCloser, error) {
+	var logs string
+	if stdout {
+		logs += st.stdout
+	}
+	if stderr {
+		logs += st.stderr
+	}
+	return io.NopCloser(bytes.NewReader([]byte(logs))), nil
+}
+
+func TestWaitForLogRegex(t *testing.T) {
+	t.Run("occurrences", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(
+				"consumer group a rebalanced\nconsumer group b rebalanced\nconsumer group c rebalanced\n",
+			))),
+		}
+
+		wg := ForLogRegex("consumer group .* rebalanced").WithOccurrence(3).WithStartupTimeout(100 * time.Millisecond)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	})
+
+	t.Run("invalid-regexp", func(t *testing.T) {
+		// the regular expression is compiled when the strategy is constructed.
+		require.Panics(t, func() { ForLogRegex("consumer group (") })
+		require.Panics(t, func() { ForLog("consumer group (").AsRegexp() })
+	})
+}
+
+func TestWaitForLogWithStream(t *testing.T) {
+	target := streamLogsNopTarget{
+		stdout: "consumer group a rebalanced\nconsumer group b rebalanced\nconsumer group c rebalanced\n",
+		stderr: "consumer group a rebalanced\n",
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		wg := ForLogRegex("consumer group .* rebalanced").
+			WithOccurrence(3).
+			WithStream(LogStreamStdout).
+			WithStartupTimeout(100 * time.Millisecond)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	})
+
+	t.Run("stderr", func(t *testing.T) {
+		wg := ForLogRegex("consumer group .* rebalanced").
+			WithOccurrence(3).
+			WithStream(LogStreamStderr).
+			WithStartupTimeout(100 * time.Millisecond)
+		require.Error(t, wg.WaitUntilReady(context.Background(), target))
+
+		wg.WithOccurrence(1)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+	})
+
+	t.Run("not-supported", func(t *testing.T) {
+		wg := ForLog("docker").WithStream(LogStreamStderr).WithStartupTimeout(100 * time.Millisecond)
+		err := wg.WaitUntilReady(context.Background(), target.NopStrategyTarget)
+		require.ErrorIs(t, err, errLogStreamNotSupported)
+	})
+}
+
+func TestWaitForLogWithSubmatchCallback(t *testing.T) {
+	logs := "starting server\nadmin token: s3cr3t\nlistening on port 8080\nlistening on port 8443\n"
+
+	t.Run("submatches", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(logs))),
+		}
+
+		// waitForLogSubmatch {
+		var token string
+		wg := ForLogRegex(`admin token: (\w+)`).
+			WithSubmatchCallback(func(matches [][]string) error {
+				token = matches[0][1]
+				return nil
+			})
+		// }
+		wg.WithStartupTimeout(100 * time.Millisecond)
+
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+		require.Equal(t, "s3cr3t", token)
+	})
+
+	t.Run("all-occurrences", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(logs))),
+		}
+
+		var ports []string
+		wg := ForLogRegex(`listening on port (\d+)`).
+			WithOccurrence(2).
+			WithSubmatchCallback(func(matches [][]string) error {
+				for _, m := range matches {
+					ports = append(ports, m[1])
+				}
+				return nil
+			}).
+			WithStartupTimeout(100 * time.Millisecond)
+
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+		require.Equal(t, []string{"8080", "8443"}, ports)
+	})
+
+	t.Run("callback-error", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(logs))),
+		}
+
+		errToken := errors.New("unexpected token")
+		wg := ForLogRegex(`admin token: (\w+)`).
+			WithSubmatchCallback(func(_ [][]string) error {
+				return errToken
+			}).
+			WithStartupTimeout(100 * time.Millisecond)
+
+		require.ErrorIs(t, wg.WaitUntilReady(context.Background(), target), errToken)
+	})
+
+	t.Run("not-regexp", func(t *testing.T) {
+		target := NopStrategyTarget{
+			ReaderCloser: io.NopCloser(bytes.NewReader([]byte(logs))),
+		}
+
+		called := false
+		wg := ForLog("admin token").
+			WithSubmatchCallback(func(_ [][]string) error {
+				called = true
+				return nil
+			}).
+			WithStartupTimeout(100 * time.Millisecond)
+
+		require.ErrorIs(t, wg.WaitUntilReady(context.Background(), target), errSubmatchCallbackNotRegexp)
+		require.False(t, called)
+	})
+}
+
+// logsSinceNopTarget is a NopStrategyTarget reading the lines of its logs written since a given time.
+type logsSinceNopTarget struct {
+	NopStrategyTarget
+	lines map[time.Time]string
+	since *time.Time
+}
+
+func (st logsSinceNopTarget) LogsSince(_ context.Context, since time.Time, _ bool, _ bool) (io.ReadCloser, error) {
+	*st.since = since
+
+	var logs string
+	for at, line := range st.lines {
+		if !at.Before(since) {
+			logs += line + "\n"
+		}
+	}
+	return io.NopCloser(strings.NewReader(logs)), nil
+}
+
+func TestWaitForLogRestartAware(t *testing.T) {
+	firstStart := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	finished := firstStart.Add(10 * time.Second)
+	secondStart := finished.Add(200 * time.Millisecond)
+
+	restarted := func(since *time.Time) logsSinceNopTarget {
+		return logsSinceNopTarget{
+			NopStrategyTarget: NopStrategyTarget{
+				ReaderCloser: io.NopCloser(strings.NewReader("ready\n")),
+				ContainerState: types.ContainerState{
+					Running:    true,
+					StartedAt:  secondStart.Format(time.RFC3339Nano),
+					FinishedAt: finished.Format(time.RFC3339Nano),
+				},
+			},
+			lines: map[time.Time]string{
+				firstStart.Add(time.Second): "ready",
+				secondStart:                 "starting",
+			},
+			since: since,
+		}
+	}
+
+	t.Run("restarted", func(t *testing.T) {
+		var since time.Time
+		wg := ForLog("ready").WithStartupTimeout(100 * time.Millisecond)
+		require.ErrorIs(t, wg.WaitUntilReady(context.Background(), restarted(&since)), context.DeadlineExceeded)
+
+		// the clock skew doesn't go past the end of the previous run.
+		require.Equal(t, finished, since)
+	})
+
+	t.Run("clock-skew", func(t *testing.T) {
+		var since time.Time
+		wg := ForLog("ready").WithClockSkew(100 * time.Millisecond).WithStartupTimeout(100 * time.Millisecond)
+		require.Error(t, wg.WaitUntilReady(context.Background(), restarted(&since)))
+		require.Equal(t, secondStart.Add(-100*time.Millisecond), since)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var since time.Time
+		wg := ForLog("ready").WithRestartAware(false).WithStartupTimeout(100 * time.Millisecond)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), restarted(&since)))
+		require.True(t, since.IsZero())
+	})
+
+	t.Run("never-exited", func(t *testing.T) {
+		var since time.Time
+		target := restarted(&since)
+		target.ContainerState.FinishedAt = "0001-01-01T00:00:00Z"
+
+		wg := ForLog("ready").WithStartupTimeout(100 * time.Millisecond)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target))
+		require.True(t, since.IsZero())
+	})
+}
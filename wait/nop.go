@@ -39,6 +39,13 @@ func (ws *NopStrategy) WithStartupTimeout(timeout time.Duration) *NopStrategy {
 }
 
 func (ws *NopStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	// the startup timeout of the strategy combining it applies when it has no timeout of its own
+	if timeout, ok := contextStartupTimeout(ctx); ok && ws.timeout == nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	return ws.waitUntilReady(ctx, target)
 }
 
@@ -0,0 +1,124 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProbeEvent describes an attempt of a wait strategy to check if the container is ready,
+// e.g. an HTTP request, see the WithObserver method of the strategies.
+type ProbeEvent struct {
+	Strategy string        // description of the strategy, e.g. "gRPC health of the server on port 50051/tcp"
+	Attempt  int           // number of the attempt, starting at 1
+	Duration time.Duration // duration of the attempt
+	Err      error         // reason why the container is not ready yet, nil if the attempt passed
+}
+
+// ProbeObserver is notified of each attempt of a wait strategy, see the WithObserver method of the strategies.
+// It's called synchronously by the strategy, so it must not block.
+type ProbeObserver func(evt ProbeEvent)
+
+// observerKey is the key of the observer set by a MultiStrategy or an AnyStrategy for their strategies.
+type observerKey struct{}
+
+// withObserver returns a context holding the observer of the strategies without their own observer,
+// unless the observer is nil.
+func withObserver(ctx context.Context, observer ProbeObserver) context.Context {
+	if observer == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+// LogObserver returns an observer logging the attempts of a strategy with the given logger, e.g.
+// testcontainers.Logger, every given number of failing attempts, and the attempt passing.
+// Every attempt is logged if every is lower than 1.
+//
+// If the logger has leveled methods, e.g. testcontainers.LeveledLogging, the passing attempt is logged
+// at the info level and the failing ones at the warn level. Otherwise, they are all logged with Printf.
+func LogObserver(logger interface{ Printf(format string, v ...any) }, every int) ProbeObserver {
+	if every < 1 {
+		every = 1
+	}
+
+	infof, warnf := logger.Printf, logger.Printf
+	if l, ok := logger.(leveledLogger); ok {
+		infof, warnf = l.Infof, l.Warnf
+	}
+
+	return func(evt ProbeEvent) {
+		switch {
+		case evt.Err == nil:
+			infof("✅ Waiting for %s: passed at attempt %d", evt.Strategy, evt.Attempt)
+		case evt.Attempt%every == 0:
+			warnf("⏳ Waiting for %s: attempt %d failed after %s: %v", evt.Strategy, evt.Attempt, evt.Duration, evt.Err)
+		}
+	}
+}
+
+// leveledLogger is the subset of the leveled methods of testcontainers.LeveledLogging used by LogObserver.
+type leveledLogger interface {
+	Infof(format string, v ...any)
+	Warnf(format string, v ...any)
+}
+
+// prober counts the attempts of a single wait of a strategy, notifying the observer of the strategy,
+// or the one of the enclosing MultiStrategy or AnyStrategy if the strategy has none.
+type prober struct {
+	strategy Strategy
+	observer ProbeObserver
+	attempts int
+	start    time.Time // the start of the current attempt
+	lastErr  error     // the error of the last failing attempt
+}
+
+// newProber returns a prober for a single wait of the strategy.
+func newProber(ctx context.Context, strategy Strategy, observer ProbeObserver) *prober {
+	if observer == nil {
+		observer, _ = ctx.Value(observerKey{}).(ProbeObserver)
+	}
+
+	return &prober{
+		strategy: strategy,
+		observer: observer,
+	}
+}
+
+// begin marks the start of an attempt.
+func (p *prober) begin() {
+	p.start = time.Now()
+}
+
+// observe records the current attempt, failing with the given error, or passing if it's nil,
+// and notifies the observer.
+func (p *prober) observe(err error) {
+	p.attempts++
+	if err != nil {
+		p.lastErr = err
+	}
+
+	if p.observer != nil {
+		p.observer(ProbeEvent{
+			Strategy: describe(p.strategy),
+			Attempt:  p.attempts,
+			Duration: time.Since(p.start),
+			Err:      err,
+		})
+	}
+}
+
+// timeoutError annotates the error ending the wait, usually the one of the context, with the number
+// of attempts and the error of the last failing one, e.g. "context deadline exceeded after 12 attempts:
+// dial tcp 127.0.0.1:32768: connect: connection refused".
+func (p *prober) timeoutError(err error) error {
+	switch {
+	case p.attempts == 0:
+		return err
+	case p.lastErr == nil:
+		return fmt.Errorf("%w after %d attempts", err, p.attempts)
+	default:
+		return fmt.Errorf("%w after %d attempts: %w", err, p.attempts, p.lastErr)
+	}
+}
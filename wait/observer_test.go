@@ -0,0 +1,184 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// logTarget returns a target whose logs are the given ones, read anew at each attempt.
+func logTarget(logs string) *MockStrategyTarget {
+	return &MockStrategyTarget{
+		LogsImpl: func(_ context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte(logs))), nil
+		},
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+	}
+}
+
+// eventRecorder is an observer recording the events, safe for concurrent use.
+type eventRecorder struct {
+	mtx    sync.Mutex
+	events []ProbeEvent
+}
+
+func (r *eventRecorder) observe(evt ProbeEvent) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.events = append(r.events, evt)
+}
+
+func (r *eventRecorder) recorded() []ProbeEvent {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([]ProbeEvent(nil), r.events...)
+}
+
+func TestWithObserver(t *testing.T) {
+	t.Run("passing", func(t *testing.T) {
+		var rec eventRecorder
+		err := ForLog("ready").
+			WithOccurrence(2).
+			WithPollInterval(10*time.Millisecond).
+			WithObserver(rec.observe).
+			WaitUntilReady(context.Background(), logTarget("ready\nready\n"))
+		if err != nil {
+			t.Fatalf("expected the strategy to pass, got %v", err)
+		}
+
+		events := rec.recorded()
+		if len(events) != 1 {
+			t.Fatalf("expected a single attempt, got %v", events)
+		}
+		if evt := events[0]; evt.Attempt != 1 || evt.Err != nil || evt.Strategy != `log "ready"` {
+			t.Fatalf("unexpected event %+v", evt)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		var rec eventRecorder
+		err := ForLog("ready").
+			WithStartupTimeout(200*time.Millisecond).
+			WithPollInterval(10*time.Millisecond).
+			WithObserver(rec.observe).
+			WaitUntilReady(context.Background(), logTarget("starting\n"))
+		if err == nil {
+			t.Fatal("expected the strategy to time out")
+		}
+
+		events := rec.recorded()
+		if len(events) < 2 {
+			t.Fatalf("expected several attempts, got %v", events)
+		}
+		for i, evt := range events {
+			if evt.Attempt != i+1 || evt.Err == nil || evt.Err.Error() != "found 0 of 1 occurrences" {
+				t.Fatalf("unexpected event %+v", evt)
+			}
+		}
+
+		// the error reports the number of attempts and the error of the last one.
+		want := fmt.Sprintf("context deadline exceeded after %d attempts: found 0 of 1 occurrences", len(events))
+		if err.Error() != want {
+			t.Fatalf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		var rec, own eventRecorder
+
+		// the observer of the composite is used by the strategies without their own observer.
+		err := ForAll(
+			ForLog("ready").WithPollInterval(10*time.Millisecond),
+			ForAny(
+				ForLog("started").WithPollInterval(10*time.Millisecond).WithObserver(own.observe),
+			),
+		).WithObserver(rec.observe).
+			WaitUntilReady(context.Background(), logTarget("ready\nstarted\n"))
+		if err != nil {
+			t.Fatalf("expected the strategies to pass, got %v", err)
+		}
+
+		if events := rec.recorded(); len(events) != 1 || events[0].Strategy != `log "ready"` {
+			t.Fatalf("unexpected events of the composite observer %v", events)
+		}
+		if events := own.recorded(); len(events) != 1 || events[0].Strategy != `log "started"` {
+			t.Fatalf("unexpected events of the own observer %v", events)
+		}
+	})
+}
+
+// fakeLogger records the formatted messages.
+type fakeLogger struct {
+	messages []string
+}
+
+func (l *fakeLogger) Printf(format string, v ...any) {
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+// fakeLeveledLogger records the formatted messages, prefixed with their level.
+type fakeLeveledLogger struct {
+	fakeLogger
+}
+
+func (l *fakeLeveledLogger) Infof(format string, v ...any) {
+	l.Printf("INFO "+format, v...)
+}
+
+func (l *fakeLeveledLogger) Warnf(format string, v ...any) {
+	l.Printf("WARN "+format, v...)
+}
+
+func TestLogObserver(t *testing.T) {
+	logger := &fakeLogger{}
+
+	// waitObserver {
+	strategy := ForLog("ready").
+		WithStartupTimeout(time.Second).
+		WithObserver(LogObserver(logger, 3))
+	// }
+
+	errNotReady := errors.New("not ready")
+	observer := strategy.observer
+	for i := 1; i <= 7; i++ {
+		observer(ProbeEvent{Strategy: strategy.String(), Attempt: i, Duration: time.Millisecond, Err: errNotReady})
+	}
+	observer(ProbeEvent{Strategy: strategy.String(), Attempt: 8, Duration: time.Millisecond})
+
+	want := []string{
+		`⏳ Waiting for log "ready": attempt 3 failed after 1ms: not ready`,
+		`⏳ Waiting for log "ready": attempt 6 failed after 1ms: not ready`,
+		`✅ Waiting for log "ready": passed at attempt 8`,
+	}
+	if got := strings.Join(logger.messages, "\n"); got != strings.Join(want, "\n") {
+		t.Fatalf("expected %q, got %q", want, logger.messages)
+	}
+
+	t.Run("leveled", func(t *testing.T) {
+		logger := &fakeLeveledLogger{}
+		observer := LogObserver(logger, 1)
+
+		observer(ProbeEvent{Strategy: `log "ready"`, Attempt: 1, Duration: time.Millisecond, Err: errNotReady})
+		observer(ProbeEvent{Strategy: `log "ready"`, Attempt: 2, Duration: time.Millisecond})
+
+		want := []string{
+			`WARN ⏳ Waiting for log "ready": attempt 1 failed after 1ms: not ready`,
+			`INFO ✅ Waiting for log "ready": passed at attempt 2`,
+		}
+		if got := strings.Join(logger.messages, "\n"); got != strings.Join(want, "\n") {
+			t.Fatalf("expected %q, got %q", want, logger.messages)
+		}
+	})
+}
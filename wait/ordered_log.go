@@ -0,0 +1,222 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*OrderedLogStrategy)(nil)
+	_ StrategyTimeout = (*OrderedLogStrategy)(nil)
+)
+
+// OrderedLogStrategy will wait until the given log entries show up in the docker logs, in order,
+// e.g. a line logged when the database is migrated, then a line logged when the server listens.
+// Each pattern is looked for after the end of the match of the previous one, any output in between
+// being ignored, so that a pattern logged before the previous one doesn't count.
+type OrderedLogStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+
+	// additional properties
+	Patterns     []string
+	IsRegexp     []bool // whether the pattern at the same index is a regular expression, plain text otherwise
+	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+
+	// RestartAware only looks for the logs in the output of the current start of the container,
+	// see LogStrategy.WithRestartAware.
+	RestartAware bool
+
+	// res are the regular expressions of the patterns, compiled when the strategy is constructed.
+	res []*regexp.Regexp
+}
+
+// errNoLogPatterns is returned when waiting for ordered logs without any pattern.
+var errNoLogPatterns = errors.New("no log patterns to wait for")
+
+// NewOrderedLogStrategy constructs with polling interval of 100 milliseconds and startup timeout of 60 seconds by default.
+// The patterns are plain text, unless marked as regular expressions with AsRegexp.
+func NewOrderedLogStrategy(patterns ...string) *OrderedLogStrategy {
+	return &OrderedLogStrategy{
+		Patterns:     patterns,
+		IsRegexp:     make([]bool, len(patterns)),
+		PollInterval: defaultPollInterval(),
+		RestartAware: true,
+		res:          make([]*regexp.Regexp, len(patterns)),
+	}
+}
+
+// ForOrderedLogs is the default construction for the fluid interface.
+//
+// For Example:
+//
+//	wait.
+//		ForOrderedLogs("migrations applied", `listening on port \d+`).
+//		AsRegexp(1).
+//		WithStartupTimeout(30 * time.Second)
+func ForOrderedLogs(patterns ...string) *OrderedLogStrategy {
+	return NewOrderedLogStrategy(patterns...)
+}
+
+// AsRegexp marks the patterns at the given indexes as regular expressions, or all the patterns
+// without indexes. The regular expressions are compiled right away, panicking if one is invalid,
+// as regexp.MustCompile, or if an index is out of range. A regular expression can span several
+// lines, e.g. with `(?s)`.
+func (ws *OrderedLogStrategy) AsRegexp(indexes ...int) *OrderedLogStrategy {
+	if len(indexes) == 0 {
+		for i := range ws.Patterns {
+			indexes = append(indexes, i)
+		}
+	}
+
+	for _, i := range indexes {
+		ws.IsRegexp[i] = true
+		ws.res[i] = regexp.MustCompile(ws.Patterns[i])
+	}
+
+	return ws
+}
+
+// WithRestartAware can be used to only look for the logs in the output of the current start of the container,
+// which is the default, or in all its output with false, see LogStrategy.WithRestartAware.
+func (ws *OrderedLogStrategy) WithRestartAware(restartAware bool) *OrderedLogStrategy {
+	ws.RestartAware = restartAware
+	return ws
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *OrderedLogStrategy) WithStartupTimeout(timeout time.Duration) *OrderedLogStrategy {
+	ws.timeout = &timeout
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *OrderedLogStrategy) WithPollInterval(pollInterval time.Duration) *OrderedLogStrategy {
+	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
+	return ws
+}
+
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *OrderedLogStrategy) WithBackoff(b Backoff) *OrderedLogStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each attempt to find the logs, e.g. with LogObserver
+func (ws *OrderedLogStrategy) WithObserver(o ProbeObserver) *OrderedLogStrategy {
+	ws.observer = o
+	return ws
+}
+
+// String returns a human-readable description of the wait strategy.
+func (ws *OrderedLogStrategy) String() string {
+	return fmt.Sprintf("ordered logs %q", ws.Patterns)
+}
+
+func (ws *OrderedLogStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *OrderedLogStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	if len(ws.Patterns) == 0 {
+		return errNoLogPatterns
+	}
+
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
+	defer cancel()
+
+	// the logs are read as the ones of a log strategy, on both streams.
+	reader := &LogStrategy{RestartAware: ws.RestartAware, ClockSkew: defaultLogClockSkew}
+
+	length := 0
+	poller := newPoller(ws.backoff, ws.PollInterval)
+	prober := newProber(ctx, ws, ws.observer)
+	since := reader.since(ctx, target)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return prober.timeoutError(ctx.Err())
+		default:
+			prober.begin()
+			checkErr := checkTarget(ctx, target)
+
+			rc, err := reader.logs(ctx, target, since)
+			if err != nil {
+				prober.observe(fmt.Errorf("read logs: %w", err))
+				time.Sleep(poller.next())
+				continue
+			}
+
+			b, err := io.ReadAll(rc)
+			if err != nil {
+				prober.observe(fmt.Errorf("read logs: %w", err))
+				time.Sleep(poller.next())
+				continue
+			}
+
+			logs := string(b)
+			matched := ws.matched(logs)
+
+			switch {
+			case matched == len(ws.Patterns):
+				prober.observe(nil)
+				return nil
+			case length == len(logs) && checkErr != nil:
+				return checkErr
+			default:
+				length = len(logs)
+				prober.observe(fmt.Errorf("pattern %d %q not matched, %d of %d patterns matched in order",
+					matched, ws.Patterns[matched], matched, len(ws.Patterns)))
+				time.Sleep(poller.next())
+			}
+		}
+	}
+}
+
+// matched returns the number of patterns matched in order in the logs, each one being looked for
+// after the end of the earliest match of the previous one.
+func (ws *OrderedLogStrategy) matched(logs string) int {
+	offset := 0
+	for i, pattern := range ws.Patterns {
+		var end int
+		if i < len(ws.IsRegexp) && ws.IsRegexp[i] {
+			loc := ws.regexp(i).FindStringIndex(logs[offset:])
+			if loc == nil {
+				return i
+			}
+			end = loc[1]
+		} else {
+			idx := strings.Index(logs[offset:], pattern)
+			if idx < 0 {
+				return i
+			}
+			end = idx + len(pattern)
+		}
+
+		offset += end
+	}
+
+	return len(ws.Patterns)
+}
+
+// regexp returns the regular expression of the pattern at the given index.
+func (ws *OrderedLogStrategy) regexp(i int) *regexp.Regexp {
+	if i >= len(ws.res) || ws.res[i] == nil || ws.res[i].String() != ws.Patterns[i] {
+		// the fields were set directly, without AsRegexp.
+		return regexp.MustCompile(ws.Patterns[i])
+	}
+
+	return ws.res[i]
+}
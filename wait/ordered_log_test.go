@@ -0,0 +1,76 @@
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const orderedLogs = `starting server
+listening on port 8080
+applying migrations
+migrations applied
+listening on port 8081
+`
+
+func TestWaitForOrderedLogs(t *testing.T) {
+	target := func() StrategyTarget {
+		return logTarget(orderedLogs)
+	}
+
+	t.Run("in-order", func(t *testing.T) {
+		// waitForOrderedLogs {
+		wg := ForOrderedLogs("migrations applied", `listening on port \d+`).
+			AsRegexp(1).
+			WithStartupTimeout(100 * time.Millisecond)
+		// }
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target()))
+	})
+
+	t.Run("interleaved-noise", func(t *testing.T) {
+		wg := ForOrderedLogs("starting", "migrations", "8081").WithStartupTimeout(100 * time.Millisecond)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target()))
+	})
+
+	t.Run("multiline-regexp", func(t *testing.T) {
+		wg := ForOrderedLogs(`(?s)applying.*applied`).AsRegexp().WithStartupTimeout(100 * time.Millisecond)
+		require.NoError(t, wg.WaitUntilReady(context.Background(), target()))
+	})
+
+	t.Run("out-of-order", func(t *testing.T) {
+		// the only "listening on port 8080" line is logged before the migrations.
+		wg := ForOrderedLogs("starting", "migrations applied", "listening on port 8080").
+			WithStartupTimeout(100 * time.Millisecond).
+			WithPollInterval(10 * time.Millisecond)
+
+		err := wg.WaitUntilReady(context.Background(), target())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, `pattern 2 "listening on port 8080" not matched, 2 of 3 patterns matched in order`)
+	})
+
+	t.Run("no-patterns", func(t *testing.T) {
+		err := ForOrderedLogs().WaitUntilReady(context.Background(), target())
+		require.ErrorIs(t, err, errNoLogPatterns)
+	})
+}
+
+func TestOrderedLogStrategy_matched(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     int
+	}{
+		{name: "none", patterns: []string{"stopped"}, want: 0},
+		{name: "first", patterns: []string{"starting", "stopped"}, want: 1},
+		{name: "same-line", patterns: []string{"listening", "port", "8080"}, want: 3},
+		{name: "repeated", patterns: []string{"listening", "listening", "listening"}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ForOrderedLogs(tt.patterns...).matched(orderedLogs))
+		})
+	}
+}
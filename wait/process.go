@@ -0,0 +1,233 @@
+package wait
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// Implement interface
+var (
+	_ Strategy        = (*ProcessStrategy)(nil)
+	_ StrategyTimeout = (*ProcessStrategy)(nil)
+)
+
+// processPatternEnv is the environment variable used to pass the pattern to the script,
+// so that the pattern is not part of the command line of the script, which would match it.
+const processPatternEnv = "TC_WAIT_PROCESS_PATTERN"
+
+// processScript prints the system uptime and the clock ticks per second, followed by the
+// PID and the start time, in clock ticks since boot, of each process whose command line
+// matches the pattern. It uses pgrep when available, falling back to scanning /proc.
+const processScript = `printf '%s %s\n' "$(cut -d' ' -f1 /proc/uptime)" "$(getconf CLK_TCK 2>/dev/null || echo 100)"
+if command -v pgrep >/dev/null 2>&1; then
+	pids=$(pgrep -f -- "$` + processPatternEnv + `")
+else
+	pids=""
+	for d in /proc/[0-9]*; do
+		[ -r "$d/cmdline" ] || continue
+		if tr '\000' ' ' < "$d/cmdline" | grep -qE -e "$` + processPatternEnv + `"; then
+			pids="$pids ${d#/proc/}"
+		fi
+	done
+fi
+for pid in $pids; do
+	[ "$pid" = "$$" ] && continue
+	stat=$(cat "/proc/$pid/stat" 2>/dev/null) || continue
+	echo "$pid $(echo "${stat##*) }" | cut -d' ' -f20)"
+done`
+
+// ProcessStrategy will wait until a process whose command line matches a pattern
+// is running in the container, which is useful for images whose init process forks
+// or re-execs the actual service, so that PID 1 running does not mean the service is up.
+// The container must include a shell, as the processes are looked up using
+// pgrep, falling back to scanning /proc when pgrep is not available.
+type ProcessStrategy struct {
+	// all Strategies should have a startupTimeout to avoid waiting infinitely
+	timeout *time.Duration
+	pattern string
+
+	// additional properties
+	MinUptime    time.Duration
+	PollInterval time.Duration
+	backoff      Backoff
+	observer     ProbeObserver
+}
+
+// NewProcessStrategy constructs a process strategy for the given extended regular expression,
+// matched against the full command line of the processes in the container.
+func NewProcessStrategy(pattern string) *ProcessStrategy {
+	return &ProcessStrategy{
+		pattern:      pattern,
+		PollInterval: defaultPollInterval(),
+	}
+}
+
+// ForProcess is a convenience method to assign ProcessStrategy
+func ForProcess(pattern string) *ProcessStrategy {
+	return NewProcessStrategy(pattern)
+}
+
+// WithStartupTimeout can be used to change the default startup timeout
+func (ws *ProcessStrategy) WithStartupTimeout(startupTimeout time.Duration) *ProcessStrategy {
+	ws.timeout = &startupTimeout
+	return ws
+}
+
+// WithMinUptime requires the matching process to have been running for at least the given duration,
+// so that a crash-looping daemon, which is restarted over and over, is not considered ready.
+func (ws *ProcessStrategy) WithMinUptime(minUptime time.Duration) *ProcessStrategy {
+	ws.MinUptime = minUptime
+	return ws
+}
+
+// WithPollInterval can be used to override the default polling interval of 100 milliseconds
+func (ws *ProcessStrategy) WithPollInterval(pollInterval time.Duration) *ProcessStrategy {
+	ws.PollInterval = pollInterval
+	ws.backoff = ConstantBackoff(pollInterval)
+	return ws
+}
+
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (ws *ProcessStrategy) WithBackoff(b Backoff) *ProcessStrategy {
+	ws.backoff = b
+	return ws
+}
+
+// WithObserver can be used to be notified of each lookup of the process, e.g. with LogObserver
+func (ws *ProcessStrategy) WithObserver(o ProbeObserver) *ProcessStrategy {
+	ws.observer = o
+	return ws
+}
+
+func (ws *ProcessStrategy) Timeout() *time.Duration {
+	return ws.timeout
+}
+
+// String returns a human-readable description of the wait strategy.
+func (ws *ProcessStrategy) String() string {
+	if ws.MinUptime > 0 {
+		return fmt.Sprintf("process matching %q running for at least %s", ws.pattern, ws.MinUptime)
+	}
+
+	return fmt.Sprintf("process matching %q", ws.pattern)
+}
+
+// WaitUntilReady implements Strategy.WaitUntilReady
+func (ws *ProcessStrategy) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
+	ctx, cancel := withEffectiveDeadline(ctx, ws)
+	defer cancel()
+
+	shell, err := detectShell(ctx, target)
+	if err != nil {
+		if errors.Is(err, ErrNoShell) {
+			return fmt.Errorf("%s: %w: the processes of the container cannot be looked up, "+
+				"use a strategy that does not execute commands in the container instead, e.g. wait.ForLog, wait.ForHTTP or wait.ForListeningPort", ws, err)
+		}
+		return fmt.Errorf("%s: detect shell: %w", ws, err)
+	}
+
+	prober := newProber(ctx, ws, ws.observer)
+	poller := newPoller(ws.backoff, ws.PollInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return prober.timeoutError(fmt.Errorf("%w: %s", ctx.Err(), ws))
+		case <-time.After(poller.next()):
+			prober.begin()
+			if err := checkTarget(ctx, target); err != nil {
+				return err
+			}
+
+			_, reader, err := target.Exec(ctx, []string{shell, "-c", processScript},
+				tcexec.WithEnv([]string{processPatternEnv + "=" + ws.pattern}), tcexec.Multiplexed())
+			if err != nil {
+				return fmt.Errorf("exec process lookup: %w", err)
+			}
+
+			output, err := io.ReadAll(reader)
+			if err != nil {
+				return fmt.Errorf("read process lookup: %w", err)
+			}
+
+			uptimes, err := parseProcessUptimes(output)
+			if err != nil {
+				return fmt.Errorf("%s: %w", ws, err)
+			}
+
+			var lastUptime time.Duration
+			for _, uptime := range uptimes {
+				if uptime >= ws.MinUptime {
+					prober.observe(nil)
+					return nil
+				}
+
+				lastUptime = max(lastUptime, uptime)
+			}
+
+			if lastUptime > 0 {
+				prober.observe(fmt.Errorf("process found but running for %s only", lastUptime))
+			} else {
+				prober.observe(errProcessNotFound)
+			}
+		}
+	}
+}
+
+// errProcessNotFound is the reason why a ProcessStrategy is not ready when no process matches.
+var errProcessNotFound = errors.New("process not found")
+
+// parseProcessUptimes parses the output of the process script, returning for how long
+// each of the matching processes has been running.
+func parseProcessUptimes(output []byte) ([]time.Duration, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+
+	var (
+		systemUptime float64
+		clockTicks   float64
+		header       bool
+		uptimes      []time.Duration
+	)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			// skip any diagnostic output
+			continue
+		}
+
+		if !header {
+			var err error
+			if systemUptime, err = strconv.ParseFloat(fields[0], 64); err != nil {
+				return nil, fmt.Errorf("read system uptime: %q: %w", scanner.Text(), err)
+			}
+			if clockTicks, err = strconv.ParseFloat(fields[1], 64); err != nil || clockTicks <= 0 {
+				return nil, fmt.Errorf("read clock ticks: %q", scanner.Text())
+			}
+			header = true
+			continue
+		}
+
+		startTime, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			// the process exited before its stat was read.
+			continue
+		}
+
+		uptime := systemUptime - startTime/clockTicks
+		uptimes = append(uptimes, time.Duration(max(uptime, 0)*float64(time.Second)))
+	}
+
+	if !header {
+		return nil, fmt.Errorf("unexpected process lookup output: %q", strings.TrimSpace(string(output)))
+	}
+
+	return uptimes, scanner.Err()
+}
@@ -0,0 +1,176 @@
+package wait_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// processTarget returns a mock target whose process lookup returns the given outputs,
+// one per call, repeating the last one.
+func processTarget(exitCode int, outputs ...string) (*wait.MockStrategyTarget, *int) {
+	calls := 0
+	return &wait.MockStrategyTarget{
+		StateImpl: func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Running: true}, nil
+		},
+		ExecImpl: func(_ context.Context, cmd []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+			if cmd[len(cmd)-1] == "exit 0" {
+				// shell detection
+				return exitCode, bytes.NewReader(nil), nil
+			}
+
+			output := outputs[min(calls, len(outputs)-1)]
+			calls++
+
+			return exitCode, bytes.NewReader([]byte(output)), nil
+		},
+	}, &calls
+}
+
+func TestProcessStrategy(t *testing.T) {
+	t.Run("process-found", func(t *testing.T) {
+		target, calls := processTarget(0,
+			"100.00 100\n",
+			"100.50 100\n",
+			"101.00 100\n42 10000\n",
+		)
+
+		err := wait.ForProcess("^postfix").
+			WithPollInterval(10*time.Millisecond).
+			WithStartupTimeout(time.Second).
+			WaitUntilReady(context.Background(), target)
+		require.NoError(t, err)
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("min-uptime", func(t *testing.T) {
+		target, calls := processTarget(0,
+			// the process started at 100s, and the system has been up for 100.5s
+			"100.50 100\n42 10000\n",
+			// the process restarted at 101s
+			"101.20 100\n43 10100\n",
+			"103.20 100\n43 10100\n",
+		)
+
+		err := wait.ForProcess("^postfix").
+			WithMinUptime(2*time.Second).
+			WithPollInterval(10*time.Millisecond).
+			WithStartupTimeout(time.Second).
+			WaitUntilReady(context.Background(), target)
+		require.NoError(t, err)
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("crash-looping-process", func(t *testing.T) {
+		target, _ := processTarget(0, "100.50 100\n42 10000\n")
+
+		err := wait.ForProcess("^postfix").
+			WithMinUptime(2*time.Second).
+			WithPollInterval(10*time.Millisecond).
+			WithStartupTimeout(200*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "process found but running for 500ms only")
+	})
+
+	t.Run("process-not-found", func(t *testing.T) {
+		target, _ := processTarget(0, "100.00 100\n")
+
+		err := wait.ForProcess("^postfix").
+			WithPollInterval(10*time.Millisecond).
+			WithStartupTimeout(200*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, `process matching "^postfix"`)
+		require.ErrorContains(t, err, "attempts: process not found")
+	})
+
+	t.Run("no-shell", func(t *testing.T) {
+		target, calls := processTarget(127, `OCI runtime exec failed: exec failed: unable to start container process: exec: "sh": executable file not found in $PATH: unknown`)
+
+		err := wait.ForProcess("^postfix").
+			WithPollInterval(10*time.Millisecond).
+			WithStartupTimeout(time.Second).
+			WaitUntilReady(context.Background(), target)
+		require.ErrorIs(t, err, wait.ErrNoShell)
+		require.ErrorContains(t, err, "use a strategy that does not execute commands in the container instead")
+		require.Zero(t, *calls)
+	})
+
+	t.Run("container-exited", func(t *testing.T) {
+		target, _ := processTarget(0, "100.00 100\n")
+		target.StateImpl = func(_ context.Context) (*types.ContainerState, error) {
+			return &types.ContainerState{Status: "exited", ExitCode: 1}, nil
+		}
+
+		err := wait.ForProcess("^postfix").
+			WithPollInterval(10*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.EqualError(t, err, "container exited with code 1")
+	})
+
+	t.Run("exec-error", func(t *testing.T) {
+		target, _ := processTarget(0, "")
+		target.ExecImpl = func(_ context.Context, _ []string, _ ...tcexec.ProcessOption) (int, io.Reader, error) {
+			return 0, nil, errors.New("exec failure")
+		}
+
+		err := wait.ForProcess("^postfix").
+			WithPollInterval(10*time.Millisecond).
+			WaitUntilReady(context.Background(), target)
+		require.EqualError(t, err, `process matching "^postfix": detect shell: exec /bin/sh: exec failure`)
+	})
+}
+
+func TestProcessStrategyWithContainer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("forked-service", func(t *testing.T) {
+		// waitForProcess {
+		req := testcontainers.ContainerRequest{
+			Image: "docker.io/busybox",
+			// PID 1 is the shell, which starts the actual service after a few seconds
+			Cmd: []string{"sh", "-c", "sleep 3; httpd -f -p 8080"},
+			WaitingFor: wait.ForProcess("^httpd -f").
+				WithMinUptime(time.Second).
+				WithStartupTimeout(30 * time.Second),
+		}
+		// }
+
+		start := time.Now()
+		ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: req,
+			Started:          true,
+		})
+		if ctr != nil {
+			t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+		}
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 4*time.Second)
+	})
+
+	t.Run("shell-less-image", func(t *testing.T) {
+		ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: testcontainers.ContainerRequest{
+				Image:      "registry.k8s.io/pause:3.9",
+				WaitingFor: wait.ForProcess("^pause").WithStartupTimeout(10 * time.Second),
+			},
+			Started: true,
+		})
+		if ctr != nil {
+			t.Cleanup(func() { require.NoError(t, ctr.Terminate(ctx)) })
+		}
+		require.ErrorIs(t, err, wait.ErrNoShell)
+	})
+}
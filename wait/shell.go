@@ -0,0 +1,30 @@
+package wait
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go/internal/core"
+)
+
+// ErrNoShell is returned by the strategies executing shell commands in a container
+// that does not include any shell, such as distroless images.
+var ErrNoShell = core.ErrNoShell
+
+// shellTarget is implemented by the targets detecting and caching the shell
+// available in the container, such as testcontainers.DockerContainer.
+type shellTarget interface {
+	Shell(context.Context) (string, error)
+}
+
+// detectShell returns the path of the first shell available in the target, or ErrNoShell
+// if none is available. The detection of the target is used if it implements it.
+func detectShell(ctx context.Context, target StrategyTarget) (string, error) {
+	if st, ok := target.(shellTarget); ok {
+		return st.Shell(ctx)
+	}
+
+	return core.DetectShell(ctx, func(ctx context.Context, cmd []string) (int, error) {
+		exitCode, _, err := target.Exec(ctx, cmd)
+		return exitCode, err
+	})
+}
@@ -3,7 +3,10 @@ package wait
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"maps"
+	"net/url"
 	"time"
 
 	"github.com/docker/go-connections/nat"
@@ -12,6 +15,7 @@ import (
 var (
 	_ Strategy        = (*waitForSql)(nil)
 	_ StrategyTimeout = (*waitForSql)(nil)
+	_ StrategyPorts   = (*waitForSql)(nil)
 )
 
 const defaultForSqlQuery = "SELECT 1"
@@ -36,7 +40,16 @@ type waitForSql struct {
 	Port           nat.Port
 	startupTimeout time.Duration
 	PollInterval   time.Duration
+	backoff        Backoff
+	observer       ProbeObserver
 	query          string
+
+	// URLParams are the connection parameters of the database, see WithURLParams.
+	URLParams map[string]string
+	// URLBuilder builds the URL of the database, replacing URL, see WithURLBuilder.
+	URLBuilder func(host string, port nat.Port, params map[string]string) string
+	// QueryResultMatcher checks the result of the query, see WithQueryResultMatcher.
+	QueryResultMatcher func(rows *sql.Rows) bool
 }
 
 // WithStartupTimeout can be used to change the default startup timeout
@@ -48,6 +61,20 @@ func (w *waitForSql) WithStartupTimeout(timeout time.Duration) *waitForSql {
 // WithPollInterval can be used to override the default polling interval of 100 milliseconds
 func (w *waitForSql) WithPollInterval(pollInterval time.Duration) *waitForSql {
 	w.PollInterval = pollInterval
+	w.backoff = ConstantBackoff(pollInterval)
+	return w
+}
+
+// WithBackoff can be used to poll with the given backoff instead of the polling interval,
+// e.g. with an exponential backoff
+func (w *waitForSql) WithBackoff(b Backoff) *waitForSql {
+	w.backoff = b
+	return w
+}
+
+// WithObserver can be used to be notified of each attempt to run the query, e.g. with LogObserver
+func (w *waitForSql) WithObserver(observer ProbeObserver) *waitForSql {
+	w.observer = observer
 	return w
 }
 
@@ -57,20 +84,61 @@ func (w *waitForSql) WithQuery(query string) *waitForSql {
 	return w
 }
 
+// WithQueryResultMatcher can be used to wait for the query to return an expected result, instead of
+// only executing without error, e.g. for a replica of a clustered Postgres database to be promoted:
+//
+//	wait.ForSQL("5432/tcp", "pgx", dbURL).
+//		WithQuery("SELECT pg_is_in_recovery()").
+//		WithQueryResultMatcher(func(rows *sql.Rows) bool {
+//			var recovering bool
+//			return rows.Next() && rows.Scan(&recovering) == nil && !recovering
+//		})
+//
+// The rows are closed once the matcher returns, and the query is run again if it returns false.
+func (w *waitForSql) WithQueryResultMatcher(matcher func(rows *sql.Rows) bool) *waitForSql {
+	w.QueryResultMatcher = matcher
+	return w
+}
+
+// WithURLParams can be used to set connection parameters of the database, e.g. "sslmode" or "search_path".
+// They are passed to the URL builder set with WithURLBuilder, if any, or merged otherwise into the query
+// of the URL returned by the URL function of ForSQL, which must then be a URL, e.g. "postgres://host:port/db".
+// The params are merged with the ones already set by previous calls.
+func (w *waitForSql) WithURLParams(params map[string]string) *waitForSql {
+	if w.URLParams == nil {
+		w.URLParams = make(map[string]string, len(params))
+	}
+
+	maps.Copy(w.URLParams, params)
+	return w
+}
+
+// WithURLBuilder can be used to build the data source name of the database from the host, the mapped port and
+// the params set with WithURLParams, replacing the URL function of ForSQL, e.g. for a DSN which is not a URL.
+func (w *waitForSql) WithURLBuilder(builder func(host string, port nat.Port, params map[string]string) string) *waitForSql {
+	w.URLBuilder = builder
+	return w
+}
+
 func (w *waitForSql) Timeout() *time.Duration {
 	return w.timeout
 }
 
+// Ports implements StrategyPorts, returning the port of the strategy, unless it targets the lowest exposed port.
+func (w *waitForSql) Ports() []nat.Port {
+	return singlePort(w.Port)
+}
+
+// String returns a human-readable description of the wait strategy.
+func (w *waitForSql) String() string {
+	return fmt.Sprintf("SQL query %q on port %s", w.query, w.Port)
+}
+
 // WaitUntilReady repeatedly tries to run "SELECT 1" or user defined query on the given port using sql and driver.
 //
 // If it doesn't succeed until the timeout value which defaults to 60 seconds, it will return an error.
 func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget) error {
-	timeout := defaultStartupTimeout()
-	if w.timeout != nil {
-		timeout = *w.timeout
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := withEffectiveDeadline(ctx, w)
 	defer cancel()
 
 	host, err := target.Host(ctx)
@@ -78,8 +146,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		return err
 	}
 
-	ticker := time.NewTicker(w.PollInterval)
-	defer ticker.Stop()
+	poller := newPoller(w.backoff, w.PollInterval)
 
 	var port nat.Port
 	port, err = target.MappedPort(ctx, w.Port)
@@ -88,7 +155,7 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("%w: %w", ctx.Err(), err)
-		case <-ticker.C:
+		case <-time.After(poller.next()):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
@@ -96,23 +163,82 @@ func (w *waitForSql) WaitUntilReady(ctx context.Context, target StrategyTarget)
 		}
 	}
 
-	db, err := sql.Open(w.Driver, w.URL(host, port))
+	dsn, err := w.dataSourceName(host, port)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open(w.Driver, dsn)
 	if err != nil {
 		return fmt.Errorf("sql.Open: %w", err)
 	}
 	defer db.Close()
+
+	prober := newProber(ctx, w, w.observer)
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
+			return prober.timeoutError(ctx.Err())
+		case <-time.After(poller.next()):
 			if err := checkTarget(ctx, target); err != nil {
 				return err
 			}
-			if _, err := db.ExecContext(ctx, w.query); err != nil {
+
+			prober.begin()
+			err := w.ready(ctx, db)
+			prober.observe(err)
+			if err != nil {
 				continue
 			}
 			return nil
 		}
 	}
 }
+
+// dataSourceName returns the data source name of the database, built with
+// the URL builder, or the URL function with the params merged into its query.
+func (w *waitForSql) dataSourceName(host string, port nat.Port) (string, error) {
+	if w.URLBuilder != nil {
+		return w.URLBuilder(host, port, maps.Clone(w.URLParams)), nil
+	}
+
+	dsn := w.URL(host, port)
+	if len(w.URLParams) == 0 {
+		return dsn, nil
+	}
+
+	// the parse error is not wrapped, as it would include the credentials of the URL.
+	u, err := url.Parse(dsn)
+	if err != nil || u.Scheme == "" || u.Opaque != "" {
+		return "", errors.New("URL params require the URL function to return a URL, use WithURLBuilder instead")
+	}
+
+	query := u.Query()
+	for k, v := range w.URLParams {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// ready runs the query, and matches its result if a matcher is set,
+// returning why the database is not ready yet.
+func (w *waitForSql) ready(ctx context.Context, db *sql.DB) error {
+	if w.QueryResultMatcher == nil {
+		_, err := db.ExecContext(ctx, w.query)
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx, w.query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !w.QueryResultMatcher(rows) {
+		return errors.New("query result not matching")
+	}
+
+	return rows.Err()
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -79,7 +81,36 @@ func run() error {
 		}
 	})
 
-	server := http.Server{Addr: ":6443", Handler: mux}
+	// the endpoint is only ready a few seconds after starting, as a reverse proxy waiting for its upstream.
+	readyAt := time.Now().Add(3 * time.Second)
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("X-Request-Host", req.Host)
+		w.Header().Set("X-Ready", strconv.FormatBool(time.Now().After(readyAt)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("pong"))
+	})
+
+	mux.HandleFunc("/mtls-ping", func(w http.ResponseWriter, req *http.Request) {
+		if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("pong"))
+	})
+
+	server := http.Server{
+		Addr:    ":6443",
+		Handler: mux,
+		// request, without requiring, a client certificate for the mTLS endpoint.
+		TLSConfig: &tls.Config{ClientAuth: tls.RequestClientCert},
+	}
 	go func() {
 		log.Println("serving...")
 		if err := server.ListenAndServeTLS("tls.pem", "tls-key.pem"); err != nil && !errors.Is(err, http.ErrServerClosed) {
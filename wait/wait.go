@@ -23,6 +23,31 @@ type StrategyTimeout interface {
 	Timeout() *time.Duration
 }
 
+// StrategyPorts is implemented by the strategies targeting given ports of the container, so that
+// the ports can be checked against the exposed ports of the container before it's created.
+// The strategies targeting the lowest exposed port don't return it.
+type StrategyPorts interface {
+	Ports() []nat.Port
+}
+
+// portsOf returns the ports targeted by the strategy, if it implements StrategyPorts.
+func portsOf(strategy Strategy) []nat.Port {
+	if sp, ok := strategy.(StrategyPorts); ok {
+		return sp.Ports()
+	}
+
+	return nil
+}
+
+// singlePort returns the port as a slice, or nil if the port is not set.
+func singlePort(port nat.Port) []nat.Port {
+	if port == "" {
+		return nil
+	}
+
+	return []nat.Port{port}
+}
+
 type StrategyTarget interface {
 	Host(context.Context) (string, error)
 	Inspect(context.Context) (*types.ContainerJSON, error)
@@ -55,6 +80,81 @@ func checkState(state *types.ContainerState) error {
 	}
 }
 
+// EffectiveDeadline returns the deadline the strategy waits until when it's run with the given context:
+// the earliest of the deadline of the context and the end of the startup timeout of the strategy from now.
+// The startup timeout is the one returned by StrategyTimeout, or the timeout of the strategy combining it,
+// e.g. MultiStrategy.WithStartupTimeout, or the default startup timeout of 60 seconds, except for the
+// strategies combining other strategies, limited by their deadline only, see MultiStrategy.WithDeadline,
+// and for ForExit, limited by its exit timeout only. The returned
+// boolean is false when there is no deadline, as context.Context.Deadline.
+func EffectiveDeadline(ctx context.Context, s Strategy) (time.Time, bool) {
+	deadline, ok := ctx.Deadline()
+
+	timeout, bounded := startupTimeout(ctx, s)
+	if !bounded {
+		return deadline, ok
+	}
+
+	if end := time.Now().Add(timeout); !ok || end.Before(deadline) {
+		return end, true
+	}
+
+	return deadline, true
+}
+
+// startupTimeoutStrategy is implemented by the strategies whose startup timeout doesn't default to
+// the default startup timeout, returning false if they are not limited by a startup timeout.
+type startupTimeoutStrategy interface {
+	startupTimeout(ctx context.Context) (time.Duration, bool)
+}
+
+// startupTimeout returns the startup timeout of the strategy, and false if the strategy isn't limited by one.
+// The strategies without their own startup timeout use the one set in the context, see withDefaultStartupTimeout.
+func startupTimeout(ctx context.Context, s Strategy) (time.Duration, bool) {
+	if sts, ok := s.(startupTimeoutStrategy); ok {
+		return sts.startupTimeout(ctx)
+	}
+
+	if st, ok := s.(StrategyTimeout); ok && st.Timeout() != nil {
+		return *st.Timeout(), true
+	}
+
+	if timeout, ok := contextStartupTimeout(ctx); ok {
+		return timeout, true
+	}
+
+	return defaultStartupTimeout(), true
+}
+
+// contextStartupTimeout returns the startup timeout set in the context, if any, see withDefaultStartupTimeout.
+func contextStartupTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(defaultStartupTimeoutKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// defaultStartupTimeoutKey is the context key of the startup timeout of the strategies without their own.
+type defaultStartupTimeoutKey struct{}
+
+// withDefaultStartupTimeout returns a copy of the context setting the startup timeout of the strategies
+// without their own, as the strategies combining other strategies do with their timeout, if not nil.
+func withDefaultStartupTimeout(ctx context.Context, timeout *time.Duration) context.Context {
+	if timeout == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, defaultStartupTimeoutKey{}, *timeout)
+}
+
+// withEffectiveDeadline returns a copy of the context ending at the effective deadline of the strategy,
+// see EffectiveDeadline.
+func withEffectiveDeadline(ctx context.Context, s Strategy) (context.Context, context.CancelFunc) {
+	if deadline, ok := EffectiveDeadline(ctx, s); ok {
+		return context.WithDeadline(ctx, deadline)
+	}
+
+	return context.WithCancel(ctx)
+}
+
 func defaultStartupTimeout() time.Duration {
 	return 60 * time.Second
 }
@@ -0,0 +1,58 @@
+package testcontainers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// ErrWaitPortNotExposed is returned when a port the wait strategy of a container request
+// waits for is not in its exposed ports, so that the strategy would time out instead.
+var ErrWaitPortNotExposed = errors.New("wait strategy port not exposed")
+
+// validateWaitPorts ensures that the ports the wait strategy waits for are exposed. The check
+// is skipped if no port is exposed, as the strategies then wait for the lowest exposed port of
+// the image, or if the network mode of the host config, once modified, shares the network stack
+// of the host or of another container.
+func (c *ContainerRequest) validateWaitPorts(networkMode container.NetworkMode) error {
+	if c.WaitingFor == nil || len(c.ExposedPorts) == 0 {
+		return nil
+	}
+
+	withPorts, ok := c.WaitingFor.(wait.StrategyPorts)
+	if !ok {
+		return nil
+	}
+
+	if networkMode.IsHost() || networkMode.IsContainer() {
+		return nil
+	}
+
+	exposed, _, err := nat.ParsePortSpecs(c.ExposedPorts)
+	if err != nil {
+		return fmt.Errorf("parse exposed ports: %w", err)
+	}
+
+	var missing []string
+	for _, p := range withPorts.Ports() {
+		port, err := nat.NewPort(p.Proto(), p.Port())
+		if err != nil {
+			return fmt.Errorf("parse wait strategy port %q: %w", p, err)
+		}
+
+		if _, ok := exposed[port]; !ok && !slices.Contains(missing, string(port)) {
+			missing = append(missing, string(port))
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %v not in the exposed ports %v", ErrWaitPortNotExposed, missing, c.ExposedPorts)
+}
@@ -0,0 +1,88 @@
+package testcontainers
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestContainerRequest_validateWaitPorts(t *testing.T) {
+	t.Run("exposed", func(t *testing.T) {
+		req := ContainerRequest{
+			ExposedPorts: []string{"80", "5432/tcp"},
+			WaitingFor:   wait.ForAll(wait.ForListeningPort("80/tcp"), wait.ForSQL("5432", "pgx", nil)),
+		}
+		require.NoError(t, req.validateWaitPorts(""))
+	})
+
+	t.Run("not-exposed", func(t *testing.T) {
+		// waitPortNotExposed {
+		req := ContainerRequest{
+			Image:        nginxAlpineImage,
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForHTTP("/").WithPort("8080/tcp"),
+		}
+		// }
+
+		err := req.validateWaitPorts("")
+		require.ErrorIs(t, err, ErrWaitPortNotExposed)
+		require.EqualError(t, err, "wait strategy port not exposed: [8080/tcp] not in the exposed ports [80/tcp]")
+	})
+
+	t.Run("not-exposed/composite", func(t *testing.T) {
+		req := ContainerRequest{
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor: wait.ForAll(
+				wait.ForListeningPort("80/tcp"),
+				wait.ForAny(wait.ForListeningPort("53/udp"), wait.ForLog("ready")),
+			),
+		}
+		require.ErrorIs(t, req.validateWaitPorts(""), ErrWaitPortNotExposed)
+	})
+
+	t.Run("no-exposed-ports", func(t *testing.T) {
+		req := ContainerRequest{WaitingFor: wait.ForListeningPort("8080/tcp")}
+		require.NoError(t, req.validateWaitPorts(""))
+	})
+
+	t.Run("strategy-without-ports", func(t *testing.T) {
+		req := ContainerRequest{
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForAll(wait.ForLog("ready"), wait.ForListeningPort("")),
+		}
+		require.NoError(t, req.validateWaitPorts(""))
+	})
+
+	t.Run("host-network", func(t *testing.T) {
+		req := ContainerRequest{
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("8080/tcp"),
+		}
+		require.NoError(t, req.validateWaitPorts("host"))
+	})
+
+	t.Run("container-network", func(t *testing.T) {
+		req := ContainerRequest{
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor:   wait.ForListeningPort("8080/tcp"),
+		}
+		require.NoError(t, req.validateWaitPorts(container.NetworkMode("container:abcdef")))
+	})
+
+	t.Run("internal-check", func(t *testing.T) {
+		req := ContainerRequest{
+			ExposedPorts: []string{"80/tcp"},
+			WaitingFor: wait.ForAll(
+				wait.ForListeningPort("80/tcp"),
+				wait.ForListeningPort("8080/tcp").WithCheck(wait.PortCheckInternal),
+			),
+		}
+		require.NoError(t, req.validateWaitPorts(""))
+
+		req.WaitingFor = wait.ForListeningPort("8080/tcp").WithCheck(wait.PortCheckBoth)
+		require.ErrorIs(t, req.validateWaitPorts(""), ErrWaitPortNotExposed)
+	})
+}